@@ -0,0 +1,201 @@
+package cli
+
+import (
+    "encoding/csv"
+    "fmt"
+    "math"
+    "os"
+    "strconv"
+    "time"
+
+    "euclid/poly"
+    "euclid/polymod"
+)
+
+// benchPrime is the prime benchAlgorithms runs halfgcd over when -mod does
+// not give one
+const benchPrime = 1<<31 - 1
+
+// algorithmTimes collects the timings of one algorithm of benchAlgorithms
+type algorithmTimes struct {
+    name string
+    // p is the prime of the field the algorithm runs over, 0 for Q
+    p uint64
+    // label names the algorithm in the output, with its field when the
+    // algorithms do not all run over the same one
+    label     string
+    means     []poly.Point
+    low, high []float64
+    total     time.Duration
+}
+
+// benchAlgorithms times the extended GCD algorithms named by algorithms
+// (GCD strategies) on the same pairs of family, samples pairs for every
+// length up to maxLength, and plots the mean time per call of each one with
+// min-max error bars on one chart, so that the crossover points show. Each
+// algorithm runs over the field it supports: "subresultant" over Q,
+// "halfgcd" over GF(p), p = benchPrime unless given, and "euclid" over GF(p)
+// when p is given and over Q otherwise; the pairs are reduced modulo p for
+// the algorithms over GF(p). Every pair must give the same monic GCD under
+// all algorithms over the same field. csvFile, when set, gets one row per
+// sample and algorithm.
+func benchAlgorithms(cfg cliConfig, algorithms []string, p uint64, maxLength, samples int, logLog bool, family poly.CorpusFamily, file, csvFile string, opts ...poly.Option) error {
+    if len(algorithms) < 2 {
+        return fmt.Errorf("bench: need at least two algorithms to compare, got %d", len(algorithms))
+    }
+    times := make([]*algorithmTimes, len(algorithms))
+    mixed := false
+    for k, name := range algorithms {
+        if !poly.IsGCDStrategy(name) {
+            return fmt.Errorf("bench: unknown GCD algorithm %q", name)
+        }
+        at := &algorithmTimes{
+            name:  name,
+            p:     p,
+            means: make([]poly.Point, maxLength),
+            low:   make([]float64, maxLength),
+            high:  make([]float64, maxLength),
+        }
+        switch {
+        case name == "subresultant":
+            at.p = 0
+        case name == "halfgcd" && p == 0:
+            at.p = benchPrime
+        }
+        mixed = mixed || k > 0 && at.p != times[0].p
+        times[k] = at
+    }
+    for _, at := range times {
+        at.label = at.name
+        if mixed && at.p == 0 {
+            at.label += " (Q)"
+        } else if mixed {
+            at.label += fmt.Sprintf(" (GF(%d))", at.p)
+        }
+    }
+    var table *csv.Writer
+    if csvFile != "" {
+        out, err := os.Create(csvFile)
+        if err != nil {
+            return err
+        }
+        defer out.Close()
+        table = csv.NewWriter(out)
+        table.Write([]string{"length", "sample", "algorithm", "deg_f", "deg_g", "time_ns"})
+    }
+
+    // run times one algorithm on a pair over the field of GF(p), or Q if p
+    // is 0, and returns its monic GCD as text
+    run := func(name string, p uint64, f, g *poly.Polynomial) (string, time.Duration, error) {
+        if p != 0 {
+            fp, err := f.ModP(p)
+            if err != nil {
+                return "", 0, err
+            }
+            gp, err := g.ModP(p)
+            if err != nil {
+                return "", 0, err
+            }
+            modOpts := append(cfg.modOpts[:len(cfg.modOpts):len(cfg.modOpts)], polymod.WithGCDStrategy(name))
+            startTime := time.Now()
+            gcd, _, _, err := polymod.ExtendedGCDMod(fp, gp, modOpts...)
+            elapsed := time.Since(startTime)
+            if err != nil {
+                return "", 0, err
+            }
+            return gcd.Monic().String(), elapsed, nil
+        }
+        algOpts := append(opts[:len(opts):len(opts)], poly.WithGCDStrategy(name))
+        startTime := time.Now()
+        res, err := poly.ExtendedGCDResult(f, g, algOpts...)
+        elapsed := time.Since(startTime)
+        if err != nil {
+            return "", 0, err
+        }
+        return res.GCD.Monic().String(), elapsed, nil
+    }
+
+    rng := poly.NewRand(opts...)
+    for i := 1; i <= maxLength; i++ {
+        sums := make([]float64, len(times))
+        fastest, slowest := make([]float64, len(times)), make([]float64, len(times))
+        for k := range times {
+            fastest[k] = math.Inf(1)
+        }
+        for j := 1; j <= samples; j++ {
+            f, g := family(rng, i)
+            // the GCD and the algorithm that found it first, by field
+            want, first := map[uint64]string{}, map[uint64]string{}
+            for k, at := range times {
+                gcd, elapsed, err := run(at.name, at.p, f, g)
+                if err != nil {
+                    return fmt.Errorf("length %d: %s: %v", i, at.label, err)
+                }
+                if _, ok := want[at.p]; !ok {
+                    want[at.p], first[at.p] = gcd, at.label
+                } else if gcd != want[at.p] {
+                    return fmt.Errorf("length %d: %s and %s disagree on the GCD of f = %s, g = %s", i, first[at.p], at.label, f, g)
+                }
+                at.total += elapsed
+                t := elapsed.Seconds()
+                sums[k] += t
+                fastest[k], slowest[k] = math.Min(fastest[k], t), math.Max(slowest[k], t)
+                if table != nil {
+                    table.Write([]string{strconv.Itoa(i), strconv.Itoa(j), at.label, strconv.Itoa(f.Deg()), strconv.Itoa(g.Deg()),
+                        strconv.FormatInt(elapsed.Nanoseconds(), 10)})
+                }
+            }
+        }
+        for k, at := range times {
+            mean := sums[k] / float64(samples)
+            at.means[i-1] = poly.Point{X: float64(i), Y: mean}
+            at.low[i-1], at.high[i-1] = mean-fastest[k], slowest[k]-mean
+        }
+    }
+    if table != nil {
+        table.Flush()
+        if err := table.Error(); err != nil {
+            return err
+        }
+    }
+
+    var series []poly.Series
+    for _, at := range times {
+        label := at.label
+        summary := fmt.Sprintf("%.6f %s", at.total.Seconds(), tr(cfg.language, "seconds"))
+        if c, k, ok := fitPowerLaw(at.means); ok {
+            label = fmt.Sprintf("%s (n^%.2f)", at.label, k)
+            summary += fmt.Sprintf(", %s: t ≈ %.3g·n^%.2f", tr(cfg.language, "fit"), c, k)
+        }
+        fmt.Printf("%s %s\n", colorize(at.label+":", "\033[1;35m"), summary)
+        series = append(series, poly.Series{Label: label, Points: at.means, Markers: true, Low: at.low, High: at.high})
+    }
+    // the lengths where another algorithm becomes the fastest
+    leader := -1
+    for i := 0; i < maxLength; i++ {
+        best := 0
+        for k, at := range times {
+            if at.means[i].Y < times[best].means[i].Y {
+                best = k
+            }
+        }
+        if best != leader {
+            fmt.Printf("%s %s\n", colorize(fmt.Sprintf(tr(cfg.language, "Fastest from length %d:"), i+1), "\033[1;34m"), times[best].label)
+            leader = best
+        }
+    }
+
+    return savePlot(&poly.Figure{
+        Title:      tr(cfg.language, "Polynomial Length vs. Execution Time"),
+        XLabel:     tr(cfg.language, "Polynomial Length"),
+        YLabel:     tr(cfg.language, "Time per call (seconds)"),
+        Width:      6,
+        Height:     4,
+        Grid:       true,
+        LegendTop:  true,
+        LegendLeft: true,
+        LogX:       logLog,
+        LogY:       logLog,
+        Series:     series,
+    }, file)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+
+    "euclid/poly"
+)
+
+// jsonBatchResult is the JSON report of one line of a batch: the GCD report,
+// or the error that stopped the line
+type jsonBatchResult struct {
+    Line  int    `json:"line"`
+    Error string `json:"error,omitempty"`
+    *jsonGCDReport
+}
+
+// parseBatchLine parses a batch line "f ; g", each polynomial written as
+// on the command line: a coefficient list or an expression
+func parseBatchLine(line string) (f, g *poly.Polynomial, err error) {
+    parts := strings.Split(line, ";")
+    if len(parts) != 2 {
+        return nil, nil, fmt.Errorf("expected two polynomials separated by \";\", got %q", line)
+    }
+    if f, err = parsePoly(strings.TrimSpace(parts[0])); err != nil {
+        return nil, nil, err
+    }
+    if g, err = parsePoly(strings.TrimSpace(parts[1])); err != nil {
+        return nil, nil, err
+    }
+    return f, g, nil
+}
+
+// batchGCD runs the extended GCD on every pair of cfg.inputFile, skipping
+// blank lines and # comments, and writes a report per line to
+// cfg.outputFile, or with cfg.jsonOutput one document
+// {"results": [...], "failures": n}. Every result gets the Bézout check; a
+// line that does not parse or fails the check is reported and counted, and
+// the batch goes on, returning an error at the end if any line failed.
+func batchGCD(cfg cliConfig, opts ...poly.Option) error {
+    in := os.Stdin
+    if cfg.inputFile != "-" {
+        file, err := os.Open(cfg.inputFile)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        in = file
+    }
+    var out io.Writer = os.Stdout
+    // colors only on the terminal, not in result files
+    label := colorize
+    if cfg.outputFile != "" {
+        file, err := os.Create(cfg.outputFile)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        out = file
+        label = func(text, _ string) string { return text }
+    }
+
+    scanner := bufio.NewScanner(in)
+    scanner.Buffer(nil, 1<<24)
+    results := []jsonBatchResult{}
+    pairs, failures := 0, 0
+    for n := 1; scanner.Scan(); n++ {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" || strings.HasPrefix(line, "#") {
+            continue
+        }
+        pairs++
+        f, g, err := parseBatchLine(line)
+        var res *poly.GCDResult
+        if err == nil {
+            res, err = poly.ExtendedGCDResult(f, g, opts...)
+        }
+        if err != nil {
+            failures++
+            if cfg.jsonOutput {
+                results = append(results, jsonBatchResult{Line: n, Error: err.Error()})
+            } else {
+                fmt.Fprintf(out, "%s %d: %s\n\n", label(tr(cfg.language, "Line"), "\033[1;34m"), n, label(err.Error(), "\033[1;31m"))
+            }
+            continue
+        }
+        report := gcdReport(cfg, f, g, res, 0)
+        if report.Verification.Status == "fail" {
+            failures++
+        }
+        if cfg.jsonOutput {
+            results = append(results, jsonBatchResult{Line: n, jsonGCDReport: &report})
+            continue
+        }
+        fmt.Fprintf(out, "%s %d\n", label(tr(cfg.language, "Line"), "\033[1;34m"), n)
+        fmt.Fprintf(out, "%s %s\n", label("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+        fmt.Fprintf(out, "%s %s\n", label("g(x):", "\033[1;32m"), poly.Display(g, opts...))
+        fmt.Fprintf(out, "%s %s\n", label(tr(cfg.language, "GCD:"), "\033[1;33m"), poly.Display(res.GCD, opts...))
+        fmt.Fprintf(out, "%s %s\n", label("s(x):", "\033[1;36m"), poly.Display(res.S, opts...))
+        fmt.Fprintf(out, "%s %s\n", label("t(x):", "\033[1;36m"), poly.Display(res.T, opts...))
+        fmt.Fprintf(out, "%s %s\n", label(tr(cfg.language, "Iterations:"), "\033[1;35m"), res.IterationsSummary())
+        verdict := label(tr(cfg.language, "pass"), "\033[1;32m")
+        if report.Verification.Status == "fail" {
+            verdict = label(tr(cfg.language, "fail"), "\033[1;31m")
+        }
+        fmt.Fprintf(out, "%s %s\n\n", label(tr(cfg.language, "Bézout check:"), "\033[1;34m"), verdict)
+    }
+    if err := scanner.Err(); err != nil {
+        return err
+    }
+    if cfg.jsonOutput {
+        if err := encodeJSON(out, struct {
+            Results  []jsonBatchResult `json:"results"`
+            Failures int               `json:"failures"`
+        }{results, failures}); err != nil {
+            return err
+        }
+    }
+    if failures > 0 {
+        return fmt.Errorf(tr(cfg.language, "%d of %d pairs failed"), failures, pairs)
+    }
+    return nil
+}
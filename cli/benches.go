@@ -0,0 +1,200 @@
+package cli
+
+import (
+    "errors"
+    "fmt"
+    "math"
+
+    "euclid/intring"
+    "euclid/poly"
+    "euclid/polymod"
+)
+
+// backendBench prints poly.BackendBench as a table with the speedup of
+// the gmp backend over math/big
+func backendBench() error {
+    gmp, err := poly.NewBackend("gmp")
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize("gmp backend:", "\033[1;33m"), gmp.Name())
+    fmt.Println(colorize(fmt.Sprintf("%8s %14s %14s %14s %9s", "degree", "polyRing ns/op", "rat ns/op", "gmp ns/op", "speedup"), "\033[1;34m"))
+    rows, err := poly.BackendBench()
+    for _, row := range rows {
+        fmt.Printf("%8d %14.0f %14.0f %14.0f %8.2fx\n", row.Degree, row.Poly, row.Rat, row.GMP, row.Rat/row.GMP)
+    }
+    return err
+}
+
+// costFitDemo prints the cost models fitted by poly.FitCostModels next
+// to the built-in ones, with the largest factor by which each fitted model
+// misses a measurement
+func costFitDemo(opts ...poly.Option) {
+    for _, fit := range poly.FitCostModels(opts...) {
+        fmt.Printf("%s fitted {%.2f, %.2f, %.2f}, built in {%.2f, %.2f, %.2f}, worst miss %.2fx over %d samples\n",
+            colorize(fmt.Sprintf("%-10s", fit.Strategy), "\033[1;34m"),
+            fit.Fitted[0], fit.Fitted[1], fit.Fitted[2], fit.BuiltIn[0], fit.BuiltIn[1], fit.BuiltIn[2],
+            fit.WorstMiss, fit.Samples)
+    }
+}
+
+// inverseBench prints polymod.InverseBench as a table, with a dash for the
+// strategies that do not apply to a field
+func inverseBench() error {
+    fmt.Println(colorize(fmt.Sprintf("%-22s %14s %14s %16s", "field", "euclid ns/op", "almost ns/op", "consttime ns/op"), "\033[1;34m"))
+    rows, err := polymod.InverseBench()
+    for _, row := range rows {
+        columns := []string{"-", "-", "-"}
+        for i, ns := range row.Ns {
+            columns[i] = fmt.Sprintf("%.0f", ns)
+        }
+        fmt.Printf("%-22s %14s %14s %16s\n", row.Field, columns[0], columns[1], columns[2])
+    }
+    return err
+}
+
+// halfGCDBench prints polymod.HalfGCDBench as a table with the speedup of
+// half-GCD over the classical remainder sequence
+func halfGCDBench() error {
+    fmt.Println(colorize(fmt.Sprintf("%8s %16s %16s %9s", "degree", "euclid ns/op", "halfgcd ns/op", "speedup"), "\033[1;34m"))
+    rows, err := polymod.HalfGCDBench()
+    for _, row := range rows {
+        fmt.Printf("%8d %16.0f %16.0f %8.2fx\n", row.Degree, row.Euclid, row.Half, row.Euclid/row.Half)
+    }
+    return err
+}
+
+// mulBench prints the tables of poly.MulBench, the crossover degrees
+// of the multiplication strategies and the thresholds "auto" uses
+func mulBench() error {
+    results, err := poly.MulBench()
+    for _, res := range results {
+        kind := "integer coefficients"
+        if res.MaxDen > 1 {
+            kind = fmt.Sprintf("rational coefficients, denominators up to %d", res.MaxDen)
+        }
+        fmt.Println(colorize(kind, "\033[1;32m"))
+        fmt.Println(colorize(fmt.Sprintf("%8s %15s %15s %15s %15s", "degree", "naive ns/op", "karatsuba ns/op", "kronecker ns/op", "ntt ns/op"), "\033[1;34m"))
+        for _, row := range res.Rows {
+            columns := []interface{}{row.Degree}
+            for _, ns := range row.Ns {
+                if math.IsInf(ns, 1) {
+                    columns = append(columns, "-")
+                } else {
+                    columns = append(columns, fmt.Sprintf("%.0f", ns))
+                }
+            }
+            fmt.Printf("%8d %15s %15s %15s %15s\n", columns...)
+        }
+        for i, n := range res.Crossover {
+            if n == 0 {
+                fmt.Printf("%s %s not faster than %s up to the largest degree\n", colorize("crossover:", "\033[1;33m"), res.Strategies[i+1], res.Strategies[i])
+                continue
+            }
+            fmt.Printf("%s %s faster than %s from degree %d\n", colorize("crossover:", "\033[1;33m"), res.Strategies[i+1], res.Strategies[i], n)
+        }
+        fmt.Printf("%s naive below degree %d, kronecker below %d, ntt from there on\n", colorize("auto:", "\033[1;33m"), res.KroneckerMinDeg, res.NTTMinDeg)
+    }
+    return err
+}
+
+// modBench prints poly.ModBench as a table, followed by the measured
+// crossover points next to the ones the library uses
+func modBench() error {
+    res, err := poly.ModBench()
+    if err != nil {
+        return err
+    }
+    fmt.Println(colorize(fmt.Sprintf("%6s %10s %10s %12s %12s %12s  %s", "bits", "mul word", "mul big",
+        "inv word", "inv euclid", "inv ModInv", "strategy"), "\033[1;34m"))
+    for _, row := range res.Rows {
+        mulWord, invWord := "-", "-"
+        if !math.IsNaN(row.MulWord) {
+            mulWord = fmt.Sprintf("%.1f", row.MulWord)
+            invWord = fmt.Sprintf("%.1f", row.InvWord)
+        }
+        fmt.Printf("%6d %10s %10.1f %12s %12.1f %12.1f  %s\n", row.Bits, mulWord, row.MulBig, invWord, row.InvEuclid, row.InvModInv, row.Strategy)
+    }
+
+    fmt.Printf("%s word arithmetic fastest up to %d bits (used up to %d bits)\n",
+        colorize("crossover:", "\033[1;33m"), res.WordUpTo, intring.WordModulusBits)
+    fmt.Printf("%s big.Int.ModInverse fastest from %d bits (used above %d bits)\n",
+        colorize("crossover:", "\033[1;33m"), res.ModInvFrom, intring.WordModulusBits)
+    return nil
+}
+
+// multipointBench prints poly.MultipointBench as a table with the
+// speedup of EvalMany over Horner's scheme
+func multipointBench() error {
+    fmt.Println(colorize(fmt.Sprintf("%8s %9s %14s %14s %9s", "degree", "points", "horner ns/op", "tree ns/op", "speedup"), "\033[1;34m"))
+    rows, err := poly.MultipointBench()
+    for _, row := range rows {
+        fmt.Printf("%8d %9s %14.0f %14.0f %8.2fx\n", row.Degree, row.Kind, row.Horner, row.Tree, row.Horner/row.Tree)
+    }
+    return err
+}
+
+// subresultantBench prints poly.SubresultantBench as a table with the
+// speedup of the subresultant PRS over the Euclidean algorithm
+func subresultantBench() error {
+    fmt.Println(colorize(fmt.Sprintf("%8s %14s %14s %11s %11s %9s", "degree", "euclid ns/op", "subres ns/op", "euclid bits", "subres bits", "speedup"), "\033[1;34m"))
+    rows, err := poly.SubresultantBench()
+    for _, row := range rows {
+        fmt.Printf("%8d %14.0f %14.0f %11d %11d %8.2fx\n", row.Degree, row.Euclid, row.Subres,
+            row.EuclidBits, row.SubresBits, row.Euclid/row.Subres)
+    }
+    return err
+}
+
+// gf2Bench prints polymod.GF2Bench as a table with the speedup of the
+// binary GCD over the generic loop
+func gf2Bench() error {
+    fmt.Println(colorize(fmt.Sprintf("%8s %14s %14s %14s %10s", "degree", "generic ns/op", "packed ns/op",
+        "binary ns/op", "speedup"), "\033[1;34m"))
+    rows, err := polymod.GF2Bench()
+    for _, row := range rows {
+        fmt.Printf("%8d %14.0f %14.0f %14.0f %9.0fx\n", row.Degree, row.Generic, row.Packed, row.Binary, row.Generic/row.Binary)
+    }
+    return err
+}
+
+// checkConstantTime prints one line per modulus of
+// polymod.CheckConstantTime and returns the number of failures
+func checkConstantTime(opts ...polymod.Option) int {
+    failures := 0
+    for _, c := range polymod.CheckConstantTime(opts...) {
+        status := colorize("ok", "\033[1;32m")
+        if c.Err != nil {
+            status = colorize("FAIL", "\033[1;31m") + " " + c.Err.Error()
+            failures++
+        }
+        fmt.Printf("%-34s %5d inputs, %4d divsteps each (expected %d): %s\n", c.Name, c.Inputs, c.MinSteps, c.Divsteps, status)
+    }
+    return failures
+}
+
+// runFuzz prints the results of poly.RunFuzz and, when a target
+// panics, the original and the minimized reproducer; it returns the
+// failure
+func runFuzz(iterations int, opts ...poly.Option) error {
+    results, err := poly.RunFuzz(iterations, opts...)
+    for _, r := range results {
+        fmt.Printf("%s %s: %d inputs, %d interesting\n", colorize("ok", "\033[1;32m"), r.Target, r.Inputs, r.Interesting)
+    }
+    var failure *poly.FuzzFailure
+    if !errors.As(err, &failure) {
+        if err != nil {
+            fmt.Println(colorize(err.Error(), "\033[1;31m"))
+        }
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize("FAIL", "\033[1;31m"), failure.Target)
+    fmt.Printf("input: %q\n", failure.Input)
+    fmt.Printf("panic: %v\n", failure.Panic)
+    fmt.Printf("%s %q\n", colorize("minimized input:", "\033[1;33m"), failure.Minimized)
+    if failure.Pair != "" {
+        fmt.Println(failure.Pair)
+    }
+    fmt.Printf("panic: %v\n", failure.MinPanic)
+    return err
+}
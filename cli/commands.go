@@ -0,0 +1,1482 @@
+package cli
+
+import (
+    "context"
+    "encoding/hex"
+    "errors"
+    "flag"
+    "fmt"
+    "io"
+    "math/big"
+    "os"
+    "os/signal"
+    "runtime"
+    "strconv"
+    "strings"
+    "time"
+    "unicode"
+
+    "euclid/poly"
+    "euclid/polymod"
+)
+
+// runCommand dispatches a non-interactive subcommand given on the command
+// line; cfg and opts come from the global flags
+func runCommand(cfg cliConfig, name string, args []string, opts ...poly.Option) {
+    switch name {
+    case "gcd":
+        // gcd <f> <g> [--squarefree], or gcd -f <f> -g <g> [-squarefree]
+        var fArg, gArg string
+        squarefree := false
+        if startsWithFlag(args, "f", "g", "squarefree") {
+            fs := newFlagSet(name)
+            fs.StringVar(&fArg, "f", "", "")
+            fs.StringVar(&gArg, "g", "", "")
+            fs.BoolVar(&squarefree, "squarefree", false, "")
+            fs.Parse(args)
+            if fArg == "" || gArg == "" || fs.NArg() != 0 {
+                usage()
+            }
+        } else {
+            if len(args) != 2 && !(len(args) == 3 && args[2] == "--squarefree") {
+                usage()
+            }
+            fArg, gArg, squarefree = args[0], args[1], len(args) == 3
+        }
+        f, g := parsePolyArg(fArg), parsePolyArg(gArg)
+        res, err := poly.ExtendedGCDWith(f, g, poly.GCDOptions{SquarefreeFirst: squarefree}, opts...)
+        exitOnError(err)
+        if cfg.jsonOutput {
+            writeJSON(gcdReport(cfg, f, g, res, 0))
+            return
+        }
+        printTrace(cfg, res, opts...)
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "GCD:"), "\033[1;33m"), poly.Display(res.GCD, opts...))
+        fmt.Printf("%s %s\n", colorize("s(x):", "\033[1;36m"), poly.Display(res.S, opts...))
+        fmt.Printf("%s %s\n", colorize("t(x):", "\033[1;36m"), poly.Display(res.T, opts...))
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Iterations:"), "\033[1;35m"), res.IterationsSummary())
+        if squarefree {
+            fmt.Printf("%s %v\n", colorize(tr(cfg.language, "Squarefree preprocessing changed the inputs:"), "\033[1;35m"), res.SquarefreeChanged)
+        }
+        printCoefficientStats(cfg, res)
+        if res.SquarefreeChanged && cfg.verify {
+            // s and t belong to the squarefree parts, not to f and g
+            fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Bézout check:"), "\033[1;34m"), tr(cfg.language, "skipped, the inputs were replaced by their squarefree parts"))
+        } else {
+            printVerification(cfg, f, g, res)
+        }
+    case "rpc":
+        // rpc
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(poly.ServeRPC(os.Stdin, os.Stdout, opts...))
+    case "gcdjob":
+        // gcdjob <f> <g> <file> [<seconds>]
+        if len(args) != 3 && len(args) != 4 {
+            usage()
+        }
+        interval := time.Minute
+        if len(args) == 4 {
+            interval = time.Duration(atoiOrUsage(args[3])) * time.Second
+        }
+        ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+        defer stop()
+        res, resumed, err := poly.ExtendedGCDResumable(ctx, parsePolyArg(args[0]), parsePolyArg(args[1]), args[2], interval)
+        if resumed {
+            fmt.Printf("%s %s\n", colorize(tr(cfg.language, "resumed from"), "\033[1;35m"), args[2])
+        }
+        if err == context.Canceled {
+            fmt.Printf("%s %s\n", colorize(tr(cfg.language, "interrupted, state saved to"), "\033[1;35m"), args[2])
+            os.Exit(1)
+        }
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "GCD:"), "\033[1;33m"), poly.Display(res.GCD, opts...))
+        fmt.Printf("%s %s\n", colorize("s(x):", "\033[1;36m"), poly.Display(res.S, opts...))
+        fmt.Printf("%s %s\n", colorize("t(x):", "\033[1;36m"), poly.Display(res.T, opts...))
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Iterations:"), "\033[1;35m"), res.IterationsSummary())
+        printCoefficientStats(cfg, res)
+    case "conformance":
+        // conformance [--update] [<file>]
+        update := len(args) > 0 && args[0] == "--update"
+        if update {
+            args = args[1:]
+        }
+        if len(args) > 1 {
+            usage()
+        }
+        path := poly.ConformanceFile
+        if len(args) == 1 {
+            path = args[0]
+        }
+        if update {
+            file, err := os.Create(path)
+            if err == nil {
+                err = poly.WriteConformanceVectors(file)
+                if cerr := file.Close(); err == nil {
+                    err = cerr
+                }
+            }
+            if err != nil {
+                fmt.Fprintln(os.Stderr, err)
+                os.Exit(2)
+            }
+            return
+        }
+        failures, total, err := poly.CheckConformance(path)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        for _, f := range failures {
+            fmt.Printf("%s %s %s\n", colorize("FAIL", "\033[1;31m"), f.Op, strings.Join(f.Args, " "))
+            fmt.Printf("    expected %s\n    got      %s\n", f.Expected, f.Got)
+        }
+        fmt.Printf("%s %d of %d vectors match\n", colorize("conformance:", "\033[1;33m"), total-len(failures), total)
+        if len(failures) > 0 {
+            os.Exit(1)
+        }
+    case "gcdvectors":
+        // gcdvectors [--update] [<file>]
+        update := len(args) > 0 && args[0] == "--update"
+        if update {
+            args = args[1:]
+        }
+        if len(args) > 1 {
+            usage()
+        }
+        path := poly.GCDVectorsFile
+        if len(args) == 1 {
+            path = args[0]
+        }
+        if update {
+            file, err := os.Create(path)
+            if err == nil {
+                err = poly.WriteGCDVectors(file)
+                if cerr := file.Close(); err == nil {
+                    err = cerr
+                }
+            }
+            if err != nil {
+                fmt.Fprintln(os.Stderr, err)
+                os.Exit(2)
+            }
+            return
+        }
+        failures, total, err := poly.CheckGCDVectors(path)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        for _, err := range failures {
+            fmt.Printf("%s %v\n", colorize("FAIL", "\033[1;31m"), err)
+        }
+        fmt.Printf("%s %d of %d vectors match\n", colorize("gcd vectors:", "\033[1;33m"), total-len(failures), total)
+        if len(failures) > 0 {
+            os.Exit(1)
+        }
+    case "gcd-backend":
+        // gcd-backend [<backend> <f> <g>]
+        if len(args) == 0 {
+            for _, name := range poly.BackendNames() {
+                fmt.Println(name)
+            }
+            return
+        }
+        if len(args) != 3 {
+            usage()
+        }
+        if err := backendGCDDemo(args[0], parsePolyArg(args[1]), parsePolyArg(args[2])); err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+    case "bench":
+        // bench <family> <maxLength>, or bench [-family <family>] -max <maxLength> [-out <file>]
+        //     [-samples <n>] [-loglog] [-algorithms <a,b,...> [-mod <p>]] [-csv <file>]
+        //     [-cpuprofile <file>] [-memprofile <file>]
+        familyName, maxLength, samples, out := "random", 0, 3, "plot.png"
+        var algorithms, csvFile, cpuProfile, memProfile string
+        var logLog bool
+        var p uint64
+        if startsWithFlag(args, "family", "max", "out", "samples", "loglog", "algorithms", "mod", "csv", "cpuprofile", "memprofile") {
+            fs := newFlagSet(name)
+            fs.StringVar(&familyName, "family", familyName, "")
+            fs.IntVar(&maxLength, "max", 0, "")
+            fs.StringVar(&algorithms, "algorithms", "", "")
+            fs.Uint64Var(&p, "mod", 0, "")
+            fs.IntVar(&samples, "samples", samples, "")
+            fs.BoolVar(&logLog, "loglog", false, "")
+            fs.StringVar(&out, "out", out, "")
+            fs.StringVar(&csvFile, "csv", "", "")
+            fs.StringVar(&cpuProfile, "cpuprofile", "", "")
+            fs.StringVar(&memProfile, "memprofile", "", "")
+            fs.Parse(args)
+            if maxLength < 1 || samples < 1 || p != 0 && algorithms == "" || fs.NArg() != 0 {
+                usage()
+            }
+        } else {
+            if len(args) != 2 {
+                usage()
+            }
+            familyName, maxLength = args[0], atoiOrUsage(args[1])
+        }
+        family, ok := poly.GCDCorpus[familyName]
+        if !ok {
+            fmt.Fprintf(os.Stderr, "unknown corpus family %q (available: %s)\n", familyName, strings.Join(poly.CorpusNames(), ", "))
+            os.Exit(2)
+        }
+        exitOnError(withProfiles(cpuProfile, memProfile, func() error {
+            if algorithms != "" {
+                return benchAlgorithms(cfg, strings.Split(algorithms, ","), p, maxLength, samples, logLog, family, out, csvFile, opts...)
+            }
+            return testExtendedEuclideanLength(cfg, maxLength, samples, logLog, family, out, csvFile, opts...)
+        }))
+    case "test":
+        // test -n <count> [-workers <n>] [-length <maxLength> [-samples <n>] [-loglog] [-out <file>] [-csv <file>]]
+        count, maxLength, samples, out := 0, 0, 3, "plot.png"
+        var csvFile string
+        var logLog bool
+        workers := runtime.NumCPU()
+        fs := newFlagSet(name)
+        fs.IntVar(&count, "n", 0, "")
+        fs.IntVar(&workers, "workers", workers, "")
+        fs.IntVar(&maxLength, "length", 0, "")
+        fs.IntVar(&samples, "samples", samples, "")
+        fs.BoolVar(&logLog, "loglog", false, "")
+        fs.StringVar(&out, "out", out, "")
+        fs.StringVar(&csvFile, "csv", "", "")
+        fs.Parse(args)
+        if count < 0 || maxLength < 0 || count == 0 && maxLength == 0 || workers < 1 || samples < 1 || fs.NArg() != 0 {
+            usage()
+        }
+        exitOnError(testExtendedEuclidean(cfg, count, workers, opts...))
+        if maxLength > 0 {
+            exitOnError(testExtendedEuclideanLength(cfg, maxLength, samples, logLog, poly.GCDCorpus["random"], out, csvFile, opts...))
+        }
+    case "corpus":
+        // corpus [<family> <degree>]
+        if len(args) == 0 {
+            for _, name := range poly.CorpusNames() {
+                fmt.Println(name)
+            }
+            return
+        }
+        if len(args) != 2 {
+            usage()
+        }
+        family, ok := poly.GCDCorpus[args[0]]
+        if !ok {
+            fmt.Fprintf(os.Stderr, "unknown corpus family %q (available: %s)\n", args[0], strings.Join(poly.CorpusNames(), ", "))
+            os.Exit(2)
+        }
+        f, g := family(poly.NewRand(opts...), atoiOrUsage(args[1]))
+        fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+        fmt.Printf("%s %s\n", colorize("g(x):", "\033[1;32m"), poly.Display(g, opts...))
+    case "modbench":
+        // modbench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(modBench())
+    case "gf2bench":
+        // gf2bench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(gf2Bench())
+    case "halfgcdbench":
+        // halfgcdbench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(halfGCDBench())
+    case "subresbench":
+        // subresbench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(subresultantBench())
+    case "invbench":
+        // invbench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(inverseBench())
+    case "ctcheck":
+        // ctcheck
+        if len(args) != 0 {
+            usage()
+        }
+        if checkConstantTime(cfg.modOpts...) > 0 {
+            os.Exit(1)
+        }
+    case "cost":
+        // cost <f> <g> [<strategy>]
+        if len(args) != 2 && len(args) != 3 {
+            usage()
+        }
+        strategy := "auto"
+        if len(args) == 3 {
+            if !poly.IsMulStrategy(args[2]) {
+                usage()
+            }
+            strategy = args[2]
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        estimate, err := poly.EstimateCost(f, g, strategy)
+        exitOnError(err)
+        start := time.Now()
+        _, err = poly.ExtendedGCDResult(f, g, append(opts, poly.WithStrategy(strategy))...)
+        exitOnError(err)
+        fmt.Printf("%s %v\n", colorize("estimated:", "\033[1;33m"), estimate)
+        fmt.Printf("%s %v\n", colorize("measured:", "\033[1;36m"), time.Since(start))
+    case "costfit":
+        // costfit
+        if len(args) != 0 {
+            usage()
+        }
+        costFitDemo(opts...)
+    case "backendbench":
+        // backendbench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(backendBench())
+    case "comparebench":
+        // comparebench [-out <file>]
+        out := ""
+        fs := newFlagSet(name)
+        fs.StringVar(&out, "out", out, "")
+        fs.Parse(args)
+        if fs.NArg() != 0 {
+            usage()
+        }
+        w := io.Writer(os.Stdout)
+        if out != "" {
+            file, err := os.Create(out)
+            exitOnError(err)
+            defer file.Close()
+            w = file
+        }
+        exitOnError(poly.CompareBench(w, opts...))
+    case "mulbench":
+        // mulbench
+        if len(args) != 0 {
+            usage()
+        }
+        exitOnError(mulBench())
+    case "fibonacci":
+        // fibonacci <maxIndex>
+        if len(args) != 1 {
+            usage()
+        }
+        exitOnError(savePlot(fibonacciDemo(atoiOrUsage(args[0])), "fibonacci.png"))
+    case "ratfunc":
+        // ratfunc <num> <den> [<x>]
+        if len(args) != 2 && len(args) != 3 {
+            usage()
+        }
+        num, den := parsePolyArg(args[0]), parsePolyArg(args[1])
+        if den.IsZero() {
+            fmt.Fprintln(os.Stderr, poly.ErrZeroDenominator)
+            os.Exit(2)
+        }
+        r, gcd := poly.ReduceRational(num, den)
+        fmt.Printf("%s %s\n", colorize("cancelled gcd:", "\033[1;33m"), poly.Display(gcd, opts...))
+        fmt.Printf("%s %s\n", colorize("lowest terms:", "\033[1;36m"), r)
+        fmt.Printf("%s %s\n", colorize("LaTeX:", "\033[1;36m"), r.LaTeX())
+        if len(args) == 3 {
+            x, ok := new(big.Rat).SetString(args[2])
+            if !ok {
+                usage()
+            }
+            if v, err := r.Eval(x); err != nil {
+                fmt.Println(colorize(err.Error(), "\033[1;31m"))
+            } else {
+                fmt.Printf("%s %s\n", colorize(fmt.Sprintf("value at %s:", x.RatString()), "\033[1;32m"), v.RatString())
+            }
+        }
+    case "ratcalc":
+        // ratcalc <num> <den> +|-|*|/ <num> <den>
+        if len(args) != 5 {
+            usage()
+        }
+        var operands [2]*poly.RationalFunction
+        for i, pair := range [2][2]string{{args[0], args[1]}, {args[3], args[4]}} {
+            r, err := poly.NewRationalFunction(parsePolyArg(pair[0]), parsePolyArg(pair[1]))
+            if err != nil {
+                fmt.Fprintln(os.Stderr, err)
+                os.Exit(2)
+            }
+            operands[i] = r
+        }
+        a, b := operands[0], operands[1]
+        var res *poly.RationalFunction
+        var err error
+        switch args[2] {
+        case "+":
+            res = a.Add(b)
+        case "-":
+            res = a.Sub(b)
+        case "*":
+            res = a.Mul(b)
+        case "/":
+            res, err = a.Div(b)
+        default:
+            usage()
+        }
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        fmt.Printf("%s %s %s %s = %s\n", colorize("result:", "\033[1;33m"), a, args[2], b, res)
+        fmt.Printf("%s %s\n", colorize("LaTeX:", "\033[1;36m"), res.LaTeX())
+    case "recurrence":
+        // recurrence <c_1,...,c_d> <a_0,...,a_(d-1)> [<terms>]
+        if len(args) != 2 && len(args) != 3 {
+            usage()
+        }
+        coeffs, err := poly.ParseRatList(args[0])
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        initial, err := poly.ParseRatList(args[1])
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        rec, err := poly.NewLinearRecurrence(coeffs, initial)
+        exitOnError(err)
+        count := 10
+        if len(args) == 3 {
+            count = atoiOrUsage(args[2])
+        }
+        recurrenceDemo(rec, count)
+    case "guess-recurrence":
+        // guess-recurrence [<numbers>], reading standard input without arguments
+        var text string
+        switch len(args) {
+        case 0:
+            data, err := io.ReadAll(os.Stdin)
+            if err != nil {
+                fmt.Fprintln(os.Stderr, err)
+                os.Exit(2)
+            }
+            text = string(data)
+        case 1:
+            text = args[0]
+        default:
+            usage()
+        }
+        seq, err := poly.ParseRatList(strings.Join(strings.FieldsFunc(text, func(r rune) bool {
+            return r == ',' || r == ';' || unicode.IsSpace(r)
+        }), ","))
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        if len(seq) == 0 {
+            usage()
+        }
+        guessRecurrence(seq, opts...)
+    case "divide":
+        // divide <p> <q> [ascii|latex|synthetic]
+        if len(args) != 2 && len(args) != 3 {
+            usage()
+        }
+        p, q := parsePolyArg(args[0]), parsePolyArg(args[1])
+        style := "ascii"
+        if len(args) == 3 {
+            style = args[2]
+        }
+        var layout string
+        var err error
+        switch style {
+        case "ascii":
+            layout, err = poly.LongDivision(p, q)
+        case "latex":
+            layout, err = poly.LongDivisionLaTeX(p, q)
+        case "synthetic":
+            layout, err = poly.SyntheticDivision(p, q)
+        default:
+            usage()
+        }
+        exitOnError(err)
+        fmt.Print(layout)
+    case "resultant":
+        // resultant <f> <g>
+        if len(args) != 2 {
+            usage()
+        }
+        fmt.Println(poly.Resultant(parsePolyArg(args[0]), parsePolyArg(args[1])).RatString())
+    case "sylvester":
+        // sylvester <f> <g>
+        if len(args) != 2 {
+            usage()
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        fmt.Println(colorize("Sylvester matrix:", "\033[1;36m"))
+        for _, row := range poly.SylvesterMatrix(f, g) {
+            fmt.Println("  " + poly.RatList(row))
+        }
+        det := poly.ResultantSylvester(f, g)
+        res := poly.Resultant(f, g)
+        fmt.Printf("%s %s\n", colorize("determinant (Bareiss):", "\033[1;33m"), det.RatString())
+        fmt.Printf("%s %s\n", colorize("resultant (remainder sequence):", "\033[1;33m"), res.RatString())
+        if det.Cmp(res) != 0 {
+            fmt.Println(colorize("mismatch", "\033[1;31m"))
+            os.Exit(1)
+        }
+        fmt.Printf("%s %s\n", colorize("check:", "\033[1;35m"), "the two agree")
+    case "linalg":
+        // linalg det|rank <matrix>, linalg solve <matrix> <b>, rows as "a,b;c,d"
+        if len(args) < 2 || (args[0] == "solve") != (len(args) == 3) || len(args) > 3 {
+            usage()
+        }
+        a, err := poly.ParseMatrix(args[1])
+        exitOnError(err)
+        switch args[0] {
+        case "det":
+            d, err := poly.Determinant(a)
+            exitOnError(err)
+            fmt.Println(d.RatString())
+        case "rank":
+            r, err := poly.Rank(a)
+            exitOnError(err)
+            fmt.Println(r)
+        case "solve":
+            b, err := poly.ParseRatList(args[2])
+            exitOnError(err)
+            x, err := poly.Solve(a, b)
+            exitOnError(err)
+            fmt.Println(poly.RatList(x))
+        default:
+            usage()
+        }
+    case "discriminant":
+        // discriminant <f>
+        if len(args) != 1 {
+            usage()
+        }
+        d, err := poly.Discriminant(parsePolyArg(args[0]))
+        exitOnError(err)
+        fmt.Println(d.RatString())
+    case "squarefree":
+        // squarefree <f>
+        if len(args) != 1 {
+            usage()
+        }
+        exitOnError(squarefreeDemo(parsePolyArg(args[0]), opts...))
+    case "derivative":
+        // derivative <f>
+        if len(args) != 1 {
+            usage()
+        }
+        fmt.Println(poly.Display(parsePolyArg(args[0]).Derivative(), opts...))
+    case "integral":
+        // integral <f> [<c>]
+        if len(args) != 1 && len(args) != 2 {
+            usage()
+        }
+        c := new(big.Rat)
+        if len(args) == 2 {
+            if _, ok := c.SetString(args[1]); !ok {
+                usage()
+            }
+        }
+        fmt.Println(poly.Display(parsePolyArg(args[0]).Integral(c), opts...))
+    case "compose":
+        // compose <f> <g>
+        if len(args) != 2 {
+            usage()
+        }
+        fmt.Println(poly.Display(parsePolyArg(args[0]).Compose(parsePolyArg(args[1])), opts...))
+    case "reciprocal":
+        // reciprocal <f>
+        if len(args) != 1 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        if f.IsZero() {
+            fmt.Fprintln(os.Stderr, "f must be nonzero")
+            os.Exit(2)
+        }
+        fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+        fmt.Printf("%s %s\n", colorize("x^n*f(1/x):", "\033[1;32m"), poly.Display(f.Reciprocal(), opts...))
+        ok, sign := f.IsSelfReciprocal()
+        switch {
+        case ok && sign > 0:
+            fmt.Println(colorize("f is palindromic", "\033[1;36m"))
+        case ok:
+            fmt.Println(colorize("f is anti-palindromic", "\033[1;36m"))
+        default:
+            fmt.Println(colorize("f is not self-reciprocal", "\033[1;36m"))
+        }
+        fmt.Printf("%s %s\n", colorize("gcd(f, x^n*f(1/x)):", "\033[1;33m"), poly.Display(poly.ReciprocalGCD(f), opts...))
+    case "graeffe":
+        // graeffe <f> [<iterations>]
+        if len(args) != 1 && len(args) != 2 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        if f.Deg() < 1 {
+            fmt.Fprintln(os.Stderr, "f must have positive degree")
+            os.Exit(2)
+        }
+        iterations := 8
+        if len(args) == 2 {
+            iterations = atoiOrUsage(args[1])
+        }
+        fig, err := graeffeDemo(f, iterations)
+        exitOnError(err)
+        exitOnError(savePlot(fig, "graeffe.png"))
+    case "bairstow":
+        // bairstow <f>
+        if len(args) != 1 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        if f.Deg() < 1 {
+            fmt.Fprintln(os.Stderr, "f must have positive degree")
+            os.Exit(2)
+        }
+        bairstowDemo(f)
+    case "aberth":
+        // aberth <f> [<digits> [<factor>]]
+        if len(args) < 1 || len(args) > 3 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        if f.Deg() < 1 {
+            fmt.Fprintln(os.Stderr, "f must have positive degree")
+            os.Exit(2)
+        }
+        digits := 30
+        if len(args) >= 2 {
+            digits = atoiOrUsage(args[1])
+        }
+        var factor *poly.Polynomial
+        if len(args) == 3 {
+            factor = parsePolyArg(args[2])
+        }
+        aberthDemo(f, digits, factor)
+    case "roots":
+        // roots <f> <g> [<file>]
+        if len(args) != 2 && len(args) != 3 {
+            usage()
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        if f.Deg() < 1 || g.Deg() < 1 {
+            fmt.Fprintln(os.Stderr, "f and g must have positive degree")
+            os.Exit(2)
+        }
+        file := "roots.png"
+        if len(args) == 3 {
+            file = args[2]
+        }
+        fig, err := plotRootsDemo(f, g, opts...)
+        exitOnError(err)
+        exitOnError(savePlot(fig, file))
+    case "wilkinson":
+        // wilkinson [<n> [<k> <delta>]]
+        n, k, delta := 20, 19, big.NewRat(-1, 1<<23)
+        switch len(args) {
+        case 0:
+        case 1, 3:
+            n = atoiOrUsage(args[0])
+            k = n - 1
+            if len(args) == 3 {
+                var err error
+                if k, err = strconv.Atoi(args[1]); err != nil || k < 0 || k > n {
+                    usage()
+                }
+                var ok bool
+                if delta, ok = new(big.Rat).SetString(args[2]); !ok {
+                    usage()
+                }
+            }
+        default:
+            usage()
+        }
+        fig, err := wilkinsonDemo(n, k, delta, opts...)
+        exitOnError(err)
+        exitOnError(savePlot(fig, "wilkinson.png"))
+    case "basis":
+        // basis <f> [<x>]
+        if len(args) != 1 && len(args) != 2 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        cheb := f.ToChebyshev()
+        bern, err := f.ToBernstein(f.Deg())
+        exitOnError(err)
+        binom := f.ToBinomial()
+        fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+        fmt.Printf("%s %s\n", colorize("Chebyshev (c_0..c_n):", "\033[1;36m"), poly.RatList(cheb))
+        fmt.Printf("%s %s\n", colorize("Bernstein on [0,1] (b_0..b_n):", "\033[1;36m"), poly.RatList(bern))
+        fmt.Printf("%s %s\n", colorize("binomial C(x,k) (c_0..c_n):", "\033[1;36m"), poly.RatList(binom))
+        fmt.Printf("%s %v\n", colorize("integer-valued:", "\033[1;35m"), f.IsIntegerValued())
+        if !poly.FromChebyshev(cheb).Equal(f) || !poly.FromBernstein(bern).Equal(f) || !poly.FromBinomial(binom).Equal(f) {
+            exitOnError(errors.New("basis conversion does not round-trip"))
+        }
+        if len(args) == 2 {
+            x, ok := new(big.Rat).SetString(args[1])
+            if !ok {
+                usage()
+            }
+            fmt.Printf("%s %s\n", colorize("f(x) by Clenshaw:", "\033[1;33m"), poly.EvalChebyshev(cheb, x).RatString())
+            fmt.Printf("%s %s\n", colorize("f(x) by de Casteljau:", "\033[1;33m"), poly.EvalBernstein(bern, x).RatString())
+            fmt.Printf("%s %s\n", colorize("f(x) by binomial sum:", "\033[1;33m"), poly.EvalBinomial(binom, x).RatString())
+        }
+    case "plot":
+        // plot <f> <lo> <hi> [<samples> [<file>]]
+        if len(args) < 3 || len(args) > 5 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        lo, ok1 := new(big.Rat).SetString(args[1])
+        hi, ok2 := new(big.Rat).SetString(args[2])
+        if !ok1 || !ok2 || lo.Cmp(hi) >= 0 {
+            usage()
+        }
+        samples, file := 1000, "curve.png"
+        if len(args) >= 4 {
+            samples = atoiOrUsage(args[3])
+        }
+        if len(args) == 5 {
+            file = args[4]
+        }
+        if samples < 2 {
+            usage()
+        }
+        fig, err := plotCurveDemo(f, lo, hi, samples)
+        exitOnError(err)
+        exitOnError(savePlot(fig, file))
+    case "dual":
+        // dual <f> <x>
+        if len(args) != 2 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        x, ok := new(big.Rat).SetString(args[1])
+        if !ok {
+            usage()
+        }
+        v, d := f.EvalWithDerivative(x)
+        fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+        fmt.Printf("%s %s + %s*ε\n", colorize("f(x + ε):", "\033[1;36m"), v.RatString(), d.RatString())
+        fmt.Printf("%s %s\n", colorize("f'(x) formally:", "\033[1;33m"), f.Derivative().Eval(x).RatString())
+    case "interval":
+        // interval <f> <radius> <x or lo:hi> [<bits>]
+        if len(args) != 3 && len(args) != 4 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        radius, ok := new(big.Rat).SetString(args[1])
+        if !ok || radius.Sign() < 0 {
+            usage()
+        }
+        x, err := poly.ParseInterval(args[2])
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        bits := 64
+        if len(args) == 4 {
+            bits = atoiOrUsage(args[3])
+        }
+        intervalDemo(f, radius, x, bits)
+    case "padic":
+        // padic <f> <p> <k>
+        if len(args) != 3 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        p, ok := new(big.Int).SetString(args[1], 10)
+        if !ok || !p.ProbablyPrime(20) {
+            fmt.Fprintf(os.Stderr, "%s is not a prime\n", args[1])
+            os.Exit(2)
+        }
+        padicDemo(f, p, atoiOrUsage(args[2]), opts...)
+    case "gfp":
+        // gfp <p> <f> <g>
+        if len(args) != 3 {
+            usage()
+        }
+        p, err := strconv.ParseUint(args[0], 10, 64)
+        if err != nil {
+            usage()
+        }
+        if cfg.modGCDErr != nil {
+            fmt.Fprintln(os.Stderr, cfg.modGCDErr)
+            os.Exit(2)
+        }
+        if err := polyModDemo(parsePolyArg(args[1]), parsePolyArg(args[2]), p, cfg.modOpts...); err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+    case "gfpt":
+        // gfpt <p> <f> <g> [<x> <t>]
+        if len(args) != 3 && len(args) != 5 {
+            usage()
+        }
+        p, err := strconv.ParseUint(args[0], 10, 64)
+        if err != nil {
+            usage()
+        }
+        vars := polymod.DefaultFuncFieldVars
+        if len(args) == 5 {
+            vars = polymod.FuncFieldVars{X: args[3], T: args[4]}
+        }
+        exitOnError(funcFieldDemo(p, args[1], args[2], vars))
+    case "race":
+        // race <f> <g> [<strategy>...]
+        if len(args) < 2 {
+            usage()
+        }
+        strategies := args[2:]
+        if len(strategies) == 0 {
+            strategies = []string{"naive", "kronecker", "rat"}
+        }
+        exitOnError(raceDemo(parsePolyArg(args[0]), parsePolyArg(args[1]), strategies, opts...))
+    case "divisors":
+        // divisors <maxDegree> <factor> <multiplicity> [<factor> <multiplicity>...]
+        if len(args) < 3 || len(args)%2 != 1 {
+            usage()
+        }
+        maxDegree, err := strconv.Atoi(args[0])
+        if err != nil {
+            usage()
+        }
+        var factors []poly.Factor
+        for i := 1; i < len(args); i += 2 {
+            factors = append(factors, poly.Factor{Poly: parsePolyArg(args[i]), Multiplicity: atoiOrUsage(args[i+1])})
+        }
+        exitOnError(divisorsDemo(factors, maxDegree, opts...))
+    case "encode":
+        // encode <f>
+        if len(args) != 1 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        data, err := f.MarshalBinary()
+        exitOnError(err)
+        digest := f.Digest()
+        fmt.Printf("%s %s\n", colorize(fmt.Sprintf("encoding v%d:", poly.EncodingVersion), "\033[1;36m"), hex.EncodeToString(data))
+        fmt.Printf("%s %s\n", colorize("sha256:", "\033[1;35m"), hex.EncodeToString(digest[:]))
+    case "decode":
+        // decode <hex>
+        if len(args) != 1 {
+            usage()
+        }
+        data, err := hex.DecodeString(args[0])
+        if err != nil {
+            usage()
+        }
+        var f poly.Polynomial
+        exitOnError(f.UnmarshalBinary(data))
+        fmt.Println(poly.Display(&f, opts...))
+    case "crt":
+        // crt <remainder> <modulus> [<remainder> <modulus>...]
+        if len(args) < 2 || len(args)%2 != 0 {
+            usage()
+        }
+        var remainders, moduli []*poly.Polynomial
+        for i := 0; i < len(args); i += 2 {
+            remainders = append(remainders, parsePolyArg(args[i]))
+            moduli = append(moduli, parsePolyArg(args[i+1]))
+        }
+        x, err := poly.CRT(remainders, moduli)
+        exitOnError(err)
+        fmt.Printf("%s %s\n", colorize("solution:", "\033[1;33m"), poly.Display(x, opts...))
+        for i, m := range moduli {
+            _, r, err := x.Div(m)
+            exitOnError(err)
+            fmt.Printf("%s %s\n", colorize(fmt.Sprintf("x mod (%s):", poly.Display(m, opts...)), "\033[1;36m"), poly.Display(r, opts...))
+            _, want, _ := remainders[i].Div(m)
+            if !r.Equal(want) {
+                exitOnError(errors.New("crt: the solution misses a congruence"))
+            }
+        }
+    case "bezout":
+        // bezout <f> <g>
+        if len(args) != 2 {
+            usage()
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        raw, err := poly.ExtendedGCDResult(f, g, opts...)
+        exitOnError(err)
+        res, err := poly.MinimalBezout(f, g, opts...)
+        exitOnError(err)
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "GCD:"), "\033[1;33m"), poly.Display(res.GCD, opts...))
+        fmt.Printf("%s %s\n", colorize("s(x):", "\033[1;36m"), poly.Display(res.S, opts...))
+        fmt.Printf("%s %s\n", colorize("t(x):", "\033[1;36m"), poly.Display(res.T, opts...))
+        if res.GCD.IsZero() {
+            break
+        }
+        sBound, tBound, err := poly.BezoutBounds(f, g, res.GCD)
+        exitOnError(err)
+        degree := func(p *poly.Polynomial) string {
+            if p.IsZero() {
+                return "-inf"
+            }
+            return strconv.Itoa(p.Deg())
+        }
+        fmt.Printf("%s deg s = %s < %d, deg t = %s < %d\n", colorize("bounds:", "\033[1;35m"), degree(res.S), sBound, degree(res.T), tBound)
+        if err := poly.CheckBezoutBounds(f, g, raw.GCD, raw.S, raw.T); err != nil {
+            fmt.Printf("%s no, reduced from deg s = %s, deg t = %s\n", colorize("minimal as computed:", "\033[1;35m"), degree(raw.S), degree(raw.T))
+        } else {
+            fmt.Printf("%s yes\n", colorize("minimal as computed:", "\033[1;35m"))
+        }
+        printVerification(cfg, f, g, res)
+    case "inverse":
+        // inverse <f> <m>
+        if len(args) != 2 {
+            usage()
+        }
+        f, m := parsePolyArg(args[0]), parsePolyArg(args[1])
+        inv, err := poly.Inverse(f, m, opts...)
+        exitOnError(err)
+        fmt.Printf("%s %s\n", colorize("inverse:", "\033[1;33m"), poly.Display(inv, opts...))
+        _, r, err := f.Mul(inv).Div(m)
+        exitOnError(err)
+        fmt.Printf("%s %s\n", colorize("f·inverse mod m:", "\033[1;35m"), poly.Display(r, opts...))
+        if !r.Equal(poly.One()) {
+            exitOnError(errors.New("inverse: f times its inverse is not 1 modulo m"))
+        }
+    case "pipeline":
+        // pipeline <f> [<op> [<g>]...], e.g. pipeline x^2+1 mul x-1 mod x^3 derivative
+        if len(args) < 1 {
+            usage()
+        }
+        pl := poly.NewPipeline(parsePolyArg(args[0]), opts...)
+        for i := 1; i < len(args); i++ {
+            switch op := args[i]; op {
+            case "derivative":
+                pl = pl.Derivative()
+            case "monic":
+                pl = pl.Monic()
+            case "add", "sub", "mul", "quo", "mod", "compose":
+                if i+1 == len(args) {
+                    usage()
+                }
+                i++
+                g := parsePolyArg(args[i])
+                pl = map[string]func(*poly.Polynomial) *poly.Pipeline{
+                    "add": pl.Add, "sub": pl.Sub, "mul": pl.Mul,
+                    "quo": pl.Quo, "mod": pl.Mod, "compose": pl.Compose,
+                }[op](g)
+            default:
+                usage()
+            }
+        }
+        fmt.Printf("%s %s\n", colorize("pipeline:", "\033[1;36m"), pl)
+        p, err := pl.Result()
+        exitOnError(err)
+        fmt.Printf("%s %s\n", colorize("result:", "\033[1;33m"), poly.Display(p, opts...))
+    case "pade":
+        // pade <c_0,c_1,...> <m> <n>
+        if len(args) != 3 {
+            usage()
+        }
+        series, err := poly.ParseRatList(args[0])
+        exitOnError(err)
+        m, errM := strconv.Atoi(args[1])
+        n, errN := strconv.Atoi(args[2])
+        if errM != nil || errN != nil {
+            usage()
+        }
+        num, den, err := poly.Pade(series, m, n)
+        exitOnError(err)
+        fmt.Printf("%s %s\n", colorize("numerator:", "\033[1;33m"), poly.Display(num, opts...))
+        fmt.Printf("%s %s\n", colorize("denominator:", "\033[1;33m"), poly.Display(den, opts...))
+        // den*series - num must vanish up to x^(m+n)
+        diff := den.Mul(poly.NewPolynomial(series[:m+n+1]), opts...).Sub(num)
+        for i := 0; i <= m+n; i++ {
+            if diff.Coeff(i).Sign() != 0 {
+                exitOnError(errors.New("pade: the approximant misses the series"))
+            }
+        }
+        fmt.Printf("%s den·series − num = O(x^%d)\n", colorize("check:", "\033[1;35m"), m+n+1)
+    case "quiz":
+        // quiz [<f> <g>]
+        switch len(args) {
+        case 0:
+            quiz(cfg, nil, nil, opts...)
+        case 2:
+            quiz(cfg, parsePolyArg(args[0]), parsePolyArg(args[1]), opts...)
+        default:
+            usage()
+        }
+    case "interpolate":
+        // interpolate <x0,y0;x1,y1;...> [newton|lagrange]
+        if len(args) != 1 && !(len(args) == 2 && (args[1] == "newton" || args[1] == "lagrange")) {
+            usage()
+        }
+        points, err := poly.ParsePoints(args[0])
+        exitOnError(err)
+        xs, ys := make([]*big.Rat, len(points)), make([]*big.Rat, len(points))
+        for i, pt := range points {
+            xs[i], ys[i] = pt[0], pt[1]
+        }
+        var p *poly.Polynomial
+        if len(args) == 2 && args[1] == "lagrange" {
+            p, err = poly.InterpolateLagrange(xs, ys)
+            exitOnError(err)
+        } else {
+            diff, err := poly.DividedDifferences(xs, ys)
+            exitOnError(err)
+            fmt.Printf("%s %s\n", colorize("divided differences:", "\033[1;36m"), poly.RatList(diff))
+            p, err = poly.InterpolateNewton(xs, ys)
+            exitOnError(err)
+        }
+        fmt.Printf("%s %s\n", colorize("p(x):", "\033[1;33m"), poly.Display(p, opts...))
+        for i, x := range xs {
+            if p.Eval(x).Cmp(ys[i]) != 0 {
+                exitOnError(errors.New("interpolate: the polynomial misses a point"))
+            }
+        }
+        fmt.Printf("%s p passes through all %d points\n", colorize("check:", "\033[1;35m"), len(xs))
+    case "partialfrac":
+        // partialfrac <num> <den>
+        if len(args) != 2 {
+            usage()
+        }
+        exitOnError(partialFractionsDemo(parsePolyArg(args[0]), parsePolyArg(args[1]), opts...))
+    case "eval":
+        // eval <f> <x>...
+        if len(args) < 2 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        points := make([]*big.Rat, len(args)-1)
+        for i, s := range args[1:] {
+            x, ok := new(big.Rat).SetString(s)
+            if !ok {
+                usage()
+            }
+            points[i] = x
+        }
+        for i, v := range f.EvalMany(points) {
+            root := ""
+            if v.Sign() == 0 {
+                root = colorize(" (root)", "\033[1;32m")
+            }
+            fmt.Printf("%s %s%s\n", colorize(fmt.Sprintf("f(%s):", points[i].RatString()), "\033[1;36m"), v.RatString(), root)
+        }
+    case "evalbench":
+        // evalbench
+        exitOnError(multipointBench())
+    case "gcdint":
+        // gcdint <a> <b>
+        if len(args) != 2 {
+            usage()
+        }
+        a, okA := new(big.Int).SetString(args[0], 10)
+        b, okB := new(big.Int).SetString(args[1], 10)
+        if !okA || !okB {
+            usage()
+        }
+        exitOnError(intGCDDemo(a, b))
+    case "goppa":
+        // goppa [<m> <t> [<errors>]]
+        if len(args) == 1 || len(args) > 3 {
+            usage()
+        }
+        m, t := 8, 10
+        if len(args) >= 2 {
+            m, t = atoiOrUsage(args[0]), atoiOrUsage(args[1])
+        }
+        errs := t
+        if len(args) == 3 {
+            var err error
+            if errs, err = strconv.Atoi(args[2]); err != nil || errs < 0 {
+                usage()
+            }
+        }
+        exitOnError(goppaDemo(m, t, errs, opts...))
+    case "welch-berlekamp":
+        // welch-berlekamp [<message> [<errors>]]
+        if len(args) > 2 {
+            usage()
+        }
+        message, errs := "Euclid", 2
+        if len(args) >= 1 {
+            message = args[0]
+        }
+        if len(args) == 2 {
+            var err error
+            if errs, err = strconv.Atoi(args[1]); err != nil || errs < 0 {
+                usage()
+            }
+        }
+        if message == "" {
+            usage()
+        }
+        welchBerlekampDemo(message, errs, opts...)
+    case "reedsolomon":
+        // reedsolomon [<message> [<errors>]]
+        if len(args) > 2 {
+            usage()
+        }
+        message, errs := "Euclid", 3
+        if len(args) >= 1 {
+            message = args[0]
+        }
+        if len(args) == 2 {
+            var err error
+            if errs, err = strconv.Atoi(args[1]); err != nil || errs < 1 {
+                usage()
+            }
+        }
+        if message == "" {
+            usage()
+        }
+        exitOnError(reedSolomonDemo(message, errs, opts...))
+    case "valuation":
+        // valuation <f> [<a>]
+        if len(args) != 1 && len(args) != 2 {
+            usage()
+        }
+        f := parsePolyArg(args[0])
+        fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+        fmt.Printf("%s %d\n", colorize("order of vanishing at 0:", "\033[1;36m"), f.ValuationAtZero())
+        if len(args) == 2 {
+            a, ok := new(big.Rat).SetString(args[1])
+            if !ok {
+                usage()
+            }
+            fmt.Printf("%s %d\n", colorize(fmt.Sprintf("multiplicity of %s:", a.RatString()), "\033[1;36m"), f.ValuationAt(a))
+        }
+    case "intersect":
+        // intersect <control points> <control points>
+        if len(args) != 2 {
+            usage()
+        }
+        var curves [2]poly.PlaneCurve
+        for i, arg := range args {
+            points, err := poly.ParseControlPoints(arg)
+            if err != nil {
+                fmt.Fprintln(os.Stderr, err)
+                os.Exit(2)
+            }
+            curves[i] = poly.BezierCurve(points)
+        }
+        intersectDemo(curves[0], curves[1])
+    case "fuzz":
+        // fuzz [<iterations>]
+        iterations := 10000
+        if len(args) == 1 {
+            iterations = atoiOrUsage(args[0])
+        } else if len(args) > 1 {
+            usage()
+        }
+        if err := runFuzz(iterations, opts...); err != nil {
+            // runFuzz has printed the reproducer
+            os.Exit(1)
+        }
+    case "markdown":
+        // markdown <f> <g> [<file>]
+        if len(args) != 2 && len(args) != 3 {
+            usage()
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        if g.IsZero() {
+            fmt.Fprintln(os.Stderr, "g must be nonzero")
+            os.Exit(2)
+        }
+        out := os.Stdout
+        if len(args) == 3 {
+            file, err := os.Create(args[2])
+            exitOnError(err)
+            defer file.Close()
+            out = file
+        }
+        exitOnError(poly.WriteMarkdown(out, f, g))
+    case "diagram":
+        // diagram <f> <g> [dot|mermaid] [<file>]
+        if len(args) < 2 || len(args) > 4 {
+            usage()
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        if g.IsZero() {
+            fmt.Fprintln(os.Stderr, "g must be nonzero")
+            os.Exit(2)
+        }
+        write := poly.WriteDOT
+        if len(args) >= 3 {
+            var ok bool
+            if write, ok = poly.DiagramFormats[args[2]]; !ok {
+                usage()
+            }
+        }
+        out := os.Stdout
+        if len(args) == 4 {
+            file, err := os.Create(args[3])
+            exitOnError(err)
+            defer file.Close()
+            out = file
+        }
+        exitOnError(write(out, f, g))
+    case "certificate":
+        // certificate <f> <g> [json|lean|coq] [<file>]
+        if len(args) < 2 || len(args) > 4 {
+            usage()
+        }
+        f, g := parsePolyArg(args[0]), parsePolyArg(args[1])
+        if g.IsZero() {
+            fmt.Fprintln(os.Stderr, "g must be nonzero")
+            os.Exit(2)
+        }
+        write := poly.WriteCertificateJSON
+        if len(args) >= 3 {
+            var ok bool
+            if write, ok = poly.CertificateFormats[args[2]]; !ok {
+                usage()
+            }
+        }
+        out := os.Stdout
+        if len(args) == 4 {
+            file, err := os.Create(args[3])
+            exitOnError(err)
+            defer file.Close()
+            out = file
+        }
+        exitOnError(write(out, f, g))
+    default:
+        usage()
+    }
+}
+
+// parsePoly parses a polynomial written as a comma-separated list of
+// rational coefficients, highest degree first, e.g. "1,0,-1/2" for
+// x^2 - 1/2, or as an expression such as "x^2 - 1/2"
+func parsePoly(s string) (*poly.Polynomial, error) {
+    p, err := poly.ParseCoefficients(s)
+    var syntax *poly.SyntaxError
+    if errors.As(err, &syntax) && !strings.Contains(s, ",") {
+        p, err = poly.ParsePolynomial(s)
+    }
+    return p, err
+}
+
+// parsePolyArg parses a polynomial given on the command line as parsePoly
+// reads it, exiting with the parse error
+func parsePolyArg(s string) *poly.Polynomial {
+    p, err := parsePoly(s)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(2)
+    }
+    return p
+}
+
+// startsWithFlag reports whether args begin with one of the named flags,
+// given as -name, --name or -name=value, which selects the flag form of a
+// command over its positional one. A coefficient list such as "-1,2" is
+// not taken for a flag.
+func startsWithFlag(args []string, names ...string) bool {
+    if len(args) == 0 || !strings.HasPrefix(args[0], "-") {
+        return false
+    }
+    arg := strings.TrimPrefix(strings.TrimPrefix(args[0], "-"), "-")
+    if i := strings.IndexByte(arg, '='); i >= 0 {
+        arg = arg[:i]
+    }
+    for _, name := range names {
+        if arg == name {
+            return true
+        }
+    }
+    return false
+}
+
+// newFlagSet returns a flag set for the flag form of the command name that
+// prints the usage and exits on a parse error
+func newFlagSet(name string) *flag.FlagSet {
+    fs := flag.NewFlagSet(name, flag.ExitOnError)
+    fs.Usage = usage
+    return fs
+}
+
+// exitOnError reports a non-nil err and exits with status 2
+func exitOnError(err error) {
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(2)
+    }
+}
+
+func atoiOrUsage(s string) int {
+    n, err := strconv.Atoi(s)
+    if err != nil || n < 1 {
+        usage()
+    }
+    return n
+}
+
+func usage() {
+    fmt.Fprintln(os.Stderr, "usage:")
+    fmt.Fprintln(os.Stderr, "  euclid [--full] [--verbose] [--verify] [--lang en|es|ru] [--seed <n>]")
+    fmt.Fprintln(os.Stderr, "         [--mul auto|naive|karatsuba|kronecker|ntt]")
+    fmt.Fprintln(os.Stderr, "         [--inverse euclid|almost|consttime] [--gcd euclid|halfgcd|subresultant]")
+    fmt.Fprintln(os.Stderr, "         [--monic] [--json] [--format plain|unicode|latex]")
+    fmt.Fprintln(os.Stderr, "         [--input <file>|- [--output <file>]] [--trace] [<command>]")
+    fmt.Fprintln(os.Stderr, "                                     interactive mode, or one of the commands below;")
+    fmt.Fprintln(os.Stderr, "                                     --full prints polynomials of degree above 100 in full,")
+    fmt.Fprintln(os.Stderr, "                                     --verbose adds coefficient statistics to GCD reports,")
+    fmt.Fprintln(os.Stderr, "                                     --verify checks s*f + t*g = gcd in GCD reports and tests,")
+    fmt.Fprintln(os.Stderr, "                                     --lang the language of prompts and labels (or EUCLID_LANG),")
+    fmt.Fprintln(os.Stderr, "                                     --seed makes random inputs reproducible,")
+    fmt.Fprintln(os.Stderr, "                                     --mul selects the multiplication algorithm,")
+    fmt.Fprintln(os.Stderr, "                                     --inverse the modular polynomial inversion algorithm,")
+    fmt.Fprintln(os.Stderr, "                                     --gcd the extended GCD algorithm (halfgcd over GF(p) only,")
+    fmt.Fprintln(os.Stderr, "                                     subresultant over Q only; elsewhere they are an error),")
+    fmt.Fprintln(os.Stderr, "                                     --monic scales gcd, s and t so that the gcd is monic,")
+    fmt.Fprintln(os.Stderr, "                                     --json writes GCD reports (gcd, interactive mode, test -n) as JSON,")
+    fmt.Fprintln(os.Stderr, "                                     --format writes polynomials as ASCII, with Unicode superscripts")
+    fmt.Fprintln(os.Stderr, "                                     or as LaTeX,")
+    fmt.Fprintln(os.Stderr, "                                     --input runs the GCD on every \"f ; g\" line of file (- for")
+    fmt.Fprintln(os.Stderr, "                                     stdin) instead of prompting, --output writes the reports to file,")
+    fmt.Fprintln(os.Stderr, "                                     --trace adds a table of every division step with the quotient,")
+    fmt.Fprintln(os.Stderr, "                                     remainder, s and t to GCD reports (gcd, interactive mode)")
+    fmt.Fprintln(os.Stderr, "  euclid gcd <f> <g> [--squarefree]  extended GCD of f and g, optionally of their squarefree parts")
+    fmt.Fprintln(os.Stderr, "  euclid gcd -f <f> -g <g> [-squarefree]")
+    fmt.Fprintln(os.Stderr, "                                     the same with flags, for scripts")
+    fmt.Fprintln(os.Stderr, "  euclid test -n <count> [-workers <n>] [-length <maxLength> [-samples <n>] [-loglog] [-out <file>] [-csv <file>]]")
+    fmt.Fprintln(os.Stderr, "                                     the random tests of interactive mode: count random pairs on n")
+    fmt.Fprintln(os.Stderr, "                                     goroutines (one per CPU) with a summary and, with -length,")
+    fmt.Fprintln(os.Stderr, "                                     timings up to maxLength plotted to file (plot.png) as for bench")
+    fmt.Fprintln(os.Stderr, "                                     and, with -csv, written as a table")
+    fmt.Fprintln(os.Stderr, "  euclid gcdjob <f> <g> <file> [<seconds>]")
+    fmt.Fprintln(os.Stderr, "                                     extended GCD saving its state to file every 60 seconds (and")
+    fmt.Fprintln(os.Stderr, "                                     on Ctrl-C); run again with the same arguments to resume")
+    fmt.Fprintln(os.Stderr, "  euclid rpc                         serve JSON-RPC 2.0 on stdin/stdout, one request per line")
+    fmt.Fprintln(os.Stderr, "                                     (methods gcd, div, add, sub, mul, eval, ...; see \"methods\")")
+    fmt.Fprintln(os.Stderr, "  euclid conformance [--update] [<file>]")
+    fmt.Fprintln(os.Stderr, "                                     check the canonical outputs of the exact operations against")
+    fmt.Fprintln(os.Stderr, "                                     testdata/conformance.txt, or regenerate it with --update")
+    fmt.Fprintln(os.Stderr, "  euclid gcdvectors [--update] [<file>]")
+    fmt.Fprintln(os.Stderr, "                                     check the published extended GCD vectors of")
+    fmt.Fprintln(os.Stderr, "                                     testdata/gcd_vectors.json, or regenerate them with --update")
+    fmt.Fprintln(os.Stderr, "  euclid gcd-backend [<backend> <f> <g>]")
+    fmt.Fprintln(os.Stderr, "                                     extended GCD with coefficients in a backend (rat, modp:<p>,")
+    fmt.Fprintln(os.Stderr, "                                     fixed:<bits>, ...); without arguments, list the backends")
+    fmt.Fprintln(os.Stderr, "  euclid ratfunc <num> <den> [<x>]   the rational function num/den in lowest terms and its value at x")
+    fmt.Fprintln(os.Stderr, "  euclid ratcalc <num> <den> +|-|*|/ <num> <den>")
+    fmt.Fprintln(os.Stderr, "                                     arithmetic on rational functions")
+    fmt.Fprintln(os.Stderr, "  euclid recurrence <c_1,...,c_d> <a_0,...,a_(d-1)> [<terms>]")
+    fmt.Fprintln(os.Stderr, "                                     closed form of a_n = c_1 a_(n-1) + ... + c_d a_(n-d) by partial fractions")
+    fmt.Fprintln(os.Stderr, "  euclid guess-recurrence [<numbers>] shortest linear recurrence of a sequence (read from stdin")
+    fmt.Fprintln(os.Stderr, "                                     if not given), its characteristic polynomial and closed form")
+    fmt.Fprintln(os.Stderr, "  euclid divide <p> <q> [ascii|latex|synthetic]")
+    fmt.Fprintln(os.Stderr, "                                     show p / q in the long-division layout or, for")
+    fmt.Fprintln(os.Stderr, "                                     linear q, as a synthetic-division tableau")
+    fmt.Fprintln(os.Stderr, "  euclid resultant <f> <g>           resultant of f and g, 0 exactly when they have a common root")
+    fmt.Fprintln(os.Stderr, "  euclid sylvester <f> <g>           Sylvester matrix and its determinant by Bareiss elimination, checked")
+    fmt.Fprintln(os.Stderr, "                                     against the resultant from the remainder sequence")
+    fmt.Fprintln(os.Stderr, "  euclid linalg det|rank <matrix>    exact determinant or rank of a rational matrix written \"a,b;c,d\"")
+    fmt.Fprintln(os.Stderr, "  euclid linalg solve <matrix> <b>   solution x of a x = b, b written \"e,f\"")
+    fmt.Fprintln(os.Stderr, "  euclid discriminant <f>            discriminant of f, 0 exactly when f has a multiple root")
+    fmt.Fprintln(os.Stderr, "  euclid squarefree <f>              square-free decomposition of f by Yun's algorithm")
+    fmt.Fprintln(os.Stderr, "  euclid derivative <f>              the formal derivative f'")
+    fmt.Fprintln(os.Stderr, "  euclid integral <f> [<c>]          the antiderivative of f with constant term c (default 0)")
+    fmt.Fprintln(os.Stderr, "  euclid compose <f> <g>             the composition f(g(x))")
+    fmt.Fprintln(os.Stderr, "  euclid markdown <f> <g> [<file>]   write the worked computation as Markdown")
+    fmt.Fprintln(os.Stderr, "  euclid diagram <f> <g> [dot|mermaid] [<file>]")
+    fmt.Fprintln(os.Stderr, "                                     the run as a Graphviz (default) or Mermaid diagram: nodes")
+    fmt.Fprintln(os.Stderr, "                                     are the pairs (f, g), edges are labelled with the quotients")
+    fmt.Fprintln(os.Stderr, "  euclid certificate <f> <g> [json|lean|coq] [<file>]")
+    fmt.Fprintln(os.Stderr, "                                     division steps, cofactors and gcd as JSON, or as Lean 4 or Coq")
+    fmt.Fprintln(os.Stderr, "                                     theorems proving s*f + t*g = gcd, gcd | f and gcd | g")
+    fmt.Fprintln(os.Stderr, "  euclid reciprocal <f>              reciprocal polynomial, self-reciprocity and gcd with f")
+    fmt.Fprintln(os.Stderr, "  euclid basis <f> [<x>]             coefficients of f in the Chebyshev, Bernstein and binomial bases")
+    fmt.Fprintln(os.Stderr, "                                     and, given x, f(x) evaluated in those bases")
+    fmt.Fprintln(os.Stderr, "  euclid plot <f> <lo> <hi> [<samples> [<file>]]")
+    fmt.Fprintln(os.Stderr, "                                     plot f on [lo, hi] from equally spaced samples (default 1000,")
+    fmt.Fprintln(os.Stderr, "                                     curve.png), evaluated by exact forward differencing and refined")
+    fmt.Fprintln(os.Stderr, "                                     where the curve is steep, with the real roots marked exactly")
+    fmt.Fprintln(os.Stderr, "  euclid dual <f> <x>                f(x) and f'(x) at once by evaluating at the dual number x + ε")
+    fmt.Fprintln(os.Stderr, "  euclid interval <f> <radius> <x> [<bits>]")
+    fmt.Fprintln(os.Stderr, "                                     enclose f(x), x a number or lo:hi, with coefficients known")
+    fmt.Fprintln(os.Stderr, "                                     to ±radius, exactly and rounded outward to 2^-bits")
+    fmt.Fprintln(os.Stderr, "  euclid padic <f> <p> <k>           lift the simple roots of f mod p to Z/p^k by Newton (Hensel) iteration")
+    fmt.Fprintln(os.Stderr, "  euclid gfp <p> <f> <g>             extended GCD of f and g over GF(p) and the inverse of f in GF(p)[x]/(g)")
+    fmt.Fprintln(os.Stderr, "  euclid gfpt <p> <f> <g> [<x> <t>]  extended GCD of f and g in GF(p)(t)[x], written in x and t")
+    fmt.Fprintln(os.Stderr, "                                     or in the variables named x and t")
+    fmt.Fprintln(os.Stderr, "  euclid eval <f> <x>...             f at the points x, marking roots; many points at once by a remainder tree")
+    fmt.Fprintln(os.Stderr, "  euclid evalbench                   benchmark multipoint evaluation against Horner's scheme point by point")
+    fmt.Fprintln(os.Stderr, "  euclid encode <f>                  canonical byte encoding of f in hex, and its SHA-256")
+    fmt.Fprintln(os.Stderr, "  euclid decode <hex>                the polynomial of a canonical encoding")
+    fmt.Fprintln(os.Stderr, "  euclid bezout <f> <g>              minimal Bézout cofactors, deg s < deg(g/gcd), deg t < deg(f/gcd)")
+    fmt.Fprintln(os.Stderr, "  euclid inverse <f> <m>             inverse of f in Q[x]/(m)")
+    fmt.Fprintln(os.Stderr, "  euclid pipeline <f> [<op> [<g>]...] runs add|sub|mul|quo|mod|compose <g>, derivative, monic on f in order")
+    fmt.Fprintln(os.Stderr, "  euclid pade <c_0,c_1,...> <m> <n>  [m/n] Padé approximant of the power series c_0 + c_1 x + ...")
+    fmt.Fprintln(os.Stderr, "  euclid quiz [<f> <g>]              asks for the quotient or remainder of each Euclidean step, on a random")
+    fmt.Fprintln(os.Stderr, "                                     pair by default, and keeps score")
+    fmt.Fprintln(os.Stderr, "  euclid interpolate <x0,y0;x1,y1;...> [newton|lagrange]")
+    fmt.Fprintln(os.Stderr, "                                     the polynomial through the points, from divided differences")
+    fmt.Fprintln(os.Stderr, "                                     (default) or the Lagrange form")
+    fmt.Fprintln(os.Stderr, "  euclid crt <r> <m> [<r> <m>...]    the polynomial x with x = r mod m for pairwise coprime moduli m")
+    fmt.Fprintln(os.Stderr, "  euclid partialfrac <num> <den>     partial fractions of num/den over the square-free factors of den")
+    fmt.Fprintln(os.Stderr, "  euclid divisors <maxDegree> <factor> <multiplicity> [<factor> <multiplicity>...]")
+    fmt.Fprintln(os.Stderr, "                                     monic divisors of degree at most maxDegree (all if negative)")
+    fmt.Fprintln(os.Stderr, "                                     of the product of pairwise coprime factors")
+    fmt.Fprintln(os.Stderr, "  euclid gcdint <a> <b>              extended GCD of two integers of any size, with Bézout check")
+    fmt.Fprintln(os.Stderr, "  euclid reedsolomon [<message> [<errors>]]")
+    fmt.Fprintln(os.Stderr, "                                     encode message with a Reed–Solomon code over GF(2^8), corrupt")
+    fmt.Fprintln(os.Stderr, "                                     some symbols (default 3) and decode with Sugiyama's algorithm")
+    fmt.Fprintln(os.Stderr, "  euclid welch-berlekamp [<message> [<errors>]]")
+    fmt.Fprintln(os.Stderr, "                                     send message as values of a polynomial, corrupt some (default")
+    fmt.Fprintln(os.Stderr, "                                     2) and decode it again by Welch–Berlekamp rational interpolation")
+    fmt.Fprintln(os.Stderr, "  euclid goppa [<m> <t> [<errors>]]  binary Goppa code of length 2^m correcting t errors (default 8 10):")
+    fmt.Fprintln(os.Stderr, "                                     flip errors bits (default t) and decode by Patterson's algorithm")
+    fmt.Fprintln(os.Stderr, "  euclid valuation <f> [<a>]         order of vanishing of f at 0 and multiplicity of the root a")
+    fmt.Fprintln(os.Stderr, "  euclid aberth <f> [<digits> [<factor>]]")
+    fmt.Fprintln(os.Stderr, "                                     complex roots of f to many digits (Aberth–Ehrlich),")
+    fmt.Fprintln(os.Stderr, "                                     marking those that are roots of the exact factor")
+    fmt.Fprintln(os.Stderr, "  euclid bairstow <f>                real quadratic factors of f by Bairstow's method")
+    fmt.Fprintln(os.Stderr, "  euclid graeffe <f> [<iterations>]  root magnitude estimates by Graeffe root squaring, writes graeffe.png")
+    fmt.Fprintln(os.Stderr, "  euclid roots <f> <g> [<file>]      plot the complex roots of f and g, highlighting common ones")
+    fmt.Fprintln(os.Stderr, "  euclid intersect <points> <points> intersect two Bézier curves given by control points \"x0,y0;x1,y1;...\"")
+    fmt.Fprintln(os.Stderr, "  euclid wilkinson [<n> [<k> <delta>]]")
+    fmt.Fprintln(os.Stderr, "                                     root displacement of Wilkinson's polynomial after adding delta")
+    fmt.Fprintln(os.Stderr, "                                     to the coefficient of x^k (default 20, 19, -2^-23), writes wilkinson.png")
+    fmt.Fprintln(os.Stderr, "  euclid fibonacci <maxIndex>        integer Euclid on Fibonacci numbers vs. Lamé's bound, writes fibonacci.png")
+    fmt.Fprintln(os.Stderr, "  euclid modbench                    benchmark word-size vs. big.Int modular arithmetic and inverses")
+    fmt.Fprintln(os.Stderr, "  euclid race <f> <g> [<strategy>...]")
+    fmt.Fprintln(os.Stderr, "                                     run the extended GCD with several strategies at once (default")
+    fmt.Fprintln(os.Stderr, "                                     naive, kronecker, rat; also auto or a backend such as modp:<p>)")
+    fmt.Fprintln(os.Stderr, "                                     streaming their progress, and rank them by finishing time")
+    fmt.Fprintln(os.Stderr, "  euclid cost <f> <g> [<strategy>]   predicted running time of the extended GCD next to the measured one")
+    fmt.Fprintln(os.Stderr, "  euclid costfit                     refit the running-time model of cost on this machine")
+    fmt.Fprintln(os.Stderr, "  euclid gf2bench                    benchmark the binary (shift-and-xor) GCD over GF(2) vs. Euclid")
+    fmt.Fprintln(os.Stderr, "  euclid halfgcdbench                extended GCD over GF(p) up to degree 10000: remainder sequence vs. half-GCD")
+    fmt.Fprintln(os.Stderr, "  euclid subresbench                 extended GCD over Q: rational Euclid vs. subresultant PRS")
+    fmt.Fprintln(os.Stderr, "  euclid invbench                    benchmark extended Euclid vs. almost inverse in GF(2^m) and GF(p)[x]/(m)")
+    fmt.Fprintln(os.Stderr, "  euclid ctcheck                     check the constant-time inverses against Euclid and their fixed divstep counts")
+    fmt.Fprintln(os.Stderr, "  euclid backendbench                extended GCD with the rat and gmp backends (build with -tags gmp)")
+    fmt.Fprintln(os.Stderr, "  euclid comparebench [-out <file>]  Markdown report comparing GCD and product with the gmp backend and float64")
+    fmt.Fprintln(os.Stderr, "                                     coefficients, gonum with -tags compare_gonum, other libraries through a Competitor adapter")
+    fmt.Fprintln(os.Stderr, "  euclid mulbench                    benchmark schoolbook, Karatsuba, Kronecker and NTT multiplication")
+    fmt.Fprintln(os.Stderr, "  euclid bench <family> <maxLength>  benchmark a corpus family, writes plot.png")
+    fmt.Fprintln(os.Stderr, "  euclid bench [-family <family>] -max <maxLength> [-out <file>]")
+    fmt.Fprintln(os.Stderr, "                                     the same with flags (family random, file plot.png by default)")
+    fmt.Fprintln(os.Stderr, "                                     per length the mean time per call of -samples pairs (3) with")
+    fmt.Fprintln(os.Stderr, "                                     min-max error bars and a fitted n^k curve, -loglog for log axes")
+    fmt.Fprintln(os.Stderr, "                                     [-algorithms <a,b,...>] compares GCD strategies on the same pairs")
+    fmt.Fprintln(os.Stderr, "                                     in one chart, each over its field: subresultant over Q, halfgcd")
+    fmt.Fprintln(os.Stderr, "                                     over GF(p) with [-mod <p>] (2^31-1 by default), euclid over GF(p)")
+    fmt.Fprintln(os.Stderr, "                                     with -mod and over Q without")
+    fmt.Fprintln(os.Stderr, "                                     [-csv <file>] writes degree, time, allocations and iterations")
+    fmt.Fprintln(os.Stderr, "                                     [-cpuprofile <file>] [-memprofile <file>] write pprof profiles")
+    fmt.Fprintln(os.Stderr, "  euclid corpus [<family> <degree>]  list corpus families or print one input pair")
+    fmt.Fprintln(os.Stderr, "  euclid fuzz [<iterations>]         run the fuzz targets on random inputs")
+    fmt.Fprintln(os.Stderr, "")
+    fmt.Fprintln(os.Stderr, "polynomials are given as coefficient lists, highest degree first: \"1,0,-1/2\" is x^2 - 1/2")
+    os.Exit(2)
+}
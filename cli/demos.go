@@ -0,0 +1,680 @@
+package cli
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "math/cmplx"
+    "sort"
+    "strings"
+
+    "euclid/codes/goppa"
+    "euclid/intring"
+    "euclid/poly"
+    "euclid/polymod"
+)
+
+// intGCDDemo prints the extended Euclidean algorithm on the integers a and
+// b: the gcd, the Bézout coefficients, the number of division steps and the
+// check of s*a + t*b = gcd
+func intGCDDemo(a, b *big.Int) error {
+    gcd, s, t, err := poly.ExtendedGCDInt(a, b)
+    if err != nil {
+        return err
+    }
+    _, _, _, steps := intring.ExtendedEuclidean(a, b)
+    fmt.Printf("%s %s\n", colorize("a:", "\033[1;32m"), a)
+    fmt.Printf("%s %s\n", colorize("b:", "\033[1;32m"), b)
+    fmt.Printf("%s %s\n", colorize("GCD:", "\033[1;33m"), gcd)
+    fmt.Printf("%s %s\n", colorize("s:", "\033[1;36m"), s)
+    fmt.Printf("%s %s\n", colorize("t:", "\033[1;36m"), t)
+    fmt.Printf("%s %d\n", colorize("Division steps:", "\033[1;35m"), steps)
+    fmt.Printf("%s (%s)·(%s) + (%s)·(%s) = %s\n", colorize("Check:", "\033[1;32m"), s, a, t, b, gcd)
+    return nil
+}
+
+// aberthDemo prints the roots of f to the requested number of digits and,
+// if factor is not nil, marks the roots that belong to it
+func aberthDemo(f *poly.Polynomial, digits int, factor *poly.Polynomial) {
+    roots, err := poly.AberthRoots(f, digits)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+    }
+    var matches []bool
+    if factor != nil {
+        matches = poly.RootsOfFactor(roots, factor)
+    }
+    for i, z := range roots {
+        line := fmt.Sprintf("%s %s", colorize("root:", "\033[1;36m"), z.Text(digits))
+        if matches != nil && matches[i] {
+            line += " " + colorize("(root of factor)", "\033[1;33m")
+        }
+        fmt.Println(line)
+    }
+}
+
+// bairstowDemo prints the real quadratic factorization of f and the roots of
+// each factor
+func bairstowDemo(f *poly.Polynomial) {
+    factors, err := poly.BairstowFactors(f)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+    }
+    for _, factor := range factors {
+        c := factor.Coeffs
+        var term string
+        if len(c) == 3 {
+            term = fmt.Sprintf("x^2 %+.10g*x %+.10g", c[1], c[0])
+        } else {
+            term = fmt.Sprintf("x %+.10g", c[0])
+        }
+        fmt.Printf("%s %s\n", colorize("factor:", "\033[1;33m"), term)
+        for _, root := range factor.Roots {
+            if imag(root) == 0 {
+                fmt.Printf("    %s %.10g\n", colorize("root:", "\033[1;36m"), real(root))
+            } else {
+                fmt.Printf("    %s %.10g %+.10gi\n", colorize("root:", "\033[1;36m"), real(root), imag(root))
+            }
+        }
+    }
+}
+
+// backendGCDDemo prints the extended GCD of f and g computed with the
+// backend selected by spec
+func backendGCDDemo(spec string, f, g *poly.Polynomial) error {
+    b, err := poly.NewBackend(spec)
+    if err != nil {
+        return err
+    }
+    gcd, s, t, err := poly.BackendGCD(b, f, g)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize("backend:", "\033[1;34m"), b.Name())
+    fmt.Printf("%s %s\n", colorize("GCD:", "\033[1;33m"), gcd)
+    fmt.Printf("%s %s\n", colorize("s(x):", "\033[1;36m"), s)
+    fmt.Printf("%s %s\n", colorize("t(x):", "\033[1;36m"), t)
+    return nil
+}
+
+// intersectDemo intersects the two Bézier curves given by their control
+// points and prints the intersection parameters and points
+func intersectDemo(a, b poly.PlaneCurve) {
+    fmt.Printf("%s (%v, %v)\n", colorize("curve 1:", "\033[1;32m"), a.X(), a.Y())
+    fmt.Printf("%s (%v, %v)\n", colorize("curve 2:", "\033[1;32m"), b.X(), b.Y())
+    points, err := poly.Intersections(a, b)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+        return
+    }
+    if len(points) == 0 {
+        fmt.Println(colorize("no intersections for parameters in [0, 1]", "\033[1;33m"))
+    }
+    for _, p := range points {
+        fmt.Printf("%s s = %.12g, t = %.12g at (%.12g, %.12g)\n", colorize("intersection:", "\033[1;33m"), p.S, p.T, p.X, p.Y)
+    }
+}
+
+// divisorsDemo prints the monic divisors of degree at most maxDegree of the
+// product of the factors, one per line with its degree, and how many of all
+// the divisors they are
+func divisorsDemo(factors []poly.Factor, maxDegree int, opts ...poly.Option) error {
+    it, err := poly.Divisors(factors, maxDegree)
+    if err != nil {
+        return err
+    }
+    count := 0
+    for d, ok := it.Next(); ok; d, ok = it.Next() {
+        count++
+        fmt.Printf("%s %s\n", colorize(fmt.Sprintf("degree %d:", d.Deg()), "\033[1;36m"), poly.Display(d, opts...))
+    }
+    fmt.Printf("%s %d of %s\n", colorize("divisors:", "\033[1;35m"), count, poly.NumDivisors(factors))
+    return nil
+}
+
+// fibonacciDemo prints the table of poly.FibonacciWorstCase and returns
+// its plot
+func fibonacciDemo(maxIndex int) *poly.Figure {
+    rows, fig := poly.FibonacciWorstCase(maxIndex)
+    fmt.Printf("%s\n", colorize(fmt.Sprintf("%6s %12s %12s %12s", "k", "digits(F_k)", "iterations", "Lamé bound"), "\033[1;34m"))
+    for _, row := range rows {
+        fmt.Printf("%6d %12d %12d %12d\n", row.K, row.Digits, row.Iterations, row.LameBound)
+    }
+    return fig
+}
+
+// graeffeDemo prints the root magnitude estimates of f for each Graeffe
+// iteration and returns the plot of their convergence
+func graeffeDemo(f *poly.Polynomial, iterations int) (*poly.Figure, error) {
+    estimates, fig, err := poly.GraeffeMagnitudes(f, iterations)
+    if err != nil {
+        return nil, err
+    }
+    for k, row := range estimates {
+        fmt.Printf("%s", colorize(fmt.Sprintf("iteration %2d:", k+1), "\033[1;34m"))
+        for _, r := range row {
+            fmt.Printf(" %.10g", r)
+        }
+        fmt.Println()
+    }
+    return fig, nil
+}
+
+// plotCurveDemo plots f on [lo, hi] with poly.PlotCurve, printing the
+// sampling times, the real roots and the number of points plotted, and
+// returns the plot
+func plotCurveDemo(f *poly.Polynomial, lo, hi *big.Rat, samples int) (*poly.Figure, error) {
+    plot, err := poly.PlotCurve(f, lo, hi, samples)
+    if err != nil {
+        return nil, err
+    }
+    fmt.Printf("%s %d points by forward differences in %v (Horner: %v)\n",
+        colorize("sampled", "\033[1;35m"), plot.Samples, plot.Differencing, plot.Horner)
+    for i, r := range plot.Roots {
+        exact := ""
+        if plot.Exact[i] {
+            exact = " (exact)"
+        }
+        fmt.Printf("%s %s%s\n", colorize("root:", "\033[1;36m"), r.FloatString(15), exact)
+    }
+    fmt.Printf("%s %d points after adaptive refinement\n", colorize("plotted", "\033[1;35m"), plot.Plotted)
+    return plot.Figure, nil
+}
+
+// plotRootsDemo plots the roots of f and g with poly.PlotRoots, printing
+// their GCD and its roots, and returns the plot
+func plotRootsDemo(f, g *poly.Polynomial, opts ...poly.Option) (*poly.Figure, error) {
+    plot, err := poly.PlotRoots(f, g)
+    if err != nil {
+        return nil, err
+    }
+    fmt.Printf("%s %s\n", colorize("GCD:", "\033[1;33m"), poly.Display(plot.GCD, opts...))
+    for _, z := range plot.Common {
+        fmt.Printf("%s %s\n", colorize("common root:", "\033[1;36m"), z.Text(12))
+    }
+    return plot.Figure, nil
+}
+
+// wilkinsonDemo runs poly.Wilkinson, printing W, the perturbation and
+// both sets of roots with their displacement, and returns the plot
+func wilkinsonDemo(n, k int, delta *big.Rat, opts ...poly.Option) (*poly.Figure, error) {
+    res, err := poly.Wilkinson(n, k, delta)
+    if res == nil {
+        return nil, err
+    }
+    fmt.Printf("%s %s\n", colorize("W(x):", "\033[1;32m"), poly.Display(res.W, opts...))
+    fmt.Printf("%s coefficient of x^%d changed by %s\n\n", colorize("Perturbation:", "\033[1;32m"), k, delta.RatString())
+    if err != nil {
+        return nil, err
+    }
+    show := func(title string, roots []poly.DisplacedRoot) {
+        fmt.Println(colorize(title, "\033[1;34m"))
+        for _, r := range roots {
+            fmt.Printf("    %-45s displacement %.3g\n", r.Root.Text(12), r.Displacement)
+        }
+    }
+    show("Roots of the perturbed polynomial (exact coefficients):", res.Perturbed)
+    show("Roots of W with coefficients rounded to float64:", res.Rounded)
+    return res.Figure, nil
+}
+
+// funcFieldDemo prints the extended GCD of f and g in GF(p)(t)[x], given as
+// polymod.ParseFuncFieldPolyVars expressions in the variables vars, and
+// checks the Bezout identity
+func funcFieldDemo(p uint64, f, g string, vars polymod.FuncFieldVars) error {
+    fp, err := polymod.ParseFuncFieldPolyVars(f, p, vars)
+    if err != nil {
+        return err
+    }
+    gp, err := polymod.ParseFuncFieldPolyVars(g, p, vars)
+    if err != nil {
+        return err
+    }
+    gcd, s, t, err := polymod.ExtendedGCDFuncField(fp, gp)
+    if err != nil {
+        return err
+    }
+    field := fmt.Sprintf("GF(%d)(%s)", p, vars.T)
+    x := vars.X
+    fmt.Printf("%s %s\n", colorize("field:", "\033[1;34m"), field)
+    fmt.Printf("%s %s\n", colorize("f("+x+"):", "\033[1;32m"), fp)
+    fmt.Printf("%s %s\n", colorize("g("+x+"):", "\033[1;32m"), gp)
+    fmt.Printf("%s %s\n", colorize("GCD:", "\033[1;33m"), gcd)
+    fmt.Printf("%s %s\n", colorize("s("+x+"):", "\033[1;36m"), s)
+    fmt.Printf("%s %s\n", colorize("t("+x+"):", "\033[1;36m"), t)
+    sf, err := s.Mul(fp)
+    if err != nil {
+        return err
+    }
+    tg, err := t.Mul(gp)
+    if err != nil {
+        return err
+    }
+    sum, err := sf.Add(tg)
+    if err != nil {
+        return err
+    }
+    if !sum.Equal(gcd) {
+        return fmt.Errorf("ratfunc: s*f + t*g != gcd over %s", field)
+    }
+    fmt.Printf("%s s*f + t*g = gcd\n", colorize("check:", "\033[1;35m"))
+    return nil
+}
+
+// bitString formats bits as a string of 0s and 1s, eliding the middle of
+// long ones
+func bitString(b []byte) string {
+    const keep = 32
+    var s strings.Builder
+    for i, v := range b {
+        if len(b) > 2*keep && i == keep {
+            fmt.Fprintf(&s, "...(%d bits)...", len(b)-2*keep)
+        }
+        if len(b) <= 2*keep || i < keep || i >= len(b)-keep {
+            s.WriteByte('0' + v&1)
+        }
+    }
+    return s.String()
+}
+
+// goppaDemo builds a binary Goppa code of length 2^m correcting t errors,
+// encodes a random message, flips errs random bits of the codeword and
+// decodes it again with Patterson's algorithm
+func goppaDemo(m, t, errs int, opts ...poly.Option) error {
+    code, err := goppa.New(m, t, 1<<m, poly.NewRand(opts...))
+    if err != nil {
+        return err
+    }
+    if errs < 0 || errs > code.N() {
+        return fmt.Errorf("goppa: cannot flip %d of %d bits", errs, code.N())
+    }
+    rng := poly.NewRand(opts...)
+    message := make([]byte, code.K())
+    for i := range message {
+        message[i] = byte(rng.Intn(2))
+    }
+    word, err := code.Encode(message)
+    if err != nil {
+        return err
+    }
+    flips := rng.Perm(code.N())[:errs]
+    sort.Ints(flips)
+    for _, i := range flips {
+        word[i] ^= 1
+    }
+
+    fmt.Printf("%s GF(2^%d) built on %s\n", colorize("field:", "\033[1;34m"), m, code.FieldPolynomial())
+    fmt.Printf("%s %s\n", colorize("g(x):", "\033[1;32m"), code.GoppaPolynomial())
+    fmt.Printf("%s [n = %d, k = %d] corrects t = %d errors\n", colorize("code:", "\033[1;34m"), code.N(), code.K(), code.T())
+    fmt.Printf("%s %s\n", colorize("message:", "\033[1;33m"), bitString(message))
+    fmt.Printf("%s %d bits flipped at %v\n", colorize("sent:", "\033[1;36m"), errs, flips)
+
+    decoded, flipped, err := code.Decode(word)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+        return nil
+    }
+    fmt.Printf("%s %v\n", colorize("errors located at:", "\033[1;35m"), flipped)
+    verdict := "equal to the message"
+    if string(decoded) != string(message) {
+        verdict = colorize("not the message", "\033[1;31m")
+    }
+    fmt.Printf("%s %s, %s\n", colorize("decoded:", "\033[1;32m"), bitString(decoded), verdict)
+    return nil
+}
+
+// intervalDemo widens the coefficients of f by ±radius and encloses f on x,
+// both exactly and with outward rounding to 2^-bits, reporting whether a
+// root of some polynomial in the family can lie in x
+func intervalDemo(f *poly.Polynomial, radius *big.Rat, x poly.Interval, bits int) {
+    enc := poly.EncloseInterval(f, radius, x, bits)
+    fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), enc.Family)
+    w, _ := enc.Exact.Width().Float64()
+    fmt.Printf("%s %s (width %.6g)\n", colorize("f(x) enclosure, exact:", "\033[1;36m"), enc.Exact, w)
+    w, _ = enc.Rounded.Width().Float64()
+    fmt.Printf("%s %s (width %.6g)\n", colorize(fmt.Sprintf("f(x) enclosure, rounded to 2^-%d:", bits), "\033[1;36m"), enc.Rounded, w)
+    if enc.Rounded.ContainsZero() {
+        fmt.Println(colorize("0 is enclosed: x may contain a root", "\033[1;33m"))
+    } else {
+        fmt.Println(colorize("0 is excluded: no polynomial in the family has a root in x", "\033[1;33m"))
+    }
+}
+
+// padicDemo finds the simple roots of f modulo p and lifts each to a root in
+// Z/p^k, printing the p-adic digits after each Newton step and the resulting
+// linear factor x - a of f over Z/p^k
+func padicDemo(f *poly.Polynomial, p *big.Int, k int, opts ...poly.Option) {
+    lifts, err := poly.PadicRoots(f, p, k)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+        return
+    }
+    fmt.Printf("%s %s over Z/%s^%d\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...), p, k)
+
+    if len(lifts) == 0 {
+        fmt.Println(colorize(fmt.Sprintf("no roots modulo %s", p), "\033[1;33m"))
+        return
+    }
+    for _, lift := range lifts {
+        fmt.Printf("%s %s mod %s\n", colorize("root:", "\033[1;36m"), lift.Root, p)
+        if lift.Err != nil {
+            fmt.Printf("    %s\n", colorize(lift.Err.Error(), "\033[1;31m"))
+            continue
+        }
+        for i, prec := range lift.Precisions {
+            fmt.Printf("    step %d: correct to p^%d\n", i+1, prec)
+        }
+        a := lift.Lifted
+        fmt.Printf("    %s %s = %s\n", colorize("lifted:", "\033[1;33m"), a, lift.Digits)
+        fmt.Printf("    %s x - %s, f(a) has valuation %d\n", colorize("factor:", "\033[1;33m"), a, lift.Valuation)
+        if lift.Cofactor != nil {
+            fmt.Printf("    %s (x - %s)(%s)\n", colorize("f(x) =", "\033[1;33m"), a, lift.Cofactor)
+        }
+    }
+}
+
+// formatFactorization writes lead * a_1^m_1 * ... with parenthesized
+// factors, e.g. "2 * (x - 1)^2 * (x^2 + 1)"
+func formatFactorization(lead *big.Rat, factors []poly.Factor, opts ...poly.Option) string {
+    parts := []string{lead.RatString()}
+    for _, fa := range factors {
+        s := "(" + poly.Display(fa.Poly, opts...) + ")"
+        if fa.Multiplicity > 1 {
+            s += fmt.Sprintf("^%d", fa.Multiplicity)
+        }
+        parts = append(parts, s)
+    }
+    return strings.Join(parts, " * ")
+}
+
+// squarefreeDemo prints the square-free decomposition of f, each factor
+// with its multiplicity, and checks that the factors multiply back to f
+func squarefreeDemo(f *poly.Polynomial, opts ...poly.Option) error {
+    lead, factors, err := poly.SquarefreeFactorization(f, opts...)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+    product := poly.Constant(lead)
+    for _, fa := range factors {
+        fmt.Printf("%s %s\n", colorize(fmt.Sprintf("multiplicity %d:", fa.Multiplicity), "\033[1;36m"), poly.Display(fa.Poly, opts...))
+        for k := 0; k < fa.Multiplicity; k++ {
+            product = product.Mul(fa.Poly)
+        }
+    }
+    fmt.Printf("%s %s\n", colorize("factorization:", "\033[1;33m"), formatFactorization(lead, factors, opts...))
+    if !product.Equal(f) {
+        return fmt.Errorf("squarefree: the factors multiply to %s, not f", product)
+    }
+    fmt.Printf("%s the factors multiply back to f\n", colorize("check:", "\033[1;35m"))
+    return nil
+}
+
+// formatPartialFractions writes polyPart + (A)/(f)^j + ..., leaving out a zero
+// polynomial part
+func formatPartialFractions(polyPart *poly.Polynomial, terms []poly.PartialFraction, opts ...poly.Option) string {
+    var parts []string
+    if !polyPart.IsZero() || len(terms) == 0 {
+        parts = append(parts, poly.Display(polyPart, opts...))
+    }
+    for _, t := range terms {
+        s := "(" + poly.Display(t.Num, opts...) + ")/(" + poly.Display(t.Den, opts...) + ")"
+        if t.Power > 1 {
+            s += fmt.Sprintf("^%d", t.Power)
+        }
+        parts = append(parts, s)
+    }
+    return strings.Join(parts, " + ")
+}
+
+// partialFractionsDemo prints the partial fraction decomposition of
+// num / den over the square-free factorization of den, one term per line,
+// and checks that the terms add back up to num / den
+func partialFractionsDemo(num, den *poly.Polynomial, opts ...poly.Option) error {
+    if den.IsZero() {
+        return poly.ErrZeroDenominator
+    }
+    lead, factors, err := poly.SquarefreeFactorization(den, opts...)
+    if err != nil {
+        return err
+    }
+    polyPart, terms, err := poly.PartialFractions(num.Mul(poly.Constant(new(big.Rat).Inv(lead))), factors)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s (%s)/(%s)\n", colorize("f(x):", "\033[1;32m"), poly.Display(num, opts...), poly.Display(den, opts...))
+    fmt.Printf("%s %s\n", colorize("denominator:", "\033[1;36m"), formatFactorization(lead, factors, opts...))
+    fmt.Printf("%s %s\n", colorize("polynomial part:", "\033[1;36m"), poly.Display(polyPart, opts...))
+    sum, _ := poly.NewRationalFunction(polyPart, poly.One())
+    for _, t := range terms {
+        fmt.Printf("%s %s\n", colorize(fmt.Sprintf("power %d:", t.Power), "\033[1;36m"), formatPartialFractions(poly.Zero(), []poly.PartialFraction{t}, opts...))
+        power := poly.One()
+        for k := 0; k < t.Power; k++ {
+            power = power.Mul(t.Den)
+        }
+        term, _ := poly.NewRationalFunction(t.Num, power)
+        sum = sum.Add(term)
+    }
+    fmt.Printf("%s %s\n", colorize("partial fractions:", "\033[1;33m"), formatPartialFractions(polyPart, terms, opts...))
+    want, _ := poly.NewRationalFunction(num, den)
+    if !sum.Equal(want) {
+        return fmt.Errorf("partial fractions: the terms add up to %s, not f", sum)
+    }
+    fmt.Printf("%s the terms add back up to f\n", colorize("check:", "\033[1;35m"))
+    return nil
+}
+
+// polyModDemo prints the extended GCD of f and g reduced modulo p and, when
+// g has positive degree, the inverse of f modulo g by the strategy
+// polymod.WithInverseStrategy selects
+func polyModDemo(f, g *poly.Polynomial, p uint64, opts ...polymod.Option) error {
+    fp, err := f.ModP(p)
+    if err != nil {
+        return err
+    }
+    gp, err := g.ModP(p)
+    if err != nil {
+        return err
+    }
+    gcd, s, t, err := polymod.ExtendedGCDMod(fp, gp, opts...)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize(fmt.Sprintf("f mod %d:", p), "\033[1;32m"), fp)
+    fmt.Printf("%s %s\n", colorize(fmt.Sprintf("g mod %d:", p), "\033[1;32m"), gp)
+    fmt.Printf("%s %s\n", colorize("GCD:", "\033[1;33m"), gcd)
+    fmt.Printf("%s %s\n", colorize("s(x):", "\033[1;36m"), s)
+    fmt.Printf("%s %s\n", colorize("t(x):", "\033[1;36m"), t)
+    if gp.Deg() < 1 {
+        return nil
+    }
+    inv, err := polymod.InverseMod(fp, gp, opts...)
+    if err != nil {
+        fmt.Printf("%s %v\n", colorize("f^-1 mod g:", "\033[1;35m"), err)
+        return nil
+    }
+    prod, err := fp.Mul(inv)
+    if err != nil {
+        return err
+    }
+    _, check, err := prod.Div(gp)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s (f * f^-1 mod g = %s)\n", colorize("f^-1 mod g:", "\033[1;35m"), inv, check)
+    return nil
+}
+
+// raceDemo races strategies on f and g, printing the progress as it
+// streams in and then a table of the entrants in the order they finished
+// with their time relative to the winner
+func raceDemo(f, g *poly.Polynomial, strategies []string, opts ...poly.Option) error {
+    fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+    fmt.Printf("%s %s\n", colorize("g(x):", "\033[1;32m"), poly.Display(g, opts...))
+    width := 0
+    for _, s := range strategies {
+        if len(s) > width {
+            width = len(s)
+        }
+    }
+    results, err := poly.Race(f, g, strategies, func(p poly.RaceProgress) {
+        status := fmt.Sprintf("step %d, remainder of degree %d", p.Iterations, p.Degree)
+        if p.Degree < 0 {
+            status = fmt.Sprintf("step %d, remainder zero", p.Iterations)
+        }
+        fmt.Printf("%10.3fms  %-*s  %s\n", float64(p.Elapsed.Microseconds())/1000, width, p.Strategy, status)
+    })
+    if err != nil {
+        return err
+    }
+
+    fmt.Printf("%s %s\n", colorize("winner:", "\033[1;33m"), results[0].Strategy)
+    fmt.Println(colorize(fmt.Sprintf("%5s  %-*s %10s %12s %9s  %s", "place", width, "strategy", "divisions", "time ms", "vs. 1st", "gcd"), "\033[1;34m"))
+    for _, r := range results {
+        gcd := r.GCD
+        if r.Err != nil {
+            gcd = colorize(r.Err.Error(), "\033[1;31m")
+        }
+        fmt.Printf("%5d  %-*s %10d %12.3f %8.2fx  %s\n", r.Place, width, r.Strategy, r.Iterations,
+            float64(r.Elapsed.Microseconds())/1000, r.Elapsed.Seconds()/results[0].Elapsed.Seconds(), gcd)
+    }
+    return nil
+}
+
+// recurrenceDemo prints the generating function and closed form of rec and
+// checks the closed form against the first terms of the recurrence
+func recurrenceDemo(rec poly.LinearRecurrence, count int) {
+    gf := rec.GeneratingFunction()
+    fmt.Printf("%s %s\n", colorize("generating function:", "\033[1;32m"), gf)
+    cf, err := poly.ClosedFormOf(gf)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+        if cf == nil {
+            return
+        }
+    }
+    fmt.Printf("%s a(n) = %s\n", colorize("closed form:", "\033[1;33m"), cf)
+
+    worst := 0.0
+    for n, a := range rec.Terms(count) {
+        exact, _ := a.Float64()
+        v := cf.Term(n)
+        fmt.Printf("    a(%d) = %s, closed form %.12g\n", n, a.RatString(), real(v))
+        if cf.Exact() {
+            if cf.ExactTerm(n).Cmp(a) != 0 {
+                fmt.Println(colorize("    mismatch", "\033[1;31m"))
+            }
+            continue
+        }
+        worst = math.Max(worst, cmplx.Abs(v-complex(exact, 0))/math.Max(1, math.Abs(exact)))
+    }
+    if cf.Exact() {
+        fmt.Println(colorize("closed form is exact and matches every term", "\033[1;36m"))
+    } else {
+        fmt.Printf("%s %.3g\n", colorize("largest relative error:", "\033[1;36m"), worst)
+    }
+}
+
+// guessRecurrence prints the shortest linear recurrence satisfied by seq, its
+// characteristic polynomial and, when the characteristic roots are rational,
+// the exact closed form
+func guessRecurrence(seq []*big.Rat, opts ...poly.Option) {
+    rec, p := poly.GuessRecurrence(seq)
+    l := p.Deg()
+    if l == 0 {
+        fmt.Println(colorize("the sequence is zero", "\033[1;33m"))
+        return
+    }
+    var b strings.Builder
+    for i, c := range rec.Coeffs() {
+        if c.Sign() == 0 {
+            continue
+        }
+        if b.Len() > 0 && c.Sign() > 0 {
+            b.WriteString(" + ")
+        } else if c.Sign() < 0 {
+            b.WriteString(" - ")
+        }
+        fmt.Fprintf(&b, "%s*a(n-%d)", new(big.Rat).Abs(c).RatString(), i+1)
+    }
+    fmt.Printf("%s a(n) = %s\n", colorize("recurrence:", "\033[1;32m"), b.String())
+    fmt.Printf("%s %s\n", colorize("characteristic polynomial:", "\033[1;36m"), poly.Display(p, opts...))
+    if len(seq) < 2*l {
+        fmt.Println(colorize(fmt.Sprintf("only %d terms for a recurrence of order %d: give at least %d to be sure", len(seq), l, 2*l), "\033[1;31m"))
+    }
+
+    cf, err := poly.ClosedFormOf(rec.GeneratingFunction())
+    if err != nil || !cf.Exact() {
+        fmt.Println(colorize("characteristic roots are not all rational: no exact closed form", "\033[1;33m"))
+        return
+    }
+    fmt.Printf("%s a(n) = %s\n", colorize("closed form:", "\033[1;33m"), cf)
+}
+
+// welchBerlekampDemo encodes message as the polynomial with its bytes as
+// coefficients, evaluates it at 1, ..., len(message) + 2*errs, corrupts errs
+// of the values at random and decodes the result again
+func welchBerlekampDemo(message string, errs int, opts ...poly.Option) {
+    rng := poly.NewRand(opts...)
+    k := len(message)
+    n := k + 2*errs
+    coeffs := make([]*big.Rat, k)
+    for i := 0; i < k; i++ {
+        coeffs[i] = big.NewRat(int64(message[i]), 1)
+    }
+    m := poly.NewPolyNoCopy(coeffs)
+
+    xs := make([]*big.Rat, n)
+    ys := make([]*big.Rat, n)
+    for i := range xs {
+        xs[i] = big.NewRat(int64(i+1), 1)
+        ys[i] = m.Eval(xs[i])
+    }
+    corrupted := make(map[int]bool)
+    for len(corrupted) < errs {
+        i := rng.Intn(n)
+        if !corrupted[i] {
+            corrupted[i] = true
+            ys[i] = new(big.Rat).Add(ys[i], big.NewRat(int64(rng.Intn(1000)+1), 1))
+        }
+    }
+
+    fmt.Printf("%s %q as a polynomial of degree %d\n", colorize("message:", "\033[1;33m"), message, k-1)
+    fmt.Printf("%s %d values at x = 1, ..., %d, %d of them corrupted\n", colorize("sent:", "\033[1;36m"), n, n, errs)
+    for i := range xs {
+        value := ys[i].RatString()
+        if corrupted[i] {
+            value = colorize(value, "\033[1;31m")
+        }
+        fmt.Printf("    f(%s) = %s\n", xs[i].RatString(), value)
+    }
+
+    decoded, wrong, err := poly.WelchBerlekampDecode(xs, ys, k)
+    if err != nil {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+        return
+    }
+    positions := make([]string, len(wrong))
+    for i, w := range wrong {
+        positions[i] = xs[w].RatString()
+    }
+    if len(positions) == 0 {
+        positions = []string{"none"}
+    }
+    fmt.Printf("%s %s\n", colorize("errors located at x =", "\033[1;35m"), strings.Join(positions, ", "))
+
+    var text strings.Builder
+    for i := 0; i < k; i++ {
+        c := decoded.Coeff(i)
+        if !c.IsInt() || !c.Num().IsInt64() || c.Num().Int64() < 0 || c.Num().Int64() > 255 {
+            text.WriteString("?")
+            continue
+        }
+        text.WriteByte(byte(c.Num().Int64()))
+    }
+    verdict := "equal to the message"
+    if !decoded.Equal(m) {
+        verdict = colorize("not the message", "\033[1;31m")
+    }
+    fmt.Printf("%s %q, %s\n", colorize("decoded:", "\033[1;32m"), text.String(), verdict)
+}
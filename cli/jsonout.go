@@ -0,0 +1,120 @@
+package cli
+
+import (
+    "encoding/json"
+    "io"
+    "os"
+    "time"
+
+    "euclid/poly"
+)
+
+// jsonPoly is a polynomial as its coefficients, highest degree first like
+// the command line input, each written "num/den"; the zero polynomial is
+// ["0/1"]
+type jsonPoly []string
+
+func toJSONPoly(p *poly.Polynomial) jsonPoly {
+    out := make(jsonPoly, p.Deg()+1)
+    for i := range out {
+        out[i] = p.Coeff(p.Deg() - i).String()
+    }
+    return out
+}
+
+// jsonTiming is GCDTiming in nanoseconds
+type jsonTiming struct {
+    Total         int64 `json:"total"`
+    Divisions     int64 `json:"divisions"`
+    Updates       int64 `json:"updates"`
+    Normalization int64 `json:"normalization"`
+}
+
+// jsonVerification is the outcome of the Bézout check: Status is "pass",
+// "fail" with the Discrepancy of the BezoutError or an Error, or "skipped"
+// when squarefree preprocessing replaced the inputs
+type jsonVerification struct {
+    Status      string   `json:"status"`
+    Discrepancy jsonPoly `json:"discrepancy,omitempty"`
+    Error       string   `json:"error,omitempty"`
+}
+
+// jsonGCDReport is one GCD report: the inputs, the results, the run
+// metadata and the Bézout check, which JSON output always runs, and with
+// --trace the division steps
+type jsonGCDReport struct {
+    F                 jsonPoly         `json:"f"`
+    G                 jsonPoly         `json:"g"`
+    GCD               jsonPoly         `json:"gcd"`
+    S                 jsonPoly         `json:"s"`
+    T                 jsonPoly         `json:"t"`
+    Iterations        int              `json:"iterations"`
+    MaxIterations     int              `json:"max_iterations"`
+    WorstCase         bool             `json:"worst_case"`
+    SquarefreeChanged bool             `json:"squarefree_changed,omitempty"`
+    ElapsedNs         int64            `json:"elapsed_ns,omitempty"`
+    TimingNs          jsonTiming       `json:"timing_ns"`
+    Verification      jsonVerification `json:"verification"`
+    Steps             []jsonStep       `json:"steps,omitempty"`
+}
+
+// jsonTestSummary sums up the random tests of test -n: the pool size, the
+// Bézout check counts and the time per test and in total in nanoseconds
+type jsonTestSummary struct {
+    Workers int   `json:"workers"`
+    Passed  int   `json:"passed"`
+    Failed  int   `json:"failed"`
+    MinNs   int64 `json:"min_ns"`
+    AvgNs   int64 `json:"avg_ns"`
+    MaxNs   int64 `json:"max_ns"`
+    WallNs  int64 `json:"wall_ns"`
+}
+
+// gcdReport builds the report of res for f and g; elapsed is the wall time
+// around the call, left out when 0
+func gcdReport(cfg cliConfig, f, g *poly.Polynomial, res *poly.GCDResult, elapsed time.Duration) jsonGCDReport {
+    r := jsonGCDReport{
+        F: toJSONPoly(f), G: toJSONPoly(g),
+        GCD: toJSONPoly(res.GCD), S: toJSONPoly(res.S), T: toJSONPoly(res.T),
+        Iterations:        res.Iterations,
+        MaxIterations:     res.MaxIterations,
+        WorstCase:         res.WorstCase,
+        SquarefreeChanged: res.SquarefreeChanged,
+        ElapsedNs:         elapsed.Nanoseconds(),
+        TimingNs: jsonTiming{
+            Total:         res.Timing.Total.Nanoseconds(),
+            Divisions:     res.Timing.Divisions.Nanoseconds(),
+            Updates:       res.Timing.Updates.Nanoseconds(),
+            Normalization: res.Timing.Normalization.Nanoseconds(),
+        },
+    }
+    if cfg.trace {
+        r.Steps = jsonSteps(res.Steps)
+    }
+    switch err := poly.Verify(f, g, res.GCD, res.S, res.T); {
+    case res.SquarefreeChanged:
+        r.Verification.Status = "skipped"
+    case err == nil:
+        r.Verification.Status = "pass"
+    default:
+        r.Verification.Status = "fail"
+        if be, ok := err.(*poly.BezoutError); ok {
+            r.Verification.Discrepancy = toJSONPoly(be.Discrepancy)
+        } else {
+            r.Verification.Error = err.Error()
+        }
+    }
+    return r
+}
+
+// writeJSON writes v to stdout as indented JSON
+func writeJSON(v interface{}) {
+    exitOnError(encodeJSON(os.Stdout, v))
+}
+
+// encodeJSON writes v to w as indented JSON
+func encodeJSON(w io.Writer, v interface{}) error {
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(v)
+}
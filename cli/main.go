@@ -0,0 +1,554 @@
+// Package cli is the euclid command: the interactive extended Euclidean
+// algorithm on two polynomials, the random tests, and the demos, exports and
+// benchmarks of poly, polymod and the codes packages. It prints, colors and
+// plots; the packages under it return data only.
+package cli
+
+import (
+    "bufio"
+    "encoding/csv"
+    "fmt"
+    "image/color"
+    "math"
+    "os"
+    "runtime"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "euclid/poly"
+    "euclid/polymod"
+)
+
+func colorize(text, color string) string {
+    return fmt.Sprintf("%s%s%s", color, text, "\033[0m")
+}
+
+// testOutcome is the result of one random test of testExtendedEuclidean
+type testOutcome struct {
+    f, g      *poly.Polynomial
+    res       *poly.GCDResult
+    err       error
+    bezoutErr error
+    elapsed   time.Duration
+}
+
+// testExtendedEuclidean runs the extended GCD on numTests random pairs of
+// degree 1 to 5 across a pool of workers goroutines, checks the Bézout
+// identity of every result and then prints the tests in order, followed by
+// a summary of passes, failures and the minimum, average and maximum time
+// per test. The pairs are drawn before the workers start, so a seed gives
+// the same tests for any number of workers.
+func testExtendedEuclidean(cfg cliConfig, numTests, workers int, opts ...poly.Option) error {
+    if numTests < 0 || workers < 1 {
+        return fmt.Errorf("test: need a test count of at least 0 and at least 1 worker, got %d and %d", numTests, workers)
+    }
+    rng := poly.NewRand(opts...)
+    outcomes := make([]testOutcome, numTests)
+    for i := range outcomes {
+        degreeF := rng.Intn(5) + 1 // Random degree between 1 and 5
+        degreeG := rng.Intn(5) + 1 // Random degree between 1 and 5
+
+        f := poly.RandomPolynomial(rng, degreeF)
+        g := poly.RandomPolynomial(rng, degreeG)
+
+        // Ensure g is not zero
+        for g.IsZero() {
+            g = poly.RandomPolynomial(rng, degreeG)
+        }
+        outcomes[i].f, outcomes[i].g = f, g
+    }
+
+    wallStart := time.Now()
+    jobs := make(chan int)
+    var wg sync.WaitGroup
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                o := &outcomes[i]
+                startTime := time.Now()
+                o.res, o.err = poly.ExtendedGCDResult(o.f, o.g, opts...)
+                o.elapsed = time.Since(startTime)
+                if o.err == nil {
+                    o.bezoutErr = poly.Verify(o.f, o.g, o.res.GCD, o.res.S, o.res.T)
+                }
+            }
+        }()
+    }
+    for i := range outcomes {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    wall := time.Since(wallStart)
+
+    failures := 0
+    var firstF, firstG *poly.Polynomial
+    var minTime, maxTime, sumTime time.Duration
+    reports := []jsonGCDReport{}
+    for i, o := range outcomes {
+        if o.err != nil {
+            return o.err
+        }
+        if o.bezoutErr != nil {
+            failures++
+            if firstF == nil {
+                firstF, firstG = o.f, o.g
+            }
+        }
+        if i == 0 || o.elapsed < minTime {
+            minTime = o.elapsed
+        }
+        if o.elapsed > maxTime {
+            maxTime = o.elapsed
+        }
+        sumTime += o.elapsed
+
+        if cfg.jsonOutput {
+            reports = append(reports, gcdReport(cfg, o.f, o.g, o.res, o.elapsed))
+            continue
+        }
+
+        // Print results
+        fmt.Printf("\n%s %d\n", colorize(tr(cfg.language, "Test"), "\033[1;34m"), i+1)
+        fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(o.f, opts...))
+        fmt.Printf("%s %s\n", colorize("g(x):", "\033[1;32m"), poly.Display(o.g, opts...))
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "GCD:"), "\033[1;33m"), poly.Display(o.res.GCD, opts...))
+        fmt.Printf("%s %s\n", colorize("s(x):", "\033[1;36m"), poly.Display(o.res.S, opts...))
+        fmt.Printf("%s %s\n", colorize("t(x):", "\033[1;36m"), poly.Display(o.res.T, opts...))
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Iterations:"), "\033[1;35m"), o.res.IterationsSummary())
+        fmt.Printf("%s %.6f %s\n", colorize(tr(cfg.language, "Execution time:"), "\033[1;35m"), o.elapsed.Seconds(), tr(cfg.language, "seconds"))
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Phases:"), "\033[1;35m"), o.res.TimingSummary())
+        printVerification(cfg, o.f, o.g, o.res)
+    }
+    var avgTime time.Duration
+    if numTests > 0 {
+        avgTime = sumTime / time.Duration(numTests)
+    }
+    if cfg.jsonOutput {
+        writeJSON(struct {
+            Tests    []jsonGCDReport `json:"tests"`
+            Failures int             `json:"failures"`
+            Summary  jsonTestSummary `json:"summary"`
+        }{reports, failures, jsonTestSummary{
+            Workers: workers, Passed: numTests - failures, Failed: failures,
+            MinNs: minTime.Nanoseconds(), AvgNs: avgTime.Nanoseconds(), MaxNs: maxTime.Nanoseconds(),
+            WallNs: wall.Nanoseconds(),
+        }})
+    } else if numTests > 0 {
+        fmt.Printf("\n%s %s\n", colorize(tr(cfg.language, "Summary:"), "\033[1;34m"),
+            fmt.Sprintf(tr(cfg.language, "%d tests on %d workers, %d passed, %d failed the Bézout check"), numTests, workers, numTests-failures, failures))
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Time per test:"), "\033[1;35m"),
+            fmt.Sprintf(tr(cfg.language, "min %.6f, avg %.6f, max %.6f seconds; wall time %.6f seconds"), minTime.Seconds(), avgTime.Seconds(), maxTime.Seconds(), wall.Seconds()))
+    }
+    if failures > 0 {
+        f, g := poly.ShrinkPair(firstF, firstG, bezoutFails(opts...))
+        return fmt.Errorf(tr(cfg.language, "%d of %d tests failed the Bézout check; smallest failing pair found: f = %s, g = %s"), failures, numTests, f, g)
+    }
+    return nil
+}
+
+// testExtendedEuclideanLength times the extended GCD on pairs of family for
+// every length up to maxLength, samples pairs per length, and plots the mean
+// time per call with error bars from the fastest to the slowest sample,
+// every sample as a point and the power law c·n^k fitted to the means. With
+// logLog both axes are logarithmic, so that the fit is a straight line of
+// slope k. csvFile, when set, gets one row per sample.
+func testExtendedEuclideanLength(cfg cliConfig, maxLength, samples int, logLog bool, family poly.CorpusFamily, file, csvFile string, opts ...poly.Option) error {
+    if maxLength < 1 || samples < 1 {
+        return fmt.Errorf("test: need a length and a sample count of at least 1, got %d and %d", maxLength, samples)
+    }
+    rng := poly.NewRand(opts...)
+    means := make([]poly.Point, maxLength)
+    low, high := make([]float64, maxLength), make([]float64, maxLength)
+    var runs []poly.Point
+    var totalTime time.Duration
+    var table *csv.Writer
+    if csvFile != "" {
+        out, err := os.Create(csvFile)
+        if err != nil {
+            return err
+        }
+        defer out.Close()
+        table = csv.NewWriter(out)
+        table.Write([]string{"length", "sample", "deg_f", "deg_g", "time_ns", "alloc_bytes", "allocs", "iterations"})
+    }
+
+    var before, after runtime.MemStats
+    for i := 1; i <= maxLength; i++ {
+        var sum float64
+        fastest, slowest := math.Inf(1), 0.0
+        for j := 1; j <= samples; j++ {
+            f, g := family(rng, i)
+
+            // the statistics stop the world, so they are read outside the timing
+            runtime.ReadMemStats(&before)
+            startTime := time.Now()
+            res, err := poly.ExtendedGCDResult(f, g, opts...)
+            if err != nil {
+                return err
+            }
+            elapsed := time.Since(startTime)
+            runtime.ReadMemStats(&after)
+            totalTime += elapsed
+            if cfg.verify {
+                if err := poly.Verify(f, g, res.GCD, res.S, res.T); err != nil {
+                    f, g = poly.ShrinkPair(f, g, bezoutFails(opts...))
+                    return fmt.Errorf(tr(cfg.language, "length %d: %v; smallest failing pair found: f = %s, g = %s"), i, err, f, g)
+                }
+            }
+
+            t := elapsed.Seconds()
+            sum += t
+            fastest, slowest = math.Min(fastest, t), math.Max(slowest, t)
+            runs = append(runs, poly.Point{X: float64(i), Y: t})
+            if table != nil {
+                table.Write([]string{strconv.Itoa(i), strconv.Itoa(j), strconv.Itoa(f.Deg()), strconv.Itoa(g.Deg()),
+                    strconv.FormatInt(elapsed.Nanoseconds(), 10),
+                    strconv.FormatUint(after.TotalAlloc-before.TotalAlloc, 10), strconv.FormatUint(after.Mallocs-before.Mallocs, 10),
+                    strconv.Itoa(res.Iterations)})
+            }
+        }
+        mean := sum / float64(samples)
+        means[i-1] = poly.Point{X: float64(i), Y: mean}
+        low[i-1], high[i-1] = mean-fastest, slowest-mean
+    }
+
+    fmt.Printf("%s %.6f %s\n", colorize(tr(cfg.language, "Total execution time:"), "\033[1;35m"), totalTime.Seconds(), tr(cfg.language, "seconds"))
+    if table != nil {
+        table.Flush()
+        if err := table.Error(); err != nil {
+            return err
+        }
+    }
+
+    series := []poly.Series{
+        {Points: runs, Scatter: true, Glyph: poly.GlyphRing, Radius: 1.5, Color: color.Gray{Y: 160}},
+        {Label: tr(cfg.language, "mean"), Points: means, Markers: true, Glyph: poly.GlyphCircle, Color: color.Black, Low: low, High: high},
+    }
+    if c, k, ok := fitPowerLaw(means); ok {
+        label := fmt.Sprintf("%s: t ≈ %.3g·n^%.2f", tr(cfg.language, "fit"), c, k)
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Fitted complexity:"), "\033[1;35m"), label)
+        fit := make([]poly.Point, maxLength)
+        for i := range fit {
+            n := float64(i + 1)
+            fit[i] = poly.Point{X: n, Y: c * math.Pow(n, k)}
+        }
+        series = append(series, poly.Series{Label: label, Points: fit, Color: color.RGBA{R: 200, A: 255}, Dashes: []float64{4, 2}})
+    }
+    return savePlot(&poly.Figure{
+        Title:      tr(cfg.language, "Polynomial Length vs. Execution Time"),
+        XLabel:     tr(cfg.language, "Polynomial Length"),
+        YLabel:     tr(cfg.language, "Time per call (seconds)"),
+        Width:      6,
+        Height:     4,
+        Grid:       true,
+        LegendTop:  true,
+        LegendLeft: true,
+        LogX:       logLog,
+        LogY:       logLog,
+        Series:     series,
+    }, file)
+}
+
+// fitPowerLaw fits t = c·n^k to the points (n, t) by least squares on
+// log t = log c + k·log n, and reports false with fewer than two distinct
+// positive points
+func fitPowerLaw(points []poly.Point) (c, k float64, ok bool) {
+    var n, sx, sy, sxx, sxy float64
+    for _, pt := range points {
+        if pt.X <= 0 || pt.Y <= 0 {
+            continue
+        }
+        x, y := math.Log(pt.X), math.Log(pt.Y)
+        n++
+        sx, sy, sxx, sxy = sx+x, sy+y, sxx+x*x, sxy+x*y
+    }
+    d := n*sxx - sx*sx
+    if n < 2 || d == 0 {
+        return 0, 0, false
+    }
+    k = (n*sxy - sx*sy) / d
+    return math.Exp((sy - k*sx) / n), k, true
+}
+
+// cliConfig holds the settings of the global flags that are not library
+// options; parseGlobalFlags fills it in and main passes it to the commands
+type cliConfig struct {
+    // verbose adds the coefficient statistics of the results to GCD reports
+    // (--verbose)
+    verbose bool
+    // verify checks the Bézout identity of every GCD report (--verify)
+    verify bool
+    // jsonOutput replaces the colored text of the GCD reports (the gcd
+    // command, interactive mode and the random tests of test -n) with JSON
+    // on stdout (--json)
+    jsonOutput bool
+    // trace adds the table of every division step to GCD reports (--trace)
+    trace bool
+    // inputFile and outputFile are the batch files of --input and --output:
+    // a batch reads one pair of polynomials per line from inputFile ("-"
+    // for stdin) instead of prompting, and writes its reports to
+    // outputFile, stdout when empty
+    inputFile, outputFile string
+    // modOpts are the options of the calls over GF(p), which package
+    // polymod takes apart from those of package poly: --seed, --inverse and
+    // --gcd
+    modOpts []polymod.Option
+    // modGCDErr is the error of a --gcd strategy that does not run over
+    // GF(p), such as the subresultant PRS, which the commands over GF(p)
+    // report instead of running
+    modGCDErr error
+    // language selects the language of the prompts and report labels of
+    // the GCD commands and interactive mode: --lang, or the EUCLID_LANG
+    // environment variable, "en" by default. Polynomials, numbers,
+    // summaries formatted by poly and machine-readable outputs (corpus
+    // files, conformance and vector files, benchmark tables) are never
+    // translated.
+    language string
+}
+
+// printVerification checks s*f + t*g against the gcd of res when
+// cfg.verify is set and prints pass or fail with the discrepancy; it
+// reports whether the check passed or was skipped
+func printVerification(cfg cliConfig, f, g *poly.Polynomial, res *poly.GCDResult) bool {
+    if !cfg.verify {
+        return true
+    }
+    err := poly.Verify(f, g, res.GCD, res.S, res.T)
+    if err == nil {
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Bézout check:"), "\033[1;34m"), colorize(tr(cfg.language, "pass"), "\033[1;32m"))
+        return true
+    }
+    fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Bézout check:"), "\033[1;34m"), colorize(tr(cfg.language, "fail"), "\033[1;31m"))
+    if be, ok := err.(*poly.BezoutError); ok {
+        fmt.Printf("%s %s\n", colorize("s·f + t·g:", "\033[1;31m"), be.Sum)
+        fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Discrepancy:"), "\033[1;31m"), be.Discrepancy)
+    } else {
+        fmt.Println(colorize(err.Error(), "\033[1;31m"))
+    }
+    return false
+}
+
+// bezoutFails returns the failure predicate for ShrinkPair that reruns the
+// extended GCD with opts and the Bézout check
+func bezoutFails(opts ...poly.Option) func(f, g *poly.Polynomial) bool {
+    return func(f, g *poly.Polynomial) bool {
+        res, err := poly.ExtendedGCDResult(f, g, opts...)
+        return err != nil || poly.Verify(f, g, res.GCD, res.S, res.T) != nil
+    }
+}
+
+// printCoefficientStats prints the coefficient statistics of the GCD and
+// the cofactors of res when cfg.verbose is set
+func printCoefficientStats(cfg cliConfig, res *poly.GCDResult) {
+    if !cfg.verbose {
+        return
+    }
+    for _, r := range []struct {
+        name string
+        p    *poly.Polynomial
+    }{{"GCD", res.GCD}, {"s(x)", res.S}, {"t(x)", res.T}} {
+        fmt.Printf("%s %s\n", colorize(fmt.Sprintf(tr(cfg.language, "Coefficients of %s:"), r.name), "\033[1;34m"), r.p.CoefficientStatistics())
+    }
+}
+
+// parseGlobalFlags strips the flags that may precede a command and returns
+// the remaining arguments and the options of package poly they select:
+// --full prints large polynomials in full instead of as a summary,
+// --seed <n> makes random inputs reproducible,
+// --mul <strategy> selects the multiplication algorithm and
+// --inverse <strategy> the modular polynomial inversion algorithm,
+// --gcd <strategy> the extended GCD algorithm (half-GCD over GF(p) only,
+// subresultant PRS over Q only), --monic normalizes the GCD to be monic,
+// --format <style> selects plain, Unicode or LaTeX output of polynomials;
+// --verbose, --verify, --trace, --json, --input <file>, --output <file> and
+// --lang <language> set the fields of the returned cliConfig instead, and
+// --seed, --inverse and --gcd select the options of package polymod in its
+// modOpts as well
+func parseGlobalFlags(args []string) ([]string, cliConfig, []poly.Option) {
+    cfg := cliConfig{language: languageFromEnv()}
+    var opts []poly.Option
+    for len(args) > 0 {
+        switch args[0] {
+        case "--full", "-full":
+            opts = append(opts, poly.WithFullOutput(true))
+            args = args[1:]
+        case "--seed":
+            if len(args) < 2 {
+                usage()
+            }
+            seed, err := strconv.ParseInt(args[1], 10, 64)
+            if err != nil {
+                usage()
+            }
+            opts = append(opts, poly.WithSeed(seed))
+            cfg.modOpts = append(cfg.modOpts, polymod.WithSeed(seed))
+            args = args[2:]
+        case "--mul":
+            if len(args) < 2 || !poly.IsMulStrategy(args[1]) {
+                usage()
+            }
+            opts = append(opts, poly.WithStrategy(args[1]))
+            args = args[2:]
+        case "--inverse":
+            if len(args) < 2 || !polymod.IsInverseStrategy(args[1]) {
+                usage()
+            }
+            cfg.modOpts = append(cfg.modOpts, polymod.WithInverseStrategy(args[1]))
+            args = args[2:]
+        case "--gcd":
+            if len(args) < 2 || !poly.IsGCDStrategy(args[1]) {
+                usage()
+            }
+            opts = append(opts, poly.WithGCDStrategy(args[1]))
+            if polymod.IsGCDStrategy(args[1]) {
+                cfg.modOpts = append(cfg.modOpts, polymod.WithGCDStrategy(args[1]))
+            } else {
+                cfg.modGCDErr = poly.CheckGCDStrategy(args[1], "GF(p)")
+            }
+            args = args[2:]
+        case "--monic":
+            opts = append(opts, poly.WithMonicGCD(true))
+            args = args[1:]
+        case "--format":
+            if len(args) < 2 || !poly.IsFormatStyle(args[1]) {
+                usage()
+            }
+            opts = append(opts, poly.WithFormat(args[1]))
+            args = args[2:]
+        case "--input":
+            if len(args) < 2 {
+                usage()
+            }
+            cfg.inputFile = args[1]
+            args = args[2:]
+        case "--output":
+            if len(args) < 2 {
+                usage()
+            }
+            cfg.outputFile = args[1]
+            args = args[2:]
+        case "--json":
+            cfg.jsonOutput = true
+            args = args[1:]
+        case "--verbose", "-v":
+            cfg.verbose = true
+            args = args[1:]
+        case "--lang":
+            if len(args) < 2 || !isLanguage(args[1]) {
+                usage()
+            }
+            cfg.language = args[1]
+            args = args[2:]
+        case "--verify":
+            cfg.verify = true
+            args = args[1:]
+        case "--trace":
+            cfg.trace = true
+            args = args[1:]
+        default:
+            return args, cfg, opts
+        }
+    }
+    return args, cfg, opts
+}
+
+// readCount prompts for a count on stdout and reads it from in, exiting
+// with a message and status 2 when the line is not a whole number of at
+// least 1 or the input has ended
+func readCount(cfg cliConfig, in *bufio.Reader, prompt string) int {
+    fmt.Print("\n" + tr(cfg.language, prompt))
+    var n int
+    if _, err := fmt.Fscanln(in, &n); err != nil {
+        exitOnError(fmt.Errorf(tr(cfg.language, "expected a whole number of at least 1: %v"), err))
+    }
+    if n < 1 {
+        exitOnError(fmt.Errorf(tr(cfg.language, "expected a whole number of at least 1, got %d"), n))
+    }
+    return n
+}
+
+// readPolynomial prompts for a polynomial expression until one parses,
+// exiting at the end of the input; with JSON output the prompts go to
+// stderr, keeping stdout valid JSON
+func readPolynomial(cfg cliConfig, in *bufio.Reader, prompt string) *poly.Polynomial {
+    prompts := os.Stdout
+    if cfg.jsonOutput {
+        prompts = os.Stderr
+    }
+    for {
+        fmt.Fprint(prompts, prompt)
+        line, err := in.ReadString('\n')
+        if line = strings.TrimSpace(line); line != "" {
+            p, perr := poly.ParsePolynomial(line)
+            if perr == nil {
+                return p
+            }
+            fmt.Fprintln(prompts, colorize(perr.Error(), "\033[1;31m"))
+        }
+        if err != nil {
+            os.Exit(1)
+        }
+    }
+}
+
+// Main runs the euclid command on the arguments in os.Args and exits with a
+// nonzero status on errors
+func Main() {
+    args, cfg, opts := parseGlobalFlags(os.Args[1:])
+    if cfg.inputFile != "" {
+        if len(args) > 0 {
+            usage()
+        }
+        exitOnError(batchGCD(cfg, opts...))
+        return
+    }
+    if len(args) > 0 {
+        runCommand(cfg, args[0], args[1:], opts...)
+        return
+    }
+
+    in := bufio.NewReader(os.Stdin)
+    f := readPolynomial(cfg, in, tr(cfg.language, "Enter the first polynomial (e.g. 3x^4 - 2/5x + 7): "))
+    g := readPolynomial(cfg, in, tr(cfg.language, "Enter the second polynomial: "))
+
+    // Start timing
+    startTime := time.Now()
+
+    // Perform extended Euclidean algorithm
+    res, err := poly.ExtendedGCDResult(f, g, opts...)
+    exitOnError(err)
+
+    // End timing
+    endTime := time.Now()
+    totalTime := endTime.Sub(startTime)
+
+    if cfg.jsonOutput {
+        // one JSON document; the random tests have their own command
+        writeJSON(gcdReport(cfg, f, g, res, totalTime))
+        return
+    }
+
+    // Print results
+    fmt.Println()
+    printTrace(cfg, res, opts...)
+    fmt.Printf("%s %s\n", colorize(tr(cfg.language, "GCD of the two polynomials:"), "\033[1;33m"), poly.Display(res.GCD, opts...))
+    fmt.Printf("%s %s\n", colorize("U(x):", "\033[1;36m"), poly.Display(res.S, opts...))
+    fmt.Printf("%s %s\n", colorize("V(x):", "\033[1;36m"), poly.Display(res.T, opts...))
+    fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Iterations:"), "\033[1;35m"), res.IterationsSummary())
+    fmt.Printf("%s %.6f %s\n", colorize(tr(cfg.language, "Execution time:"), "\033[1;35m"), totalTime.Seconds(), tr(cfg.language, "seconds"))
+    fmt.Printf("%s %s\n", colorize(tr(cfg.language, "Phases:"), "\033[1;35m"), res.TimingSummary())
+    printCoefficientStats(cfg, res)
+    printVerification(cfg, f, g, res)
+
+    // Run tests
+    numTests := readCount(cfg, in, "Enter the number of random tests to run: ")
+    exitOnError(testExtendedEuclidean(cfg, numTests, runtime.NumCPU(), opts...))
+
+    numTestsL := readCount(cfg, in, "Enter the length of random polynoms to test: ")
+    exitOnError(testExtendedEuclideanLength(cfg, numTestsL, 3, false, poly.GCDCorpus["random"], "plot.png", "", opts...))
+}
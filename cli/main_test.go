@@ -0,0 +1,170 @@
+package cli
+
+import (
+    "encoding/json"
+    "math"
+    "os"
+    "os/exec"
+    "strings"
+    "testing"
+
+    "euclid/poly"
+)
+
+// cliArgsEnv carries the arguments of a command that TestMain runs in a
+// child process, separated by newlines, so that tests can check the output
+// and exit status of Main
+const cliArgsEnv = "EUCLID_CLI_TEST_ARGS"
+
+func TestMain(m *testing.M) {
+    if args, ok := os.LookupEnv(cliArgsEnv); ok {
+        os.Args = append([]string{"euclid"}, strings.Split(args, "\n")...)
+        Main()
+        os.Exit(0)
+    }
+    os.Exit(m.Run())
+}
+
+// runMain runs Main on args in a child process and returns its stdout and
+// exit status
+func runMain(t *testing.T, args ...string) (string, int) {
+    t.Helper()
+    return runMainInput(t, "", args...)
+}
+
+// runMainInput is runMain with stdin reading input
+func runMainInput(t *testing.T, input string, args ...string) (string, int) {
+    t.Helper()
+    cmd := exec.Command(os.Args[0], "-test.run=^$")
+    cmd.Stdin = strings.NewReader(input)
+    cmd.Env = append(os.Environ(), cliArgsEnv+"="+strings.Join(args, "\n"), "EUCLID_LANG=en")
+    out, err := cmd.Output()
+    if ee, ok := err.(*exec.ExitError); ok {
+        return string(out), ee.ExitCode()
+    } else if err != nil {
+        t.Fatal(err)
+    }
+    return string(out), 0
+}
+
+// TestCommands runs a few commands end to end and checks their output
+func TestCommands(t *testing.T) {
+    cases := []struct {
+        args   []string
+        status int
+        want   []string
+    }{
+        {[]string{"gcdint", "240", "46"}, 0, []string{"2", "(-9)·(240) + (47)·(46) = 2"}},
+        {[]string{"gfp", "7", "1,0,-1", "1,-3,2"}, 0, []string{"x^2 + 6", "x + 6"}},
+        {[]string{"--inverse", "consttime", "gfp", "7", "1,0,-1", "1,-3,2"}, 0, []string{"x + 6"}},
+        {[]string{"--seed", "1", "goppa", "4", "2", "2"}, 0, []string{"x^4 + x + 1", "equal to the message"}},
+        {[]string{"gfp", "6", "1,0,-1", "1,-3,2"}, 2, nil},
+        {[]string{"--gcd", "halfgcd", "gcd", "1,0,-1", "1,-3,2"}, 2, nil},
+        {[]string{"no-such-command"}, 2, nil},
+    }
+    for _, c := range cases {
+        out, status := runMain(t, c.args...)
+        if status != c.status {
+            t.Errorf("euclid %s exited with %d, want %d", strings.Join(c.args, " "), status, c.status)
+        }
+        for _, w := range c.want {
+            if !strings.Contains(out, w) {
+                t.Errorf("euclid %s printed %q, missing %q", strings.Join(c.args, " "), out, w)
+            }
+        }
+    }
+}
+
+// TestInteractiveCounts checks that interactive mode rejects test counts
+// that are not whole numbers of at least 1 with exit status 2
+func TestInteractiveCounts(t *testing.T) {
+    for _, counts := range []string{"-3\n", "0\n", "abc\n", "", "1\n-1\n"} {
+        if _, status := runMainInput(t, "x^2 - 1\nx^2 - 3x + 2\n"+counts); status != 2 {
+            t.Errorf("counts %q: exit status %d, want 2", counts, status)
+        }
+    }
+}
+
+// TestJSONReport checks that --json gcd prints one document with the
+// result and a passing Bézout check
+func TestJSONReport(t *testing.T) {
+    out, status := runMain(t, "--json", "gcd", "1,0,-1", "1,-3,2")
+    if status != 0 {
+        t.Fatalf("exit status %d", status)
+    }
+    var report struct {
+        GCD          []string `json:"gcd"`
+        Verification struct {
+            Status string `json:"status"`
+        } `json:"verification"`
+    }
+    if err := json.Unmarshal([]byte(out), &report); err != nil {
+        t.Fatalf("output is not one JSON document: %v\n%s", err, out)
+    }
+    if strings.Join(report.GCD, ",") != "3/1,-3/1" || report.Verification.Status != "pass" {
+        t.Errorf("gcd %v, verification %q", report.GCD, report.Verification.Status)
+    }
+}
+
+// TestParseGlobalFlags checks that the flags before the command are
+// consumed into the configuration and the options
+func TestParseGlobalFlags(t *testing.T) {
+    args, cfg, opts := parseGlobalFlags([]string{"--seed", "7", "--json", "--trace", "--gcd", "halfgcd", "--inverse", "almost", "gfp", "--json"})
+    if strings.Join(args, " ") != "gfp --json" {
+        t.Errorf("remaining arguments %q", args)
+    }
+    if !cfg.jsonOutput || !cfg.trace || cfg.verify {
+        t.Errorf("configuration %+v", cfg)
+    }
+    if len(opts) != 2 || len(cfg.modOpts) != 3 {
+        t.Errorf("%d poly options and %d polymod options, want 2 and 3", len(opts), len(cfg.modOpts))
+    }
+    if err := poly.CheckOptions(opts...); err != nil {
+        t.Error(err)
+    }
+}
+
+// TestLanguageFlag checks that --lang selects the messages of its own
+// configuration only, so that two configurations do not share a language
+func TestLanguageFlag(t *testing.T) {
+    t.Setenv("EUCLID_LANG", "")
+    _, ru, _ := parseGlobalFlags([]string{"--lang", "ru", "gcd"})
+    _, en, _ := parseGlobalFlags([]string{"gcd"})
+    if got := tr(ru.language, "GCD:"); got != "НОД:" {
+        t.Errorf("--lang ru translates GCD: as %q", got)
+    }
+    if got := tr(en.language, "GCD:"); got != "GCD:" {
+        t.Errorf("the default language translates GCD: as %q", got)
+    }
+}
+
+// TestParseBatchLine checks the "f ; g" batch line format
+func TestParseBatchLine(t *testing.T) {
+    f, g, err := parseBatchLine(" x^2 - 1 ; 1,-3,2 ")
+    wantF, _ := poly.ParseCoefficients("1,0,-1")
+    wantG, _ := poly.ParseCoefficients("1,-3,2")
+    if err != nil || !f.Equal(wantF) || !g.Equal(wantG) {
+        t.Errorf("parsed %v, %v, %v", f, g, err)
+    }
+    for _, line := range []string{"x^2 - 1", "x ; x ; x", "x^2 - ; x"} {
+        if _, _, err := parseBatchLine(line); err == nil {
+            t.Errorf("parseBatchLine(%q) did not fail", line)
+        }
+    }
+}
+
+// TestFitPowerLaw checks that an exact power law is recovered and that too
+// few usable points are reported
+func TestFitPowerLaw(t *testing.T) {
+    var points []poly.Point
+    for n := 1.0; n <= 64; n *= 2 {
+        points = append(points, poly.Point{X: n, Y: 3e-6 * math.Pow(n, 2.5)})
+    }
+    c, k, ok := fitPowerLaw(points)
+    if !ok || math.Abs(k-2.5) > 1e-9 || math.Abs(c/3e-6-1) > 1e-9 {
+        t.Errorf("fit t = %g·n^%g (%v), want 3e-06·n^2.5", c, k, ok)
+    }
+    if _, _, ok := fitPowerLaw([]poly.Point{{X: 2, Y: 1}, {X: 0, Y: 1}, {X: 3, Y: -1}}); ok {
+        t.Error("fit of one usable point reported ok")
+    }
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+    "fmt"
+    "os"
+    "sort"
+    "strings"
+)
+
+// translations maps a language to the translations of the English
+// messages; a message missing from a catalog is printed in English
+var translations = map[string]map[string]string{
+    "ru": {
+        "Enter the first polynomial (e.g. 3x^4 - 2/5x + 7): ": "Введите первый многочлен (например, 3x^4 - 2/5x + 7): ",
+        "Enter the second polynomial: ":                       "Введите второй многочлен: ",
+        "GCD of the two polynomials:":                         "НОД двух многочленов:",
+        "Enter the number of random tests to run: ":           "Введите число случайных тестов: ",
+        "Enter the length of random polynoms to test: ":       "Введите длину случайных многочленов для теста: ",
+        "expected a whole number of at least 1: %v":           "ожидалось целое число не меньше 1: %v",
+        "expected a whole number of at least 1, got %d":       "ожидалось целое число не меньше 1, получено %d",
+        "Test":                  "Тест",
+        "GCD:":                  "НОД:",
+        "Iterations:":           "Итерации:",
+        "Execution time:":       "Время выполнения:",
+        "Total execution time:": "Общее время выполнения:",
+        "Phases:":               "Фазы:",
+        "seconds":               "с",
+        "Coefficients of %s:":   "Коэффициенты %s:",
+        "Bézout check:":         "Проверка Безу:",
+        "pass":                  "успех",
+        "fail":                  "ошибка",
+        "Discrepancy:":          "Расхождение:",
+        "skipped, the inputs were replaced by their squarefree parts": "пропущена, входные данные заменены бесквадратными частями",
+        "Squarefree preprocessing changed the inputs:":                "Бесквадратная предобработка изменила входные данные:",
+        "resumed from":                         "продолжено из",
+        "interrupted, state saved to":          "прервано, состояние сохранено в",
+        "Polynomial Length vs. Execution Time": "Время выполнения в зависимости от длины многочлена",
+        "Polynomial Length":                    "Длина многочлена",
+        "Time per call (seconds)":              "Время одного вызова (с)",
+        "mean":                                 "среднее",
+        "fit":                                  "аппроксимация",
+        "Fitted complexity:":                   "Оценка сложности:",
+        "Fastest from length %d:":              "Быстрее всех с длины %d:",
+        "%d of %d tests failed the Bézout check; smallest failing pair found: f = %s, g = %s": "%d из %d тестов не прошли проверку Безу; наименьшая найденная пара: f = %s, g = %s",
+        "length %d: %v; smallest failing pair found: f = %s, g = %s":                          "длина %d: %v; наименьшая найденная пара: f = %s, g = %s",
+        "Step %d:":         "Шаг %d:",
+        "divide %s by %s":  "разделите %s на %s",
+        "quotient? ":       "частное? ",
+        "remainder? ":      "остаток? ",
+        "quotient:":        "частное:",
+        "remainder:":       "остаток:",
+        "quotient":         "частное",
+        "remainder":        "остаток",
+        "correct":          "верно",
+        "wrong, it is":     "неверно, правильно",
+        "Score:":           "Счёт:",
+        "Line":                  "Строка",
+        "%d of %d pairs failed": "%d из %d пар не прошли",
+        "Summary:":       "Итог:",
+        "Time per test:": "Время на тест:",
+        "%d tests on %d workers, %d passed, %d failed the Bézout check":        "%d тестов на %d потоках, %d прошли, %d не прошли проверку Безу",
+        "min %.6f, avg %.6f, max %.6f seconds; wall time %.6f seconds": "мин. %.6f, сред. %.6f, макс. %.6f с; общее время %.6f с",
+    },
+    "es": {
+        "Enter the first polynomial (e.g. 3x^4 - 2/5x + 7): ": "Introduzca el primer polinomio (p. ej. 3x^4 - 2/5x + 7): ",
+        "Enter the second polynomial: ":                       "Introduzca el segundo polinomio: ",
+        "GCD of the two polynomials:":                         "MCD de los dos polinomios:",
+        "Enter the number of random tests to run: ":           "Introduzca el número de pruebas aleatorias: ",
+        "Enter the length of random polynoms to test: ":       "Introduzca la longitud de los polinomios aleatorios a probar: ",
+        "expected a whole number of at least 1: %v":           "se esperaba un número entero mayor o igual que 1: %v",
+        "expected a whole number of at least 1, got %d":       "se esperaba un número entero mayor o igual que 1, se obtuvo %d",
+        "Test":                  "Prueba",
+        "GCD:":                  "MCD:",
+        "Iterations:":           "Iteraciones:",
+        "Execution time:":       "Tiempo de ejecución:",
+        "Total execution time:": "Tiempo total de ejecución:",
+        "Phases:":               "Fases:",
+        "seconds":               "segundos",
+        "Coefficients of %s:":   "Coeficientes de %s:",
+        "Bézout check:":         "Comprobación de Bézout:",
+        "pass":                  "correcta",
+        "fail":                  "fallida",
+        "Discrepancy:":          "Discrepancia:",
+        "skipped, the inputs were replaced by their squarefree parts": "omitida, las entradas se sustituyeron por sus partes libres de cuadrados",
+        "Squarefree preprocessing changed the inputs:":                "El preprocesado libre de cuadrados cambió las entradas:",
+        "resumed from":                         "reanudado desde",
+        "interrupted, state saved to":          "interrumpido, estado guardado en",
+        "Polynomial Length vs. Execution Time": "Longitud del polinomio frente a tiempo de ejecución",
+        "Polynomial Length":                    "Longitud del polinomio",
+        "Time per call (seconds)":              "Tiempo por llamada (segundos)",
+        "mean":                                 "media",
+        "fit":                                  "ajuste",
+        "Fitted complexity:":                   "Complejidad ajustada:",
+        "Fastest from length %d:":              "Más rápido desde la longitud %d:",
+        "%d of %d tests failed the Bézout check; smallest failing pair found: f = %s, g = %s": "%d de %d pruebas no superaron la comprobación de Bézout; par más pequeño encontrado: f = %s, g = %s",
+        "length %d: %v; smallest failing pair found: f = %s, g = %s":                          "longitud %d: %v; par más pequeño encontrado: f = %s, g = %s",
+        "Step %d:":         "Paso %d:",
+        "divide %s by %s":  "divida %s entre %s",
+        "quotient? ":       "¿cociente? ",
+        "remainder? ":      "¿resto? ",
+        "quotient:":        "cociente:",
+        "remainder:":       "resto:",
+        "quotient":         "cociente",
+        "remainder":        "resto",
+        "correct":          "correcto",
+        "wrong, it is":     "incorrecto, es",
+        "Score:":           "Puntuación:",
+        "Line":                  "Línea",
+        "%d of %d pairs failed": "%d de %d pares fallaron",
+        "Summary:":       "Resumen:",
+        "Time per test:": "Tiempo por prueba:",
+        "%d tests on %d workers, %d passed, %d failed the Bézout check":        "%d pruebas en %d hilos, %d correctas, %d fallaron la comprobación de Bézout",
+        "min %.6f, avg %.6f, max %.6f seconds; wall time %.6f seconds": "mín. %.6f, media %.6f, máx. %.6f s; tiempo total %.6f s",
+    },
+}
+
+// isLanguage reports whether name is "en" or has a catalog
+func isLanguage(name string) bool {
+    _, ok := translations[name]
+    return ok || name == "en"
+}
+
+// languages lists the selectable languages
+func languages() []string {
+    names := []string{"en"}
+    for name := range translations {
+        names = append(names, name)
+    }
+    sort.Strings(names[1:])
+    return names
+}
+
+// languageFromEnv returns the language of EUCLID_LANG, "en" when it is
+// unset or unknown, reporting an unknown value on stderr
+func languageFromEnv() string {
+    name := os.Getenv("EUCLID_LANG")
+    if name == "" {
+        return "en"
+    }
+    if !isLanguage(name) {
+        fmt.Fprintf(os.Stderr, "EUCLID_LANG: unknown language %q (available: %s), using en\n", name, strings.Join(languages(), ", "))
+        return "en"
+    }
+    return name
+}
+
+// tr returns the translation of the English message s into language
+func tr(language, s string) string {
+    if t, ok := translations[language][s]; ok {
+        return t
+    }
+    return s
+}
@@ -0,0 +1,16 @@
+//go:build noplot
+
+package cli
+
+import (
+    "fmt"
+
+    "euclid/poly"
+)
+
+// savePlot fails: built with -tags noplot, the command does not link
+// gonum/plot and has no external dependencies at all, so a command that
+// draws a chart reports that it wrote none instead of succeeding
+func savePlot(fig *poly.Figure, file string) error {
+    return fmt.Errorf("%s not written: plotting is not compiled in (built with -tags noplot)", file)
+}
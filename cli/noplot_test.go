@@ -0,0 +1,23 @@
+//go:build noplot
+
+package cli
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+
+    "euclid/poly"
+)
+
+// TestSavePlotNoplot checks that a build without plotting reports the
+// chart it cannot write as an error and leaves no file behind
+func TestSavePlotNoplot(t *testing.T) {
+    file := filepath.Join(t.TempDir(), "chart.png")
+    if err := savePlot(&poly.Figure{Title: "chart"}, file); err == nil {
+        t.Error("savePlot succeeded without plotting compiled in")
+    }
+    if _, err := os.Stat(file); !os.IsNotExist(err) {
+        t.Errorf("%s exists after savePlot: %v", file, err)
+    }
+}
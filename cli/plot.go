@@ -0,0 +1,13 @@
+//go:build !noplot
+
+package cli
+
+import (
+    "euclid/plotutil"
+    "euclid/poly"
+)
+
+// savePlot renders fig to file with gonum/plot
+func savePlot(fig *poly.Figure, file string) error {
+    return plotutil.Save(fig, file)
+}
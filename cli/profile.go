@@ -0,0 +1,38 @@
+package cli
+
+import (
+    "os"
+    "runtime"
+    "runtime/pprof"
+)
+
+// withProfiles runs fn, writing a pprof CPU profile of it to cpuFile and
+// the allocations it made (the "allocs" profile, after a garbage
+// collection) to memFile; an empty name skips that profile. Read them with
+// go tool pprof.
+func withProfiles(cpuFile, memFile string, fn func() error) error {
+    if cpuFile != "" {
+        file, err := os.Create(cpuFile)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        if err := pprof.StartCPUProfile(file); err != nil {
+            return err
+        }
+        defer pprof.StopCPUProfile()
+    }
+    if err := fn(); err != nil {
+        return err
+    }
+    if memFile != "" {
+        file, err := os.Create(memFile)
+        if err != nil {
+            return err
+        }
+        defer file.Close()
+        runtime.GC()
+        return pprof.Lookup("allocs").WriteTo(file, 0)
+    }
+    return nil
+}
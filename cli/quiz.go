@@ -0,0 +1,92 @@
+package cli
+
+import (
+    "bufio"
+    "fmt"
+    "math/rand"
+    "os"
+    "strings"
+
+    "euclid/poly"
+)
+
+// quizPair returns a random pair for the quiz with small integer
+// coefficients: f of degree 3 and g of degree 2 sharing a linear factor, so
+// that the run ends on a nonconstant GCD after a few steps
+func quizPair(rng *rand.Rand) (*poly.Polynomial, *poly.Polynomial) {
+    for {
+        c := poly.RandomPolynomial(rng, 1)
+        f := poly.RandomPolynomial(rng, 2).Mul(c)
+        g := poly.RandomPolynomial(rng, 1).Mul(c)
+        if f.Deg() == 3 && g.Deg() == 2 {
+            return f, g
+        }
+    }
+}
+
+// runQuiz runs the Euclidean algorithm on f and g one division at a time,
+// asking at each step for the quotient or the remainder, picked at random,
+// and checking the answer exactly; a wrong or empty answer shows the right
+// one. It returns the number of right answers and of questions, which stop
+// early at the end of the input.
+func runQuiz(cfg cliConfig, in *bufio.Reader, f, g *poly.Polynomial, rng *rand.Rand, opts ...poly.Option) (score, asked int) {
+    res, err := poly.ExtendedGCDResult(f, g, opts...)
+    exitOnError(err)
+    for i, st := range res.Steps {
+        fmt.Printf("\n%s %s\n", colorize(fmt.Sprintf(tr(cfg.language, "Step %d:"), i+1), "\033[1;36m"),
+            fmt.Sprintf(tr(cfg.language, "divide %s by %s"), poly.Display(st.Dividend, opts...), poly.Display(st.Divisor, opts...)))
+        question, want, other, otherLabel := tr(cfg.language, "quotient? "), st.Quotient, st.Remainder, tr(cfg.language, "remainder:")
+        if rng.Intn(2) == 1 {
+            question, want, other, otherLabel = tr(cfg.language, "remainder? "), st.Remainder, st.Quotient, tr(cfg.language, "quotient:")
+        }
+        answer, ok := readAnswer(in, question)
+        if !ok {
+            break
+        }
+        asked++
+        if answer != nil && answer.Equal(want) {
+            score++
+            fmt.Println(colorize(tr(cfg.language, "correct"), "\033[1;32m"))
+        } else {
+            fmt.Printf("%s %s\n", colorize(tr(cfg.language, "wrong, it is"), "\033[1;31m"), poly.Display(want, opts...))
+        }
+        fmt.Printf("%s %s\n", colorize(otherLabel, "\033[1;35m"), poly.Display(other, opts...))
+    }
+    if asked == len(res.Steps) {
+        fmt.Printf("\n%s %s\n", colorize(tr(cfg.language, "GCD:"), "\033[1;33m"), poly.Display(res.GCD, opts...))
+    }
+    return score, asked
+}
+
+// readAnswer prompts for a polynomial until the line is empty or parses,
+// returning nil for an empty line and false at the end of the input
+func readAnswer(in *bufio.Reader, prompt string) (*poly.Polynomial, bool) {
+    for {
+        fmt.Print(prompt)
+        line, err := in.ReadString('\n')
+        if line = strings.TrimSpace(line); line == "" {
+            return nil, err == nil
+        }
+        p, perr := poly.ParsePolynomial(line)
+        if perr == nil {
+            return p, true
+        }
+        fmt.Println(colorize(perr.Error(), "\033[1;31m"))
+        if err != nil {
+            return nil, false
+        }
+    }
+}
+
+// quiz runs the quiz on f and g, or on a random pair when they are nil,
+// and prints the score
+func quiz(cfg cliConfig, f, g *poly.Polynomial, opts ...poly.Option) {
+    rng := poly.NewRand(opts...)
+    if f == nil {
+        f, g = quizPair(rng)
+    }
+    fmt.Printf("%s %s\n", colorize("f(x):", "\033[1;32m"), poly.Display(f, opts...))
+    fmt.Printf("%s %s\n", colorize("g(x):", "\033[1;32m"), poly.Display(g, opts...))
+    score, asked := runQuiz(cfg, bufio.NewReader(os.Stdin), f, g, rng, opts...)
+    fmt.Printf("%s %d/%d\n", colorize(tr(cfg.language, "Score:"), "\033[1;33m"), score, asked)
+}
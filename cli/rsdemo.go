@@ -0,0 +1,87 @@
+package cli
+
+import (
+    "fmt"
+    "strings"
+
+    "euclid/poly"
+    "euclid/codes/reedsolomon"
+)
+
+// symbolList writes GF(2^8) symbols as two hex digits each, marking the
+// positions in red
+func symbolList(symbols []uint32, red map[int]bool) string {
+    parts := make([]string, len(symbols))
+    for i, s := range symbols {
+        parts[i] = fmt.Sprintf("%02x", s)
+        if red[i] {
+            parts[i] = colorize(parts[i], "\033[1;31m")
+        }
+    }
+    return strings.Join(parts, " ")
+}
+
+// reedSolomonDemo encodes the bytes of message with the Reed–Solomon code
+// over GF(2^8) correcting errs symbols, corrupts errs symbols of the
+// codeword at random and decodes it with Sugiyama's algorithm
+func reedSolomonDemo(message string, errs int, opts ...poly.Option) error {
+    field, err := reedsolomon.NewField(8)
+    if err != nil {
+        return err
+    }
+    k := len(message)
+    code, err := reedsolomon.New(field, k+2*errs, k)
+    if err != nil {
+        return err
+    }
+    data := make([]uint32, k)
+    for i := range data {
+        data[i] = uint32(message[i])
+    }
+    word, err := code.Encode(data)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s RS(%d, %d) over GF(2^8) mod %#x, correcting %d symbols\n", colorize("code:", "\033[1;36m"), code.N(), code.K(), field.Modulus(), code.T())
+    fmt.Printf("%s %s\n", colorize("generator:", "\033[1;36m"), symbolList(code.Generator(), nil))
+    fmt.Printf("%s %s\n", colorize("sent:", "\033[1;33m"), symbolList(word, nil))
+
+    rng := poly.NewRand(opts...)
+    corrupted := make(map[int]bool)
+    for len(corrupted) < errs {
+        i := rng.Intn(code.N())
+        if !corrupted[i] {
+            corrupted[i] = true
+            word[i] ^= uint32(rng.Intn(255) + 1)
+        }
+    }
+    fmt.Printf("%s %s\n", colorize("received:", "\033[1;33m"), symbolList(word, corrupted))
+
+    syndromes, err := code.Syndromes(word)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize("syndromes:", "\033[1;36m"), symbolList(syndromes, nil))
+    locator, evaluator, err := code.ErrorLocator(syndromes)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %s\n", colorize("error locator:", "\033[1;36m"), symbolList(locator, nil))
+    fmt.Printf("%s %s\n", colorize("error evaluator:", "\033[1;36m"), symbolList(evaluator, nil))
+
+    decoded, positions, err := code.Decode(word)
+    if err != nil {
+        return err
+    }
+    fmt.Printf("%s %v\n", colorize("errors at positions:", "\033[1;35m"), positions)
+    text := make([]byte, len(decoded))
+    for i, s := range decoded {
+        text[i] = byte(s)
+    }
+    verdict := "equal to the message"
+    if string(text) != message {
+        verdict = colorize("not the message", "\033[1;31m")
+    }
+    fmt.Printf("%s %q, %s\n", colorize("decoded:", "\033[1;32m"), text, verdict)
+    return nil
+}
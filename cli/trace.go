@@ -0,0 +1,65 @@
+package cli
+
+import (
+    "fmt"
+    "strings"
+    "unicode/utf8"
+
+    "euclid/poly"
+)
+
+// jsonStep is one division step of a traced JSON report
+type jsonStep struct {
+    Quotient  jsonPoly `json:"quotient"`
+    Remainder jsonPoly `json:"remainder"`
+    S         jsonPoly `json:"s"`
+    T         jsonPoly `json:"t"`
+}
+
+// jsonSteps converts the steps of a GCD result for JSON output
+func jsonSteps(steps []poly.EuclidStep) []jsonStep {
+    out := make([]jsonStep, len(steps))
+    for i, st := range steps {
+        out[i] = jsonStep{toJSONPoly(st.Quotient), toJSONPoly(st.Remainder), toJSONPoly(st.S), toJSONPoly(st.T)}
+    }
+    return out
+}
+
+// printTrace prints the division steps of res as a table when cfg.trace
+// is set: per step the quotient, the remainder and its Bézout cofactors s and
+// t, formatted with opts
+func printTrace(cfg cliConfig, res *poly.GCDResult, opts ...poly.Option) {
+    if !cfg.trace {
+        return
+    }
+    rows := [][]string{{"#", tr(cfg.language, "quotient"), tr(cfg.language, "remainder"), "s", "t"}}
+    for i, st := range res.Steps {
+        rows = append(rows, []string{fmt.Sprint(i + 1),
+            poly.Display(st.Quotient, opts...), poly.Display(st.Remainder, opts...),
+            poly.Display(st.S, opts...), poly.Display(st.T, opts...)})
+    }
+    widths := make([]int, len(rows[0]))
+    for _, row := range rows {
+        for j, cell := range row {
+            if n := utf8.RuneCountInString(cell); n > widths[j] {
+                widths[j] = n
+            }
+        }
+    }
+    line := func(row []string) string {
+        cells := make([]string, len(row))
+        for j, cell := range row {
+            cells[j] = cell + strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell))
+        }
+        return strings.TrimRight(strings.Join(cells, " | "), " ")
+    }
+    fmt.Println(colorize(line(rows[0]), "\033[1;34m"))
+    rule := make([]string, len(widths))
+    for j, w := range widths {
+        rule[j] = strings.Repeat("-", w)
+    }
+    fmt.Println(strings.Join(rule, "-+-"))
+    for _, row := range rows[1:] {
+        fmt.Println(line(row))
+    }
+}
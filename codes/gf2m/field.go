@@ -0,0 +1,103 @@
+// Package gf2m implements the finite fields GF(2^m) for 2 <= m <= 16 and
+// polynomials over them, the arithmetic the Reed–Solomon and Goppa codes
+// share. Field elements are the bit vectors of polynomials over GF(2)
+// modulo a primitive polynomial of degree m, stored in a uint32; polynomials
+// over the field are coefficient slices, lowest degree first. It depends only
+// on the standard library.
+package gf2m
+
+import "fmt"
+
+// Field is GF(2^m) for 2 <= m <= 16. Elements multiply through tables of the
+// powers of the root a of the primitive polynomial.
+type Field struct {
+    m       int
+    modulus uint32
+    // exp[i] = a^i for i < 2(2^m - 1), so sums of two logarithms need no
+    // reduction; log[e] = i with a^i = e for nonzero e
+    exp []uint32
+    log []int
+}
+
+// New returns GF(2^m) built on the smallest primitive polynomial of degree
+// m, 0x11d for m = 8 as in most byte-oriented Reed–Solomon codes
+func New(m int) (*Field, error) {
+    if m < 2 || m > 16 {
+        return nil, fmt.Errorf("gf2m: field degree m = %d is not between 2 and 16", m)
+    }
+    q := 1 << m
+    exp := make([]uint32, 2*(q-1))
+    log := make([]int, q)
+    for poly := uint32(q + 1); poly < uint32(2*q); poly += 2 {
+        // poly is primitive when a generates all q - 1 nonzero elements
+        e, order := uint32(1), 0
+        for {
+            exp[order] = e
+            log[e] = order
+            order++
+            e <<= 1
+            if e&uint32(q) != 0 {
+                e ^= poly
+            }
+            if e == 1 || order == q-1 {
+                break
+            }
+        }
+        if e == 1 && order == q-1 {
+            copy(exp[q-1:], exp[:q-1])
+            return &Field{m, poly, exp, log}, nil
+        }
+    }
+    panic(fmt.Sprintf("gf2m: no primitive polynomial of degree %d", m))
+}
+
+// M returns the degree m of the field over GF(2)
+func (f *Field) M() int { return f.m }
+
+// Size returns the number of elements, 2^m
+func (f *Field) Size() int { return len(f.log) }
+
+// Modulus returns the primitive polynomial of the field as a bit vector
+func (f *Field) Modulus() uint32 { return f.modulus }
+
+// Exp returns a^i for the primitive root a and any integer i
+func (f *Field) Exp(i int) uint32 {
+    order := len(f.log) - 1
+    if i %= order; i < 0 {
+        i += order
+    }
+    return f.exp[i]
+}
+
+// Mul returns a*b
+func (f *Field) Mul(a, b uint32) uint32 {
+    if a == 0 || b == 0 {
+        return 0
+    }
+    return f.exp[f.log[a]+f.log[b]]
+}
+
+// Quo returns a/b for nonzero b
+func (f *Field) Quo(a, b uint32) uint32 {
+    if b == 0 {
+        panic("gf2m: division by zero")
+    }
+    if a == 0 {
+        return 0
+    }
+    return f.exp[f.log[a]+len(f.log)-1-f.log[b]]
+}
+
+// Element writes e as a power of the primitive root a
+func (f *Field) Element(e uint32) string {
+    switch {
+    case e == 0:
+        return "0"
+    case e == 1:
+        return "1"
+    case f.log[e] == 1:
+        return "a"
+    default:
+        return fmt.Sprintf("a^%d", f.log[e])
+    }
+}
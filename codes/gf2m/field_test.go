@@ -0,0 +1,97 @@
+package gf2m_test
+
+import (
+    "math/rand"
+    "testing"
+
+    "euclid/codes/gf2m"
+)
+
+// TestNew checks that the powers of a run through every nonzero element
+// once and that Mul and Quo agree with them
+func TestNew(t *testing.T) {
+    for m := 2; m <= 16; m++ {
+        f, err := gf2m.New(m)
+        if err != nil {
+            t.Fatalf("New(%d): %v", m, err)
+        }
+        if f.M() != m || f.Size() != 1<<m {
+            t.Errorf("GF(2^%d) has degree %d and size %d", m, f.M(), f.Size())
+        }
+        seen := make([]bool, f.Size())
+        for i := 0; i < f.Size()-1; i++ {
+            e := f.Exp(i)
+            if e == 0 || seen[e] {
+                t.Fatalf("GF(2^%d): a^%d = %d repeats or is 0", m, i, e)
+            }
+            seen[e] = true
+            if f.Mul(e, f.Exp(-i)) != 1 || f.Quo(1, e) != f.Exp(-i) {
+                t.Fatalf("GF(2^%d): a^%d times a^-%d is not 1", m, i, i)
+            }
+        }
+    }
+    for _, m := range []int{1, 17} {
+        if _, err := gf2m.New(m); err == nil {
+            t.Errorf("New(%d) did not fail", m)
+        }
+    }
+}
+
+// randomPoly returns a polynomial of degree d over f with random
+// coefficients
+func randomPoly(rng *rand.Rand, f *gf2m.Field, d int) []uint32 {
+    p := make([]uint32, d+1)
+    for i := range p {
+        p[i] = uint32(rng.Intn(f.Size()))
+    }
+    p[d] = uint32(1 + rng.Intn(f.Size()-1))
+    return p
+}
+
+// TestPolynomials checks division, the extended GCD and the inverse and
+// square root modulo an irreducible polynomial on random polynomials over
+// GF(2^4)
+func TestPolynomials(t *testing.T) {
+    f, _ := gf2m.New(4)
+    rng := rand.New(rand.NewSource(1))
+    var g []uint32
+    for g == nil || !f.Irreducible(g) {
+        g = randomPoly(rng, f, 3)
+    }
+    for i := 0; i < 50; i++ {
+        a, b := randomPoly(rng, f, 1+rng.Intn(6)), randomPoly(rng, f, 1+rng.Intn(4))
+        q, r := f.DivPoly(a, b)
+        if gf2m.Deg(r) >= gf2m.Deg(b) || gf2m.Deg(gf2m.Add(a, gf2m.Add(f.MulPoly(q, b), r))) >= 0 {
+            t.Fatalf("%s = (%s)(%s) + %s does not hold", f.Format(a), f.Format(q), f.Format(b), f.Format(r))
+        }
+        if f.Irreducible(f.MulPoly(a, b)) {
+            t.Errorf("%s reported irreducible", f.Format(f.MulPoly(a, b)))
+        }
+        gcd, s := f.ExtendedGCD(a, b)
+        if gf2m.Deg(f.Rem(a, gcd)) >= 0 || gf2m.Deg(f.Rem(b, gcd)) >= 0 || gf2m.Deg(gf2m.Add(f.Rem(f.MulPoly(s, a), b), f.Rem(gcd, b))) >= 0 {
+            t.Errorf("gcd(%s, %s) = %s with s = %s", f.Format(a), f.Format(b), f.Format(gcd), f.Format(s))
+        }
+        z := f.Rem(a, g)
+        if gf2m.Deg(z) < 0 {
+            continue
+        }
+        if inv := f.InverseMod(z, g); gf2m.Deg(f.Rem(f.MulPoly(inv, z), g)) != 0 || f.Rem(f.MulPoly(inv, z), g)[0] != 1 {
+            t.Errorf("(%s)^-1 = %s mod %s", f.Format(z), f.Format(inv), f.Format(g))
+        }
+        if root := f.SqrtMod(z, g); gf2m.Deg(gf2m.Add(f.Rem(f.Square(root), g), z)) >= 0 {
+            t.Errorf("sqrt(%s) = %s mod %s", f.Format(z), f.Format(root), f.Format(g))
+        }
+    }
+}
+
+func TestDerivativeAndFormat(t *testing.T) {
+    f, _ := gf2m.New(4)
+    // (a^2 x^3 + x^2 + a x + 1)' = a^2 x^2 + a
+    p := []uint32{1, f.Exp(1), 1, f.Exp(2), 0}
+    if got, want := f.Format(gf2m.Derivative(p)), "a^2*x^2 + a"; got != want {
+        t.Errorf("derivative %s, want %s", got, want)
+    }
+    if got := f.Format(nil); got != "0" {
+        t.Errorf("zero polynomial formatted as %q", got)
+    }
+}
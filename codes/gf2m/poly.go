@@ -0,0 +1,196 @@
+package gf2m
+
+import (
+    "fmt"
+    "strings"
+)
+
+// The polynomials below are coefficient slices over the field, lowest
+// degree first. Zero leading coefficients are allowed in the arguments; the
+// results have none, so the zero polynomial is empty. Addition is the XOR of
+// the coefficients, as is subtraction.
+
+// Deg returns the degree of p, -1 for the zero polynomial
+func Deg(p []uint32) int {
+    d := len(p) - 1
+    for d >= 0 && p[d] == 0 {
+        d--
+    }
+    return d
+}
+
+// Trim drops the zero leading coefficients of p
+func Trim(p []uint32) []uint32 {
+    return p[:Deg(p)+1]
+}
+
+// Add returns p + q
+func Add(p, q []uint32) []uint32 {
+    if len(p) < len(q) {
+        p, q = q, p
+    }
+    sum := append([]uint32(nil), p...)
+    for i, c := range q {
+        sum[i] ^= c
+    }
+    return Trim(sum)
+}
+
+// Derivative returns the formal derivative of p: in characteristic 2 the
+// terms of even degree vanish
+func Derivative(p []uint32) []uint32 {
+    if len(p) < 2 {
+        return nil
+    }
+    d := make([]uint32, len(p)-1)
+    for i := 1; i < len(p); i += 2 {
+        d[i-1] = p[i]
+    }
+    return Trim(d)
+}
+
+// MulPoly returns p*q
+func (f *Field) MulPoly(p, q []uint32) []uint32 {
+    dp, dq := Deg(p), Deg(q)
+    if dp < 0 || dq < 0 {
+        return nil
+    }
+    prod := make([]uint32, dp+dq+1)
+    for i := 0; i <= dp; i++ {
+        for j := 0; j <= dq; j++ {
+            prod[i+j] ^= f.Mul(p[i], q[j])
+        }
+    }
+    return prod
+}
+
+// Square returns p^2, which in characteristic 2 squares the coefficients
+// and doubles the exponents
+func (f *Field) Square(p []uint32) []uint32 {
+    p = Trim(p)
+    if len(p) == 0 {
+        return nil
+    }
+    sq := make([]uint32, 2*len(p)-1)
+    for i, c := range p {
+        sq[2*i] = f.Mul(c, c)
+    }
+    return sq
+}
+
+// DivPoly returns the quotient and remainder of p by a nonzero q
+func (f *Field) DivPoly(p, q []uint32) (quo, rem []uint32) {
+    dq := Deg(q)
+    if dq < 0 {
+        panic("gf2m: division by the zero polynomial")
+    }
+    rem = append([]uint32(nil), p...)
+    dp := Deg(p)
+    if dp < dq {
+        return nil, Trim(rem)
+    }
+    quo = make([]uint32, dp-dq+1)
+    for d := dp; d >= dq; d-- {
+        c := f.Quo(rem[d], q[dq])
+        if c == 0 {
+            continue
+        }
+        quo[d-dq] = c
+        for i := 0; i <= dq; i++ {
+            rem[d-dq+i] ^= f.Mul(c, q[i])
+        }
+    }
+    return Trim(quo), Trim(rem)
+}
+
+// Rem returns p mod g for a nonzero g
+func (f *Field) Rem(p, g []uint32) []uint32 {
+    _, r := f.DivPoly(p, g)
+    return r
+}
+
+// Eval returns p(x) by Horner's rule
+func (f *Field) Eval(p []uint32, x uint32) uint32 {
+    var v uint32
+    for i := len(p) - 1; i >= 0; i-- {
+        v = f.Mul(v, x) ^ p[i]
+    }
+    return v
+}
+
+// ExtendedGCD returns gcd(a, b) and s with s*a = gcd mod b, by the extended
+// Euclidean algorithm; the gcd is not made monic
+func (f *Field) ExtendedGCD(a, b []uint32) (gcd, s []uint32) {
+    r0, r1 := Trim(a), Trim(b)
+    s0, s1 := []uint32{1}, []uint32(nil)
+    for len(r1) > 0 {
+        q, r := f.DivPoly(r0, r1)
+        r0, r1 = r1, r
+        s0, s1 = s1, Add(s0, f.MulPoly(q, s1))
+    }
+    return r0, s0
+}
+
+// InverseMod returns the inverse of a modulo g, which must be coprime to a
+func (f *Field) InverseMod(a, g []uint32) []uint32 {
+    gcd, s := f.ExtendedGCD(a, g)
+    q, _ := f.DivPoly(s, gcd)
+    return f.Rem(q, g)
+}
+
+// SqrtMod returns the square root of z in GF(2^m)[x]/(g) for an irreducible
+// g of degree t. The ring is the field GF(2^(mt)), in which squaring is a
+// bijection of order mt, so the root is z squared mt - 1 times.
+func (f *Field) SqrtMod(z, g []uint32) []uint32 {
+    for i := 1; i < f.m*Deg(g); i++ {
+        z = f.Rem(f.Square(z), g)
+    }
+    return z
+}
+
+// Irreducible reports whether g of degree t over GF(q), q = 2^m, is
+// irreducible by Ben-Or's test: g has no factor of degree i <= t/2 exactly
+// when gcd(g, x^(q^i) - x) = 1 for each such i
+func (f *Field) Irreducible(g []uint32) bool {
+    if Deg(g) < 1 {
+        return false
+    }
+    x := []uint32{0, 1}
+    h := x
+    for i := 1; i <= Deg(g)/2; i++ {
+        // h = x^(q^i) mod g by m more squarings
+        for j := 0; j < f.m; j++ {
+            h = f.Rem(f.Square(h), g)
+        }
+        if gcd, _ := f.ExtendedGCD(g, Add(h, x)); Deg(gcd) > 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// Format writes p from the highest degree down, its coefficients as powers
+// of a
+func (f *Field) Format(p []uint32) string {
+    p = Trim(p)
+    if len(p) == 0 {
+        return "0"
+    }
+    var b strings.Builder
+    for i := len(p) - 1; i >= 0; i-- {
+        if p[i] == 0 {
+            continue
+        }
+        if b.Len() > 0 {
+            b.WriteString(" + ")
+        }
+        b.WriteString(f.Element(p[i]))
+        switch {
+        case i == 1:
+            b.WriteString("*x")
+        case i > 1:
+            fmt.Fprintf(&b, "*x^%d", i)
+        }
+    }
+    return b.String()
+}
@@ -0,0 +1,225 @@
+// Package goppa implements binary Goppa codes with Patterson's decoder: the
+// error locator comes out of the extended Euclidean algorithm on the Goppa
+// polynomial g and a square root modulo g, stopped at the first remainder of
+// degree at most t/2, the same partial run reedsolomon stops for Sugiyama's
+// decoder. It depends on the standard library, gf2m and polymod only.
+package goppa
+
+import (
+    "errors"
+    "fmt"
+    "math/rand"
+
+    "euclid/codes/gf2m"
+    "euclid/polymod"
+)
+
+// ErrUndecodable is returned by Decode when the received word is further
+// than T bit flips from every codeword
+var ErrUndecodable = errors.New("goppa: too many errors to decode")
+
+// Code is a binary Goppa code: the words c of n bits with
+//
+//     sum of c_i / (x - L_i) = 0 mod g
+//
+// for an irreducible Goppa polynomial g of degree t over GF(2^m) and the
+// support L of n distinct elements of GF(2^m). It has dimension k >= n - mt
+// and corrects up to t bit flips with Patterson's algorithm.
+type Code struct {
+    field   *gf2m.Field
+    g       []uint32
+    support []uint32
+    // inverses[i] = 1/(x - L_i) mod g, the terms of the syndrome
+    inverses [][]uint32
+    // basis holds k codewords spanning the code, as bit sets of n bits;
+    // basis[j] is the only one with a 1 at messageBits[j], so codewords
+    // carry the message in the clear at those positions
+    basis       [][]uint64
+    messageBits []int
+}
+
+// New returns a binary Goppa code of length n over GF(2^m) with a random
+// irreducible Goppa polynomial of degree t drawn from rng; its support is the first n field elements (as bit
+// vectors 0, 1, 2, ...). It needs m*t < n <= 2^m.
+func New(m, t, n int, rng *rand.Rand) (*Code, error) {
+    f, err := gf2m.New(m)
+    if err != nil {
+        return nil, err
+    }
+    if t < 2 || n > 1<<m || m*t >= n {
+        return nil, fmt.Errorf("goppa: need t >= 2 and m*t < n <= 2^m, got m = %d, t = %d, n = %d", m, t, n)
+    }
+    var g []uint32
+    for g == nil || !f.Irreducible(g) {
+        cs := make([]uint32, t+1)
+        for i := range cs {
+            cs[i] = uint32(rng.Intn(1 << m))
+        }
+        cs[t] = 1
+        g = cs
+    }
+    c := &Code{field: f, g: g, support: make([]uint32, n), inverses: make([][]uint32, n)}
+    for i := range c.support {
+        c.support[i] = uint32(i)
+        c.inverses[i] = f.InverseMod([]uint32{uint32(i), 1}, g)
+    }
+    c.buildBasis()
+    return c, nil
+}
+
+// buildBasis finds the kernel of the binary parity-check matrix, whose
+// column i holds the mt bits of the coefficients of inverses[i], by
+// reducing it to row echelon form
+func (c *Code) buildBasis() {
+    n, m, t := len(c.support), c.field.M(), gf2m.Deg(c.g)
+    words := (n + 63) / 64
+    rows := make([][]uint64, m*t)
+    for r := range rows {
+        rows[r] = make([]uint64, words)
+    }
+    for i, inv := range c.inverses {
+        for j, e := range inv {
+            for b := 0; b < m; b++ {
+                if e>>uint(b)&1 == 1 {
+                    rows[j*m+b][i/64] |= 1 << uint(i%64)
+                }
+            }
+        }
+    }
+    bit := func(row []uint64, i int) bool { return row[i/64]>>uint(i%64)&1 == 1 }
+    var pivots []int
+    rank, col := 0, 0
+    for ; col < n && rank < len(rows); col++ {
+        r := rank
+        for r < len(rows) && !bit(rows[r], col) {
+            r++
+        }
+        if r == len(rows) {
+            c.messageBits = append(c.messageBits, col)
+            continue
+        }
+        rows[rank], rows[r] = rows[r], rows[rank]
+        for other := range rows {
+            if other != rank && bit(rows[other], col) {
+                for w := range rows[other] {
+                    rows[other][w] ^= rows[rank][w]
+                }
+            }
+        }
+        pivots = append(pivots, col)
+        rank++
+    }
+    for ; col < n; col++ {
+        c.messageBits = append(c.messageBits, col)
+    }
+    // a codeword is free at the message bits; each pivot bit is the sum of
+    // the message bits in its row
+    for _, free := range c.messageBits {
+        v := make([]uint64, words)
+        v[free/64] |= 1 << uint(free%64)
+        for r, p := range pivots {
+            if bit(rows[r], free) {
+                v[p/64] |= 1 << uint(p%64)
+            }
+        }
+        c.basis = append(c.basis, v)
+    }
+}
+
+// N returns the length of the codewords in bits
+func (c *Code) N() int { return len(c.support) }
+
+// K returns the number of message bits per codeword
+func (c *Code) K() int { return len(c.basis) }
+
+// T returns the number of bit flips Decode corrects, the degree of g
+func (c *Code) T() int { return gf2m.Deg(c.g) }
+
+// GoppaPolynomial returns g, its coefficients written as powers of the
+// primitive root a of GF(2^m)
+func (c *Code) GoppaPolynomial() string { return c.field.Format(c.g) }
+
+// FieldPolynomial returns the irreducible polynomial over GF(2) that
+// GF(2^m) is built on
+func (c *Code) FieldPolynomial() *polymod.GF2Poly {
+    return polymod.NewGF2Poly([]uint64{uint64(c.field.Modulus())})
+}
+
+// Encode returns the codeword carrying the K bits of message, each 0 or 1,
+// as N bits
+func (c *Code) Encode(message []byte) ([]byte, error) {
+    if len(message) != c.K() {
+        return nil, fmt.Errorf("goppa: message has %d bits, the code carries %d", len(message), c.K())
+    }
+    word := make([]uint64, len(c.basis[0]))
+    for j, b := range message {
+        if b&1 == 1 {
+            for w := range word {
+                word[w] ^= c.basis[j][w]
+            }
+        }
+    }
+    out := make([]byte, c.N())
+    for i := range out {
+        out[i] = byte(word[i/64] >> uint(i%64) & 1)
+    }
+    return out, nil
+}
+
+// syndrome returns the sum of 1/(x - L_i) mod g over the set bits of word
+func (c *Code) syndrome(word []byte) []uint32 {
+    var s []uint32
+    for i, b := range word {
+        if b&1 == 1 {
+            s = gf2m.Add(s, c.inverses[i])
+        }
+    }
+    return s
+}
+
+// Decode corrects up to T bit flips in the received word of N bits by
+// Patterson's algorithm and returns the message and the positions of the
+// flipped bits, or ErrUndecodable. The error locator is the
+// polynomial sigma vanishing at the support of the flipped positions;
+// it satisfies sigma' = sigma*S mod g for the syndrome S, and splitting
+// sigma = a^2 + x*b^2 into even and odd parts turns that into
+//
+//     a = b*tau mod g,   tau = sqrt(1/S + x) mod g,
+//
+// whose solution with deg a <= t/2 and deg b <= (t-1)/2 is a remainder of
+// the extended Euclidean algorithm on g and tau, stopped at the first
+// remainder of degree at most t/2, with its cofactor of tau.
+func (c *Code) Decode(received []byte) ([]byte, []int, error) {
+    if len(received) != c.N() {
+        return nil, nil, fmt.Errorf("goppa: received word has %d bits, codewords have %d", len(received), c.N())
+    }
+    f, t := c.field, c.T()
+    word := append([]byte(nil), received...)
+    var flipped []int
+    if s := c.syndrome(word); len(s) > 0 {
+        x := []uint32{0, 1}
+        tau := f.SqrtMod(f.Rem(gf2m.Add(f.InverseMod(s, c.g), x), c.g), c.g)
+        r0, r1 := c.g, tau
+        v0, v1 := []uint32(nil), []uint32{1}
+        for gf2m.Deg(r1) > t/2 {
+            q, r := f.DivPoly(r0, r1)
+            r0, r1 = r1, r
+            v0, v1 = v1, gf2m.Add(v0, f.MulPoly(q, v1))
+        }
+        sigma := gf2m.Add(f.Square(r1), f.MulPoly(x, f.Square(v1)))
+        for i, l := range c.support {
+            if f.Eval(sigma, l) == 0 {
+                flipped = append(flipped, i)
+                word[i] ^= 1
+            }
+        }
+        if len(flipped) != gf2m.Deg(sigma) || len(c.syndrome(word)) > 0 {
+            return nil, nil, ErrUndecodable
+        }
+    }
+    message := make([]byte, c.K())
+    for j, i := range c.messageBits {
+        message[j] = word[i] & 1
+    }
+    return message, flipped, nil
+}
@@ -0,0 +1,126 @@
+package goppa
+
+import (
+    "errors"
+    "math/rand"
+    "reflect"
+    "sort"
+    "testing"
+)
+
+// TestGoppaPolynomialIrreducible checks that the Goppa polynomials New
+// draws pass Ben-Or's test and have no root in the field
+func TestGoppaPolynomialIrreducible(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    code, err := New(4, 3, 16, rng)
+    if err != nil {
+        t.Fatal(err)
+    }
+    f := code.field
+    if !f.Irreducible(code.g) {
+        t.Errorf("Goppa polynomial %s reported reducible", code.GoppaPolynomial())
+    }
+    // an irreducible g of degree 3 has no root in the field
+    for x := uint32(0); x < 16; x++ {
+        if f.Eval(code.g, x) == 0 {
+            t.Errorf("Goppa polynomial %s has the root %s", code.GoppaPolynomial(), f.Element(x))
+        }
+    }
+}
+
+// TestDecodeCorrectsUpToT encodes random messages, flips up to T random
+// bits and checks that Decode restores the message and finds the flips
+func TestDecodeCorrectsUpToT(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, c := range []struct{ m, t, n int }{{4, 2, 16}, {5, 3, 32}, {6, 4, 60}, {8, 10, 256}} {
+        code, err := New(c.m, c.t, c.n, rng)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if code.N() != c.n || code.T() != c.t || code.K() < c.n-c.m*c.t {
+            t.Fatalf("code [%d, %d] with t = %d from m = %d, t = %d, n = %d", code.N(), code.K(), code.T(), c.m, c.t, c.n)
+        }
+        for trial := 0; trial < 10; trial++ {
+            message := make([]byte, code.K())
+            for i := range message {
+                message[i] = byte(rng.Intn(2))
+            }
+            word, err := code.Encode(message)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if s := code.syndrome(word); len(s) > 0 {
+                t.Fatalf("codeword has syndrome %s", code.field.Format(s))
+            }
+            flips := rng.Perm(c.n)[:rng.Intn(c.t+1)]
+            sort.Ints(flips)
+            for _, i := range flips {
+                word[i] ^= 1
+            }
+            got, found, err := code.Decode(word)
+            if err != nil {
+                t.Errorf("m = %d, t = %d with %d flips: %v", c.m, c.t, len(flips), err)
+                continue
+            }
+            if !reflect.DeepEqual(got, message) {
+                t.Errorf("m = %d, t = %d with %d flips decoded to another message", c.m, c.t, len(flips))
+            }
+            if len(found) != len(flips) || len(found) > 0 && !reflect.DeepEqual(found, flips) {
+                t.Errorf("m = %d, t = %d: flips found at %v, made at %v", c.m, c.t, found, flips)
+            }
+        }
+    }
+}
+
+// TestDecodeBeyondT checks that too many flips are reported or decoded to
+// another codeword, never to the sent message
+func TestDecodeBeyondT(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    code, err := New(6, 4, 64, rng)
+    if err != nil {
+        t.Fatal(err)
+    }
+    undecodable := 0
+    for trial := 0; trial < 30; trial++ {
+        message := make([]byte, code.K())
+        for i := range message {
+            message[i] = byte(rng.Intn(2))
+        }
+        word, _ := code.Encode(message)
+        for _, i := range rng.Perm(code.N())[:2*code.T()+1] {
+            word[i] ^= 1
+        }
+        got, _, err := code.Decode(word)
+        switch {
+        case errors.Is(err, ErrUndecodable):
+            undecodable++
+        case err != nil:
+            t.Errorf("Decode: %v", err)
+        case reflect.DeepEqual(got, message):
+            t.Error("2T + 1 flips decoded to the sent message")
+        }
+    }
+    if undecodable == 0 {
+        t.Error("no word with 2T + 1 flips was reported undecodable")
+    }
+}
+
+// TestNewChecksArguments checks the errors for bad parameters and lengths
+func TestNewChecksArguments(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, c := range [][3]int{{1, 2, 2}, {17, 2, 1 << 16}, {4, 1, 16}, {4, 4, 16}, {4, 2, 17}} {
+        if _, err := New(c[0], c[1], c[2], rng); err == nil {
+            t.Errorf("New(%d, %d, %d) did not fail", c[0], c[1], c[2])
+        }
+    }
+    code, _ := New(4, 2, 16, rng)
+    if _, err := code.Encode(make([]byte, code.K()+1)); err == nil {
+        t.Error("Encode accepted a long message")
+    }
+    if _, _, err := code.Decode(make([]byte, code.N()-1)); err == nil {
+        t.Error("Decode accepted a short word")
+    }
+    if got := code.FieldPolynomial().String(); got != "x^4 + x + 1" {
+        t.Errorf("GF(16) is built on %s, want x^4 + x + 1", got)
+    }
+}
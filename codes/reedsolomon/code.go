@@ -0,0 +1,170 @@
+// Package reedsolomon implements Reed–Solomon codes over GF(2^m) with
+// Sugiyama's decoder: the error locator and error evaluator polynomials come
+// out of the extended Euclidean algorithm on x^(2t) and the syndrome
+// polynomial, stopped halfway, the same partial run poly uses for Padé
+// approximants and Welch–Berlekamp decoding. It depends on the standard
+// library and gf2m only.
+package reedsolomon
+
+import (
+    "errors"
+    "fmt"
+
+    "euclid/codes/gf2m"
+)
+
+// ErrUndecodable is returned by Decode when the received word is more than
+// T symbol errors away from every codeword
+var ErrUndecodable = errors.New("reedsolomon: too many errors to decode")
+
+// Code is a systematic Reed–Solomon code of length n and dimension k over a
+// field GF(2^m), with n <= 2^m - 1. A codeword is the coefficient list
+// c_0, ..., c_(n-1) of a multiple of the generator polynomial
+// g = (x - a)(x - a^2)...(x - a^(n-k)), with the message in its k highest
+// coefficients; the code corrects T = (n-k)/2 wrong symbols.
+type Code struct {
+    field *Field
+    n, k  int
+    gen   []uint32
+}
+
+// New returns the Reed–Solomon code of length n and dimension k over field
+func New(field *Field, n, k int) (*Code, error) {
+    if k < 1 || n <= k || n > field.Size()-1 {
+        return nil, fmt.Errorf("reedsolomon: need 1 <= k < n <= %d, got n = %d, k = %d", field.Size()-1, n, k)
+    }
+    gen := []uint32{1}
+    for i := 1; i <= n-k; i++ {
+        gen = field.MulPoly(gen, []uint32{field.Exp(i), 1})
+    }
+    return &Code{field: field, n: n, k: k, gen: gen}, nil
+}
+
+// N returns the length of the codewords in symbols
+func (c *Code) N() int { return c.n }
+
+// K returns the number of message symbols per codeword
+func (c *Code) K() int { return c.k }
+
+// T returns the number of wrong symbols Decode corrects
+func (c *Code) T() int { return (c.n - c.k) / 2 }
+
+// Generator returns the coefficients of the generator polynomial, lowest
+// degree first
+func (c *Code) Generator() []uint32 { return append([]uint32(nil), c.gen...) }
+
+// checkSymbols checks that word has length n and symbols in the field
+func (c *Code) checkSymbols(word []uint32, n int) error {
+    if len(word) != n {
+        return fmt.Errorf("reedsolomon: %d symbols, expected %d", len(word), n)
+    }
+    for i, s := range word {
+        if int(s) >= c.field.Size() {
+            return fmt.Errorf("reedsolomon: symbol %d is %d, outside GF(%d)", i, s, c.field.Size())
+        }
+    }
+    return nil
+}
+
+// Encode returns the codeword of the K message symbols: the parity symbols
+// -(x^(n-k) m(x) mod g) followed by the message
+func (c *Code) Encode(message []uint32) ([]uint32, error) {
+    if err := c.checkSymbols(message, c.k); err != nil {
+        return nil, err
+    }
+    word := make([]uint32, c.n)
+    copy(word[c.n-c.k:], message)
+    _, parity := c.field.DivPoly(word, c.gen)
+    copy(word, parity)
+    return word, nil
+}
+
+// Syndromes returns the syndromes S_j = r(a^j) of the received word r for
+// j = 1, ..., n-k, all zero exactly when r is a codeword
+func (c *Code) Syndromes(received []uint32) ([]uint32, error) {
+    if err := c.checkSymbols(received, c.n); err != nil {
+        return nil, err
+    }
+    s := make([]uint32, c.n-c.k)
+    for j := range s {
+        s[j] = c.field.Eval(received, c.field.Exp(j+1))
+    }
+    return s, nil
+}
+
+// ErrorLocator returns the error locator Λ, with Λ(0) = 1 and the inverses
+// of the error positions a^i as roots, and the error evaluator Ω of the
+// syndromes s, by Sugiyama's algorithm: with S(x) = s_1 + s_2 x + ... the
+// key equation Λ S = Ω mod x^(2t) holds for every remainder of the extended
+// Euclidean algorithm on x^(2t) and S with its cofactor of S, and the first
+// remainder of degree below t gives Ω and, scaled, Λ. Both are returned
+// lowest degree first; no errors give Λ = 1 and Ω = 0.
+func (c *Code) ErrorLocator(syndromes []uint32) (locator, evaluator []uint32, err error) {
+    f := c.field
+    twoT := c.n - c.k
+    if len(syndromes) != twoT {
+        return nil, nil, fmt.Errorf("reedsolomon: %d syndromes, expected %d", len(syndromes), twoT)
+    }
+    r0 := make([]uint32, twoT+1)
+    r0[twoT] = 1
+    r1 := append([]uint32(nil), syndromes...)
+    r1 = gf2m.Trim(r1)
+    t0, t1 := []uint32(nil), []uint32{1}
+    for 2*gf2m.Deg(r1) >= twoT {
+        q, r := f.DivPoly(r0, r1)
+        r0, r1 = r1, r
+        t0, t1 = t1, gf2m.Add(t0, f.MulPoly(q, t1))
+    }
+    if gf2m.Deg(t1) < 0 || t1[0] == 0 {
+        return nil, nil, ErrUndecodable
+    }
+    lead := t1[0]
+    locator = make([]uint32, len(t1))
+    for i, v := range t1 {
+        locator[i] = f.Quo(v, lead)
+    }
+    evaluator = make([]uint32, len(r1))
+    for i, v := range r1 {
+        evaluator[i] = f.Quo(v, lead)
+    }
+    return locator, evaluator, nil
+}
+
+// Decode corrects up to T wrong symbols of received and returns the message
+// and the positions of the corrected symbols in increasing order, or
+// ErrUndecodable. The positions are the i with Λ(a^-i) = 0, found by trying
+// every position (Chien search), and the error values come from Forney's
+// formula e_i = Ω(a^-i) / Λ'(a^-i), which has no sign in characteristic 2.
+func (c *Code) Decode(received []uint32) (message []uint32, positions []int, err error) {
+    s, err := c.Syndromes(received)
+    if err != nil {
+        return nil, nil, err
+    }
+    lambda, omega, err := c.ErrorLocator(s)
+    if err != nil {
+        return nil, nil, err
+    }
+    f := c.field
+    word := append([]uint32(nil), received...)
+    dLambda := gf2m.Derivative(lambda)
+    for i := 0; i < c.n; i++ {
+        x := f.Exp(-i)
+        if f.Eval(lambda, x) != 0 {
+            continue
+        }
+        d := f.Eval(dLambda, x)
+        if d == 0 {
+            return nil, nil, ErrUndecodable
+        }
+        word[i] ^= f.Quo(f.Eval(omega, x), d)
+        positions = append(positions, i)
+    }
+    if len(positions) != gf2m.Deg(lambda) {
+        // Λ does not split into distinct roots at the positions of the word
+        return nil, nil, ErrUndecodable
+    }
+    if s, _ := c.Syndromes(word); gf2m.Deg(s) >= 0 {
+        return nil, nil, ErrUndecodable
+    }
+    return word[c.n-c.k:], positions, nil
+}
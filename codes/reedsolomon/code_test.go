@@ -0,0 +1,152 @@
+package reedsolomon_test
+
+import (
+    "errors"
+    "math/rand"
+    "reflect"
+    "sort"
+    "testing"
+
+    "euclid/codes/reedsolomon"
+)
+
+// TestNewField checks the primitive polynomials and that the powers of a
+// run through every nonzero element once
+func TestNewField(t *testing.T) {
+    for m := 2; m <= 16; m++ {
+        f, err := reedsolomon.NewField(m)
+        if err != nil {
+            t.Fatalf("NewField(%d): %v", m, err)
+        }
+        if f.Size() != 1<<m {
+            t.Errorf("GF(2^%d) has size %d", m, f.Size())
+        }
+        seen := make([]bool, f.Size())
+        for i := 0; i < f.Size()-1; i++ {
+            e := f.Exp(i)
+            if e == 0 || seen[e] {
+                t.Fatalf("GF(2^%d): a^%d = %d repeats or is 0", m, i, e)
+            }
+            seen[e] = true
+            if f.Mul(e, f.Exp(-i)) != 1 || f.Quo(1, e) != f.Exp(-i) {
+                t.Fatalf("GF(2^%d): a^%d times a^-%d is not 1", m, i, i)
+            }
+        }
+    }
+    if f, _ := reedsolomon.NewField(8); f.Modulus() != 0x11d {
+        t.Errorf("GF(2^8) is built on %#x, want 0x11d", f.Modulus())
+    }
+    for _, m := range []int{1, 17} {
+        if _, err := reedsolomon.NewField(m); err == nil {
+            t.Errorf("NewField(%d) did not fail", m)
+        }
+    }
+}
+
+// TestDecodeCorrectsUpToT encodes random messages, corrupts up to T random
+// symbols and checks that Decode restores the message and finds the
+// positions
+func TestDecodeCorrectsUpToT(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, c := range []struct{ m, n, k int }{{4, 15, 11}, {8, 255, 223}, {8, 40, 20}, {10, 100, 81}} {
+        field, err := reedsolomon.NewField(c.m)
+        if err != nil {
+            t.Fatal(err)
+        }
+        code, err := reedsolomon.New(field, c.n, c.k)
+        if err != nil {
+            t.Fatal(err)
+        }
+        for trial := 0; trial < 20; trial++ {
+            message := make([]uint32, c.k)
+            for i := range message {
+                message[i] = uint32(rng.Intn(field.Size()))
+            }
+            word, err := code.Encode(message)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if s, _ := code.Syndromes(word); !allZero(s) {
+                t.Fatalf("RS(%d, %d): codeword has syndromes %v", c.n, c.k, s)
+            }
+            errs := rng.Intn(code.T() + 1)
+            positions := rng.Perm(c.n)[:errs]
+            sort.Ints(positions)
+            for _, i := range positions {
+                word[i] ^= uint32(1 + rng.Intn(field.Size()-1))
+            }
+            got, found, err := code.Decode(word)
+            if err != nil {
+                t.Errorf("RS(%d, %d) with %d errors: %v", c.n, c.k, errs, err)
+                continue
+            }
+            if !reflect.DeepEqual(got, message) {
+                t.Errorf("RS(%d, %d) with %d errors decoded to another message", c.n, c.k, errs)
+            }
+            if len(found) != len(positions) || len(found) > 0 && !reflect.DeepEqual(found, positions) {
+                t.Errorf("RS(%d, %d): errors found at %v, made at %v", c.n, c.k, found, positions)
+            }
+        }
+    }
+}
+
+// TestDecodeBeyondT checks that a word further than T from every codeword
+// is reported, not miscorrected to the sent message
+func TestDecodeBeyondT(t *testing.T) {
+    field, _ := reedsolomon.NewField(8)
+    code, _ := reedsolomon.New(field, 30, 20)
+    rng := rand.New(rand.NewSource(1))
+    undecodable := 0
+    for trial := 0; trial < 50; trial++ {
+        message := make([]uint32, code.K())
+        for i := range message {
+            message[i] = uint32(rng.Intn(field.Size()))
+        }
+        word, _ := code.Encode(message)
+        for _, i := range rng.Perm(code.N())[:code.T()+1] {
+            word[i] ^= uint32(1 + rng.Intn(field.Size()-1))
+        }
+        got, _, err := code.Decode(word)
+        switch {
+        case errors.Is(err, reedsolomon.ErrUndecodable):
+            undecodable++
+        case err != nil:
+            t.Errorf("Decode: %v", err)
+        case reflect.DeepEqual(got, message):
+            t.Errorf("T + 1 errors decoded to the sent message")
+        }
+    }
+    if undecodable == 0 {
+        t.Error("no word with T + 1 errors was reported undecodable")
+    }
+}
+
+// TestCodeChecksArguments checks the errors for bad parameters and symbols
+func TestCodeChecksArguments(t *testing.T) {
+    field, _ := reedsolomon.NewField(4)
+    for _, nk := range [][2]int{{16, 8}, {8, 8}, {8, 0}} {
+        if _, err := reedsolomon.New(field, nk[0], nk[1]); err == nil {
+            t.Errorf("New(GF(16), %d, %d) did not fail", nk[0], nk[1])
+        }
+    }
+    code, _ := reedsolomon.New(field, 15, 11)
+    if _, err := code.Encode(make([]uint32, 10)); err == nil {
+        t.Error("Encode accepted a short message")
+    }
+    if _, err := code.Encode(append(make([]uint32, 10), 16)); err == nil {
+        t.Error("Encode accepted a symbol outside GF(16)")
+    }
+    if _, _, err := code.Decode(make([]uint32, 14)); err == nil {
+        t.Error("Decode accepted a short word")
+    }
+}
+
+// allZero reports whether every syndrome in s is 0
+func allZero(s []uint32) bool {
+    for _, v := range s {
+        if v != 0 {
+            return false
+        }
+    }
+    return true
+}
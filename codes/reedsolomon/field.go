@@ -0,0 +1,13 @@
+package reedsolomon
+
+import "euclid/codes/gf2m"
+
+// Field is GF(2^m) for 2 <= m <= 16, shared with the Goppa codes through
+// package gf2m
+type Field = gf2m.Field
+
+// NewField returns GF(2^m) built on the smallest primitive polynomial of
+// degree m, 0x11d for m = 8 as in most byte-oriented Reed–Solomon codes
+func NewField(m int) (*Field, error) {
+    return gf2m.New(m)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+    "testing"
+
+    "euclid/poly"
+)
+
+// TestConformance recomputes the vectors of testdata/conformance.txt, as
+// go run . conformance does, so that go test catches an output that
+// changes on some platform
+func TestConformance(t *testing.T) {
+    failures, n, err := poly.CheckConformance(poly.ConformanceFile)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if n == 0 {
+        t.Fatalf("%s has no vectors", poly.ConformanceFile)
+    }
+    for _, f := range failures {
+        t.Errorf("%s %v: got %q, want %q", f.Op, f.Args, f.Got, f.Expected)
+    }
+}
+
+// TestGCDVectors checks the published extended GCD vectors of
+// testdata/gcd_vectors.json, as go run . gcdvectors does
+func TestGCDVectors(t *testing.T) {
+    failures, n, err := poly.CheckGCDVectors(poly.GCDVectorsFile)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if n == 0 {
+        t.Fatalf("%s has no vectors", poly.GCDVectorsFile)
+    }
+    for _, err := range failures {
+        t.Error(err)
+    }
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+    "fmt"
+    "os/exec"
+    "strings"
+    "testing"
+)
+
+// depCheckTargets are the builds that must not depend on anything outside
+// the standard library and this module: the algebra package, with and
+// without GMP, the layers under it, the GF(2^m) arithmetic, the Reed–Solomon
+// and Goppa packages and the command and its cli package built without
+// plotting. Within the module the packages form layers, intring under fft
+// and polymod, both under poly, under plotutil, cli and the command, with
+// the Reed–Solomon codes over gf2m and the Goppa codes over gf2m and
+// polymod, and the benchmark timer internal/benchtime at the bottom under
+// polymod and poly; layers lists the module packages each target may
+// import, so that no package reaches up to one above it.
+var depCheckTargets = []struct {
+    pkg, tags string
+    layers    []string
+}{
+    {"euclid/internal/benchtime", "", nil},
+    {"euclid/intring", "", nil},
+    {"euclid/fft", "", []string{"euclid/intring"}},
+    {"euclid/polymod", "", []string{"euclid/internal/benchtime", "euclid/intring"}},
+    {"euclid/poly", "", []string{"euclid/internal/benchtime", "euclid/intring", "euclid/fft", "euclid/polymod"}},
+    {"euclid/poly", "gmp", []string{"euclid/internal/benchtime", "euclid/intring", "euclid/fft", "euclid/polymod"}},
+    {"euclid/codes/gf2m", "", nil},
+    {"euclid/codes/reedsolomon", "", []string{"euclid/codes/gf2m"}},
+    {"euclid/codes/goppa", "", []string{"euclid/internal/benchtime", "euclid/intring", "euclid/polymod", "euclid/codes/gf2m"}},
+    {"euclid/cli", "noplot", []string{"euclid/internal/benchtime", "euclid/intring", "euclid/fft", "euclid/polymod", "euclid/poly", "euclid/codes/gf2m", "euclid/codes/reedsolomon", "euclid/codes/goppa"}},
+    {"euclid", "noplot", []string{"euclid/internal/benchtime", "euclid/intring", "euclid/fft", "euclid/polymod", "euclid/poly", "euclid/codes/gf2m", "euclid/codes/reedsolomon", "euclid/codes/goppa", "euclid/cli"}},
+}
+
+// listDeps lists the packages outside the standard library that pkg
+// imports, directly or not, when built with tags, asking the go command as
+// it would resolve them for a build: those outside this module in external
+// and those inside it, other than pkg, in module
+func listDeps(pkg, tags string) (external, module []string, err error) {
+    out, err := exec.Command("go", "list", "-deps", "-tags", tags,
+        "-f", "{{if not .Standard}}{{.ImportPath}}{{end}}", pkg).Output()
+    if err != nil {
+        if ee, ok := err.(*exec.ExitError); ok {
+            return nil, nil, fmt.Errorf("go list %s: %s", pkg, strings.TrimSpace(string(ee.Stderr)))
+        }
+        return nil, nil, err
+    }
+    for _, line := range strings.Fields(string(out)) {
+        switch {
+        case line == pkg:
+        case line == "euclid" || strings.HasPrefix(line, "euclid/"):
+            module = append(module, line)
+        default:
+            external = append(external, line)
+        }
+    }
+    return external, module, nil
+}
+
+// TestDependencies checks that every depCheckTarget has no external
+// dependencies and imports no module package above its layer; it needs the
+// go command and the module's source, which go test provides
+func TestDependencies(t *testing.T) {
+    if _, err := exec.LookPath("go"); err != nil {
+        t.Skip("go command not found")
+    }
+    for _, target := range depCheckTargets {
+        name := target.pkg
+        if target.tags != "" {
+            name += " (-tags " + target.tags + ")"
+        }
+        external, module, err := listDeps(target.pkg, target.tags)
+        if err != nil {
+            t.Errorf("%s: %v", name, err)
+            continue
+        }
+        allowed := make(map[string]bool)
+        for _, l := range target.layers {
+            allowed[l] = true
+        }
+        var upward []string
+        for _, m := range module {
+            if !allowed[m] {
+                upward = append(upward, m)
+            }
+        }
+        if len(external) > 0 {
+            t.Errorf("%s depends on packages outside the standard library: %s", name, strings.Join(external, ", "))
+        }
+        if len(upward) > 0 {
+            t.Errorf("%s imports %s from a higher layer", name, strings.Join(upward, ", "))
+        }
+    }
+}
@@ -0,0 +1,315 @@
+// Package fft multiplies polynomials with big integer coefficients, given
+// as coefficient slices lowest degree first: by the schoolbook method, by
+// Karatsuba's algorithm and by number-theoretic transforms modulo word-size
+// primes. Package poly multiplies rational polynomials with them after
+// clearing denominators. It depends only on the standard library and
+// package intring.
+package fft
+
+import (
+    "math/big"
+    "sync"
+
+    "euclid/intring"
+)
+
+// karatsubaMinLen is the number of coefficients of the shorter factor below
+// which Karatsuba falls back to the schoolbook product
+const karatsubaMinLen = 16
+
+// Schoolbook returns the product of the integer polynomials a and b,
+// lowest degree first
+func Schoolbook(a, b []*big.Int) []*big.Int {
+    if len(a) == 0 || len(b) == 0 {
+        return nil
+    }
+    product := make([]*big.Int, len(a)+len(b)-1)
+    for i := range product {
+        product[i] = new(big.Int)
+    }
+    t := new(big.Int)
+    for i, x := range a {
+        for j, y := range b {
+            product[i+j].Add(product[i+j], t.Mul(x, y))
+        }
+    }
+    return product
+}
+
+// add returns a + b for integer polynomials of any lengths
+func add(a, b []*big.Int) []*big.Int {
+    if len(a) < len(b) {
+        a, b = b, a
+    }
+    sum := make([]*big.Int, len(a))
+    for i := range a {
+        sum[i] = new(big.Int).Set(a[i])
+        if i < len(b) {
+            sum[i].Add(sum[i], b[i])
+        }
+    }
+    return sum
+}
+
+// Karatsuba returns the product of the integer polynomials a and b by
+// Karatsuba's algorithm: with a = a0 + x^m a1 and b = b0 + x^m b1, the three
+// products a0*b0, a1*b1 and (a0 + a1)*(b0 + b1) give all of a*b
+func Karatsuba(a, b []*big.Int) []*big.Int {
+    if min(len(a), len(b)) < karatsubaMinLen {
+        return Schoolbook(a, b)
+    }
+    m := max(len(a), len(b)) / 2
+    split := func(x []*big.Int) ([]*big.Int, []*big.Int) {
+        if len(x) <= m {
+            return x, nil
+        }
+        return x[:m], x[m:]
+    }
+    a0, a1 := split(a)
+    b0, b1 := split(b)
+    z0 := Karatsuba(a0, b0)
+    z2 := Karatsuba(a1, b1)
+    z1 := Karatsuba(add(a0, a1), add(b0, b1))
+
+    product := make([]*big.Int, len(a)+len(b)-1)
+    for i := range product {
+        product[i] = new(big.Int)
+    }
+    for i, c := range z0 {
+        product[i].Add(product[i], c)
+        z1[i].Sub(z1[i], c)
+    }
+    for i, c := range z2 {
+        product[i+2*m].Add(product[i+2*m], c)
+        z1[i].Sub(z1[i], c)
+    }
+    for i, c := range z1 {
+        if i+m < len(product) {
+            product[i+m].Add(product[i+m], c)
+        }
+    }
+    return product
+}
+
+// nttLog is the largest power of two dividing p - 1 for the NTT primes, so
+// transforms have up to 2^nttLog points
+const nttLog = 40
+
+// nttPrime is a prime p = c*2^nttLog + 1 between 2^61 and 2^62 with Montgomery
+// arithmetic and a generator of its multiplicative group
+type nttPrime struct {
+    mont      intring.Montgomery
+    generator uint64
+}
+
+// The NTT primes are found on first use, from the largest down, and shared
+// by all calls
+var (
+    nttPrimesMu sync.Mutex
+    nttPrimes   []nttPrime
+    nttNextC    uint64 = 1<<(62-nttLog) - 1
+)
+
+// nttPrimeList returns the first n NTT primes
+func nttPrimeList(n int) []nttPrime {
+    nttPrimesMu.Lock()
+    defer nttPrimesMu.Unlock()
+    for len(nttPrimes) < n {
+        c := nttNextC
+        nttNextC--
+        p := c<<nttLog + 1
+        if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+            continue
+        }
+        // the prime factors of p - 1 = c * 2^nttLog, c < 2^22, by trial division
+        factors := []uint64{2}
+        rest := c
+        for rest%2 == 0 {
+            rest /= 2
+        }
+        for d := uint64(3); rest > 1; d += 2 {
+            if d*d > rest {
+                d = rest
+            }
+            if rest%d == 0 {
+                factors = append(factors, d)
+                for rest%d == 0 {
+                    rest /= d
+                }
+            }
+        }
+        for g := uint64(2); ; g++ {
+            generates := true
+            for _, f := range factors {
+                if intring.PowMod(g, (p-1)/f, p) == 1 {
+                    generates = false
+                    break
+                }
+            }
+            if generates {
+                nttPrimes = append(nttPrimes, nttPrime{intring.NewMontgomery(p), g})
+                break
+            }
+        }
+    }
+    return nttPrimes[:n]
+}
+
+// ntt transforms a, whose length is a power of two, in place to its values
+// at the powers of a primitive len(a)-th root of unity, or back when
+// inverse is set. The values are in Montgomery form.
+func (q nttPrime) ntt(a []uint64, inverse bool) {
+    n := len(a)
+    m := q.mont
+    p := m.Modulus()
+    for i, j := 1, 0; i < n; i++ {
+        bit := n >> 1
+        for ; j&bit != 0; bit >>= 1 {
+            j ^= bit
+        }
+        j ^= bit
+        if i < j {
+            a[i], a[j] = a[j], a[i]
+        }
+    }
+    for size := 2; size <= n; size <<= 1 {
+        w := intring.PowMod(q.generator, (p-1)/uint64(size), p)
+        if inverse {
+            w, _ = intring.InvMod(w, p)
+        }
+        wm := m.To(w)
+        half := size / 2
+        // the twiddle factors of this level
+        twiddles := make([]uint64, half)
+        twiddles[0] = m.To(1)
+        for k := 1; k < half; k++ {
+            twiddles[k] = m.Mul(twiddles[k-1], wm)
+        }
+        for start := 0; start < n; start += size {
+            for k := 0; k < half; k++ {
+                u := a[start+k]
+                v := m.Mul(a[start+k+half], twiddles[k])
+                a[start+k] = intring.AddMod(u, v, p)
+                a[start+k+half] = intring.SubMod(u, v, p)
+            }
+        }
+    }
+    if inverse {
+        nInv, _ := intring.InvMod(uint64(n)%p, p)
+        c := m.To(nInv)
+        for i := range a {
+            a[i] = m.Mul(a[i], c)
+        }
+    }
+}
+
+// Mul returns the product of the integer polynomials a and b by
+// number-theoretic transforms modulo as many 62-bit primes as the
+// coefficients of the product need, recombined by the Chinese remainder
+// theorem (Garner's algorithm)
+func Mul(a, b []*big.Int) []*big.Int {
+    if len(a) == 0 || len(b) == 0 {
+        return nil
+    }
+    n := len(a) + len(b) - 1
+    size := 1
+    for size < n {
+        size <<= 1
+    }
+    if size > 1<<nttLog {
+        return Karatsuba(a, b)
+    }
+    // |c| < 2^bound for every coefficient c of the product; the primes
+    // exceed 2^61 and their product has to exceed 2^(bound+1)
+    bound := maxBitLen(a) + maxBitLen(b) + bitLen(min(len(a), len(b)))
+    primes := nttPrimeList((bound+1)/61 + 1)
+
+    residues := make([][]uint64, len(primes))
+    r := new(big.Int)
+    for k, q := range primes {
+        m := q.mont
+        pBig := new(big.Int).SetUint64(m.Modulus())
+        load := func(x []*big.Int) []uint64 {
+            v := make([]uint64, size)
+            for i, c := range x {
+                v[i] = m.To(r.Mod(c, pBig).Uint64())
+            }
+            q.ntt(v, false)
+            return v
+        }
+        va, vb := load(a), load(b)
+        for i := range va {
+            va[i] = m.Mul(va[i], vb[i])
+        }
+        q.ntt(va, true)
+        for i := range va[:n] {
+            va[i] = m.From(va[i])
+        }
+        residues[k] = va[:n]
+    }
+
+    // Garner: x = d0 + p0*(d1 + p1*(d2 + ...)) with digits d_k < p_k
+    // computed from the residues with word arithmetic only
+    inverses := make([][]uint64, len(primes))
+    for k := range primes {
+        inverses[k] = make([]uint64, k)
+        for j := 0; j < k; j++ {
+            pk := primes[k].mont.Modulus()
+            inverses[k][j], _ = intring.InvMod(primes[j].mont.Modulus()%pk, pk)
+        }
+    }
+    modulus := big.NewInt(1)
+    for _, q := range primes {
+        modulus.Mul(modulus, new(big.Int).SetUint64(q.mont.Modulus()))
+    }
+    half := new(big.Int).Rsh(modulus, 1)
+    product := make([]*big.Int, n)
+    digits := make([]uint64, len(primes))
+    for i := range product {
+        for k := range primes {
+            pk := primes[k].mont.Modulus()
+            d := residues[k][i]
+            for j := 0; j < k; j++ {
+                d = intring.MulMod(intring.SubMod(d, digits[j]%pk, pk), inverses[k][j], pk)
+            }
+            digits[k] = d
+        }
+        x := new(big.Int)
+        for k := len(primes) - 1; k >= 0; k-- {
+            x.Mul(x, r.SetUint64(primes[k].mont.Modulus()))
+            x.Add(x, r.SetUint64(digits[k]))
+        }
+        if x.Cmp(half) > 0 {
+            x.Sub(x, modulus)
+        }
+        product[i] = x
+    }
+    return product
+}
+
+func min(a, b int) int {
+    if a < b {
+        return a
+    }
+    return b
+}
+
+func max(a, b int) int {
+    if a > b {
+        return a
+    }
+    return b
+}
+
+// maxBitLen returns the largest bit length among xs
+func maxBitLen(xs []*big.Int) int {
+    n := 0
+    for _, x := range xs {
+        n = max(n, x.BitLen())
+    }
+    return n
+}
+
+func bitLen(n int) int {
+    return big.NewInt(int64(n)).BitLen()
+}
@@ -0,0 +1,76 @@
+package fft_test
+
+import (
+    "math/big"
+    "math/rand"
+    "testing"
+
+    "euclid/fft"
+)
+
+// randomPoly returns n random coefficients of up to bits bits, either sign
+func randomPoly(rng *rand.Rand, n, bits int) []*big.Int {
+    p := make([]*big.Int, n)
+    for i := range p {
+        p[i] = new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+        if rng.Intn(2) == 0 {
+            p[i].Neg(p[i])
+        }
+    }
+    return p
+}
+
+// equal reports whether a and b have the same coefficients, ignoring zero
+// coefficients past the end of the shorter one
+func equal(a, b []*big.Int) bool {
+    for i := 0; i < len(a) || i < len(b); i++ {
+        var x, y big.Int
+        if i < len(a) {
+            x.Set(a[i])
+        }
+        if i < len(b) {
+            y.Set(b[i])
+        }
+        if x.Cmp(&y) != 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// TestMulAgreesWithSchoolbook checks Karatsuba and the NTT product against
+// the schoolbook product, across the Karatsuba cutoff and for coefficients
+// large enough to need several primes
+func TestMulAgreesWithSchoolbook(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, n := range []int{1, 2, 15, 16, 17, 40, 129} {
+        for _, bits := range []int{1, 30, 64, 200} {
+            a, b := randomPoly(rng, n, bits), randomPoly(rng, n/2+1, bits)
+            want := fft.Schoolbook(a, b)
+            if got := fft.Karatsuba(a, b); !equal(got, want) {
+                t.Errorf("Karatsuba differs from Schoolbook for %d and %d coefficients of %d bits", len(a), len(b), bits)
+            }
+            if got := fft.Mul(a, b); !equal(got, want) {
+                t.Errorf("Mul differs from Schoolbook for %d and %d coefficients of %d bits", len(a), len(b), bits)
+            }
+        }
+    }
+}
+
+// TestMulEmptyAndOperands checks that the empty polynomial multiplies to
+// the empty one and that the operands are left unchanged
+func TestMulEmptyAndOperands(t *testing.T) {
+    a := []*big.Int{big.NewInt(3), big.NewInt(-1)}
+    for _, mul := range []func(a, b []*big.Int) []*big.Int{fft.Schoolbook, fft.Karatsuba, fft.Mul} {
+        if got := mul(a, nil); len(got) != 0 {
+            t.Errorf("product with the empty polynomial has %d coefficients", len(got))
+        }
+        // (3 - x)^2 = 9 - 6x + x^2
+        if got := mul(a, a); !equal(got, []*big.Int{big.NewInt(9), big.NewInt(-6), big.NewInt(1)}) {
+            t.Errorf("(3 - x)^2 = %v", got)
+        }
+        if a[0].Int64() != 3 || a[1].Int64() != -1 {
+            t.Errorf("product modified its operand to %v", a)
+        }
+    }
+}
@@ -0,0 +1,36 @@
+// Package benchtime times the calls of the benchmark commands of poly and
+// polymod, so that both measure the same way.
+package benchtime
+
+import "time"
+
+// Time is how long Ns runs its final batch of calls
+const Time = time.Second
+
+// Ns returns the time per call of fn in nanoseconds. Like go test -bench it
+// runs fn in batches, growing the batch from the time of the last one until
+// a batch takes Time, so that the clock is read once per batch rather than
+// once per call.
+func Ns(fn func()) float64 {
+    n := 1
+    for {
+        start := time.Now()
+        for i := 0; i < n; i++ {
+            fn()
+        }
+        elapsed := time.Since(start)
+        if elapsed >= Time || n >= 1e9 {
+            return float64(elapsed.Nanoseconds()) / float64(n)
+        }
+        next := 100 * n
+        if elapsed > 0 {
+            if fit := int(1.2 * float64(n) * float64(Time) / float64(elapsed)); fit < next {
+                next = fit
+            }
+        }
+        if next < n+1 {
+            next = n + 1
+        }
+        n = next
+    }
+}
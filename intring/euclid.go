@@ -0,0 +1,76 @@
+// Package intring is the integer layer under package poly: the extended
+// Euclidean algorithm on big integers and arithmetic modulo a word-size
+// prime, plain and in Montgomery form. It depends only on the standard
+// library and on no other package of this module.
+package intring
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+// ExtendedEuclidean runs the extended Euclidean algorithm on a and b. Along
+// with gcd, s and t such that s*a + t*b = gcd it returns the number of
+// division steps performed; gcd has the sign the remainders end with, which
+// ExtendedGCD normalizes.
+func ExtendedEuclidean(a, b *big.Int) (gcd, s, t *big.Int, steps int) {
+    s0, s1 := big.NewInt(1), big.NewInt(0)
+    t0, t1 := big.NewInt(0), big.NewInt(1)
+    a, b = new(big.Int).Set(a), new(big.Int).Set(b)
+
+    for b.Sign() != 0 {
+        q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+        a, b = b, r
+        s0, s1 = s1, new(big.Int).Sub(s0, new(big.Int).Mul(q, s1))
+        t0, t1 = t1, new(big.Int).Sub(t0, new(big.Int).Mul(q, t1))
+        steps++
+    }
+
+    return a, s0, t0, steps
+}
+
+// ExtendedGCD returns the greatest common divisor of a and b, which is
+// never negative, and Bézout coefficients s and t with s*a + t*b = gcd. The
+// result is checked with VerifyBezout before it is returned, so an error
+// means nil arguments or a bug.
+func ExtendedGCD(a, b *big.Int) (gcd, s, t *big.Int, err error) {
+    if a == nil || b == nil {
+        return nil, nil, nil, errors.New("nil integer")
+    }
+    gcd, s, t, _ = ExtendedEuclidean(a, b)
+    if gcd.Sign() < 0 {
+        gcd.Neg(gcd)
+        s.Neg(s)
+        t.Neg(t)
+    }
+    if err := VerifyBezout(a, b, gcd, s, t); err != nil {
+        return nil, nil, nil, err
+    }
+    return gcd, s, t, nil
+}
+
+// VerifyBezout checks that gcd is a common divisor of a and b that is not
+// negative and that s*a + t*b = gcd, which together make gcd the greatest
+// common divisor
+func VerifyBezout(a, b, gcd, s, t *big.Int) error {
+    sum := new(big.Int).Add(new(big.Int).Mul(s, a), new(big.Int).Mul(t, b))
+    if sum.Cmp(gcd) != 0 {
+        return fmt.Errorf("bezout: s*a + t*b = %s, not %s", sum, gcd)
+    }
+    if gcd.Sign() < 0 {
+        return fmt.Errorf("bezout: gcd %s is negative", gcd)
+    }
+    if gcd.Sign() == 0 {
+        if a.Sign() != 0 || b.Sign() != 0 {
+            return fmt.Errorf("bezout: gcd of %s and %s is not 0", a, b)
+        }
+        return nil
+    }
+    for _, x := range []*big.Int{a, b} {
+        if new(big.Int).Rem(x, gcd).Sign() != 0 {
+            return fmt.Errorf("bezout: %s does not divide %s", gcd, x)
+        }
+    }
+    return nil
+}
@@ -0,0 +1,77 @@
+package intring_test
+
+import (
+    "math/big"
+    "math/rand"
+    "testing"
+
+    "euclid/intring"
+)
+
+// TestExtendedGCD checks the gcd against big.Int.GCD and the Bézout
+// identity on signed inputs, zeros included
+func TestExtendedGCD(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    pairs := [][2]int64{{0, 0}, {0, 5}, {5, 0}, {-12, 18}, {12, -18}, {-7, -7}, {1, 1 << 62}, {240, 46}}
+    for i := 0; i < 300; i++ {
+        pairs = append(pairs, [2]int64{rng.Int63n(1<<40) - 1<<39, rng.Int63n(1<<40) - 1<<39})
+    }
+    for _, pair := range pairs {
+        a, b := big.NewInt(pair[0]), big.NewInt(pair[1])
+        gcd, s, u, err := intring.ExtendedGCD(a, b)
+        if err != nil {
+            t.Errorf("ExtendedGCD(%s, %s): %v", a, b, err)
+            continue
+        }
+        want := new(big.Int).GCD(nil, nil, new(big.Int).Abs(a), new(big.Int).Abs(b))
+        if gcd.Cmp(want) != 0 {
+            t.Errorf("ExtendedGCD(%s, %s) = %s, want %s", a, b, gcd, want)
+        }
+        sum := new(big.Int).Add(new(big.Int).Mul(s, a), new(big.Int).Mul(u, b))
+        if sum.Cmp(gcd) != 0 {
+            t.Errorf("ExtendedGCD(%s, %s): %s*a + %s*b = %s, not %s", a, b, s, u, sum, gcd)
+        }
+        if a.Int64() != pair[0] || b.Int64() != pair[1] {
+            t.Errorf("ExtendedGCD modified its arguments to %s, %s", a, b)
+        }
+    }
+    if _, _, _, err := intring.ExtendedGCD(nil, big.NewInt(1)); err == nil {
+        t.Error("ExtendedGCD(nil, 1) did not fail")
+    }
+}
+
+// TestExtendedEuclideanFibonacci checks the step count on consecutive
+// Fibonacci numbers, the worst case of Lamé's theorem: F(n+1) and F(n) take
+// n - 1 division steps
+func TestExtendedEuclideanFibonacci(t *testing.T) {
+    f0, f1 := big.NewInt(1), big.NewInt(1)
+    for n := 2; n < 90; n++ {
+        f0, f1 = f1, new(big.Int).Add(f0, f1)
+        gcd, _, _, steps := intring.ExtendedEuclidean(f1, f0)
+        if gcd.Cmp(big.NewInt(1)) != 0 || steps != n-1 {
+            t.Errorf("F(%d), F(%d): gcd %s in %d steps, want 1 in %d", n+1, n, gcd, steps, n-1)
+        }
+    }
+}
+
+// TestVerifyBezout checks that wrong results are rejected
+func TestVerifyBezout(t *testing.T) {
+    n := big.NewInt
+    cases := []struct {
+        name             string
+        a, b, gcd, s, tt *big.Int
+        ok               bool
+    }{
+        {"correct", n(12), n(18), n(6), n(-1), n(1), true},
+        {"zeros", n(0), n(0), n(0), n(0), n(0), true},
+        {"wrong sum", n(12), n(18), n(6), n(1), n(1), false},
+        {"negative gcd", n(12), n(18), n(-6), n(1), n(-1), false},
+        {"not a divisor", n(4), n(6), n(4), n(1), n(0), false},
+        {"zero gcd", n(0), n(3), n(0), n(0), n(0), false},
+    }
+    for _, c := range cases {
+        if err := intring.VerifyBezout(c.a, c.b, c.gcd, c.s, c.tt); (err == nil) != c.ok {
+            t.Errorf("%s: VerifyBezout returned %v", c.name, err)
+        }
+    }
+}
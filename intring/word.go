@@ -0,0 +1,118 @@
+package intring
+
+import "math/bits"
+
+// WordModulusBits is the largest modulus size handled by the word-size
+// arithmetic below: with p < 2^63 the sum of two residues fits in a uint64
+const WordModulusBits = 63
+
+// AddMod returns (a + b) mod p for residues a, b < p < 2^63
+func AddMod(a, b, p uint64) uint64 {
+    s := a + b
+    if s >= p {
+        s -= p
+    }
+    return s
+}
+
+// SubMod returns (a - b) mod p for residues a, b < p
+func SubMod(a, b, p uint64) uint64 {
+    if a >= b {
+        return a - b
+    }
+    return a + p - b
+}
+
+// MulMod returns a*b mod p for residues a, b < p, using the full 128-bit product
+func MulMod(a, b, p uint64) uint64 {
+    hi, lo := bits.Mul64(a, b)
+    _, rem := bits.Div64(hi, lo, p)
+    return rem
+}
+
+// InvMod returns the inverse of a modulo p < 2^63 by the extended Euclidean
+// algorithm on machine words, and false if a is not invertible
+func InvMod(a, p uint64) (uint64, bool) {
+    r0, r1 := int64(p), int64(a%p)
+    s0, s1 := int64(0), int64(1)
+    for r1 != 0 {
+        q := r0 / r1
+        r0, r1 = r1, r0-q*r1
+        s0, s1 = s1, s0-q*s1
+    }
+    if r0 != 1 {
+        return 0, false
+    }
+    if s0 < 0 {
+        s0 += int64(p)
+    }
+    return uint64(s0), true
+}
+
+// PowMod returns a^e mod p
+func PowMod(a, e, p uint64) uint64 {
+    result := uint64(1) % p
+    for ; e > 0; e >>= 1 {
+        if e&1 == 1 {
+            result = MulMod(result, a, p)
+        }
+        a = MulMod(a, a, p)
+    }
+    return result
+}
+
+// Montgomery is Montgomery arithmetic modulo an odd p < 2^62 with R = 2^64:
+// a residue a is held as a*R mod p, and Mul multiplies without division.
+// Mul, To, From and Inv take the same time for all residues of a modulus.
+type Montgomery struct {
+    p    uint64
+    pInv uint64 // -p^-1 mod 2^64
+    r2   uint64 // R^2 mod p
+}
+
+// NewMontgomery returns Montgomery arithmetic modulo the odd p < 2^62
+func NewMontgomery(p uint64) Montgomery {
+    // Newton's iteration doubles the number of correct low bits of p^-1
+    inv := p
+    for i := 0; i < 5; i++ {
+        inv *= 2 - p*inv
+    }
+    r := -p % p
+    return Montgomery{p: p, pInv: -inv, r2: MulMod(r, r, p)}
+}
+
+// Modulus returns p
+func (m Montgomery) Modulus() uint64 { return m.p }
+
+// Mul returns a*b/R mod p for residues a, b < p
+func (m Montgomery) Mul(a, b uint64) uint64 {
+    hi, lo := bits.Mul64(a, b)
+    q := lo * m.pInv
+    qhi, qlo := bits.Mul64(q, m.p)
+    _, carry := bits.Add64(lo, qlo, 0)
+    u, _ := bits.Add64(hi, qhi, carry)
+    d, borrow := bits.Sub64(u, m.p, 0)
+    // u if the subtraction borrowed, else d, without a branch
+    mask := -borrow
+    return d ^ (mask & (u ^ d))
+}
+
+// To returns the Montgomery form a*R mod p of the residue a
+func (m Montgomery) To(a uint64) uint64 { return m.Mul(a, m.r2) }
+
+// From returns the residue of the Montgomery form a
+func (m Montgomery) From(a uint64) uint64 { return m.Mul(a, 1) }
+
+// Inv returns the inverse of the Montgomery residue a as a^(p-2); the
+// exponent is public, so branching on its bits leaks nothing about a
+func (m Montgomery) Inv(a uint64) uint64 {
+    result := m.To(1)
+    e := m.p - 2
+    for i := 63; i >= 0; i-- {
+        result = m.Mul(result, result)
+        if e>>i&1 == 1 {
+            result = m.Mul(result, a)
+        }
+    }
+    return result
+}
@@ -0,0 +1,81 @@
+package intring_test
+
+import (
+    "math/big"
+    "math/rand"
+    "testing"
+
+    "euclid/intring"
+)
+
+// wordPrimes are moduli of the word-size arithmetic, up to the 63-bit limit
+var wordPrimes = []uint64{2, 3, 65537, 1<<31 - 1, 1<<61 - 1, 1<<62 - 57, 1<<63 - 25}
+
+// residues returns the edge residues of p and random ones
+func residues(rng *rand.Rand, p uint64) []uint64 {
+    rs := []uint64{0, 1, p - 1, p / 2}
+    for i := 0; i < 100; i++ {
+        rs = append(rs, uint64(rng.Int63n(int64(p))))
+    }
+    return rs
+}
+
+// TestWordArithmetic checks AddMod, SubMod, MulMod, InvMod and PowMod
+// against big.Int
+func TestWordArithmetic(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, p := range wordPrimes {
+        pb := new(big.Int).SetUint64(p)
+        check := func(op string, a, b, got uint64, want *big.Int) {
+            if want.Mod(want, pb).Uint64() != got {
+                t.Errorf("%s(%d, %d) mod %d = %d, want %s", op, a, b, p, got, want)
+            }
+        }
+        rs := residues(rng, p)
+        for i, a := range rs {
+            b := rs[(i*7+3)%len(rs)]
+            ab, bb := new(big.Int).SetUint64(a), new(big.Int).SetUint64(b)
+            check("AddMod", a, b, intring.AddMod(a, b, p), new(big.Int).Add(ab, bb))
+            check("SubMod", a, b, intring.SubMod(a, b, p), new(big.Int).Sub(ab, bb))
+            check("MulMod", a, b, intring.MulMod(a, b, p), new(big.Int).Mul(ab, bb))
+            check("PowMod", a, b, intring.PowMod(a, b, p), new(big.Int).Exp(ab, bb, pb))
+            inv, ok := intring.InvMod(a, p)
+            if ok != (a != 0) {
+                t.Errorf("InvMod(%d, %d) reported invertible %v", a, p, ok)
+            } else if ok && intring.MulMod(a, inv, p) != 1 {
+                t.Errorf("InvMod(%d, %d) = %d, not an inverse", a, p, inv)
+            }
+        }
+    }
+    if _, ok := intring.InvMod(6, 15); ok {
+        t.Error("InvMod(6, 15) found an inverse of a non-unit")
+    }
+}
+
+// TestMontgomery checks that the Montgomery form round-trips and that Mul
+// and Inv agree with MulMod and InvMod
+func TestMontgomery(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, p := range []uint64{3, 65537, 1<<31 - 1, 1<<61 - 1, 1<<62 - 57} {
+        m := intring.NewMontgomery(p)
+        if m.Modulus() != p {
+            t.Errorf("Modulus() = %d, want %d", m.Modulus(), p)
+        }
+        rs := residues(rng, p)
+        for i, a := range rs {
+            b := rs[(i*7+3)%len(rs)]
+            if got := m.From(m.To(a)); got != a {
+                t.Errorf("mod %d: From(To(%d)) = %d", p, a, got)
+            }
+            if got, want := m.From(m.Mul(m.To(a), m.To(b))), intring.MulMod(a, b, p); got != want {
+                t.Errorf("mod %d: Mul(%d, %d) = %d, want %d", p, a, b, got, want)
+            }
+            if a == 0 {
+                continue
+            }
+            if got, want := m.From(m.Inv(m.To(a))), intring.PowMod(a, p-2, p); got != want {
+                t.Errorf("mod %d: Inv(%d) = %d, want %d", p, a, got, want)
+            }
+        }
+    }
+}
@@ -0,0 +1,129 @@
+// Package plotutil renders the figures of package poly with gonum/plot.
+// It is kept apart from poly so that programs that only compute with
+// polynomials do not depend on gonum/plot and everything it pulls in.
+package plotutil
+
+import (
+    "euclid/poly"
+
+    "gonum.org/v1/plot"
+    "gonum.org/v1/plot/plotter"
+    "gonum.org/v1/plot/plotutil"
+    "gonum.org/v1/plot/vg"
+    "gonum.org/v1/plot/vg/draw"
+)
+
+// Save draws fig and saves it to file, in the format given by the
+// extension of file (png, svg, pdf, ...)
+func Save(fig *poly.Figure, file string) error {
+    p, err := Plot(fig)
+    if err != nil {
+        return err
+    }
+    return p.Save(vg.Length(fig.Width)*vg.Inch, vg.Length(fig.Height)*vg.Inch, file)
+}
+
+// Plot draws fig on a new gonum plot, for callers that want to adjust it
+// before saving
+func Plot(fig *poly.Figure) (*plot.Plot, error) {
+    p := plot.New()
+    p.Title.Text = fig.Title
+    p.X.Label.Text = fig.XLabel
+    p.Y.Label.Text = fig.YLabel
+    if fig.Grid {
+        p.Add(plotter.NewGrid())
+    }
+    for i, s := range fig.Series {
+        points := make(plotter.XYs, len(s.Points))
+        for j, pt := range s.Points {
+            points[j] = plotter.XY{X: pt.X, Y: pt.Y}
+        }
+        var thumbs []plot.Thumbnailer
+        if !s.Scatter {
+            line, err := plotter.NewLine(points)
+            if err != nil {
+                return nil, err
+            }
+            line.Color = plotutil.Color(i)
+            if s.Color != nil {
+                line.Color = s.Color
+            }
+            for _, d := range s.Dashes {
+                line.Dashes = append(line.Dashes, vg.Points(d))
+            }
+            p.Add(line)
+            thumbs = append(thumbs, line)
+        }
+        if s.Scatter || s.Markers {
+            scatter, err := plotter.NewScatter(points)
+            if err != nil {
+                return nil, err
+            }
+            scatter.GlyphStyle.Shape = glyph(s.Glyph, i)
+            scatter.GlyphStyle.Color = plotutil.Color(i)
+            if s.Color != nil {
+                scatter.GlyphStyle.Color = s.Color
+            }
+            if s.Radius > 0 {
+                scatter.GlyphStyle.Radius = vg.Points(s.Radius)
+            }
+            p.Add(scatter)
+            thumbs = append(thumbs, scatter)
+        }
+        if s.Low != nil {
+            bars, err := plotter.NewYErrorBars(errorPoints{points, s.Low, s.High})
+            if err != nil {
+                return nil, err
+            }
+            bars.Color = plotutil.Color(i)
+            if s.Color != nil {
+                bars.Color = s.Color
+            }
+            p.Add(bars)
+        }
+        if s.Label != "" {
+            p.Legend.Add(s.Label, thumbs...)
+        }
+    }
+    p.Legend.Top = fig.LegendTop
+    p.Legend.Left = fig.LegendLeft
+    if fig.LogX {
+        p.X.Scale = plot.LogScale{}
+        p.X.Tick.Marker = plot.LogTicks{Prec: -1}
+    }
+    if fig.LogY {
+        p.Y.Scale = plot.LogScale{}
+        p.Y.Tick.Marker = plot.LogTicks{Prec: -1}
+    }
+    if fig.Pad > 0 {
+        padX := fig.Pad * (p.X.Max - p.X.Min + 1)
+        padY := fig.Pad * (p.Y.Max - p.Y.Min + 1)
+        p.X.Min, p.X.Max = p.X.Min-padX, p.X.Max+padX
+        p.Y.Min, p.Y.Max = p.Y.Min-padY, p.Y.Max+padY
+    }
+    return p, nil
+}
+
+// errorPoints are the points of a series with the extents of their error
+// bars, as plotter.NewYErrorBars takes them
+type errorPoints struct {
+    plotter.XYs
+    low, high []float64
+}
+
+func (e errorPoints) YError(i int) (float64, float64) { return e.low[i], e.high[i] }
+
+// glyph returns the gonum marker for g, the palette's i-th for GlyphAuto
+func glyph(g poly.Glyph, i int) draw.GlyphDrawer {
+    switch g {
+    case poly.GlyphCircle:
+        return draw.CircleGlyph{}
+    case poly.GlyphRing:
+        return draw.RingGlyph{}
+    case poly.GlyphPyramid:
+        return draw.PyramidGlyph{}
+    case poly.GlyphCross:
+        return draw.CrossGlyph{}
+    }
+    return plotutil.Shape(i)
+}
@@ -0,0 +1,67 @@
+package plotutil_test
+
+import (
+    "bytes"
+    "math"
+    "os"
+    "path/filepath"
+    "testing"
+
+    "euclid/plotutil"
+    "euclid/poly"
+)
+
+// testFigure returns a figure with a line, a scatter series and error bars
+// on logarithmic axes, which exercise every kind of plotter Plot adds
+func testFigure() *poly.Figure {
+    points := []poly.Point{{X: 1, Y: 2}, {X: 2, Y: 3}, {X: 4, Y: 9}}
+    return &poly.Figure{
+        Title:  "test",
+        XLabel: "x",
+        YLabel: "y",
+        Width:  3,
+        Height: 2,
+        Grid:   true,
+        LogX:   true,
+        LogY:   true,
+        Series: []poly.Series{
+            {Label: "line", Points: points, Markers: true, Dashes: []float64{2, 2},
+                Low: []float64{0.5, 0.5, 1}, High: []float64{1, 1, 2}},
+            {Label: "scatter", Points: points, Scatter: true, Glyph: poly.GlyphCross, Radius: 3},
+        },
+    }
+}
+
+// TestSave saves the figure in each format and checks the file signatures
+func TestSave(t *testing.T) {
+    dir := t.TempDir()
+    for ext, magic := range map[string]string{"png": "\x89PNG", "svg": "<?xml", "pdf": "%PDF"} {
+        file := filepath.Join(dir, "figure."+ext)
+        if err := plotutil.Save(testFigure(), file); err != nil {
+            t.Errorf("Save(%s): %v", ext, err)
+            continue
+        }
+        data, err := os.ReadFile(file)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if !bytes.HasPrefix(data, []byte(magic)) {
+            t.Errorf("%s file does not start with %q", ext, magic)
+        }
+    }
+}
+
+// TestSaveErrors checks that an unknown format and a figure gonum/plot
+// cannot draw are reported, not panicked on
+func TestSaveErrors(t *testing.T) {
+    dir := t.TempDir()
+    if err := plotutil.Save(testFigure(), filepath.Join(dir, "figure.unknown")); err == nil {
+        t.Error("Save accepted an unknown format")
+    }
+    nan := testFigure()
+    nan.LogX, nan.LogY = false, false
+    nan.Series[0].Points[1].Y = math.NaN()
+    if _, err := plotutil.Plot(nan); err == nil {
+        t.Error("Plot accepted a NaN coordinate")
+    }
+}
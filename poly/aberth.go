@@ -0,0 +1,346 @@
+package poly
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "sync"
+)
+
+// bigComplex is a complex number with big.Float parts of a common precision
+type bigComplex struct {
+    re, im *big.Float
+}
+
+func newBigComplex(re, im float64, prec uint) bigComplex {
+    return bigComplex{new(big.Float).SetPrec(prec).SetFloat64(re), new(big.Float).SetPrec(prec).SetFloat64(im)}
+}
+
+func (z bigComplex) prec() uint {
+    return z.re.Prec()
+}
+
+// withPrec returns a copy of z rounded or extended to prec bits
+func (z bigComplex) withPrec(prec uint) bigComplex {
+    return bigComplex{new(big.Float).SetPrec(prec).Set(z.re), new(big.Float).SetPrec(prec).Set(z.im)}
+}
+
+func (z bigComplex) add(w bigComplex) bigComplex {
+    p := z.prec()
+    return bigComplex{new(big.Float).SetPrec(p).Add(z.re, w.re), new(big.Float).SetPrec(p).Add(z.im, w.im)}
+}
+
+func (z bigComplex) sub(w bigComplex) bigComplex {
+    p := z.prec()
+    return bigComplex{new(big.Float).SetPrec(p).Sub(z.re, w.re), new(big.Float).SetPrec(p).Sub(z.im, w.im)}
+}
+
+func (z bigComplex) mul(w bigComplex) bigComplex {
+    p := z.prec()
+    ac := new(big.Float).SetPrec(p).Mul(z.re, w.re)
+    bd := new(big.Float).SetPrec(p).Mul(z.im, w.im)
+    ad := new(big.Float).SetPrec(p).Mul(z.re, w.im)
+    bc := new(big.Float).SetPrec(p).Mul(z.im, w.re)
+    return bigComplex{ac.Sub(ac, bd), ad.Add(ad, bc)}
+}
+
+func (z bigComplex) quo(w bigComplex) bigComplex {
+    p := z.prec()
+    den := new(big.Float).SetPrec(p).Mul(w.re, w.re)
+    den.Add(den, new(big.Float).SetPrec(p).Mul(w.im, w.im))
+    num := z.mul(bigComplex{w.re, new(big.Float).SetPrec(p).Neg(w.im)})
+    return bigComplex{num.re.Quo(num.re, den), num.im.Quo(num.im, den)}
+}
+
+// abs returns |z| as a float64, which is enough for convergence tests
+func (z bigComplex) abs() float64 {
+    re, _ := z.re.Float64()
+    im, _ := z.im.Float64()
+    return math.Hypot(re, im)
+}
+
+func (z bigComplex) isZero() bool {
+    return z.re.Sign() == 0 && z.im.Sign() == 0
+}
+
+// snap zeroes the parts of z that are negligible at its precision, such as
+// the imaginary residue left on real roots
+func (z bigComplex) snap() bigComplex {
+    tol := math.Ldexp(math.Max(1, z.abs()), -int(z.prec())+16)
+    re, _ := z.re.Float64()
+    im, _ := z.im.Float64()
+    if math.Abs(re) <= tol {
+        z.re = new(big.Float).SetPrec(z.prec())
+    }
+    if math.Abs(im) <= tol {
+        z.im = new(big.Float).SetPrec(z.prec())
+    }
+    return z
+}
+
+// text formats z with the given number of significant digits
+func (z bigComplex) text(digits int) string {
+    if z.im.Sign() == 0 {
+        return z.re.Text('g', digits)
+    }
+    sign := "+"
+    im := z.im
+    if im.Sign() < 0 {
+        sign = "-"
+        im = new(big.Float).Neg(im)
+    }
+    return fmt.Sprintf("%s %s %si", z.re.Text('g', digits), sign, im.Text('g', digits))
+}
+
+// evalBigComplex evaluates the polynomial with coefficients a (lowest degree
+// first) and its derivative da at z with Horner's scheme
+func evalBigComplex(a, da []*big.Float, z bigComplex) (bigComplex, bigComplex) {
+    p := z.prec()
+    zero := func() *big.Float { return new(big.Float).SetPrec(p) }
+    v := bigComplex{zero(), zero()}
+    for i := len(a) - 1; i >= 0; i-- {
+        v = v.mul(z)
+        v.re.Add(v.re, a[i])
+    }
+    dv := bigComplex{zero(), zero()}
+    for i := len(da) - 1; i >= 0; i-- {
+        dv = dv.mul(z)
+        dv.re.Add(dv.re, da[i])
+    }
+    return v, dv
+}
+
+// aberthInitial places the starting approximations on circles whose radii
+// come from Graeffe root magnitude estimates, at angles spread around the
+// circle and offset from the real axis
+func aberthInitial(p *Polynomial, prec uint) []bigComplex {
+    n := p.Deg()
+    radii := make([]float64, n)
+    estimates := graeffeRootMagnitudes(p, 4)
+    mean, count := 0.0, 0
+    for i := 0; i < n; i++ {
+        r := estimates[len(estimates)-1][i]
+        if math.IsNaN(r) || math.IsInf(r, 0) || r == 0 {
+            continue
+        }
+        radii[i] = r
+        mean += r
+        count++
+    }
+    if count > 0 {
+        mean /= float64(count)
+    } else {
+        mean = 1
+    }
+    z := make([]bigComplex, n)
+    for i := 0; i < n; i++ {
+        r := radii[i]
+        if r == 0 {
+            r = mean
+        }
+        angle := 2*math.Pi*float64(i)/float64(n) + 0.4
+        z[i] = newBigComplex(r*math.Cos(angle), r*math.Sin(angle), prec)
+    }
+    return z
+}
+
+// aberthIterate runs Aberth–Ehrlich iterations at the precision of z until
+// every correction is below 2^-(prec-8) relative to its root. All roots are
+// updated simultaneously from the previous approximations, one goroutine each;
+// roots that have converged are no longer updated.
+func aberthIterate(a, da []*big.Float, z []bigComplex, maxIter int) ([]bigComplex, bool) {
+    n := len(z)
+    prec := z[0].prec()
+    tol := math.Ldexp(1, -int(prec)+8)
+    one := new(big.Float).SetPrec(prec).SetInt64(1)
+
+    done := make([]bool, n)
+    for iter := 0; iter < maxIter; iter++ {
+        next := make([]bigComplex, n)
+        var wg sync.WaitGroup
+        for i := 0; i < n; i++ {
+            if done[i] {
+                // converged roots stay put for the rest of this precision level
+                next[i] = z[i]
+                continue
+            }
+            wg.Add(1)
+            go func(i int) {
+                defer wg.Done()
+                v, dv := evalBigComplex(a, da, z[i])
+                if v.isZero() {
+                    next[i], done[i] = z[i], true
+                    return
+                }
+                if dv.isZero() {
+                    // nudge away from a critical point
+                    next[i] = z[i].add(newBigComplex(tol, tol, prec))
+                    return
+                }
+                w := v.quo(dv)
+                sum := bigComplex{new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)}
+                for j := 0; j < n; j++ {
+                    if j != i {
+                        d := z[i].sub(z[j])
+                        if !d.isZero() {
+                            sum = sum.add(bigComplex{one, new(big.Float).SetPrec(prec)}.quo(d))
+                        }
+                    }
+                }
+                den := bigComplex{one, new(big.Float).SetPrec(prec)}.sub(w.mul(sum))
+                if den.isZero() {
+                    next[i] = z[i].sub(w)
+                    return
+                }
+                corr := w.quo(den)
+                next[i] = z[i].sub(corr)
+                done[i] = corr.abs() <= tol*math.Max(1, next[i].abs())
+            }(i)
+        }
+        wg.Wait()
+
+        z = next
+        converged := true
+        for _, d := range done {
+            converged = converged && d
+        }
+        if converged {
+            return z, true
+        }
+    }
+    return z, false
+}
+
+// aberthRoots computes all complex roots of the squarefree part of p to the
+// given number of decimal digits. It converges in float-like 64-bit
+// precision first, then repeatedly doubles the working precision and polishes
+// the roots until the target is reached. Ill-conditioned polynomials that do
+// not converge at some precision are retried at double precision as well.
+// Repeated roots are reported once.
+func aberthRoots(p *Polynomial, digits int) ([]bigComplex, error) {
+    if p.Deg() < 1 {
+        return nil, fmt.Errorf("aberth: polynomial must have positive degree")
+    }
+    // Multiple roots slow Aberth down to linear convergence, so work on the
+    // squarefree part f / gcd(f, f')
+    sqf := p
+    if d := p.Derivative(); !d.IsZero() {
+        gcd := extendedGCDResult(p, d).GCD
+        if gcd.Deg() > 0 {
+            sqf, _ = p.div(gcd)
+        }
+    }
+    n := sqf.Deg()
+    if n == 0 {
+        return nil, nil
+    }
+
+    target := uint(float64(digits)*math.Log2(10)) + 16
+    maxPrec := 64 * target
+    // Start from float-like precision, plus enough bits to hold the
+    // coefficients themselves so that evaluation is not pure rounding noise
+    prec := uint(64)
+    for _, c := range sqf.coeff {
+        if bits := uint(c.Num().BitLen() + c.Denom().BitLen()); 64+bits > prec {
+            prec = 64 + bits
+        }
+    }
+    if prec > target {
+        prec = target
+    }
+    z := aberthInitial(sqf, prec)
+    for {
+        a := make([]*big.Float, n+1)
+        for i := range a {
+            a[i] = new(big.Float).SetPrec(prec).SetRat(sqf.coeff[i])
+        }
+        da := make([]*big.Float, n)
+        for i := range da {
+            da[i] = new(big.Float).SetPrec(prec).Mul(a[i+1], new(big.Float).SetPrec(prec).SetInt64(int64(i+1)))
+        }
+
+        var ok bool
+        z, ok = aberthIterate(a, da, z, 200)
+        if ok && prec >= target {
+            for i := range z {
+                z[i] = z[i].snap()
+            }
+            return z, nil
+        }
+        if !ok && prec >= maxPrec {
+            return z, fmt.Errorf("aberth: no convergence at %d bits of precision", prec)
+        }
+        // Double the precision: either to polish converged roots or because
+        // the polynomial is too ill-conditioned for the current precision
+        prec *= 2
+        if ok && prec > target {
+            prec = target
+        }
+        for i := range z {
+            z[i] = z[i].withPrec(prec)
+        }
+    }
+}
+
+// rootsOfFactor reports which of the computed roots are roots of the exact
+// factor q, i.e. |q(z)| is negligible at the working precision
+func rootsOfFactor(roots []bigComplex, q *Polynomial) []bool {
+    matches := make([]bool, len(roots))
+    if len(roots) == 0 || q.IsZero() {
+        return matches
+    }
+    prec := roots[0].prec()
+    n := q.Deg()
+    a := make([]*big.Float, n+1)
+    var scale float64
+    for i := range a {
+        a[i] = new(big.Float).SetPrec(prec).SetRat(q.coeff[i])
+        f, _ := a[i].Float64()
+        scale += math.Abs(f)
+    }
+    tol := math.Ldexp(scale, -int(prec)/2)
+    for i, z := range roots {
+        v, _ := evalBigComplex(a, nil, z)
+        bound := tol * math.Pow(math.Max(1, z.abs()), float64(n))
+        matches[i] = v.abs() <= bound
+    }
+    return matches
+}
+
+// ComplexRoot is a root computed by AberthRoots, a complex number Re + Im*i
+// with big.Float parts at the working precision
+type ComplexRoot struct {
+    Re, Im *big.Float
+}
+
+// Text formats z with the given number of significant digits
+func (z ComplexRoot) Text(digits int) string {
+    return bigComplex{z.Re, z.Im}.text(digits)
+}
+
+// complexRoots converts the results of aberthRoots for the exported API
+func complexRoots(zs []bigComplex) []ComplexRoot {
+    roots := make([]ComplexRoot, len(zs))
+    for i, z := range zs {
+        roots[i] = ComplexRoot{z.re, z.im}
+    }
+    return roots
+}
+
+// AberthRoots returns the complex roots of f to the requested number of
+// decimal digits, each repeated root once. When the iteration does not
+// converge, the error comes with the last approximations.
+func AberthRoots(f *Polynomial, digits int) ([]ComplexRoot, error) {
+    zs, err := aberthRoots(f, digits)
+    return complexRoots(zs), err
+}
+
+// RootsOfFactor reports which of roots, as returned by AberthRoots, are
+// roots of the exact factor q
+func RootsOfFactor(roots []ComplexRoot, q *Polynomial) []bool {
+    zs := make([]bigComplex, len(roots))
+    for i, z := range roots {
+        zs[i] = bigComplex{z.Re, z.Im}
+    }
+    return rootsOfFactor(zs, q)
+}
@@ -0,0 +1,102 @@
+package poly
+
+import (
+    "math/big"
+    "math/rand"
+    "testing"
+)
+
+// aliasingPairs returns pairs of operands for the aliasing tests: edge cases
+// with the zero polynomial, constants and untrimmed coefficient slices, then
+// random pairs
+func aliasingPairs() [][2]*Polynomial {
+    untrimmed := NewPolyNoCopy([]*big.Rat{big.NewRat(1, 2), big.NewRat(-3, 1), new(big.Rat)})
+    pairs := [][2]*Polynomial{
+        {Zero(), One()},
+        {One(), Constant(big.NewRat(-7, 3))},
+        {X(), X()},
+        {untrimmed, X()},
+        {FromRoots([]*big.Rat{big.NewRat(1, 1), big.NewRat(-2, 3)}), X().Sub(One())},
+    }
+    rng := rand.New(rand.NewSource(1))
+    for i := 0; i < 20; i++ {
+        pairs = append(pairs, [2]*Polynomial{RandomPolynomial(rng, rng.Intn(8)), RandomPolynomial(rng, 1+rng.Intn(5))})
+    }
+    return pairs
+}
+
+// overwrite sets every coefficient of every polynomial to 7
+func overwrite(ps ...*Polynomial) {
+    for _, p := range ps {
+        for _, c := range p.coeff {
+            c.SetInt64(7)
+        }
+    }
+}
+
+func TestNewPolynomialCopies(t *testing.T) {
+    coeffs := []*big.Rat{big.NewRat(1, 2), big.NewRat(-3, 1), big.NewRat(5, 1)}
+    p := NewPolynomial(coeffs)
+    want := p.String()
+    coeffs[0] = big.NewRat(9, 1)
+    coeffs[1].SetInt64(9)
+    if got := p.String(); got != want {
+        t.Errorf("NewPolynomial kept the caller's coefficients: %s, want %s", got, want)
+    }
+}
+
+func TestNewPolyNoCopyTakesOwnership(t *testing.T) {
+    coeffs := []*big.Rat{big.NewRat(1, 1), big.NewRat(2, 1)}
+    p := NewPolyNoCopy(coeffs)
+    coeffs[1].SetInt64(5)
+    if got := p.Coeff(1); got.Cmp(big.NewRat(5, 1)) != 0 {
+        t.Errorf("NewPolyNoCopy copied its slice: coefficient of x is %s, want 5", got.RatString())
+    }
+}
+
+func TestCoeffReturnsCopy(t *testing.T) {
+    p := X()
+    p.Coeff(1).SetInt64(5)
+    if got := p.String(); got != X().String() {
+        t.Errorf("writing to Coeff changed the polynomial to %s", got)
+    }
+}
+
+// TestResultsDoNotAliasOperands overwrites every result of the public
+// operations and checks that the operands are unchanged
+func TestResultsDoNotAliasOperands(t *testing.T) {
+    for _, pair := range aliasingPairs() {
+        f, g := pair[0], pair[1]
+        before := coefficientList(f) + ";" + coefficientList(g)
+
+        results := []*Polynomial{f.Add(g), f.Sub(g), f.Monic(), f.Derivative(), f.Integral(new(big.Rat)),
+            f.Compose(g), f.Reciprocal()}
+        for _, algorithm := range mulStrategies {
+            results = append(results, f.Mul(g, WithStrategy(algorithm)))
+        }
+        if !g.IsZero() {
+            q, r, err := f.Div(g)
+            if err != nil {
+                t.Fatalf("Div(%v, %v): %v", f, g, err)
+            }
+            res, err := ExtendedGCDResult(f, g)
+            if err != nil {
+                t.Fatalf("ExtendedGCDResult(%v, %v): %v", f, g, err)
+            }
+            results = append(results, q, r, res.GCD, res.S, res.T)
+            for _, step := range res.Steps {
+                results = append(results, step.Dividend, step.Divisor, step.Quotient, step.Remainder)
+            }
+        }
+        overwrite(results...)
+        if after := coefficientList(f) + ";" + coefficientList(g); after != before {
+            t.Errorf("operands changed from %s to %s by writing to the results", before, after)
+        }
+    }
+}
+
+func TestFuzzAliasingSeeds(t *testing.T) {
+    for _, pair := range aliasingPairs() {
+        FuzzAliasing(polyPairToBytes(pair[0], pair[1]))
+    }
+}
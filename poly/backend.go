@@ -0,0 +1,322 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "math/rand"
+    "sort"
+    "strings"
+    "sync"
+
+    "euclid/internal/benchtime"
+)
+
+// Backend is a coefficient implementation the algorithms below can run on.
+// Coefficients are opaque values owned by their backend; the algorithms only
+// combine them through these methods, so a new backend (GMP bindings,
+// fixed-point numbers, word-size residues) needs no change to algorithm code.
+// Backends must be safe for concurrent use; results must not share storage
+// with the arguments.
+type Backend interface {
+    // Name returns the name the backend was selected by, with its parameter
+    Name() string
+    // FromRat converts an exact rational coefficient, failing when r has no
+    // image in the backend (such as a denominator divisible by p)
+    FromRat(r *big.Rat) (interface{}, error)
+    Zero() interface{}
+    One() interface{}
+    IsZero(a interface{}) bool
+    Add(a, b interface{}) interface{}
+    Sub(a, b interface{}) interface{}
+    Mul(a, b interface{}) interface{}
+    // Quo returns a/b for nonzero b, exactly or to the backend's precision
+    Quo(a, b interface{}) interface{}
+    String(a interface{}) string
+}
+
+// BackendFactory creates a backend from the parameter given after the colon
+// in its name ("modp:65537" passes "65537"; an empty string when there is none)
+type BackendFactory func(param string) (Backend, error)
+
+// The registry is written by RegisterBackend, normally from init functions,
+// and read by NewBackend from any goroutine
+var (
+    backendsMu sync.RWMutex
+    backends   = map[string]BackendFactory{}
+)
+
+// RegisterBackend makes a backend available to NewBackend under name.
+// Registering the same name twice panics, as with database/sql drivers.
+func RegisterBackend(name string, factory BackendFactory) {
+    backendsMu.Lock()
+    defer backendsMu.Unlock()
+    if _, dup := backends[name]; dup {
+        panic(fmt.Sprintf("backend %q registered twice", name))
+    }
+    backends[name] = factory
+}
+
+// NewBackend returns the backend selected by spec, a registered name
+// optionally followed by ":" and a parameter
+func NewBackend(spec string) (Backend, error) {
+    name, param := spec, ""
+    if i := strings.IndexByte(spec, ':'); i >= 0 {
+        name, param = spec[:i], spec[i+1:]
+    }
+    backendsMu.RLock()
+    factory, ok := backends[name]
+    backendsMu.RUnlock()
+    if !ok {
+        return nil, fmt.Errorf("unknown backend %q (available: %s)", name, strings.Join(BackendNames(), ", "))
+    }
+    return factory(param)
+}
+
+// BackendNames returns the registered backend names in sorted order
+func BackendNames() []string {
+    backendsMu.RLock()
+    defer backendsMu.RUnlock()
+    names := make([]string, 0, len(backends))
+    for name := range backends {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// backendPoly is a polynomial with coefficients in a backend, lowest degree
+// first and without zero leading coefficients; the zero polynomial has no
+// coefficients
+type backendPoly struct {
+    b     Backend
+    coeff []interface{}
+}
+
+// toBackend converts p to the backend b
+func toBackend(b Backend, p *Polynomial) (*backendPoly, error) {
+    coeffs := make([]interface{}, 0, len(p.coeff))
+    for i := 0; i <= p.Deg() && i < len(p.coeff); i++ {
+        c, err := b.FromRat(p.coeff[i])
+        if err != nil {
+            return nil, err
+        }
+        coeffs = append(coeffs, c)
+    }
+    return newBackendPoly(b, coeffs), nil
+}
+
+// newBackendPoly takes ownership of coeffs and drops zero leading coefficients
+func newBackendPoly(b Backend, coeffs []interface{}) *backendPoly {
+    n := len(coeffs)
+    for n > 0 && b.IsZero(coeffs[n-1]) {
+        n--
+    }
+    return &backendPoly{b, coeffs[:n]}
+}
+
+func (p *backendPoly) isZero() bool {
+    return len(p.coeff) == 0
+}
+
+// deg returns the degree of p, -1 for the zero polynomial
+func (p *backendPoly) deg() int {
+    return len(p.coeff) - 1
+}
+
+func (p *backendPoly) add(q *backendPoly) *backendPoly {
+    return p.combine(q, p.b.Add)
+}
+
+func (p *backendPoly) sub(q *backendPoly) *backendPoly {
+    return p.combine(q, p.b.Sub)
+}
+
+// combine applies op to the coefficients of p and q power by power
+func (p *backendPoly) combine(q *backendPoly, op func(a, b interface{}) interface{}) *backendPoly {
+    coeffs := make([]interface{}, max(len(p.coeff), len(q.coeff)))
+    for i := range coeffs {
+        a, c := p.b.Zero(), p.b.Zero()
+        if i < len(p.coeff) {
+            a = p.coeff[i]
+        }
+        if i < len(q.coeff) {
+            c = q.coeff[i]
+        }
+        coeffs[i] = op(a, c)
+    }
+    return newBackendPoly(p.b, coeffs)
+}
+
+func (p *backendPoly) mul(q *backendPoly) *backendPoly {
+    if p.isZero() || q.isZero() {
+        return &backendPoly{p.b, nil}
+    }
+    coeffs := make([]interface{}, len(p.coeff)+len(q.coeff)-1)
+    for i := range coeffs {
+        coeffs[i] = p.b.Zero()
+    }
+    for i, a := range p.coeff {
+        for j, c := range q.coeff {
+            coeffs[i+j] = p.b.Add(coeffs[i+j], p.b.Mul(a, c))
+        }
+    }
+    return newBackendPoly(p.b, coeffs)
+}
+
+// monic returns p divided by its leading coefficient; the zero polynomial
+// is returned unchanged
+func (p *backendPoly) monic() *backendPoly {
+    if p.isZero() {
+        return p
+    }
+    lead := p.coeff[p.deg()]
+    coeffs := make([]interface{}, len(p.coeff))
+    for i, c := range p.coeff {
+        coeffs[i] = p.b.Quo(c, lead)
+    }
+    return newBackendPoly(p.b, coeffs)
+}
+
+// div returns the quotient and remainder of p divided by a nonzero q
+func (p *backendPoly) div(q *backendPoly) (*backendPoly, *backendPoly) {
+    b := p.b
+    rem := make([]interface{}, len(p.coeff))
+    copy(rem, p.coeff)
+    if len(p.coeff) < len(q.coeff) {
+        return &backendPoly{b, nil}, newBackendPoly(b, rem)
+    }
+    quot := make([]interface{}, len(p.coeff)-len(q.coeff)+1)
+    lead := q.coeff[len(q.coeff)-1]
+    for k := len(quot) - 1; k >= 0; k-- {
+        c := b.Quo(rem[k+len(q.coeff)-1], lead)
+        quot[k] = c
+        for j, qc := range q.coeff {
+            rem[k+j] = b.Sub(rem[k+j], b.Mul(c, qc))
+        }
+        // the leading term cancels exactly in a field; force it for
+        // backends that round
+        rem[k+len(q.coeff)-1] = b.Zero()
+    }
+    return newBackendPoly(b, quot), newBackendPoly(b, rem[:len(q.coeff)-1])
+}
+
+func (p *backendPoly) String() string {
+    if p.isZero() {
+        return "0"
+    }
+    var b strings.Builder
+    for i := len(p.coeff) - 1; i >= 0; i-- {
+        if p.b.IsZero(p.coeff[i]) {
+            continue
+        }
+        term := p.b.String(p.coeff[i])
+        if b.Len() > 0 {
+            if strings.HasPrefix(term, "-") {
+                b.WriteString(" - ")
+                term = term[1:]
+            } else {
+                b.WriteString(" + ")
+            }
+        }
+        b.WriteString(term)
+        switch {
+        case i == 1:
+            b.WriteString("*x")
+        case i > 1:
+            fmt.Fprintf(&b, "*x^%d", i)
+        }
+    }
+    return b.String()
+}
+
+// extendedEuclideanBackend runs the extended Euclidean algorithm on f and g
+// with coefficients in b and returns gcd, s and t with s*f + t*g = gcd;
+// of opts only the step hook applies
+func extendedEuclideanBackend(b Backend, f, g *Polynomial, opts ...Option) (gcd, s, t *backendPoly, err error) {
+    step := newConfig(opts...).step
+    bf, err := toBackend(b, f)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+    bg, err := toBackend(b, g)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+    gcd, s, t = extendedEuclideanBackendPoly(bf, bg, step)
+    return gcd, s, t, nil
+}
+
+// extendedEuclideanBackendPoly is the remainder loop of
+// extendedEuclideanBackend on polynomials already in a backend, calling
+// step, if not nil, after every division
+func extendedEuclideanBackendPoly(bf, bg *backendPoly, step func(iterations, deg int)) (gcd, s, t *backendPoly) {
+    b := bf.b
+    zero := &backendPoly{b, nil}
+    one := newBackendPoly(b, []interface{}{b.One()})
+    s0, s1, t0, t1 := one, zero, zero, one
+    iterations := 0
+    // a rounding backend may never reach an exact zero remainder; the degree
+    // drops on every step, so the loop ends after at most deg g + 1 divisions
+    for !bg.isZero() {
+        q, r := bf.div(bg)
+        bf, bg = bg, r
+        s0, s1 = s1, s0.sub(q.mul(s1))
+        t0, t1 = t1, t0.sub(q.mul(t1))
+        iterations++
+        if step != nil {
+            step(iterations, bg.deg())
+        }
+    }
+    return bf, s0, t0
+}
+
+// BackendGCD returns the extended GCD of f and g computed with coefficients
+// in the backend b: gcd, s and t with s*f + t*g = gcd, as they print in b
+func BackendGCD(b Backend, f, g *Polynomial) (gcd, s, t fmt.Stringer, err error) {
+    bgcd, bs, bt, err := extendedEuclideanBackend(b, f, g)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+    return bgcd, bs, bt, nil
+}
+
+// backendBenchDegrees are the input degrees timed by BackendBench
+var backendBenchDegrees = []int{8, 16, 32, 48}
+
+// BackendBenchRow holds the ns/op of the extended Euclidean algorithm at one
+// input degree with Polynomial's own implementation and the rat and gmp
+// backends
+type BackendBenchRow struct {
+    Degree         int
+    Poly, Rat, GMP float64
+}
+
+// BackendBench times the extended Euclidean algorithm on random inputs with
+// the exact backends (rat and gmp) against Polynomial's own implementation,
+// after checking that both backends agree
+func BackendBench() ([]BackendBenchRow, error) {
+    gmp, err := NewBackend("gmp")
+    if err != nil {
+        return nil, err
+    }
+    rat, err := NewBackend("rat")
+    if err != nil {
+        return nil, err
+    }
+    var rows []BackendBenchRow
+    for _, n := range backendBenchDegrees {
+        f, g := randomPair(rand.New(rand.NewSource(int64(n))), n)
+        gcdRat, _, _, _ := extendedEuclideanBackend(rat, f, g)
+        gcdGMP, _, _, _ := extendedEuclideanBackend(gmp, f, g)
+        if gcdRat.String() != gcdGMP.String() {
+            return rows, fmt.Errorf("rat and gmp backends disagree on the GCD at degree %d", n)
+        }
+        rows = append(rows, BackendBenchRow{
+            Degree: n,
+            Poly:   benchtime.Ns(func() { extendedGCDResult(f, g) }),
+            Rat:    benchtime.Ns(func() { extendedEuclideanBackend(rat, f, g) }),
+            GMP:    benchtime.Ns(func() { extendedEuclideanBackend(gmp, f, g) }),
+        })
+    }
+    return rows, nil
+}
@@ -0,0 +1,260 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "strconv"
+    "strings"
+
+    "euclid/intring"
+    "euclid/polymod"
+)
+
+// The built-in coefficient backends. Others, such as the GMP bindings,
+// register themselves from their own files.
+func init() {
+    RegisterBackend("rat", newRatBackend)
+    RegisterBackend("modp", newModPBackend)
+    RegisterBackend("fixed", newFixedBackend)
+    RegisterBackend("ratfunc", newRatFuncModBackend)
+}
+
+// ratBackend computes exactly with big.Rat, like Polynomial itself
+type ratBackend struct{}
+
+func newRatBackend(param string) (Backend, error) {
+    if param != "" {
+        return nil, fmt.Errorf("backend rat takes no parameter")
+    }
+    return ratBackend{}, nil
+}
+
+func (ratBackend) Name() string { return "rat" }
+
+func (ratBackend) FromRat(r *big.Rat) (interface{}, error) {
+    return new(big.Rat).Set(r), nil
+}
+
+func (ratBackend) Zero() interface{}         { return new(big.Rat) }
+func (ratBackend) One() interface{}          { return big.NewRat(1, 1) }
+func (ratBackend) IsZero(a interface{}) bool { return a.(*big.Rat).Sign() == 0 }
+
+func (ratBackend) Add(a, b interface{}) interface{} {
+    return new(big.Rat).Add(a.(*big.Rat), b.(*big.Rat))
+}
+
+func (ratBackend) Sub(a, b interface{}) interface{} {
+    return new(big.Rat).Sub(a.(*big.Rat), b.(*big.Rat))
+}
+
+func (ratBackend) Mul(a, b interface{}) interface{} {
+    return new(big.Rat).Mul(a.(*big.Rat), b.(*big.Rat))
+}
+
+func (ratBackend) Quo(a, b interface{}) interface{} {
+    return new(big.Rat).Quo(a.(*big.Rat), b.(*big.Rat))
+}
+
+func (ratBackend) String(a interface{}) string { return a.(*big.Rat).RatString() }
+
+// modPBackend computes in Z/p for a prime p below 2^63 with the word-size
+// arithmetic of modular.go; coefficients are uint64 residues
+type modPBackend struct {
+    p uint64
+}
+
+func newModPBackend(param string) (Backend, error) {
+    p, err := strconv.ParseUint(param, 10, 64)
+    if err != nil || p < 2 || p >= 1<<intring.WordModulusBits {
+        return nil, fmt.Errorf("backend modp needs a prime modulus below 2^%d, as in modp:65537", intring.WordModulusBits)
+    }
+    if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+        return nil, fmt.Errorf("backend modp: %d is not prime", p)
+    }
+    return modPBackend{p}, nil
+}
+
+// ModP reduces p's rational coefficients modulo the prime q, mapping a/b to
+// a*b^-1. It fails if a denominator is divisible by q.
+func (p *Polynomial) ModP(q uint64) (*polymod.PolyMod, error) {
+    if err := polymod.CheckModulus(q); err != nil {
+        return nil, err
+    }
+    b := modPBackend{q}
+    residues := make([]uint64, p.Deg()+1)
+    for i := range residues {
+        if i >= len(p.coeff) {
+            continue
+        }
+        r, err := b.FromRat(p.coeff[i])
+        if err != nil {
+            return nil, err
+        }
+        residues[i] = r.(uint64)
+    }
+    return polymod.NewPolyMod(q, residues)
+}
+
+func (m modPBackend) Name() string { return fmt.Sprintf("modp:%d", m.p) }
+
+// FromRat maps num/den to num * den^-1 mod p
+func (m modPBackend) FromRat(r *big.Rat) (interface{}, error) {
+    mod := new(big.Int).SetUint64(m.p)
+    num := new(big.Int).Mod(r.Num(), mod).Uint64()
+    inv, ok := intring.InvMod(new(big.Int).Mod(r.Denom(), mod).Uint64(), m.p)
+    if !ok {
+        return nil, fmt.Errorf("backend %s: denominator of %s is divisible by %d", m.Name(), r.RatString(), m.p)
+    }
+    return intring.MulMod(num, inv, m.p), nil
+}
+
+func (m modPBackend) Zero() interface{}         { return uint64(0) }
+func (m modPBackend) One() interface{}          { return uint64(1) }
+func (m modPBackend) IsZero(a interface{}) bool { return a.(uint64) == 0 }
+
+func (m modPBackend) Add(a, b interface{}) interface{} { return intring.AddMod(a.(uint64), b.(uint64), m.p) }
+func (m modPBackend) Sub(a, b interface{}) interface{} { return intring.SubMod(a.(uint64), b.(uint64), m.p) }
+func (m modPBackend) Mul(a, b interface{}) interface{} { return intring.MulMod(a.(uint64), b.(uint64), m.p) }
+
+func (m modPBackend) Quo(a, b interface{}) interface{} {
+    inv, _ := intring.InvMod(b.(uint64), m.p)
+    return intring.MulMod(a.(uint64), inv, m.p)
+}
+
+func (m modPBackend) String(a interface{}) string { return strconv.FormatUint(a.(uint64), 10) }
+
+// ratFuncModBackend computes in GF(p)(t); coefficients are
+// *polymod.RatFuncMod, all over GF(p), so the errors of their arithmetic,
+// which only report mixed fields, are dropped.
+// Rational inputs become constants, so "ratfunc:p" behaves like "modp:p"
+// on them; polymod.ParseFuncFieldPoly reads input that involves t.
+type ratFuncModBackend struct {
+    p uint64
+}
+
+func newRatFuncModBackend(param string) (Backend, error) {
+    p, err := strconv.ParseUint(param, 10, 64)
+    if err != nil {
+        return nil, fmt.Errorf("backend ratfunc needs a prime modulus below 2^%d, as in ratfunc:5", intring.WordModulusBits)
+    }
+    if err := polymod.CheckModulus(p); err != nil {
+        return nil, err
+    }
+    return ratFuncModBackend{p}, nil
+}
+
+func (r ratFuncModBackend) Name() string { return fmt.Sprintf("ratfunc:%d", r.p) }
+
+// FromRat maps num/den to the constant num * den^-1 mod p
+func (r ratFuncModBackend) FromRat(q *big.Rat) (interface{}, error) {
+    c, err := modPBackend{r.p}.FromRat(q)
+    if err != nil {
+        return nil, err
+    }
+    return polymod.RatFuncConst(r.p, c.(uint64)), nil
+}
+
+func (r ratFuncModBackend) Zero() interface{}         { return polymod.RatFuncConst(r.p, 0) }
+func (r ratFuncModBackend) One() interface{}          { return polymod.RatFuncConst(r.p, 1) }
+func (r ratFuncModBackend) IsZero(a interface{}) bool { return a.(*polymod.RatFuncMod).IsZero() }
+
+func (r ratFuncModBackend) Add(a, b interface{}) interface{} {
+    sum, _ := a.(*polymod.RatFuncMod).Add(b.(*polymod.RatFuncMod))
+    return sum
+}
+
+func (r ratFuncModBackend) Sub(a, b interface{}) interface{} {
+    diff, _ := a.(*polymod.RatFuncMod).Sub(b.(*polymod.RatFuncMod))
+    return diff
+}
+
+func (r ratFuncModBackend) Mul(a, b interface{}) interface{} {
+    product, _ := a.(*polymod.RatFuncMod).Mul(b.(*polymod.RatFuncMod))
+    return product
+}
+
+func (r ratFuncModBackend) Quo(a, b interface{}) interface{} {
+    q, _ := a.(*polymod.RatFuncMod).Quo(b.(*polymod.RatFuncMod))
+    return q
+}
+
+// String parenthesizes sums and quotients, which backendPoly.String follows
+// with "*x^k"
+func (r ratFuncModBackend) String(a interface{}) string {
+    return parenthesizeRatFunc(a.(*polymod.RatFuncMod).String())
+}
+
+// parenthesizeRatFunc parenthesizes a formatted sum or quotient for use as
+// a coefficient
+func parenthesizeRatFunc(s string) string {
+    if strings.ContainsAny(s, " /") {
+        return "(" + s + ")"
+    }
+    return s
+}
+
+// fixedBackend approximates real coefficients by fixed-point numbers with
+// the given number of fraction bits, stored as big.Int multiples of 2^-bits.
+// Rounding noise means remainders are rarely exactly zero, so values below
+// 2^-(bits/2) count as zero.
+type fixedBackend struct {
+    bits uint
+}
+
+func newFixedBackend(param string) (Backend, error) {
+    bits := 64
+    if param != "" {
+        var err error
+        bits, err = strconv.Atoi(param)
+        if err != nil || bits < 8 {
+            return nil, fmt.Errorf("backend fixed needs at least 8 fraction bits, as in fixed:64")
+        }
+    }
+    return fixedBackend{uint(bits)}, nil
+}
+
+func (f fixedBackend) Name() string { return fmt.Sprintf("fixed:%d", f.bits) }
+
+// FromRat rounds r to the nearest multiple of 2^-bits
+func (f fixedBackend) FromRat(r *big.Rat) (interface{}, error) {
+    scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), f.bits)))
+    half := big.NewRat(1, 2)
+    if scaled.Sign() < 0 {
+        half.Neg(half)
+    }
+    scaled.Add(scaled, half)
+    return new(big.Int).Quo(scaled.Num(), scaled.Denom()), nil
+}
+
+func (f fixedBackend) Zero() interface{} { return new(big.Int) }
+func (f fixedBackend) One() interface{}  { return new(big.Int).Lsh(big.NewInt(1), f.bits) }
+
+func (f fixedBackend) IsZero(a interface{}) bool {
+    return a.(*big.Int).CmpAbs(new(big.Int).Lsh(big.NewInt(1), f.bits/2)) < 0
+}
+
+func (f fixedBackend) Add(a, b interface{}) interface{} {
+    return new(big.Int).Add(a.(*big.Int), b.(*big.Int))
+}
+
+func (f fixedBackend) Sub(a, b interface{}) interface{} {
+    return new(big.Int).Sub(a.(*big.Int), b.(*big.Int))
+}
+
+func (f fixedBackend) Mul(a, b interface{}) interface{} {
+    p := new(big.Int).Mul(a.(*big.Int), b.(*big.Int))
+    return p.Rsh(p, f.bits)
+}
+
+func (f fixedBackend) Quo(a, b interface{}) interface{} {
+    n := new(big.Int).Lsh(a.(*big.Int), f.bits)
+    return n.Quo(n, b.(*big.Int))
+}
+
+// String prints the value with as many significant digits as the fraction
+// bits carry
+func (f fixedBackend) String(a interface{}) string {
+    v := new(big.Float).SetPrec(f.bits + 64).SetInt(a.(*big.Int))
+    v.SetMantExp(v, -int(f.bits))
+    return v.Text('g', int(float64(f.bits)*0.30103))
+}
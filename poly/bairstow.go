@@ -0,0 +1,137 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "math/cmplx"
+)
+
+// floatCoeffs returns the coefficients of p as float64, lowest degree first
+func (p *Polynomial) floatCoeffs() []float64 {
+    a := make([]float64, p.Deg()+1)
+    for i := range a {
+        a[i], _ = p.coeff[i].Float64()
+    }
+    return a
+}
+
+// bairstowStep divides a (lowest degree first) by x^2 - r*x - s. It returns
+// the synthetic-division rows b (quotient and remainder) and c (partial
+// derivatives of b with respect to r and s).
+func bairstowStep(a []float64, r, s float64) ([]float64, []float64) {
+    n := len(a) - 1
+    b := make([]float64, n+1)
+    c := make([]float64, n+1)
+    for i := n; i >= 0; i-- {
+        b[i] = a[i]
+        if i+1 <= n {
+            b[i] += r * b[i+1]
+        }
+        if i+2 <= n {
+            b[i] += s * b[i+2]
+        }
+        c[i] = b[i]
+        if i+1 <= n {
+            c[i] += r * c[i+1]
+        }
+        if i+2 <= n {
+            c[i] += s * c[i+2]
+        }
+    }
+    return b, c
+}
+
+// bairstowFactors factors the real polynomial a (lowest degree first, nonzero
+// leading coefficient) into monic real quadratic factors, plus one linear
+// factor for odd degree, using Bairstow's method. No complex arithmetic is
+// involved. Each factor is returned lowest degree first; their product is a
+// divided by its leading coefficient.
+func bairstowFactors(a []float64, tol float64, maxIter int) ([][]float64, error) {
+    n := len(a) - 1
+    if n < 1 || a[n] == 0 {
+        return nil, errors.New("bairstow: polynomial must have positive degree")
+    }
+    work := make([]float64, n+1)
+    for i := range a {
+        work[i] = a[i] / a[n]
+    }
+
+    var factors [][]float64
+    for len(work)-1 > 2 {
+        // Start from the trailing coefficients and retry from fixed
+        // perturbations when the iteration stalls
+        guesses := [][2]float64{{0, 0}, {0.5, -0.5}, {-1, 1}, {1, 1}, {2, -3}, {-0.3, 2}}
+        if work[2] != 0 {
+            guesses[0] = [2]float64{-work[1] / work[2], -work[0] / work[2]}
+        }
+
+        converged := false
+        var r, s float64
+        for _, guess := range guesses {
+            r, s = guess[0], guess[1]
+            for iter := 0; iter < maxIter; iter++ {
+                b, c := bairstowStep(work, r, s)
+                det := c[2]*c[2] - c[1]*c[3]
+                if det == 0 {
+                    break
+                }
+                dr := (-b[1]*c[2] + b[0]*c[3]) / det
+                ds := (-b[0]*c[2] + b[1]*c[1]) / det
+                r += dr
+                s += ds
+                if math.Abs(dr) <= tol*(1+math.Abs(r)) && math.Abs(ds) <= tol*(1+math.Abs(s)) {
+                    converged = true
+                    break
+                }
+            }
+            if converged && !math.IsNaN(r) && !math.IsNaN(s) {
+                break
+            }
+            converged = false
+        }
+        if !converged {
+            return factors, fmt.Errorf("bairstow: no convergence after %d iterations", maxIter)
+        }
+
+        factors = append(factors, []float64{-s, -r, 1})
+        b, _ := bairstowStep(work, r, s)
+        work = b[2:]
+    }
+
+    if len(work)-1 == 2 {
+        factors = append(factors, []float64{work[0] / work[2], work[1] / work[2], 1})
+    } else {
+        factors = append(factors, []float64{work[0] / work[1], 1})
+    }
+    return factors, nil
+}
+
+// factorRoots returns the roots of a monic linear or quadratic real factor
+func factorRoots(f []float64) []complex128 {
+    if len(f) == 2 {
+        return []complex128{complex(-f[0], 0)}
+    }
+    p, q := f[1], f[0]
+    sq := cmplx.Sqrt(complex(p*p-4*q, 0))
+    return []complex128{(complex(-p, 0) + sq) / 2, (complex(-p, 0) - sq) / 2}
+}
+
+// BairstowFactor is a monic real factor x + c0 or x^2 + c1*x + c0 of a
+// polynomial, with its coefficients lowest degree first and its roots
+type BairstowFactor struct {
+    Coeffs []float64
+    Roots  []complex128
+}
+
+// BairstowFactors returns the real quadratic factorization of f in float64
+// arithmetic, plus one linear factor for odd degree. When the iteration
+// does not converge, the error comes with the factors found so far.
+func BairstowFactors(f *Polynomial) ([]BairstowFactor, error) {
+    factors, err := bairstowFactors(f.floatCoeffs(), 1e-14, 500)
+    res := make([]BairstowFactor, len(factors))
+    for i, factor := range factors {
+        res[i] = BairstowFactor{factor, factorRoots(factor)}
+    }
+    return res, err
+}
@@ -0,0 +1,229 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+// ErrSingular is returned by Solve for a matrix without an inverse
+var ErrSingular = errors.New("matrix: singular")
+
+// The exact linear algebra below runs Bareiss's fraction-free elimination
+// on integer matrices: the entry update
+//
+//  m[i][j] = (m[r][c] m[i][j] - m[i][c] m[r][j]) / pivot of the previous step
+//
+// divides exactly, and the entries after step k are k+1 by k+1 minors of the
+// input, so they grow only as fast as determinants do. Rational matrices are
+// first scaled row by row to integers by the LCM of each row's denominators,
+// which changes neither the rank nor the solutions and divides the
+// determinant back out at the end.
+
+// checkMatrix checks that a has rows of equal length and no nil entries,
+// naming op in the errors, and returns its number of columns
+func checkMatrix(op string, a [][]*big.Rat) (int, error) {
+    cols := 0
+    if len(a) > 0 {
+        cols = len(a[0])
+    }
+    for i, row := range a {
+        if len(row) != cols {
+            return 0, fmt.Errorf("%s: row %d has %d entries, row 1 has %d", op, i+1, len(row), cols)
+        }
+        for j, c := range row {
+            if c == nil {
+                return 0, fmt.Errorf("%s: entry (%d, %d) is nil", op, i+1, j+1)
+            }
+        }
+    }
+    return cols, nil
+}
+
+// integerRows returns the rows of a scaled to integers, each by the LCM of
+// its denominators, and the product of the scale factors
+func integerRows(a [][]*big.Rat) ([][]*big.Int, *big.Int) {
+    m := make([][]*big.Int, len(a))
+    scale := big.NewInt(1)
+    for i, row := range a {
+        lcm := big.NewInt(1)
+        for _, c := range row {
+            d := c.Denom()
+            g := new(big.Int).GCD(nil, nil, lcm, d)
+            lcm.Mul(lcm, new(big.Int).Quo(d, g))
+        }
+        scale.Mul(scale, lcm)
+        m[i] = make([]*big.Int, len(row))
+        for j, c := range row {
+            v := new(big.Int).Mul(c.Num(), lcm)
+            m[i][j] = v.Quo(v, c.Denom())
+        }
+    }
+    return m, scale
+}
+
+// bareissEliminate brings the integer matrix m to row echelon form in place
+// by fraction-free elimination, choosing pivots among the first cols
+// columns only and updating every column, so that extra columns, such as
+// the right-hand side of a system, follow the row operations. It returns
+// the number of pivots, the pivot column of each pivot row and the sign of
+// the row permutation.
+func bareissEliminate(m [][]*big.Int, cols int) (rank int, pivots []int, sign int) {
+    sign = 1
+    prev := big.NewInt(1)
+    width := 0
+    if len(m) > 0 {
+        width = len(m[0])
+    }
+    for c := 0; c < cols && rank < len(m); c++ {
+        p := rank
+        for p < len(m) && m[p][c].Sign() == 0 {
+            p++
+        }
+        if p == len(m) {
+            continue
+        }
+        if p != rank {
+            m[rank], m[p] = m[p], m[rank]
+            sign = -sign
+        }
+        for i := rank + 1; i < len(m); i++ {
+            for j := c + 1; j < width; j++ {
+                v := new(big.Int).Mul(m[rank][c], m[i][j])
+                v.Sub(v, new(big.Int).Mul(m[i][c], m[rank][j]))
+                m[i][j] = v.Quo(v, prev)
+            }
+            m[i][c] = new(big.Int)
+        }
+        prev = m[rank][c]
+        pivots = append(pivots, c)
+        rank++
+    }
+    return rank, pivots, sign
+}
+
+// DeterminantInt returns the determinant of the square integer matrix a by
+// Bareiss elimination, without leaving the integers
+func DeterminantInt(a [][]*big.Int) (*big.Int, error) {
+    for i, row := range a {
+        if len(row) != len(a) {
+            return nil, fmt.Errorf("determinant: row %d has %d entries in a %d by %d matrix", i+1, len(row), len(a), len(a))
+        }
+        for j, c := range row {
+            if c == nil {
+                return nil, fmt.Errorf("determinant: entry (%d, %d) is nil", i+1, j+1)
+            }
+        }
+    }
+    m := make([][]*big.Int, len(a))
+    for i, row := range a {
+        m[i] = make([]*big.Int, len(row))
+        for j, c := range row {
+            m[i][j] = new(big.Int).Set(c)
+        }
+    }
+    return integerDeterminant(m), nil
+}
+
+// integerDeterminant returns the determinant of the square matrix m,
+// overwriting it
+func integerDeterminant(m [][]*big.Int) *big.Int {
+    n := len(m)
+    if n == 0 {
+        return big.NewInt(1)
+    }
+    rank, _, sign := bareissEliminate(m, n)
+    if rank < n {
+        return new(big.Int)
+    }
+    det := new(big.Int).Set(m[n-1][n-1])
+    if sign < 0 {
+        det.Neg(det)
+    }
+    return det
+}
+
+// Determinant returns the determinant of the square rational matrix a,
+// given as a slice of rows, by fraction-free Bareiss elimination
+func Determinant(a [][]*big.Rat) (*big.Rat, error) {
+    cols, err := checkMatrix("determinant", a)
+    if err != nil {
+        return nil, err
+    }
+    if len(a) > 0 && cols != len(a) {
+        return nil, fmt.Errorf("determinant: %d by %d matrix is not square", len(a), cols)
+    }
+    return bareissDeterminant(a), nil
+}
+
+// bareissDeterminant is Determinant for a valid square matrix
+func bareissDeterminant(a [][]*big.Rat) *big.Rat {
+    m, scale := integerRows(a)
+    return new(big.Rat).SetFrac(integerDeterminant(m), scale)
+}
+
+// Rank returns the rank of the rational matrix a, of any shape, by
+// fraction-free Bareiss elimination
+func Rank(a [][]*big.Rat) (int, error) {
+    cols, err := checkMatrix("rank", a)
+    if err != nil {
+        return 0, err
+    }
+    m, _ := integerRows(a)
+    rank, _, _ := bareissEliminate(m, cols)
+    return rank, nil
+}
+
+// Solve returns the solution x of a x = b for a square nonsingular rational
+// matrix a, or ErrSingular. The augmented matrix is eliminated fraction-free
+// and the fractions only appear in the back substitution.
+func Solve(a [][]*big.Rat, b []*big.Rat) ([]*big.Rat, error) {
+    n := len(a)
+    cols, err := checkMatrix("solve", a)
+    if err != nil {
+        return nil, err
+    }
+    if n > 0 && cols != n {
+        return nil, fmt.Errorf("solve: %d by %d matrix is not square", n, cols)
+    }
+    if len(b) != n {
+        return nil, fmt.Errorf("solve: right-hand side has %d entries for %d equations", len(b), n)
+    }
+    aug := make([][]*big.Rat, n)
+    for i := range a {
+        if b[i] == nil {
+            return nil, fmt.Errorf("solve: right-hand side entry %d is nil", i+1)
+        }
+        aug[i] = append(append([]*big.Rat(nil), a[i]...), b[i])
+    }
+    m, _ := integerRows(aug)
+    if rank, _, _ := bareissEliminate(m, n); rank < n {
+        return nil, ErrSingular
+    }
+    x := make([]*big.Rat, n)
+    for i := n - 1; i >= 0; i-- {
+        sum := new(big.Rat).SetInt(m[i][n])
+        for j := i + 1; j < n; j++ {
+            sum.Sub(sum, new(big.Rat).Mul(new(big.Rat).SetInt(m[i][j]), x[j]))
+        }
+        x[i] = sum.Quo(sum, new(big.Rat).SetInt(m[i][i]))
+    }
+    return x, nil
+}
+
+// ParseMatrix parses a matrix written row by row as "a,b;c,d" with rational
+// entries
+func ParseMatrix(s string) ([][]*big.Rat, error) {
+    var rows [][]*big.Rat
+    for _, row := range splitNonEmpty(s, ';') {
+        r, err := ParseRatList(row)
+        if err != nil {
+            return nil, err
+        }
+        rows = append(rows, r)
+    }
+    if _, err := checkMatrix("matrix", rows); err != nil {
+        return nil, err
+    }
+    return rows, nil
+}
@@ -0,0 +1,210 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// chebyshevT returns the Chebyshev polynomials T_0, ..., T_n of the first kind,
+// T_0 = 1, T_1 = x, T_(k+1) = 2x*T_k - T_(k-1)
+func chebyshevT(n int) []*Polynomial {
+    ts := []*Polynomial{One()}
+    if n == 0 {
+        return ts
+    }
+    ts = append(ts, X())
+    twoX := Monomial(big.NewRat(2, 1), 1)
+    for k := 1; k < n; k++ {
+        ts = append(ts, twoX.mul(ts[k]).Sub(ts[k-1]))
+    }
+    return ts
+}
+
+// ToChebyshev returns the coefficients c_0, ..., c_n of p in the Chebyshev
+// basis, p = c_0*T_0 + ... + c_n*T_n. Since T_k has degree k the conversion is
+// a triangular solve from the top coefficient down.
+func (p *Polynomial) ToChebyshev() []*big.Rat {
+    n := p.Deg()
+    ts := chebyshevT(n)
+    rest := make([]*big.Rat, n+1)
+    for i := range rest {
+        rest[i] = new(big.Rat).Set(p.coeff[i])
+    }
+    c := make([]*big.Rat, n+1)
+    for k := n; k >= 0; k-- {
+        c[k] = new(big.Rat).Quo(rest[k], ts[k].coeff[k])
+        for i := 0; i <= k; i++ {
+            rest[i].Sub(rest[i], new(big.Rat).Mul(c[k], ts[k].coeff[i]))
+        }
+    }
+    return c
+}
+
+// FromChebyshev returns the polynomial c_0*T_0 + ... + c_n*T_n in the monomial basis
+func FromChebyshev(c []*big.Rat) *Polynomial {
+    ts := chebyshevT(max(len(c)-1, 0))
+    p := Zero()
+    for k, ck := range c {
+        p = p.Add(ts[k].mul(Constant(ck)))
+    }
+    return p
+}
+
+// EvalChebyshev evaluates c_0*T_0 + ... + c_n*T_n at x with Clenshaw's
+// recurrence, without converting to the monomial basis
+func EvalChebyshev(c []*big.Rat, x *big.Rat) *big.Rat {
+    b1, b2 := new(big.Rat), new(big.Rat)
+    twoX := new(big.Rat).Mul(x, big.NewRat(2, 1))
+    for k := len(c) - 1; k >= 1; k-- {
+        b := new(big.Rat).Mul(twoX, b1)
+        b.Sub(b, b2)
+        b.Add(b, c[k])
+        b1, b2 = b, b1
+    }
+    if len(c) == 0 {
+        return new(big.Rat)
+    }
+    result := new(big.Rat).Mul(x, b1)
+    result.Sub(result, b2)
+    return result.Add(result, c[0])
+}
+
+func binomialRat(n, k int) *big.Rat {
+    return new(big.Rat).SetInt(new(big.Int).Binomial(int64(n), int64(k)))
+}
+
+// ToBernstein returns the coefficients of p in the Bernstein basis of degree n
+// on [0, 1], b_(k,n)(x) = C(n,k) x^k (1-x)^(n-k). n must be at least deg p;
+// a larger n amounts to degree elevation.
+func (p *Polynomial) ToBernstein(n int) ([]*big.Rat, error) {
+    if n < p.Deg() {
+        return nil, fmt.Errorf("Bernstein degree %d below polynomial degree %d", n, p.Deg())
+    }
+    return p.toBernstein(n), nil
+}
+
+// toBernstein is ToBernstein for n >= deg p
+func (p *Polynomial) toBernstein(n int) []*big.Rat {
+    beta := make([]*big.Rat, n+1)
+    for k := 0; k <= n; k++ {
+        beta[k] = new(big.Rat)
+        for i := 0; i <= k && i <= p.Deg(); i++ {
+            // beta_k = sum_i C(k,i)/C(n,i) a_i
+            t := new(big.Rat).Quo(binomialRat(k, i), binomialRat(n, i))
+            beta[k].Add(beta[k], t.Mul(t, p.coeff[i]))
+        }
+    }
+    return beta
+}
+
+// FromBernstein returns the polynomial with Bernstein coefficients beta
+// (degree len(beta)-1 on [0, 1]) in the monomial basis
+func FromBernstein(beta []*big.Rat) *Polynomial {
+    n := len(beta) - 1
+    if n < 0 {
+        return Zero()
+    }
+    coeffs := make([]*big.Rat, n+1)
+    for i := 0; i <= n; i++ {
+        // a_i = C(n,i) sum_k (-1)^(i-k) C(i,k) beta_k
+        sum := new(big.Rat)
+        for k := 0; k <= i; k++ {
+            t := new(big.Rat).Mul(binomialRat(i, k), beta[k])
+            if (i-k)%2 == 1 {
+                t.Neg(t)
+            }
+            sum.Add(sum, t)
+        }
+        coeffs[i] = sum.Mul(sum, binomialRat(n, i))
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// EvalBernstein evaluates the polynomial with Bernstein coefficients beta at x
+// with de Casteljau's algorithm
+func EvalBernstein(beta []*big.Rat, x *big.Rat) *big.Rat {
+    if len(beta) == 0 {
+        return new(big.Rat)
+    }
+    work := make([]*big.Rat, len(beta))
+    for i := range beta {
+        work[i] = new(big.Rat).Set(beta[i])
+    }
+    oneMinusX := new(big.Rat).Sub(big.NewRat(1, 1), x)
+    for r := 1; r < len(work); r++ {
+        for i := 0; i < len(work)-r; i++ {
+            a := new(big.Rat).Mul(oneMinusX, work[i])
+            work[i] = a.Add(a, new(big.Rat).Mul(x, work[i+1]))
+        }
+    }
+    return work[0]
+}
+
+// ToBinomial returns the coefficients c_0, ..., c_n of p in the binomial
+// basis, p(x) = c_0*C(x,0) + c_1*C(x,1) + ... + c_n*C(x,n) with
+// C(x,k) = x(x-1)...(x-k+1)/k!, which is Newton's forward-difference form
+// at 0: c_k is the k-th forward difference of p(0), p(1), ..., p(n). By
+// Pólya's theorem p takes integer values at all integers exactly when every
+// c_k is an integer (IsIntegerValued).
+func (p *Polynomial) ToBinomial() []*big.Rat {
+    n := p.Deg()
+    diff := make([]*big.Rat, n+1)
+    for i := range diff {
+        diff[i] = p.Eval(big.NewRat(int64(i), 1))
+    }
+    c := make([]*big.Rat, n+1)
+    for k := 0; k <= n; k++ {
+        c[k] = new(big.Rat).Set(diff[0])
+        for i := 0; i < n-k; i++ {
+            diff[i].Sub(diff[i+1], diff[i])
+        }
+    }
+    return c
+}
+
+// binomialPolys returns C(x,0), ..., C(x,n) as polynomials,
+// C(x,k+1) = C(x,k)*(x-k)/(k+1)
+func binomialPolys(n int) []*Polynomial {
+    bs := []*Polynomial{One()}
+    for k := 0; k < n; k++ {
+        next := bs[k].mul(X().Sub(Constant(big.NewRat(int64(k), 1))))
+        bs = append(bs, next.scale(big.NewRat(1, int64(k+1))))
+    }
+    return bs
+}
+
+// FromBinomial returns the polynomial c_0*C(x,0) + ... + c_n*C(x,n) in the
+// monomial basis
+func FromBinomial(c []*big.Rat) *Polynomial {
+    bs := binomialPolys(max(len(c)-1, 0))
+    p := Zero()
+    for k, ck := range c {
+        p = p.Add(bs[k].scale(ck))
+    }
+    return p
+}
+
+// EvalBinomial evaluates c_0*C(x,0) + ... + c_n*C(x,n) at x, updating
+// C(x,k) from term to term, without converting to the monomial basis
+func EvalBinomial(c []*big.Rat, x *big.Rat) *big.Rat {
+    sum := new(big.Rat)
+    binom := big.NewRat(1, 1)
+    for k, ck := range c {
+        sum.Add(sum, new(big.Rat).Mul(ck, binom))
+        binom.Mul(binom, new(big.Rat).Sub(x, big.NewRat(int64(k), 1)))
+        binom.Quo(binom, big.NewRat(int64(k+1), 1))
+    }
+    return sum
+}
+
+// IsIntegerValued reports whether p(m) is an integer for every integer m,
+// which holds exactly when its binomial coefficients are integers even if
+// its monomial ones are not, as for x(x-1)/2
+func (p *Polynomial) IsIntegerValued() bool {
+    for _, c := range p.ToBinomial() {
+        if !c.IsInt() {
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,95 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "math/rand"
+    "testing"
+)
+
+// opBenchSizes are the degrees and coefficient sizes in bits that the
+// benchmarks below cover for each operation. The remainders of the extended
+// GCD over Q grow so fast that degree 32 with 64-bit coefficients already
+// takes a minute per call, so it gets smaller inputs than the arithmetic.
+var opBenchSizes = map[string]struct {
+    degrees []int
+    bits    []int
+}{
+    "Add":         {[]int{8, 32, 128}, []int{8, 64, 512}},
+    "Mul":         {[]int{8, 32, 128}, []int{8, 64, 512}},
+    "Div":         {[]int{8, 32, 128}, []int{8, 64, 512}},
+    "ExtendedGCD": {[]int{4, 8, 16}, []int{8, 32, 64}},
+}
+
+// opBenchPair returns two random polynomials of degree n with integer
+// coefficients of up to bits bits, the same pair for the same n and bits.
+// Independent denominators would make the coefficient size of every result
+// depend on their LCM rather than on bits.
+func opBenchPair(n, bits int) (*Polynomial, *Polynomial) {
+    rng := rand.New(rand.NewSource(int64(n)*1000 + int64(bits)))
+    limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+    random := func() *Polynomial {
+        coeffs := make([]*big.Rat, n+1)
+        for i := range coeffs {
+            a := new(big.Int).Rand(rng, limit)
+            if rng.Intn(2) == 0 {
+                a.Neg(a)
+            }
+            coeffs[i] = new(big.Rat).SetInt(a)
+        }
+        if coeffs[n].Sign() == 0 {
+            coeffs[n].SetInt64(1)
+        }
+        return NewPolyNoCopy(coeffs)
+    }
+    return random(), random()
+}
+
+// benchmarkOp runs a sub-benchmark "deg=n/bits=b" of op for each size of
+// opBenchSizes; prepare sets up the call to time from the pair of that size
+func benchmarkOp(b *testing.B, op string, prepare func(f, g *Polynomial, n, bits int) func()) {
+    size := opBenchSizes[op]
+    for _, n := range size.degrees {
+        for _, bits := range size.bits {
+            b.Run(fmt.Sprintf("deg=%d/bits=%d", n, bits), func(b *testing.B) {
+                f, g := opBenchPair(n, bits)
+                fn := prepare(f, g, n, bits)
+                b.ReportAllocs()
+                b.ResetTimer()
+                for i := 0; i < b.N; i++ {
+                    fn()
+                }
+            })
+        }
+    }
+}
+
+func BenchmarkAdd(b *testing.B) {
+    benchmarkOp(b, "Add", func(f, g *Polynomial, _, _ int) func() {
+        return func() { f.Add(g) }
+    })
+}
+
+func BenchmarkMul(b *testing.B) {
+    benchmarkOp(b, "Mul", func(f, g *Polynomial, _, _ int) func() {
+        return func() { f.Mul(g) }
+    })
+}
+
+// BenchmarkDiv divides f*g by g
+func BenchmarkDiv(b *testing.B) {
+    benchmarkOp(b, "Div", func(f, g *Polynomial, _, _ int) func() {
+        fg := f.Mul(g)
+        return func() { fg.Div(g) }
+    })
+}
+
+// BenchmarkExtendedGCD runs on f*h and g*h for a random h of a third of the
+// degree, so that the remainder sequence ends in a nontrivial GCD
+func BenchmarkExtendedGCD(b *testing.B) {
+    benchmarkOp(b, "ExtendedGCD", func(f, g *Polynomial, n, bits int) func() {
+        h, _ := opBenchPair(max(n/3, 1), bits)
+        fh, gh := f.Mul(h), g.Mul(h)
+        return func() { ExtendedGCDResult(fh, gh) }
+    })
+}
@@ -0,0 +1,76 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// berlekampMassey returns the connection polynomial C(x) = 1 + C_1 x + ... of
+// the shortest linear recurrence s_n = -(C_1 s_(n-1) + ... + C_L s_(n-L))
+// satisfied by seq, together with its length L. deg C can be less than L.
+func berlekampMassey(seq []*big.Rat) (*Polynomial, int) {
+    c := One()
+    b := One()
+    l, m := 0, 1
+    lastDiscrepancy := big.NewRat(1, 1)
+    for n := range seq {
+        // discrepancy between s_n and the prediction of the current recurrence
+        d := new(big.Rat).Set(seq[n])
+        for i := 1; i <= l && i < len(c.coeff); i++ {
+            d.Add(d, new(big.Rat).Mul(c.coeff[i], seq[n-i]))
+        }
+        if d.Sign() == 0 {
+            m++
+            continue
+        }
+        factor := new(big.Rat).Quo(d, lastDiscrepancy)
+        next := c.Sub(b.mul(Monomial(factor, m)))
+        if 2*l <= n {
+            l, b, lastDiscrepancy, m = n+1-l, c, d, 1
+        } else {
+            m++
+        }
+        c = next
+    }
+    return c, l
+}
+
+// MinimalPolynomial returns the characteristic polynomial
+// x^L + C_1 x^(L-1) + ... + C_L of the shortest linear recurrence satisfied by
+// seq, found by the Berlekamp–Massey algorithm. A recurrence of order L is
+// only determined by 2L terms, so seq should be at least twice as long as the
+// expected order.
+func MinimalPolynomial(seq []*big.Rat) *Polynomial {
+    c, l := berlekampMassey(seq)
+    coeffs := make([]*big.Rat, l+1)
+    for i := 0; i <= l; i++ {
+        coeffs[l-i] = new(big.Rat)
+        if i < len(c.coeff) {
+            coeffs[l-i].Set(c.coeff[i])
+        }
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// recurrenceFromMinimal returns the recurrence with characteristic polynomial
+// p, a_n = -(p_(L-1) a_(n-1) + ... + p_0 a_(n-L)), and the first L terms of
+// seq as its initial values
+func recurrenceFromMinimal(p *Polynomial, seq []*big.Rat) LinearRecurrence {
+    l := p.Deg()
+    rec := LinearRecurrence{coeffs: make([]*big.Rat, l), initial: make([]*big.Rat, l)}
+    for i := 0; i < l; i++ {
+        rec.coeffs[i] = new(big.Rat).Neg(p.coeff[l-1-i])
+        rec.initial[i] = new(big.Rat)
+        if i < len(seq) {
+            rec.initial[i].Set(seq[i])
+        }
+    }
+    return rec
+}
+
+// GuessRecurrence returns the shortest linear recurrence satisfied by seq,
+// with the first terms of seq as its initial values, and its
+// characteristic polynomial
+func GuessRecurrence(seq []*big.Rat) (LinearRecurrence, *Polynomial) {
+    p := MinimalPolynomial(seq)
+    return recurrenceFromMinimal(p, seq), p
+}
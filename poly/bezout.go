@@ -0,0 +1,91 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// BezoutBounds returns the degree bounds of the minimal Bézout cofactors of
+// f and g with gcd(f, g) = gcd: deg s < sBound = deg(g/gcd) and
+// deg t < tBound = deg(f/gcd), where a zero cofactor satisfies every bound
+// and a bound of 0 forces the cofactor to be zero. The cofactors within the
+// bounds are unique; the extended Euclidean algorithm produces them, but
+// other routes to s and t (subresultants, squarefree parts, cofactors of a
+// scaled gcd) may exceed them. When f and g are associates, or one of them
+// is zero, both quotients are constants and no cofactors meet the strict
+// bounds; both bounds are then 1, allowing a constant and a zero cofactor.
+func BezoutBounds(f, g, gcd *Polynomial) (sBound, tBound int, err error) {
+    if f == nil || g == nil || gcd == nil {
+        return 0, 0, ErrNilPolynomial
+    }
+    if gcd.IsZero() {
+        return 0, 0, fmt.Errorf("bezout: gcd is zero")
+    }
+    a, _ := f.div(gcd)
+    b, _ := g.div(gcd)
+    if a.Deg() == 0 && b.Deg() == 0 {
+        return 1, 1, nil
+    }
+    return b.Deg(), a.Deg(), nil
+}
+
+// withinBound reports whether p is zero or of degree below bound
+func withinBound(p *Polynomial, bound int) bool {
+    return p.IsZero() || p.Deg() < bound
+}
+
+// BezoutBoundError reports cofactors exceeding the bounds of BezoutBounds
+type BezoutBoundError struct {
+    DegS, SBound int
+    DegT, TBound int
+}
+
+func (e *BezoutBoundError) Error() string {
+    return fmt.Sprintf("bezout: deg s = %d, deg t = %d, expected deg s < %d and deg t < %d",
+        e.DegS, e.DegT, e.SBound, e.TBound)
+}
+
+// CheckBezoutBounds reports a *BezoutBoundError when s or t exceeds the
+// bounds of BezoutBounds for f, g and gcd
+func CheckBezoutBounds(f, g, gcd, s, t *Polynomial) error {
+    if s == nil || t == nil {
+        return ErrNilPolynomial
+    }
+    sBound, tBound, err := BezoutBounds(f, g, gcd)
+    if err != nil {
+        return err
+    }
+    if withinBound(s, sBound) && withinBound(t, tBound) {
+        return nil
+    }
+    return &BezoutBoundError{DegS: s.Deg(), SBound: sBound, DegT: t.Deg(), TBound: tBound}
+}
+
+// minimizeCofactors returns the minimal cofactors of f and g for gcd given
+// any cofactors with s*f + t*g = gcd: s mod (g/gcd), and the t that goes
+// with it, (gcd - s*f)/g. Every solution is (s + k*g/gcd, t - k*f/gcd), so
+// reducing s modulo g/gcd picks the one with deg s < deg(g/gcd), and then
+// deg t*g = deg(gcd - s*f) < deg g/gcd + deg f forces deg t < deg(f/gcd).
+func minimizeCofactors(f, g, gcd, s, t *Polynomial) (*Polynomial, *Polynomial) {
+    switch {
+    case gcd.IsZero():
+        return s, t
+    case g.IsZero():
+        // gcd is a unit times f
+        return Constant(new(big.Rat).Quo(gcd.coeff[gcd.Deg()], f.coeff[f.Deg()])), Zero()
+    case f.IsZero():
+        return Zero(), Constant(new(big.Rat).Quo(gcd.coeff[gcd.Deg()], g.coeff[g.Deg()]))
+    }
+    b, _ := g.div(gcd)
+    _, s = s.div(b)
+    t, _ = gcd.Sub(s.mul(f)).div(g)
+    return s.trim(), t.trim()
+}
+
+// MinimalBezout returns the extended GCD of f and g with the cofactors
+// reduced to the minimal ones, deg s < deg(g/gcd) and deg t < deg(f/gcd),
+// whatever algorithm opts select; the rest of the result is that of
+// ExtendedGCDResult
+func MinimalBezout(f, g *Polynomial, opts ...Option) (*GCDResult, error) {
+    return ExtendedGCDWith(f, g, GCDOptions{MinimalCofactors: true}, opts...)
+}
@@ -0,0 +1,172 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "math/big"
+)
+
+// PlaneCurve is a parametric polynomial curve (x(t), y(t)), t in [0, 1]
+type PlaneCurve struct {
+    x, y *Polynomial
+}
+
+// BezierCurve returns the Bézier curve with the given control points
+// (x_i, y_i); its coordinates are the Bernstein forms of those points
+func BezierCurve(control [][2]*big.Rat) PlaneCurve {
+    xs := make([]*big.Rat, len(control))
+    ys := make([]*big.Rat, len(control))
+    for i, pt := range control {
+        xs[i], ys[i] = pt[0], pt[1]
+    }
+    return PlaneCurve{FromBernstein(xs), FromBernstein(ys)}
+}
+
+// X returns the x coordinate x(t) of the curve
+func (c PlaneCurve) X() *Polynomial {
+    return c.x
+}
+
+// Y returns the y coordinate y(t) of the curve
+func (c PlaneCurve) Y() *Polynomial {
+    return c.y
+}
+
+// eliminate returns R(s) = Res_t(a.x(s) - b.x(t), a.y(s) - b.y(t)), whose roots
+// are the parameters s of a at which a meets b. Only the constant terms of the
+// two polynomials in t depend on s, so R is recovered exactly by evaluating
+// the univariate resultant at enough rational points and interpolating.
+func eliminate(a, b PlaneCurve) *Polynomial {
+    m, n := b.x.Deg(), b.y.Deg()
+    bound := n*a.x.Deg() + m*a.y.Deg()
+
+    xs := make([]*big.Rat, bound+1)
+    ys := make([]*big.Rat, bound+1)
+    for i := range xs {
+        s := big.NewRat(int64(i), 1)
+        // a.x(s) - b.x(t) and a.y(s) - b.y(t) as polynomials in t
+        px := make([]*big.Rat, m+1)
+        for j := range px {
+            px[j] = new(big.Rat).Neg(b.x.coeff[j])
+        }
+        px[0].Add(px[0], a.x.Eval(s))
+        py := make([]*big.Rat, n+1)
+        for j := range py {
+            py[j] = new(big.Rat).Neg(b.y.coeff[j])
+        }
+        py[0].Add(py[0], a.y.Eval(s))
+
+        xs[i], ys[i] = s, Resultant(NewPolyNoCopy(px), NewPolyNoCopy(py))
+    }
+    return interpolate(xs, ys)
+}
+
+// curveParams returns the parameters in [0, 1] at which curve a may meet
+// curve b, to about 50 bits
+func curveParams(a, b PlaneCurve) ([]float64, error) {
+    r := eliminate(a, b)
+    if r.IsZero() {
+        return nil, errors.New("intersection: the curves share a common component")
+    }
+    var params []float64
+    for _, iv := range isolateRealRoots(r, new(big.Rat), big.NewRat(1, 1)) {
+        f, _ := refineRoot(r, iv, 50).Float64()
+        params = append(params, f)
+    }
+    return params, nil
+}
+
+// curvePoint evaluates the curve at the float parameter t
+func curvePoint(c PlaneCurve, t float64) (float64, float64) {
+    r := new(big.Rat).SetFloat64(t)
+    x, _ := c.x.Eval(r).Float64()
+    y, _ := c.y.Eval(r).Float64()
+    return x, y
+}
+
+// curveIntersections computes the intersection points of two parametric
+// polynomial curves over t in [0, 1]. The candidate parameters of each curve
+// are the real roots of a resultant, isolated exactly and refined; the
+// candidates of the two curves are then paired up by the distance between
+// the corresponding points. Each result is (s, t) with a(s) = b(t).
+func curveIntersections(a, b PlaneCurve) ([][2]float64, error) {
+    ss, err := curveParams(a, b)
+    if err != nil {
+        return nil, err
+    }
+    ts, err := curveParams(b, a)
+    if err != nil {
+        return nil, err
+    }
+
+    var result [][2]float64
+    for _, s := range ss {
+        ax, ay := curvePoint(a, s)
+        best, bestDist := 0.0, math.Inf(1)
+        for _, t := range ts {
+            bx, by := curvePoint(b, t)
+            if d := math.Hypot(ax-bx, ay-by); d < bestDist {
+                best, bestDist = t, d
+            }
+        }
+        if bestDist <= 1e-9*math.Max(1, math.Hypot(ax, ay)) {
+            result = append(result, [2]float64{s, best})
+        }
+    }
+    return result, nil
+}
+
+// ParseControlPoints parses control points written as "x0,y0;x1,y1;..."
+func ParseControlPoints(s string) ([][2]*big.Rat, error) {
+    points, err := ParsePoints(s)
+    if err != nil {
+        return nil, err
+    }
+    if len(points) < 2 {
+        return nil, errors.New("a Bézier curve needs at least two control points")
+    }
+    return points, nil
+}
+
+// ParsePoints parses points written as "x0,y0;x1,y1;..." with rational
+// coordinates
+func ParsePoints(s string) ([][2]*big.Rat, error) {
+    var points [][2]*big.Rat
+    for _, pair := range splitNonEmpty(s, ';') {
+        coords := splitNonEmpty(pair, ',')
+        if len(coords) != 2 {
+            return nil, fmt.Errorf("invalid point %q", pair)
+        }
+        var pt [2]*big.Rat
+        for i, c := range coords {
+            r, ok := new(big.Rat).SetString(c)
+            if !ok {
+                return nil, fmt.Errorf("invalid coordinate %q", c)
+            }
+            pt[i] = r
+        }
+        points = append(points, pt)
+    }
+    return points, nil
+}
+
+// CurveIntersection is a point (X, Y) = a(S) = b(T) where two curves meet
+type CurveIntersection struct {
+    S, T, X, Y float64
+}
+
+// Intersections returns the points where the curves a and b meet for
+// parameters in [0, 1]
+func Intersections(a, b PlaneCurve) ([]CurveIntersection, error) {
+    params, err := curveIntersections(a, b)
+    if err != nil {
+        return nil, err
+    }
+    points := make([]CurveIntersection, len(params))
+    for i, st := range params {
+        x, y := curvePoint(a, st[0])
+        points[i] = CurveIntersection{st[0], st[1], x, y}
+    }
+    return points, nil
+}
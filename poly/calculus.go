@@ -0,0 +1,46 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// Derivative returns the formal derivative of p
+func (p *Polynomial) Derivative() *Polynomial {
+    n := p.Deg()
+    if n == 0 {
+        return Zero()
+    }
+    coeffs := make([]*big.Rat, n)
+    for i := 1; i <= n; i++ {
+        coeffs[i-1] = new(big.Rat).Mul(p.coeff[i], big.NewRat(int64(i), 1))
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// Integral returns the antiderivative of p with constant term c, or 0 when
+// c is nil
+func (p *Polynomial) Integral(c *big.Rat) *Polynomial {
+    n := p.Deg()
+    coeffs := make([]*big.Rat, n+2)
+    coeffs[0] = new(big.Rat)
+    if c != nil {
+        coeffs[0].Set(c)
+    }
+    for i := 0; i <= n; i++ {
+        coeffs[i+1] = new(big.Rat)
+        if i < len(p.coeff) {
+            coeffs[i+1].Quo(p.coeff[i], big.NewRat(int64(i+1), 1))
+        }
+    }
+    return NewPolyNoCopy(coeffs).trim()
+}
+
+// Compose returns p(q), by Horner's scheme with q in place of x: deg p
+// multiplications by q, the last of which has degree deg p * deg q
+func (p *Polynomial) Compose(q *Polynomial) *Polynomial {
+    result := Zero()
+    for i := p.Deg(); i >= 0 && i < len(p.coeff); i-- {
+        result = result.mul(q).Add(Constant(p.coeff[i]))
+    }
+    return result
+}
@@ -0,0 +1,225 @@
+package poly
+
+import (
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "io"
+    "math/big"
+    "strings"
+)
+
+// gcdCertificate is everything needed to check the result of the extended
+// Euclidean algorithm on f and g without trusting this program: the division
+// steps with their quotients, the Bézout cofactors s and t with
+// s*f + t*g = gcd, and the cofactors of the gcd with f = FOverGCD*gcd and
+// g = GOverGCD*gcd. The identities together show that gcd is a greatest
+// common divisor. Polynomials are coefficient lists, highest degree first;
+// FDigest and GDigest are the SHA-256 hashes of the canonical encodings (of
+// version Encoding) of f and g, to match a certificate to cached inputs.
+type gcdCertificate struct {
+    Encoding int               `json:"encoding"`
+    F        string            `json:"f"`
+    G        string            `json:"g"`
+    FDigest  string            `json:"f_sha256"`
+    GDigest  string            `json:"g_sha256"`
+    Steps    []certificateStep `json:"steps"`
+    GCD      string            `json:"gcd"`
+    S        string            `json:"s"`
+    T        string            `json:"t"`
+    FOverGCD string            `json:"f_over_gcd"`
+    GOverGCD string            `json:"g_over_gcd"`
+}
+
+// certificateStep is one division, Dividend = Quotient*Divisor + Remainder
+type certificateStep struct {
+    Dividend  string `json:"dividend"`
+    Divisor   string `json:"divisor"`
+    Quotient  string `json:"quotient"`
+    Remainder string `json:"remainder"`
+}
+
+// certificateIdentity states that the sum of the products lhs[k] equals the
+// product rhs
+type certificateIdentity struct {
+    name string
+    lhs  [][]*Polynomial
+    rhs  []*Polynomial
+}
+
+// certificateIdentities returns the identities that certify the extended
+// Euclidean algorithm on f and g, which must not both be zero
+func certificateIdentities(f, g *Polynomial) []certificateIdentity {
+    res := extendedGCDResult(f, g)
+    var ids []certificateIdentity
+    for i, st := range res.Steps {
+        ids = append(ids, certificateIdentity{fmt.Sprintf("step%d", i+1),
+            [][]*Polynomial{{st.Quotient, st.Divisor}, {st.Remainder}}, []*Polynomial{st.Dividend}})
+    }
+    ids = append(ids, certificateIdentity{"bezout",
+        [][]*Polynomial{{res.S, f}, {res.T, g}}, []*Polynomial{res.GCD}})
+    a, _ := f.div(res.GCD)
+    b, _ := g.div(res.GCD)
+    ids = append(ids,
+        certificateIdentity{"gcd_dvd_f", [][]*Polynomial{{a, res.GCD}}, []*Polynomial{f}},
+        certificateIdentity{"gcd_dvd_g", [][]*Polynomial{{b, res.GCD}}, []*Polynomial{g}})
+    return ids
+}
+
+// newGCDCertificate computes the certificate for f and g
+func newGCDCertificate(f, g *Polynomial) *gcdCertificate {
+    res := extendedGCDResult(f, g)
+    fd, gd := f.Digest(), g.Digest()
+    c := &gcdCertificate{
+        Encoding: EncodingVersion,
+        F: coefficientList(f), G: coefficientList(g),
+        FDigest: hex.EncodeToString(fd[:]), GDigest: hex.EncodeToString(gd[:]),
+        GCD: coefficientList(res.GCD), S: coefficientList(res.S), T: coefficientList(res.T),
+    }
+    for _, st := range res.Steps {
+        c.Steps = append(c.Steps, certificateStep{coefficientList(st.Dividend), coefficientList(st.Divisor),
+            coefficientList(st.Quotient), coefficientList(st.Remainder)})
+    }
+    a, _ := f.div(res.GCD)
+    b, _ := g.div(res.GCD)
+    c.FOverGCD, c.GOverGCD = coefficientList(a), coefficientList(b)
+    return c
+}
+
+// WriteCertificateJSON writes the certificate of f and g as indented JSON
+func WriteCertificateJSON(w io.Writer, f, g *Polynomial) error {
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(newGCDCertificate(f, g))
+}
+
+// denominatorLCM returns the least common multiple of the coefficient
+// denominators of p
+func denominatorLCM(p *Polynomial) *big.Int {
+    l := big.NewInt(1)
+    for i := 0; i <= p.Deg() && i < len(p.coeff); i++ {
+        den := p.coeff[i].Denom()
+        g := new(big.Int).GCD(nil, nil, l, den)
+        l.Mul(l, new(big.Int).Quo(den, g))
+    }
+    return l
+}
+
+// integerTerm formats a product of polynomials with rational coefficients as
+// a multiple of integer polynomials in variable x, clearing each factor's
+// denominators. It returns the text and the product of the denominators
+// cleared, by which the term has been multiplied.
+func integerTerm(factors []*Polynomial, x string) ([]string, *big.Int) {
+    den := big.NewInt(1)
+    var parts []string
+    for _, p := range factors {
+        d := denominatorLCM(p)
+        den.Mul(den, d)
+        parts = append(parts, "("+integerPolyString(p, d, x)+")")
+    }
+    return parts, den
+}
+
+// integerPolyString formats the integer polynomial scale*p in x, highest
+// degree first, with the operators Lean and Coq share
+func integerPolyString(p *Polynomial, scale *big.Int, x string) string {
+    var b strings.Builder
+    for i := p.Deg(); i >= 0; i-- {
+        if i >= len(p.coeff) || p.coeff[i].Sign() == 0 {
+            continue
+        }
+        c := new(big.Int).Mul(p.coeff[i].Num(), new(big.Int).Quo(scale, p.coeff[i].Denom()))
+        switch {
+        case b.Len() == 0 && c.Sign() < 0:
+            b.WriteString("-")
+        case b.Len() > 0 && c.Sign() < 0:
+            b.WriteString(" - ")
+        case b.Len() > 0:
+            b.WriteString(" + ")
+        }
+        c.Abs(c)
+        switch {
+        case i == 0:
+            b.WriteString(c.String())
+        case c.Cmp(big.NewInt(1)) != 0:
+            b.WriteString(c.String() + " * ")
+            fallthrough
+        default:
+            b.WriteString(x)
+            if i > 1 {
+                b.WriteString(fmt.Sprintf(" ^ %d", i))
+            }
+        }
+    }
+    if b.Len() == 0 {
+        return "0"
+    }
+    return b.String()
+}
+
+// integerIdentity returns both sides of id with all denominators cleared:
+// every term is multiplied by the least common multiple of the denominators
+// of all terms, which is written out as an integer factor where needed
+func integerIdentity(id certificateIdentity, x string) (string, string) {
+    var terms [][]string
+    var dens []*big.Int
+    for _, product := range append(id.lhs, id.rhs) {
+        t, d := integerTerm(product, x)
+        terms, dens = append(terms, t), append(dens, d)
+    }
+    l := big.NewInt(1)
+    for _, d := range dens {
+        g := new(big.Int).GCD(nil, nil, l, d)
+        l.Mul(l, new(big.Int).Quo(d, g))
+    }
+    sides := make([]string, len(terms))
+    for k, t := range terms {
+        if factor := new(big.Int).Quo(l, dens[k]); factor.Cmp(big.NewInt(1)) != 0 {
+            t = append([]string{factor.String()}, t...)
+        }
+        sides[k] = strings.Join(t, " * ")
+    }
+    return strings.Join(sides[:len(sides)-1], " + "), sides[len(sides)-1]
+}
+
+// writeLean writes the certificate of f and g as Lean 4 theorems over ℤ[X],
+// each closed by Mathlib's ring tactic. Dividing the identities by their
+// nonzero integer factors gives them over ℚ[X].
+func writeLean(w io.Writer, f, g *Polynomial) error {
+    var b strings.Builder
+    b.WriteString("import Mathlib\n\nopen Polynomial\n\n")
+    b.WriteString(fmt.Sprintf("-- f = %s\n-- g = %s\n", layoutPolyString(f), layoutPolyString(g)))
+    b.WriteString("-- the division steps, the Bézout identity s*f + t*g = gcd and gcd | f, gcd | g,\n")
+    b.WriteString("-- each multiplied through by an integer to clear denominators\n")
+    for _, id := range certificateIdentities(f, g) {
+        lhs, rhs := integerIdentity(id, "X")
+        b.WriteString(fmt.Sprintf("\ntheorem euclid_%s : (%s : ℤ[X]) = %s := by\n  ring\n", id.name, lhs, rhs))
+    }
+    _, err := io.WriteString(w, b.String())
+    return err
+}
+
+// writeCoq writes the certificate of f and g as Coq lemmas over Z, for every
+// integer x, each closed by the ring tactic. Integer polynomials that agree at
+// every integer are equal, so the lemmas are the polynomial identities.
+func writeCoq(w io.Writer, f, g *Polynomial) error {
+    var b strings.Builder
+    b.WriteString("Require Import ZArith.\nOpen Scope Z_scope.\n\n")
+    b.WriteString(fmt.Sprintf("(* f = %s *)\n(* g = %s *)\n", layoutPolyString(f), layoutPolyString(g)))
+    b.WriteString("(* the division steps, the Bézout identity s*f + t*g = gcd and gcd | f, gcd | g,\n")
+    b.WriteString("   each multiplied through by an integer to clear denominators *)\n")
+    for _, id := range certificateIdentities(f, g) {
+        lhs, rhs := integerIdentity(id, "x")
+        b.WriteString(fmt.Sprintf("\nLemma euclid_%s : forall x : Z, %s = %s.\nProof. intros x. ring. Qed.\n", id.name, lhs, rhs))
+    }
+    _, err := io.WriteString(w, b.String())
+    return err
+}
+
+// CertificateFormats maps the format names of the certificate command to
+// writers
+var CertificateFormats = map[string]func(io.Writer, *Polynomial, *Polynomial) error{
+    "json": WriteCertificateJSON,
+    "lean": writeLean,
+    "coq":  writeCoq,
+}
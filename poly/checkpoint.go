@@ -0,0 +1,175 @@
+package poly
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// gcdCheckpointVersion is the format version of checkpoint files; files of
+// another version are rejected instead of being misread. Version 2 identifies
+// the inputs by the hashes of their canonical encodings.
+const gcdCheckpointVersion = 2
+
+// gcdCheckpoint is the state of an extended Euclidean computation between two
+// division steps: the current remainders F and G and the cofactors of both,
+// with S0*f + T0*g = F and S1*f + T1*g = G for the original inputs f and g.
+// Polynomials are stored as coefficient lists, which are exact and unlimited
+// in size.
+type gcdCheckpoint struct {
+    Version int `json:"version"`
+    // Input identifies the original inputs, so a checkpoint is never
+    // resumed for a different job
+    Input      string `json:"input"`
+    Iterations int    `json:"iterations"`
+    F          string `json:"f"`
+    G          string `json:"g"`
+    S0         string `json:"s0"`
+    S1         string `json:"s1"`
+    T0         string `json:"t0"`
+    T1         string `json:"t1"`
+}
+
+// checkpointInput returns the identifier of the job on f and g
+func checkpointInput(f, g *Polynomial) string {
+    return polyHash(f) + "/" + polyHash(g)
+}
+
+// saveCheckpoint writes c to path atomically: a crash while writing leaves
+// the previous checkpoint in place
+func saveCheckpoint(path string, c *gcdCheckpoint) error {
+    tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+    if err != nil {
+        return err
+    }
+    if err := json.NewEncoder(tmp).Encode(c); err != nil {
+        tmp.Close()
+        os.Remove(tmp.Name())
+        return err
+    }
+    if err := tmp.Close(); err != nil {
+        os.Remove(tmp.Name())
+        return err
+    }
+    return os.Rename(tmp.Name(), path)
+}
+
+// loadCheckpoint reads the checkpoint at path. It returns nil and no error
+// when there is no checkpoint.
+func loadCheckpoint(path string) (*gcdCheckpoint, error) {
+    data, err := os.ReadFile(path)
+    if os.IsNotExist(err) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    var c gcdCheckpoint
+    if err := json.Unmarshal(data, &c); err != nil {
+        return nil, fmt.Errorf("checkpoint %s: %v", path, err)
+    }
+    if c.Version != gcdCheckpointVersion {
+        return nil, fmt.Errorf("checkpoint %s: version %d, expected %d", path, c.Version, gcdCheckpointVersion)
+    }
+    return &c, nil
+}
+
+// polys parses the polynomials of the checkpoint
+func (c *gcdCheckpoint) polys() ([6]*Polynomial, error) {
+    var ps [6]*Polynomial
+    for i, s := range []string{c.F, c.G, c.S0, c.S1, c.T0, c.T1} {
+        p, err := ParseCoefficientsWithLimits(s, ParseLimits{})
+        if err != nil {
+            return ps, fmt.Errorf("checkpoint: %v", err)
+        }
+        ps[i] = p
+    }
+    return ps, nil
+}
+
+// ExtendedGCDResumable runs the extended Euclidean algorithm on f and g
+// and saves its state to path at least every interval, so that a long job can
+// be resumed after an interruption. If path holds a checkpoint of the same
+// inputs, the computation continues from there. When ctx is cancelled the
+// state is saved once more and ctx.Err() returned; on success the checkpoint
+// is removed. The result counts all iterations, but Steps and Timing cover
+// only the run since the last resumption.
+func ExtendedGCDResumable(ctx context.Context, f, g *Polynomial, path string, interval time.Duration) (res *GCDResult, resumed bool, err error) {
+    start := time.Now()
+    input := checkpointInput(f, g)
+    res = &GCDResult{MaxIterations: maxEuclideanIterations(f, g)}
+
+    f, g = NewPolynomial(f.coeff), NewPolynomial(g.coeff)
+    s0, s1, t0, t1 := One(), Zero(), Zero(), One()
+
+    c, err := loadCheckpoint(path)
+    if err != nil {
+        return nil, false, err
+    }
+    if c != nil {
+        if c.Input != input {
+            return nil, false, fmt.Errorf("checkpoint %s belongs to other inputs", path)
+        }
+        ps, err := c.polys()
+        if err != nil {
+            return nil, false, err
+        }
+        f, g, s0, s1, t0, t1 = ps[0], ps[1], ps[2], ps[3], ps[4], ps[5]
+        res.Iterations = c.Iterations
+        resumed = true
+    }
+
+    save := func() error {
+        return saveCheckpoint(path, &gcdCheckpoint{
+            Version: gcdCheckpointVersion, Input: input, Iterations: res.Iterations,
+            F: coefficientList(f), G: coefficientList(g),
+            S0: coefficientList(s0), S1: coefficientList(s1),
+            T0: coefficientList(t0), T1: coefficientList(t1),
+        })
+    }
+    lastSave := time.Now()
+    for !g.IsZero() {
+        select {
+        case <-ctx.Done():
+            if err := save(); err != nil {
+                return nil, resumed, err
+            }
+            return nil, resumed, ctx.Err()
+        default:
+        }
+
+        phase := time.Now()
+        q, r := f.div(g)
+        res.Timing.Divisions += time.Since(phase)
+
+        step := EuclidStep{Dividend: f, Divisor: g, Quotient: q, Remainder: r}
+        f, g = g, r
+
+        phase = time.Now()
+        s0, s1 = s1, s0.Sub(q.mul(s1)).trim()
+        t0, t1 = t1, t0.Sub(q.mul(t1)).trim()
+        res.Timing.Updates += time.Since(phase)
+
+        step.S, step.T = s1, t1
+        res.Steps = append(res.Steps, step)
+        res.Iterations++
+
+        if time.Since(lastSave) >= interval {
+            if err := save(); err != nil {
+                return nil, resumed, err
+            }
+            lastSave = time.Now()
+        }
+    }
+
+    res.GCD, res.S, res.T = f, s0, t0
+    res.WorstCase = res.Iterations == res.MaxIterations
+    res.Timing.Total = time.Since(start)
+    if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+        return res, resumed, err
+    }
+    return res, resumed, nil
+}
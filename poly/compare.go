@@ -0,0 +1,327 @@
+package poly
+
+import (
+    "fmt"
+    "io"
+    "math"
+    "math/big"
+    "math/rand"
+    "sort"
+    "strings"
+    "sync"
+
+    "euclid/internal/benchtime"
+)
+
+// Competitor is another polynomial implementation CompareBench runs on the
+// same inputs as this package. Polynomials cross the interface as exact
+// coefficient lists, lowest degree first, so an adapter only converts to
+// and from its library's own type, and the conversion is left out of the
+// timing by Prepare. An adapter for a third-party package belongs in a file
+// with its own build tag, so that the package does not depend on it unless
+// the comparison is built, e.g. in compare_mylib.go:
+//
+//     //go:build compare_mylib
+//
+//     type mylibCompetitor struct{}
+//
+//     func (mylibCompetitor) Name() string { return "mylib" }
+//     func (mylibCompetitor) Prepare(f, g []*big.Rat) (gcd, mul func() []*big.Rat, err error) {
+//         a, b := mylib.FromRats(f), mylib.FromRats(g)
+//         gcd = func() []*big.Rat { return mylib.GCD(a, b).Monic().Rats() }
+//         mul = func() []*big.Rat { return mylib.Mul(a, b).Rats() }
+//         return gcd, mul, nil
+//     }
+//
+//     func init() { RegisterCompetitor(mylibCompetitor{}) }
+//
+// and the comparison is run with go run -tags compare_mylib . comparebench.
+// The gmp backend (GMP with -tags gmp) and plain float64 coefficients are
+// registered here, and gonum's FFT product in compare_gonum.go with
+// -tags compare_gonum.
+type Competitor interface {
+    Name() string
+    // Prepare converts f and g, which it must not modify, and returns functions computing their monic
+    // GCD and their product, which CompareBench checks and times; gcd or
+    // mul is nil when the implementation lacks the operation
+    Prepare(f, g []*big.Rat) (gcd, mul func() []*big.Rat, err error)
+}
+
+var (
+    competitorsMu sync.RWMutex
+    competitors   = map[string]Competitor{}
+)
+
+// RegisterCompetitor adds c to the implementations CompareBench compares.
+// Registering the same name twice panics, as with RegisterBackend.
+func RegisterCompetitor(c Competitor) {
+    competitorsMu.Lock()
+    defer competitorsMu.Unlock()
+    if _, dup := competitors[c.Name()]; dup {
+        panic(fmt.Sprintf("competitor %q registered twice", c.Name()))
+    }
+    competitors[c.Name()] = c
+}
+
+// competitorList returns the registered competitors sorted by name
+func competitorList() []Competitor {
+    competitorsMu.RLock()
+    defer competitorsMu.RUnlock()
+    list := make([]Competitor, 0, len(competitors))
+    for _, c := range competitors {
+        list = append(list, c)
+    }
+    sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+    return list
+}
+
+func init() {
+    RegisterCompetitor(backendCompetitor{"gmp"})
+    RegisterCompetitor(float64Competitor{})
+}
+
+// backendCompetitor runs the extended Euclidean algorithm and schoolbook
+// multiplication on a registered backend
+type backendCompetitor struct {
+    spec string
+}
+
+func (c backendCompetitor) Name() string {
+    b, err := NewBackend(c.spec)
+    if err != nil {
+        return c.spec
+    }
+    return b.Name()
+}
+
+func (c backendCompetitor) Prepare(f, g []*big.Rat) (gcd, mul func() []*big.Rat, err error) {
+    b, err := NewBackend(c.spec)
+    if err != nil {
+        return nil, nil, err
+    }
+    bf, err := toBackend(b, NewPolyNoCopy(f))
+    if err != nil {
+        return nil, nil, err
+    }
+    bg, err := toBackend(b, NewPolyNoCopy(g))
+    if err != nil {
+        return nil, nil, err
+    }
+    rats := func(p *backendPoly) []*big.Rat {
+        out := make([]*big.Rat, len(p.coeff))
+        for i, a := range p.coeff {
+            out[i], _ = new(big.Rat).SetString(b.String(a))
+        }
+        return out
+    }
+    gcd = func() []*big.Rat {
+        d, _, _ := extendedEuclideanBackendPoly(bf, bg, nil)
+        return rats(d.monic())
+    }
+    mul = func() []*big.Rat { return rats(bf.mul(bg)) }
+    return gcd, mul, nil
+}
+
+// float64Competitor stores coefficients as float64, as numeric Go code
+// usually does, which is fast but loses the GCD once cancellation in the
+// remainder sequence eats the 53 bits of precision
+type float64Competitor struct{}
+
+// float64Tolerance is the relative size below which float64Competitor
+// rounds a coefficient to zero
+const float64Tolerance = 1e-9
+
+func (float64Competitor) Name() string { return "float64" }
+
+func (float64Competitor) Prepare(f, g []*big.Rat) (gcd, mul func() []*big.Rat, err error) {
+    floats := func(p []*big.Rat) []float64 {
+        out := make([]float64, len(p))
+        for i, c := range p {
+            out[i], _ = c.Float64()
+        }
+        return out
+    }
+    ff, fg := floats(f), floats(g)
+    // rats drops zero leading coefficients, which products can have after
+    // underflow
+    rats := func(p []float64) []*big.Rat {
+        n := len(p)
+        for n > 0 && p[n-1] == 0 {
+            n--
+        }
+        out := make([]*big.Rat, n)
+        for i, c := range p[:n] {
+            out[i] = new(big.Rat).SetFloat64(c)
+        }
+        return out
+    }
+    gcd = func() []*big.Rat { return rats(gcdFloat64(ff, fg)) }
+    mul = func() []*big.Rat {
+        if len(ff) == 0 || len(fg) == 0 {
+            return nil
+        }
+        out := make([]float64, len(ff)+len(fg)-1)
+        for i, a := range ff {
+            for j, b := range fg {
+                out[i+j] += a * b
+            }
+        }
+        return rats(out)
+    }
+    return gcd, mul, nil
+}
+
+// gcdFloat64 is the monic Euclidean GCD in float64. A remainder coefficient
+// counts as zero when it is below float64Tolerance times the largest
+// coefficient of the dividend, as everything left of an exact zero
+// remainder is rounding error.
+func gcdFloat64(f, g []float64) []float64 {
+    trim := func(p []float64, scale float64) []float64 {
+        n := len(p)
+        for n > 0 && math.Abs(p[n-1]) <= float64Tolerance*scale {
+            n--
+        }
+        return p[:n]
+    }
+    norm := func(p []float64) float64 {
+        scale := 0.0
+        for _, c := range p {
+            scale = math.Max(scale, math.Abs(c))
+        }
+        return scale
+    }
+    a := trim(append([]float64(nil), f...), 0)
+    b := trim(append([]float64(nil), g...), 0)
+    for len(b) > 0 {
+        r := append([]float64(nil), a...)
+        for k := len(r) - len(b); k >= 0; k-- {
+            c := r[k+len(b)-1] / b[len(b)-1]
+            for j, bc := range b {
+                r[k+j] -= c * bc
+            }
+        }
+        if len(r) >= len(b) {
+            r = r[:len(b)-1]
+        }
+        a, b = b, trim(r, norm(a))
+    }
+    if len(a) == 0 {
+        return a
+    }
+    lead := a[len(a)-1]
+    for i := range a {
+        a[i] /= lead
+    }
+    return a
+}
+
+// compareBenchDegrees are the input degrees CompareBench times
+var compareBenchDegrees = []int{8, 16, 32, 48}
+
+// comparePair returns f = a*c and g = b*c for random a, b and c with c of
+// a quarter of the degree, so that the GCD is nontrivial
+func comparePair(rng *rand.Rand, degree int) (*Polynomial, *Polynomial) {
+    c := generateRandomPolynomialOfDegree(rng, max(degree/4, 1))
+    a := generateRandomPolynomialOfDegree(rng, degree-c.Deg())
+    b := generateRandomPolynomialOfDegree(rng, degree-c.Deg())
+    return a.mul(c), b.mul(c)
+}
+
+// agreement compares the result got of a competitor with want: "yes" when
+// they are equal, "approx" when they have the same degree and differ by at
+// most float64Tolerance relative to the largest coefficient of want, as
+// rounded results of a correct algorithm do, and "**no**" otherwise
+func agreement(got, want []*big.Rat) string {
+    p, q := NewPolyNoCopy(got), NewPolyNoCopy(want)
+    if p.Equal(q) {
+        return "yes"
+    }
+    if p.IsZero() || q.IsZero() || p.Deg() != q.Deg() {
+        return "**no**"
+    }
+    scale, diff := 0.0, 0.0
+    for i := 0; i <= q.Deg(); i++ {
+        w, _ := q.coeff[i].Float64()
+        d, _ := new(big.Rat).Sub(p.coeff[i], q.coeff[i]).Float64()
+        scale, diff = math.Max(scale, math.Abs(w)), math.Max(diff, math.Abs(d))
+    }
+    if diff <= float64Tolerance*scale {
+        return "approx"
+    }
+    return "**no**"
+}
+
+// CompareBench runs this package and every registered Competitor on the
+// same random pairs with a common factor, checks their monic GCDs and
+// products against this package's and writes a Markdown report to w: ns/op
+// per operation, degree and implementation, the time relative to this
+// package (above 1 means slower) and whether the result agrees, exactly or
+// up to rounding. The resultant rows time ResultantSylvester against
+// Resultant on random coprime pairs. opts apply
+// to this package's GCD and product as to ExtendedGCDResult and Mul.
+func CompareBench(w io.Writer, opts ...Option) error {
+    if err := newConfig(opts...).checkGCD("Q"); err != nil {
+        return err
+    }
+    var b strings.Builder
+    b.WriteString("# Polynomial implementations compared\n\n")
+    b.WriteString("Inputs: f = a·c and g = b·c with random a, b, c of coefficients in [-5, 5] and deg c = deg f / 4.\n")
+    b.WriteString("GCDs are compared monic; time is relative to poly, above 1 meaning slower;\n")
+    b.WriteString(fmt.Sprintf("approx means equal up to a relative error of %g.\n", float64Tolerance))
+    list := competitorList()
+    names := []string{"poly"}
+    for _, c := range list {
+        names = append(names, c.Name())
+    }
+    b.WriteString(fmt.Sprintf("Implementations: %s.\n", strings.Join(names, ", ")))
+    b.WriteString("Third-party libraries take part through Competitor adapters compiled in with their own\n")
+    b.WriteString("build tags, such as gonum-fft with -tags compare_gonum.\n\n")
+    b.WriteString("| operation | degree | implementation | ns/op | relative | agrees |\n")
+    b.WriteString("|---|---:|---|---:|---:|---|\n")
+    for _, op := range []string{"gcd", "mul"} {
+        for _, n := range compareBenchDegrees {
+            f, g := comparePair(rand.New(rand.NewSource(int64(n))), n)
+            own := func() []*big.Rat { return extendedGCDResult(f, g, opts...).GCD.monic().coeff }
+            if op == "mul" {
+                own = func() []*big.Rat { return f.Mul(g, opts...).coeff }
+            }
+            want := own()
+            ownNs := benchtime.Ns(func() { own() })
+            b.WriteString(fmt.Sprintf("| %s | %d | poly | %.0f | 1 | yes |\n", op, n, ownNs))
+            for _, c := range list {
+                gcd, mul, err := c.Prepare(f.coeff[:f.Deg()+1], g.coeff[:g.Deg()+1])
+                if err != nil {
+                    return fmt.Errorf("%s: %w", c.Name(), err)
+                }
+                run := gcd
+                if op == "mul" {
+                    run = mul
+                }
+                if run == nil {
+                    b.WriteString(fmt.Sprintf("| %s | %d | %s | – | – | n/a |\n", op, n, c.Name()))
+                    continue
+                }
+                agrees := agreement(run(), want)
+                ns := benchtime.Ns(func() { run() })
+                b.WriteString(fmt.Sprintf("| %s | %d | %s | %.0f | %.3g | %s |\n", op, n, c.Name(), ns, ns/ownNs, agrees))
+            }
+        }
+    }
+    // the resultant has no competitors yet; the determinant of the Sylvester
+    // matrix is timed against the remainder sequence on coprime pairs
+    for _, n := range compareBenchDegrees {
+        rng := rand.New(rand.NewSource(int64(n)))
+        f, g := generateRandomPolynomialOfDegree(rng, n), generateRandomPolynomialOfDegree(rng, n)
+        want := Resultant(f, g)
+        ownNs := benchtime.Ns(func() { Resultant(f, g) })
+        b.WriteString(fmt.Sprintf("| resultant | %d | poly | %.0f | 1 | yes |\n", n, ownNs))
+        agrees := "yes"
+        if ResultantSylvester(f, g).Cmp(want) != 0 {
+            agrees = "**no**"
+        }
+        ns := benchtime.Ns(func() { ResultantSylvester(f, g) })
+        b.WriteString(fmt.Sprintf("| resultant | %d | sylvester-bareiss | %.0f | %.3g | %s |\n", n, ns, ns/ownNs, agrees))
+    }
+    _, err := io.WriteString(w, b.String())
+    return err
+}
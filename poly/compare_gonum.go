@@ -0,0 +1,54 @@
+//go:build compare_gonum
+
+package poly
+
+import (
+    "math/big"
+
+    "gonum.org/v1/gonum/dsp/fourier"
+)
+
+// gonumCompetitor multiplies by convolution with gonum's real FFT in
+// float64, the way numeric Go code multiplies coefficient vectors. gonum has
+// no polynomial GCD, so only the product is compared. It is built in with
+// go run -tags compare_gonum . comparebench.
+type gonumCompetitor struct{}
+
+func init() {
+    RegisterCompetitor(gonumCompetitor{})
+}
+
+func (gonumCompetitor) Name() string { return "gonum-fft" }
+
+func (gonumCompetitor) Prepare(f, g []*big.Rat) (gcd, mul func() []*big.Rat, err error) {
+    if len(f) == 0 || len(g) == 0 {
+        return nil, func() []*big.Rat { return nil }, nil
+    }
+    // the product has len(f) + len(g) - 1 coefficients, so a transform of
+    // that length computes the linear convolution without wrapping around
+    n := len(f) + len(g) - 1
+    padded := func(p []*big.Rat) []float64 {
+        out := make([]float64, n)
+        for i, c := range p {
+            out[i], _ = c.Float64()
+        }
+        return out
+    }
+    ff, fg := padded(f), padded(g)
+    fft := fourier.NewFFT(n)
+    mul = func() []*big.Rat {
+        cf := fft.Coefficients(nil, ff)
+        cg := fft.Coefficients(nil, fg)
+        for i := range cf {
+            cf[i] *= cg[i]
+        }
+        // the transforms are unnormalized: the round trip scales by n
+        seq := fft.Sequence(nil, cf)
+        out := make([]*big.Rat, n)
+        for i, c := range seq {
+            out[i] = new(big.Rat).SetFloat64(c / float64(n))
+        }
+        return out
+    }
+    return nil, mul, nil
+}
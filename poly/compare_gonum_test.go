@@ -0,0 +1,24 @@
+//go:build compare_gonum
+
+package poly
+
+import (
+    "math/rand"
+    "testing"
+)
+
+func TestGonumCompetitor(t *testing.T) {
+    for _, n := range compareBenchDegrees {
+        f, g := comparePair(rand.New(rand.NewSource(int64(n))), n)
+        gcd, mul, err := gonumCompetitor{}.Prepare(f.coeff[:f.Deg()+1], g.coeff[:g.Deg()+1])
+        if err != nil {
+            t.Fatal(err)
+        }
+        if gcd != nil {
+            t.Errorf("degree %d: gonum-fft has a GCD, want none", n)
+        }
+        if got := agreement(mul(), f.Mul(g).coeff); got == "**no**" {
+            t.Errorf("degree %d: gonum-fft product disagrees with Mul", n)
+        }
+    }
+}
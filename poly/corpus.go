@@ -0,0 +1,132 @@
+package poly
+
+import (
+    "math/big"
+    "math/rand"
+    "sort"
+)
+
+// CorpusFamily generates a pair of GCD inputs of (at most) the given degree;
+// random families draw from rng
+type CorpusFamily func(rng *rand.Rand, degree int) (*Polynomial, *Polynomial)
+
+// GCDCorpus is the named corpus of input families used by the bench command.
+// Besides uniform random inputs it holds structured worst cases, so algorithm
+// comparisons are not limited to the easy average case.
+var GCDCorpus = map[string]CorpusFamily{
+    "random":      randomPair,
+    "mignotte":    mignottePair,
+    "near-common": nearCommonFactorPair,
+    "fibonacci":   fibonacciPair,
+}
+
+// mignotteA is the parameter a of the Mignotte-like polynomials x^n - 2(ax - 1)^2
+const mignotteA = 10
+
+// CorpusNames returns the corpus family names in sorted order
+func CorpusNames() []string {
+    names := make([]string, 0, len(GCDCorpus))
+    for name := range GCDCorpus {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// generateRandomPolynomialOfDegree is like RandomPolynomial but never
+// returns a zero leading coefficient, so the degree is exactly the one requested
+func generateRandomPolynomialOfDegree(rng *rand.Rand, degree int) *Polynomial {
+    p := RandomPolynomial(rng, degree)
+    for p.coeff[degree].Sign() == 0 {
+        p.coeff[degree] = big.NewRat(int64(rng.Intn(11)-5), 1)
+    }
+    return p
+}
+
+// randomPair returns two uniformly random polynomials, the classic benchmark input
+func randomPair(rng *rand.Rand, degree int) (*Polynomial, *Polynomial) {
+    return RandomPolynomial(rng, degree), RandomPolynomial(rng, degree)
+}
+
+// mignottePair returns the Mignotte-like polynomial f = x^n - 2(ax - 1)^2 and
+// its derivative. f has two real roots very close to 1/a, so the remainder
+// sequence of f and f' suffers heavy coefficient growth.
+func mignottePair(_ *rand.Rand, degree int) (*Polynomial, *Polynomial) {
+    if degree < 3 {
+        degree = 3
+    }
+    a := int64(mignotteA)
+
+    f := make([]*big.Rat, degree+1)
+    for i := range f {
+        f[i] = new(big.Rat)
+    }
+    f[degree].SetInt64(1)
+    f[2].SetInt64(-2 * a * a)
+    f[1].SetInt64(4 * a)
+    f[0].SetInt64(-2)
+
+    g := make([]*big.Rat, degree)
+    for i := range g {
+        g[i] = new(big.Rat)
+    }
+    g[degree-1].SetInt64(int64(degree))
+    g[1].SetInt64(-4 * a * a)
+    g[0].SetInt64(4 * a)
+
+    return NewPolyNoCopy(f), NewPolyNoCopy(g)
+}
+
+// nearCommonFactorPair returns f = h*u + 1 and g = h*v for random h, u, v.
+// The inputs almost share the factor h, but f is coprime to h, and v is
+// drawn again until gcd(f, v) = 1, so that f and g are coprime and the
+// algorithm has to run the whole remainder sequence to find out.
+func nearCommonFactorPair(rng *rand.Rand, degree int) (*Polynomial, *Polynomial) {
+    if degree < 2 {
+        degree = 2
+    }
+    h := generateRandomPolynomialOfDegree(rng, degree / 2)
+    u := generateRandomPolynomialOfDegree(rng, degree - degree/2)
+    f := h.mul(u).Add(One())
+    for {
+        v := generateRandomPolynomialOfDegree(rng, degree - degree/2)
+        if extendedGCDResult(f, v).GCD.Deg() == 0 {
+            return f, h.mul(v)
+        }
+    }
+}
+
+// fibonacciPolynomial returns the n-th Fibonacci polynomial,
+// F_0 = 0, F_1 = 1, F_n = x*F_(n-1) + F_(n-2)
+func fibonacciPolynomial(n int) *Polynomial {
+    x := X()
+    prev := Zero()
+    cur := One()
+    if n == 0 {
+        return prev
+    }
+    for i := 1; i < n; i++ {
+        prev, cur = cur, x.mul(cur).Add(prev)
+    }
+    return cur
+}
+
+// fibonacciPair returns consecutive Fibonacci polynomials F_(n+1) and F_n.
+// Every quotient in their remainder sequence is x, so the degree drops by
+// exactly one per step: the polynomial analogue of the integer worst case.
+func fibonacciPair(_ *rand.Rand, degree int) (*Polynomial, *Polynomial) {
+    if degree < 1 {
+        degree = 1
+    }
+    return fibonacciPolynomial(degree + 1), fibonacciPolynomial(degree)
+}
+
+// fibonacciIntPair returns consecutive Fibonacci numbers F_(n+1) and F_n,
+// the classical worst case for the integer Euclidean algorithm
+func fibonacciIntPair(n int) (*big.Int, *big.Int) {
+    prev, cur := big.NewInt(0), big.NewInt(1)
+    for i := 0; i < n; i++ {
+        prev, cur = cur, new(big.Int).Add(prev, cur)
+    }
+    return cur, prev
+}
@@ -0,0 +1,205 @@
+package poly
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "math/rand"
+    "time"
+)
+
+// costModel predicts the running time of the extended Euclidean algorithm
+// from the input size as
+//
+//     t = exp(C[0]) * n^C[1] * b^C[2] nanoseconds,
+//
+// where n is the larger degree plus one and b the coefficient height in bits
+// plus one. On random inputs the remainder coefficients grow with the
+// degree, so C[1] comes out well above the quadratic operation count.
+type costModel struct {
+    C [3]float64
+}
+
+// defaultCostModels holds the models fitted by the costfit command, per
+// multiplication strategy. Refit them after changing the algorithm or the
+// multiplication code; the figures depend on the machine only through a
+// constant factor, which mostly shifts C[0].
+var defaultCostModels = map[string]costModel{
+    "naive":     {[3]float64{1.17, 4.88, 1.34}},
+    "kronecker": {[3]float64{1.88, 4.61, 1.23}},
+}
+
+// heightBits returns the largest bit length of a numerator or denominator
+// among the coefficients of p
+func (p *Polynomial) heightBits() int {
+    height := 0
+    for i := 0; i <= p.Deg() && i < len(p.coeff); i++ {
+        height = max(height, max(p.coeff[i].Num().BitLen(), p.coeff[i].Denom().BitLen()))
+    }
+    return height
+}
+
+// costFeatures returns the model inputs log n and log b for f and g
+func costFeatures(f, g *Polynomial) (float64, float64) {
+    n := max(f.Deg(), g.Deg()) + 1
+    b := max(f.heightBits(), g.heightBits()) + 1
+    return math.Log(float64(n)), math.Log(float64(b))
+}
+
+func (m costModel) predict(logN, logB float64) float64 {
+    return math.Exp(m.C[0] + m.C[1]*logN + m.C[2]*logB)
+}
+
+// EstimateCost predicts how long ExtendedGCDResult takes on f and g
+// with the multiplication strategy, one of mulStrategies. "auto" gives the
+// estimate of the cheaper strategy. The estimate is meant for admission and
+// scheduling decisions; expect it to be within a small factor of the actual
+// time, not exact. An unknown strategy is an error.
+func EstimateCost(f, g *Polynomial, strategy string) (time.Duration, error) {
+    logN, logB := costFeatures(f, g)
+    if strategy == "auto" {
+        _, ns := cheapestStrategy(logN, logB)
+        return time.Duration(ns), nil
+    }
+    m, ok := defaultCostModels[strategy]
+    if !ok {
+        return 0, fmt.Errorf("unknown multiplication strategy %q", strategy)
+    }
+    return time.Duration(m.predict(logN, logB)), nil
+}
+
+// cheapestStrategy returns the strategy with the smallest predicted time and
+// that time in nanoseconds
+func cheapestStrategy(logN, logB float64) (string, float64) {
+    best, bestNs := "", math.Inf(1)
+    for _, name := range mulStrategies {
+        if m, ok := defaultCostModels[name]; ok {
+            if ns := m.predict(logN, logB); ns < bestNs {
+                best, bestNs = name, ns
+            }
+        }
+    }
+    return best, bestNs
+}
+
+// costSample is one measurement for fitting a costModel
+type costSample struct {
+    logN, logB, logNs float64
+}
+
+// timeNs runs fn repeatedly for at least min and returns the mean time per
+// run in nanoseconds
+func timeNs(fn func(), min time.Duration) float64 {
+    runs := 0
+    start := time.Now()
+    for time.Since(start) < min {
+        fn()
+        runs++
+    }
+    return float64(time.Since(start).Nanoseconds()) / float64(runs)
+}
+
+// randomPolynomialBits returns a random polynomial of exactly the given
+// degree with integer coefficients of up to bits bits
+func randomPolynomialBits(rng *rand.Rand, degree, bits int) *Polynomial {
+    coeffs := make([]*big.Rat, degree+1)
+    limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+    for i := range coeffs {
+        c := new(big.Int).Rand(rng, limit)
+        if rng.Intn(2) == 0 {
+            c.Neg(c)
+        }
+        coeffs[i] = new(big.Rat).SetInt(c)
+    }
+    if coeffs[degree].Sign() == 0 {
+        coeffs[degree].SetInt64(1)
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// collectCostSamples times the extended Euclidean algorithm with the given
+// multiplication strategy on random inputs over a grid of degrees and
+// coefficient sizes
+func collectCostSamples(rng *rand.Rand, strategy string) []costSample {
+    var samples []costSample
+    for _, n := range []int{4, 6, 8, 12, 16, 20} {
+        for _, bits := range []int{4, 16, 64} {
+            f, g := randomPolynomialBits(rng, n, bits), randomPolynomialBits(rng, n-1, bits)
+            ns := timeNs(func() { extendedGCDResult(f, g, WithStrategy(strategy)) }, 20*time.Millisecond)
+            logN, logB := costFeatures(f, g)
+            samples = append(samples, costSample{logN, logB, math.Log(ns)})
+        }
+    }
+    return samples
+}
+
+// fitCostModel fits a costModel to samples by least squares on the
+// logarithms, solving the 3x3 normal equations
+func fitCostModel(samples []costSample) costModel {
+    var a [3][4]float64
+    for _, s := range samples {
+        x := [3]float64{1, s.logN, s.logB}
+        for i := 0; i < 3; i++ {
+            for j := 0; j < 3; j++ {
+                a[i][j] += x[i] * x[j]
+            }
+            a[i][3] += x[i] * s.logNs
+        }
+    }
+    // Gaussian elimination with partial pivoting
+    for col := 0; col < 3; col++ {
+        pivot := col
+        for r := col + 1; r < 3; r++ {
+            if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+                pivot = r
+            }
+        }
+        a[col], a[pivot] = a[pivot], a[col]
+        for r := 0; r < 3; r++ {
+            if r != col && a[col][col] != 0 {
+                factor := a[r][col] / a[col][col]
+                for c := col; c < 4; c++ {
+                    a[r][c] -= factor * a[col][c]
+                }
+            }
+        }
+    }
+    var m costModel
+    for i := 0; i < 3; i++ {
+        m.C[i] = a[i][3] / a[i][i]
+    }
+    return m
+}
+
+// CostFit is the cost model fitted for one multiplication strategy:
+// Fitted and BuiltIn hold the coefficients C of the fitted and the built-in
+// model, and WorstMiss is the largest factor by which the fitted model
+// misses one of the Samples measurements
+type CostFit struct {
+    Strategy        string
+    Fitted, BuiltIn [3]float64
+    WorstMiss       float64
+    Samples         int
+}
+
+// FitCostModels measures every strategy and fits a model to the
+// measurements, for comparison with the built-in one
+func FitCostModels(opts ...Option) []CostFit {
+    rng := newConfig(opts...).rand()
+    var fits []CostFit
+    for _, strategy := range mulStrategies {
+        builtIn, ok := defaultCostModels[strategy]
+        if !ok {
+            continue
+        }
+        samples := collectCostSamples(rng, strategy)
+        m := fitCostModel(samples)
+        worst := 1.0
+        for _, s := range samples {
+            ratio := math.Exp(math.Abs(m.C[0] + m.C[1]*s.logN + m.C[2]*s.logB - s.logNs))
+            worst = math.Max(worst, ratio)
+        }
+        fits = append(fits, CostFit{strategy, m.C, builtIn.C, worst, len(samples)})
+    }
+    return fits
+}
@@ -0,0 +1,48 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// CRT returns the unique polynomial x of degree below deg(m_1 ... m_k) with
+// x ≡ r_i (mod m_i) for the remainders r_i and pairwise coprime moduli m_i.
+// The moduli are merged one at a time: with x solving the first i
+// congruences modulo M = m_1 ... m_i, the extended Euclidean algorithm gives
+// s*M + t*m_(i+1) = c for a nonzero constant c, and
+// x + M*((r_(i+1) - x)*s/c mod m_(i+1)) also solves the next one. Moduli
+// with a common factor are reported as an error naming the first pair
+// found, since the congruences then have no solution or no unique one.
+func CRT(remainders, moduli []*Polynomial) (*Polynomial, error) {
+    if len(remainders) != len(moduli) {
+        return nil, fmt.Errorf("crt: %d remainders for %d moduli", len(remainders), len(moduli))
+    }
+    if len(moduli) == 0 {
+        return nil, fmt.Errorf("crt: no congruences")
+    }
+    for i := range moduli {
+        if remainders[i] == nil || moduli[i] == nil {
+            return nil, ErrNilPolynomial
+        }
+        if moduli[i].IsZero() {
+            return nil, fmt.Errorf("crt: modulus %d is zero", i+1)
+        }
+    }
+    _, x := remainders[0].div(moduli[0])
+    m := moduli[0]
+    for i := 1; i < len(moduli); i++ {
+        res := extendedGCDResult(m, moduli[i])
+        if res.GCD.Deg() > 0 {
+            for j := 0; j < i; j++ {
+                if gcd := extendedGCDResult(moduli[j], moduli[i]).GCD; gcd.Deg() > 0 {
+                    return nil, fmt.Errorf("crt: moduli %d and %d have the common factor %s", j+1, i+1, gcd.monic())
+                }
+            }
+        }
+        s := res.S.scale(new(big.Rat).Inv(res.GCD.coeff[0]))
+        _, k := remainders[i].Sub(x).mul(s).div(moduli[i])
+        x = x.Add(m.mul(k))
+        m = m.mul(moduli[i])
+    }
+    return x, nil
+}
@@ -0,0 +1,61 @@
+package poly
+
+import (
+    "errors"
+    "testing"
+)
+
+// TestCRT checks that the solution satisfies every congruence with a
+// degree below that of the product of the moduli, and that invalid systems
+// are errors
+func TestCRT(t *testing.T) {
+    parse := func(ss ...string) []*Polynomial {
+        ps := make([]*Polynomial, len(ss))
+        for i, s := range ss {
+            ps[i], _ = ParsePolynomial(s)
+        }
+        return ps
+    }
+    cases := []struct {
+        remainders, moduli []*Polynomial
+    }{
+        {parse("1", "x"), parse("x - 1", "x + 1")},
+        {parse("2x + 1", "3", "x"), parse("x^2 + 1", "x - 2", "x + 3")},
+        {parse("x^3", "1/2"), parse("x^2 - 2", "2x^2 + x + 1")},
+        {parse("5"), parse("x^3 - x + 1")},
+    }
+    for _, c := range cases {
+        x, err := CRT(c.remainders, c.moduli)
+        if err != nil {
+            t.Errorf("CRT(%v, %v): %v", c.remainders, c.moduli, err)
+            continue
+        }
+        deg := 0
+        for i, m := range c.moduli {
+            deg += m.Deg()
+            _, got, _ := x.Div(m)
+            _, want, _ := c.remainders[i].Div(m)
+            if !got.Equal(want) {
+                t.Errorf("CRT = %s is %s mod %s, want %s", x, got, m, want)
+            }
+        }
+        if x.Deg() >= deg {
+            t.Errorf("CRT = %s has degree %d, want below %d", x, x.Deg(), deg)
+        }
+    }
+    for _, c := range []struct {
+        remainders, moduli []*Polynomial
+    }{
+        {parse("1", "2"), parse("x^2 - 1", "x - 1")},
+        {parse("1"), parse("0")},
+        {parse("1", "2"), parse("x")},
+        {nil, nil},
+    } {
+        if _, err := CRT(c.remainders, c.moduli); err == nil {
+            t.Errorf("CRT(%v, %v): no error", c.remainders, c.moduli)
+        }
+    }
+    if _, err := CRT([]*Polynomial{nil}, parse("x")); !errors.Is(err, ErrNilPolynomial) {
+        t.Errorf("CRT with a nil remainder returned %v, want ErrNilPolynomial", err)
+    }
+}
@@ -0,0 +1,243 @@
+package poly
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "sort"
+    "time"
+)
+
+// differenceTable holds the forward differences of p at equally spaced
+// points x0 + k*h, scaled by a common denominator so that all of them are
+// integers: table[j] is scale * Δ^j p(x0 + k*h) for the current k. Since p has
+// degree d, Δ^d is constant and Δ^(d+1) vanishes, so stepping to the next
+// point takes d big.Int additions and no multiplication; the integers keep
+// the values exact however many steps are taken.
+type differenceTable struct {
+    table []*big.Int
+    scale *big.Int
+}
+
+// newDifferenceTable evaluates p at x0, x0 + h, ..., x0 + d*h with Horner's
+// scheme and builds the difference table at x0
+func newDifferenceTable(p *Polynomial, x0, h *big.Rat) *differenceTable {
+    d := max(p.Deg(), 0)
+    values := make([]*big.Rat, d+1)
+    scale := big.NewInt(1)
+    x := new(big.Rat).Set(x0)
+    for j := range values {
+        values[j] = p.Eval(x)
+        x.Add(x, h)
+        // every later value is an integer combination of these, so the lcm
+        // of their denominators clears all denominators for good
+        den := values[j].Denom()
+        g := new(big.Int).GCD(nil, nil, scale, den)
+        scale.Mul(scale, new(big.Int).Quo(den, g))
+    }
+    table := make([]*big.Int, d+1)
+    for j, v := range values {
+        table[j] = new(big.Int).Mul(v.Num(), new(big.Int).Quo(scale, v.Denom()))
+    }
+    // turn values into differences in place: after pass j, table[i] for i > j
+    // holds Δ^(j+1) at x0 + (i-j-1)*h
+    for j := 0; j < d; j++ {
+        for i := d; i > j; i-- {
+            table[i].Sub(table[i], table[i-1])
+        }
+    }
+    return &differenceTable{table, scale}
+}
+
+// step advances the table to the next point with d additions
+func (t *differenceTable) step() {
+    for j := 0; j < len(t.table)-1; j++ {
+        t.table[j].Add(t.table[j], t.table[j+1])
+    }
+}
+
+// value returns the polynomial's value at the current point exactly
+func (t *differenceTable) value() *big.Rat {
+    return new(big.Rat).SetFrac(t.table[0], t.scale)
+}
+
+// sampleEquallySpaced returns p(x0 + k*h) for k = 0, ..., n-1 exactly, by
+// forward differencing
+func (p *Polynomial) sampleEquallySpaced(x0, h *big.Rat, n int) []*big.Rat {
+    t := newDifferenceTable(p, x0, h)
+    values := make([]*big.Rat, n)
+    for k := range values {
+        values[k] = t.value()
+        t.step()
+    }
+    return values
+}
+
+// sampleFloat64 returns the points (x0 + k*h, p(x0 + k*h)), k = 0, ..., n-1,
+// as float64 for plotting. The values are computed exactly and rounded only
+// at the end, so unlike floating-point differencing there is no error
+// accumulation however many points are taken.
+func (p *Polynomial) sampleFloat64(x0, h *big.Rat, n int) []Point {
+    t := newDifferenceTable(p, x0, h)
+    points := make([]Point, n)
+    scale := new(big.Float).SetInt(t.scale)
+    x := new(big.Rat).Set(x0)
+    for k := range points {
+        points[k].X, _ = x.Float64()
+        points[k].Y, _ = new(big.Float).Quo(new(big.Float).SetInt(t.table[0]), scale).Float64()
+        x.Add(x, h)
+        t.step()
+    }
+    return points
+}
+
+// Adaptive refinement subdivides a segment of the sampled curve while the
+// polynomial at its midpoint is farther than curveTolerance times the
+// plotted y range from the straight line drawn between its ends, at most
+// curveMaxDepth times
+const (
+    curveTolerance = 1e-3
+    curveMaxDepth  = 12
+)
+
+// plotSample is a sample of the curve, exact and as drawn
+type plotSample struct {
+    x, y *big.Rat
+    xy   Point
+}
+
+func newPlotSample(x, y *big.Rat) plotSample {
+    fx, _ := x.Float64()
+    fy, _ := y.Float64()
+    return plotSample{x, y, Point{X: fx, Y: fy}}
+}
+
+// adaptiveSamples returns samples of p on [lo, hi] in increasing order of x:
+// n equally spaced points from forward differencing, the given extra points
+// (such as roots, so that the curve crosses the axis exactly there), and
+// midpoints added where the polynomial bends or climbs too steeply for the
+// straight segments between samples to follow it. All values are exact.
+func (p *Polynomial) adaptiveSamples(lo, hi *big.Rat, n int, extra []*big.Rat) []Point {
+    h := new(big.Rat).Sub(hi, lo)
+    h.Quo(h, big.NewRat(int64(n-1), 1))
+    values := p.sampleEquallySpaced(lo, h, n)
+    base := make([]plotSample, 0, n+len(extra))
+    x := new(big.Rat).Set(lo)
+    for _, v := range values {
+        base = append(base, newPlotSample(new(big.Rat).Set(x), v))
+        x.Add(x, h)
+    }
+    for _, e := range extra {
+        base = append(base, newPlotSample(e, p.Eval(e)))
+    }
+    sort.Slice(base, func(i, j int) bool { return base[i].x.Cmp(base[j].x) < 0 })
+
+    minY, maxY := math.Inf(1), math.Inf(-1)
+    for _, c := range base {
+        minY, maxY = math.Min(minY, c.xy.Y), math.Max(maxY, c.xy.Y)
+    }
+    tol := curveTolerance * (maxY - minY)
+    if tol == 0 || math.IsInf(tol, 0) || math.IsNaN(tol) {
+        tol = curveTolerance
+    }
+
+    half := big.NewRat(1, 2)
+    points := []Point{base[0].xy}
+    var refine func(a, b plotSample, depth int)
+    refine = func(a, b plotSample, depth int) {
+        if depth < curveMaxDepth {
+            mx := new(big.Rat).Add(a.x, b.x)
+            mx.Mul(mx, half)
+            m := newPlotSample(mx, p.Eval(mx))
+            if math.Abs(m.xy.Y-(a.xy.Y+b.xy.Y)/2) > tol {
+                refine(a, m, depth+1)
+                refine(m, b, depth+1)
+                return
+            }
+        }
+        points = append(points, b.xy)
+    }
+    for i := 1; i < len(base); i++ {
+        if base[i].x.Cmp(base[i-1].x) != 0 {
+            refine(base[i-1], base[i], 0)
+        }
+    }
+    return points
+}
+
+// CurvePlot is the plot of a polynomial made by PlotCurve, with the time
+// forward differencing and plain Horner evaluation took on the initial grid
+// of Samples points, the real roots found and the number of points plotted
+type CurvePlot struct {
+    Figure               *Figure
+    Samples              int
+    Differencing, Horner time.Duration
+    // Roots are the real roots refined to 64 bits; Exact[i] tells whether
+    // Roots[i] was isolated exactly
+    Roots   []*big.Rat
+    Exact   []bool
+    Plotted int
+}
+
+// PlotCurve plots f on [lo, hi]. The curve starts from samples equally
+// spaced points, computed by forward differencing, and is refined adaptively
+// where it bends or climbs steeply. The real roots in [lo, hi] are isolated
+// exactly and marked on the axis; the time spent on sampling is measured
+// next to the time plain Horner evaluation of the same grid takes. lo must
+// be below hi and samples at least 2, for the two ends of the interval.
+func PlotCurve(f *Polynomial, lo, hi *big.Rat, samples int) (*CurvePlot, error) {
+    if f == nil {
+        return nil, ErrNilPolynomial
+    }
+    if lo == nil || hi == nil || lo.Cmp(hi) >= 0 {
+        return nil, fmt.Errorf("plot: [%v, %v] is not an interval with lo below hi", lo, hi)
+    }
+    if samples < 2 {
+        return nil, fmt.Errorf("plot: %d samples, need at least 2", samples)
+    }
+    h := new(big.Rat).Sub(hi, lo)
+    h.Quo(h, big.NewRat(int64(samples-1), 1))
+    plot := &CurvePlot{Samples: samples}
+
+    start := time.Now()
+    f.sampleFloat64(lo, h, samples)
+    plot.Differencing = time.Since(start)
+
+    start = time.Now()
+    x := new(big.Rat).Set(lo)
+    for k := 0; k < samples; k++ {
+        f.Eval(x).Float64()
+        x.Add(x, h)
+    }
+    plot.Horner = time.Since(start)
+
+    var rootPoints []Point
+    if !f.IsZero() {
+        for _, iv := range isolateRealRoots(f, lo, hi) {
+            r := refineRoot(f, iv, 64)
+            plot.Roots = append(plot.Roots, r)
+            plot.Exact = append(plot.Exact, iv.lo.Cmp(iv.hi) == 0)
+            fr, _ := r.Float64()
+            rootPoints = append(rootPoints, Point{X: fr})
+        }
+    }
+    points := f.adaptiveSamples(lo, hi, samples, plot.Roots)
+    plot.Plotted = len(points)
+
+    fig := &Figure{
+        Title:  fmt.Sprintf("f(x) on [%s, %s]", lo.RatString(), hi.RatString()),
+        XLabel: "x",
+        YLabel: "f(x)",
+        Width:  6,
+        Height: 4,
+        Grid:   true,
+        Series: []Series{{Points: points, Color: figureBlack}},
+    }
+    if len(rootPoints) > 0 {
+        fig.Series = append(fig.Series, Series{Label: "real roots (exact isolation)", Points: rootPoints,
+            Scatter: true, Glyph: GlyphCircle, Color: figureRed, Radius: 3})
+        fig.LegendTop = true
+    }
+    plot.Figure = fig
+    return plot, nil
+}
@@ -0,0 +1,47 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+func TestPlotCurve(t *testing.T) {
+    // x^2 - 1 on [-2, 2] has the exact roots -1 and 1
+    f := NewPolynomial([]*big.Rat{big.NewRat(-1, 1), new(big.Rat), big.NewRat(1, 1)})
+    plot, err := PlotCurve(f, big.NewRat(-2, 1), big.NewRat(2, 1), 2)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(plot.Roots) != 2 || plot.Roots[0].Cmp(big.NewRat(-1, 1)) != 0 || plot.Roots[1].Cmp(big.NewRat(1, 1)) != 0 {
+        t.Errorf("roots %v, want -1 and 1", plot.Roots)
+    }
+    if plot.Plotted < 2 {
+        t.Errorf("%d points plotted, want at least the 2 samples", plot.Plotted)
+    }
+}
+
+func TestPlotCurveChecksInputs(t *testing.T) {
+    f := X()
+    lo, hi := big.NewRat(-1, 1), big.NewRat(1, 1)
+    cases := []struct {
+        name    string
+        lo, hi  *big.Rat
+        samples int
+    }{
+        {"no samples", lo, hi, 0},
+        {"one sample", lo, hi, 1},
+        {"negative samples", lo, hi, -5},
+        {"empty interval", lo, lo, 10},
+        {"reversed interval", hi, lo, 10},
+        {"nil lo", nil, hi, 10},
+    }
+    for _, c := range cases {
+        if _, err := PlotCurve(f, c.lo, c.hi, c.samples); err == nil {
+            t.Errorf("%s: no error", c.name)
+        }
+    }
+    if _, err := PlotCurve(nil, lo, hi, 10); !errors.Is(err, ErrNilPolynomial) {
+        t.Errorf("nil f: error %v, want ErrNilPolynomial", err)
+    }
+}
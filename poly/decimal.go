@@ -0,0 +1,188 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "strconv"
+    "strings"
+)
+
+// DecimalArithmetic is the shim a caller writes to run the algorithms on
+// their own arbitrary-precision decimal type D, such as shopspring/decimal's
+// decimal.Decimal or apd's *apd.Decimal. Division is where decimals round,
+// so Quo rounds to whatever precision the shim chooses, and IsZero should
+// then treat values below that precision as zero, or remainders that are
+// zero up to rounding never vanish. For shopspring/decimal the shim is a few
+// lines:
+//
+//     type shopspringShim struct{ places int32 }
+//
+//     func (s shopspringShim) Zero() decimal.Decimal           { return decimal.Zero }
+//     func (s shopspringShim) One() decimal.Decimal            { return decimal.NewFromInt(1) }
+//     func (s shopspringShim) Add(a, b decimal.Decimal) decimal.Decimal { return a.Add(b) }
+//     func (s shopspringShim) Sub(a, b decimal.Decimal) decimal.Decimal { return a.Sub(b) }
+//     func (s shopspringShim) Mul(a, b decimal.Decimal) decimal.Decimal { return a.Mul(b) }
+//     func (s shopspringShim) Quo(a, b decimal.Decimal) decimal.Decimal { return a.DivRound(b, s.places) }
+//     func (s shopspringShim) IsZero(a decimal.Decimal) bool {
+//         return a.Abs().LessThan(decimal.New(1, -s.places/2))
+//     }
+//     func (s shopspringShim) FromRat(r *big.Rat) (decimal.Decimal, error) {
+//         return decimal.NewFromBigInt(r.Num(), 0).DivRound(decimal.NewFromBigInt(r.Denom(), 0), s.places), nil
+//     }
+//     func (s shopspringShim) String(a decimal.Decimal) string { return a.String() }
+//
+//     RegisterBackend("shopspring", func(string) (Backend, error) {
+//         return NewDecimalBackend[decimal.Decimal]("shopspring", shopspringShim{30}), nil
+//     })
+//
+// exampleDecimalArithmetic below is a complete shim for a small decimal type
+// defined here, registered as the decimal backend.
+type DecimalArithmetic[D any] interface {
+    Zero() D
+    One() D
+    Add(a, b D) D
+    Sub(a, b D) D
+    Mul(a, b D) D
+    Quo(a, b D) D
+    IsZero(a D) bool
+    FromRat(r *big.Rat) (D, error)
+    String(a D) string
+}
+
+// decimalBackend adapts a DecimalArithmetic to the Backend interface
+type decimalBackend[D any] struct {
+    name string
+    ops  DecimalArithmetic[D]
+}
+
+// NewDecimalBackend wraps the shim ops as a Backend under name, ready for
+// RegisterBackend or for calling extendedEuclideanBackend directly
+func NewDecimalBackend[D any](name string, ops DecimalArithmetic[D]) Backend {
+    return decimalBackend[D]{name, ops}
+}
+
+func (b decimalBackend[D]) Name() string { return b.name }
+
+func (b decimalBackend[D]) FromRat(r *big.Rat) (interface{}, error) {
+    return b.ops.FromRat(r)
+}
+
+func (b decimalBackend[D]) Zero() interface{}         { return b.ops.Zero() }
+func (b decimalBackend[D]) One() interface{}          { return b.ops.One() }
+func (b decimalBackend[D]) IsZero(a interface{}) bool { return b.ops.IsZero(a.(D)) }
+
+func (b decimalBackend[D]) Add(x, y interface{}) interface{} { return b.ops.Add(x.(D), y.(D)) }
+func (b decimalBackend[D]) Sub(x, y interface{}) interface{} { return b.ops.Sub(x.(D), y.(D)) }
+func (b decimalBackend[D]) Mul(x, y interface{}) interface{} { return b.ops.Mul(x.(D), y.(D)) }
+func (b decimalBackend[D]) Quo(x, y interface{}) interface{} { return b.ops.Quo(x.(D), y.(D)) }
+
+func (b decimalBackend[D]) String(a interface{}) string { return b.ops.String(a.(D)) }
+
+// exampleDecimal is a minimal arbitrary-precision decimal, unscaled * 10^-scale,
+// standing in for a third-party decimal type in the example shim. Values are
+// immutable.
+type exampleDecimal struct {
+    unscaled *big.Int
+    scale    int
+}
+
+// rescale returns d with the given larger scale
+func (d exampleDecimal) rescale(scale int) exampleDecimal {
+    factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+    return exampleDecimal{new(big.Int).Mul(d.unscaled, factor), scale}
+}
+
+// align returns d and e with a common scale
+func (d exampleDecimal) align(e exampleDecimal) (exampleDecimal, exampleDecimal) {
+    if d.scale < e.scale {
+        return d.rescale(e.scale), e
+    }
+    if e.scale < d.scale {
+        return d, e.rescale(d.scale)
+    }
+    return d, e
+}
+
+// String prints d in plain decimal notation without trailing zeros
+func (d exampleDecimal) String() string {
+    s := new(big.Int).Abs(d.unscaled).String()
+    if d.scale > 0 {
+        if len(s) <= d.scale {
+            s = strings.Repeat("0", d.scale-len(s)+1) + s
+        }
+        s = strings.TrimRight(s[:len(s)-d.scale]+"."+s[len(s)-d.scale:], "0")
+        s = strings.TrimSuffix(s, ".")
+    }
+    if d.unscaled.Sign() < 0 {
+        s = "-" + s
+    }
+    return s
+}
+
+// exampleDecimalArithmetic is the shim for exampleDecimal: addition,
+// subtraction and multiplication are exact, division rounds half away from
+// zero to places fractional digits
+type exampleDecimalArithmetic struct {
+    places int
+}
+
+func (s exampleDecimalArithmetic) Zero() exampleDecimal { return exampleDecimal{new(big.Int), 0} }
+func (s exampleDecimalArithmetic) One() exampleDecimal  { return exampleDecimal{big.NewInt(1), 0} }
+
+func (s exampleDecimalArithmetic) Add(a, b exampleDecimal) exampleDecimal {
+    a, b = a.align(b)
+    return exampleDecimal{new(big.Int).Add(a.unscaled, b.unscaled), a.scale}
+}
+
+func (s exampleDecimalArithmetic) Sub(a, b exampleDecimal) exampleDecimal {
+    a, b = a.align(b)
+    return exampleDecimal{new(big.Int).Sub(a.unscaled, b.unscaled), a.scale}
+}
+
+func (s exampleDecimalArithmetic) Mul(a, b exampleDecimal) exampleDecimal {
+    return exampleDecimal{new(big.Int).Mul(a.unscaled, b.unscaled), a.scale + b.scale}
+}
+
+func (s exampleDecimalArithmetic) Quo(a, b exampleDecimal) exampleDecimal {
+    q, _ := s.FromRat(new(big.Rat).Quo(s.rat(a), s.rat(b)))
+    return q
+}
+
+func (s exampleDecimalArithmetic) IsZero(a exampleDecimal) bool {
+    bound := exampleDecimal{big.NewInt(1), s.places / 2}
+    a, bound = a.align(bound)
+    return a.unscaled.CmpAbs(bound.unscaled) < 0
+}
+
+// FromRat rounds r half away from zero to places fractional digits
+func (s exampleDecimalArithmetic) FromRat(r *big.Rat) (exampleDecimal, error) {
+    scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(s.places)), nil)))
+    half := big.NewRat(1, 2)
+    if scaled.Sign() < 0 {
+        half.Neg(half)
+    }
+    scaled.Add(scaled, half)
+    return exampleDecimal{new(big.Int).Quo(scaled.Num(), scaled.Denom()), s.places}, nil
+}
+
+func (s exampleDecimalArithmetic) String(a exampleDecimal) string { return a.String() }
+
+// rat returns the exact value of a
+func (s exampleDecimalArithmetic) rat(a exampleDecimal) *big.Rat {
+    den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.scale)), nil)
+    return new(big.Rat).SetFrac(a.unscaled, den)
+}
+
+func init() {
+    RegisterBackend("decimal", func(param string) (Backend, error) {
+        places := 30
+        if param != "" {
+            var err error
+            places, err = strconv.Atoi(param)
+            if err != nil || places < 2 {
+                return nil, fmt.Errorf("backend decimal needs at least 2 fractional digits, as in decimal:30")
+            }
+        }
+        return NewDecimalBackend[exampleDecimal](fmt.Sprintf("decimal:%d", places), exampleDecimalArithmetic{places}), nil
+    })
+}
@@ -0,0 +1,85 @@
+package poly
+
+import (
+    "fmt"
+    "io"
+    "strings"
+)
+
+// diagramNode is a state of the Euclidean algorithm, the pair (f, g) it is
+// about to divide; the last node is (gcd, 0)
+type diagramNode struct {
+    f, g *Polynomial
+}
+
+// euclidDiagram returns the states of the Euclidean algorithm on f and g and
+// the quotient that leads from each state to the next, so nodes has one more
+// element than quotients
+func euclidDiagram(f, g *Polynomial) (nodes []diagramNode, quotients []*Polynomial) {
+    res := extendedGCDResult(f, g)
+    for _, st := range res.Steps {
+        nodes = append(nodes, diagramNode{st.Dividend, st.Divisor})
+        quotients = append(quotients, st.Quotient)
+    }
+    return append(nodes, diagramNode{res.GCD, Zero()}), quotients
+}
+
+func (n diagramNode) label() string {
+    return fmt.Sprintf("(%s, %s)", layoutPolyString(n.f), layoutPolyString(n.g))
+}
+
+// WriteDOT writes the run of the Euclidean algorithm on f and g as a Graphviz
+// graph: one node per pair (f, g), edges labelled with the quotients, and the
+// final node (gcd, 0) highlighted. Render it with `dot -Tsvg`.
+func WriteDOT(w io.Writer, f, g *Polynomial) error {
+    nodes, quotients := euclidDiagram(f, g)
+    quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+    var b strings.Builder
+    b.WriteString("digraph euclid {\n")
+    b.WriteString("    rankdir=TB;\n")
+    b.WriteString("    node [shape=box, fontname=\"monospace\"];\n")
+    b.WriteString("    edge [fontname=\"monospace\"];\n")
+    for i, n := range nodes {
+        style := ""
+        if i == len(nodes)-1 {
+            style = ", style=filled, fillcolor=\"#fff3b0\""
+        }
+        b.WriteString(fmt.Sprintf("    n%d [label=\"%s\"%s];\n", i, quote.Replace(n.label()), style))
+    }
+    for i, q := range quotients {
+        b.WriteString(fmt.Sprintf("    n%d -> n%d [label=\"q = %s\"];\n", i, i+1, quote.Replace(layoutPolyString(q))))
+    }
+    b.WriteString("}\n")
+
+    _, err := io.WriteString(w, b.String())
+    return err
+}
+
+// writeMermaid writes the same graph as WriteDOT as a Mermaid flowchart,
+// which Markdown renderers such as GitHub's and most slide tools display
+// directly inside a ```mermaid block
+func writeMermaid(w io.Writer, f, g *Polynomial) error {
+    nodes, quotients := euclidDiagram(f, g)
+    // Mermaid labels are quoted; quotes inside them need an entity
+    quote := strings.NewReplacer(`"`, "#quot;")
+
+    var b strings.Builder
+    b.WriteString("flowchart TD\n")
+    for i, n := range nodes {
+        b.WriteString(fmt.Sprintf("    n%d[\"%s\"]\n", i, quote.Replace(n.label())))
+    }
+    for i, q := range quotients {
+        b.WriteString(fmt.Sprintf("    n%d -->|\"q = %s\"| n%d\n", i, quote.Replace(layoutPolyString(q)), i+1))
+    }
+    b.WriteString(fmt.Sprintf("    style n%d fill:#fff3b0\n", len(nodes)-1))
+
+    _, err := io.WriteString(w, b.String())
+    return err
+}
+
+// DiagramFormats maps the format names of the diagram command to writers
+var DiagramFormats = map[string]func(io.Writer, *Polynomial, *Polynomial) error{
+    "dot":     WriteDOT,
+    "mermaid": writeMermaid,
+}
@@ -0,0 +1,44 @@
+package poly
+
+import (
+    "encoding/hex"
+    "fmt"
+)
+
+// Large polynomials are shown as a summary unless WithFullOutput is given
+const (
+    // displayMaxDegree is the largest degree printed in full
+    displayMaxDegree = 100
+    // displayKeepTerms is the number of leading and trailing terms in a summary
+    displayKeepTerms = 3
+)
+
+// Display formats p for output: in full up to displayMaxDegree, otherwise as
+// a summary. Unlike String it shows the zero polynomial as "0".
+func Display(p *Polynomial, opts ...Option) string {
+    if p.IsZero() {
+        return "0"
+    }
+    c := newConfig(opts...)
+    if c.fullOutput || p.Deg() <= displayMaxDegree {
+        return p.Format(opts...)
+    }
+    return summary(p, displayKeepTerms, opts...)
+}
+
+// summary describes p by its degree, number of terms, the first and last keep
+// terms, its coefficient height and a hash of its coefficients. The height is
+// the largest absolute numerator or denominator among the coefficients, given
+// in bits; the hash identifies the polynomial exactly, so two summaries can be
+// compared without printing either polynomial in full.
+func summary(p *Polynomial, keep int, opts ...Option) string {
+    return fmt.Sprintf("%s [degree %d, %d terms, height %d bits, sha256 %s]",
+        p.elidedString(keep, opts...), p.Deg(), p.termCount(), p.heightBits(), polyHash(p))
+}
+
+// polyHash returns the first 16 hex digits of p's Digest, the SHA-256 hash
+// of its canonical encoding
+func polyHash(p *Polynomial) string {
+    sum := p.Digest()
+    return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,101 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// Factor is one factor of a factorization: Poly raised to Multiplicity
+type Factor struct {
+    Poly         *Polynomial
+    Multiplicity int
+}
+
+// DivisorIterator enumerates the monic divisors of a factored polynomial
+// one at a time; see Divisors
+type DivisorIterator struct {
+    maxDegree      int
+    multiplicities []int
+    // powers[i][e] is the e-th power of the i-th monic factor, computed on
+    // first use
+    powers   [][]*Polynomial
+    exponent []int
+    degree   int // degree of the divisor of the current exponent vector
+    done     bool
+}
+
+// Divisors returns an iterator over the monic divisors of degree at most
+// maxDegree (every divisor when maxDegree is negative) of the product of
+// the given factors, which must be nonconstant and pairwise coprime, as
+// distinct irreducible factors are, so that each divisor comes up exactly
+// once. A factorization with k factors of multiplicities m_i has
+// (m_1 + 1)...(m_k + 1) divisors, so they are computed lazily, starting
+// with 1, in order of increasing exponent of the first factor, then the
+// second, and so on; exponent vectors over the degree bound are skipped
+// without being multiplied out, which keeps recombination searches over
+// small divisors cheap.
+func Divisors(factors []Factor, maxDegree int) (*DivisorIterator, error) {
+    it := &DivisorIterator{maxDegree: maxDegree, exponent: make([]int, len(factors))}
+    if err := checkCoprimeFactors("divisors", factors); err != nil {
+        return nil, err
+    }
+    for _, fa := range factors {
+        it.multiplicities = append(it.multiplicities, fa.Multiplicity)
+        it.powers = append(it.powers, []*Polynomial{One(), fa.Poly.monic()})
+    }
+    return it, nil
+}
+
+// power returns the e-th power of the i-th factor
+func (it *DivisorIterator) power(i, e int) *Polynomial {
+    for len(it.powers[i]) <= e {
+        last := it.powers[i][len(it.powers[i])-1]
+        it.powers[i] = append(it.powers[i], last.mul(it.powers[i][1]))
+    }
+    return it.powers[i][e]
+}
+
+// Next returns the next divisor and true, or nil and false once every
+// divisor within the degree bound has been returned
+func (it *DivisorIterator) Next() (*Polynomial, bool) {
+    if it.done {
+        return nil, false
+    }
+    divisor := One()
+    for i, e := range it.exponent {
+        if e > 0 {
+            divisor = divisor.mul(it.power(i, e))
+        }
+    }
+    it.advance()
+    return divisor, true
+}
+
+// advance moves the exponent vector on like an odometer: the first digit
+// that can grow without exceeding its multiplicity or the degree bound is
+// incremented and the digits before it are reset to 0. Resetting a digit
+// only lowers the degree, and a digit that would exceed the bound now
+// exceeds it for every later vector with the same higher digits, so no
+// divisor within the bound is skipped.
+func (it *DivisorIterator) advance() {
+    for i := range it.exponent {
+        d := it.powers[i][1].Deg()
+        if it.exponent[i] < it.multiplicities[i] && (it.maxDegree < 0 || it.degree+d <= it.maxDegree) {
+            it.exponent[i]++
+            it.degree += d
+            return
+        }
+        it.degree -= it.exponent[i] * d
+        it.exponent[i] = 0
+    }
+    it.done = true
+}
+
+// NumDivisors returns the number of monic divisors of the product of the
+// factors without a degree bound, the product of the multiplicities plus one
+func NumDivisors(factors []Factor) *big.Int {
+    n := big.NewInt(1)
+    for _, fa := range factors {
+        n.Mul(n, big.NewInt(int64(fa.Multiplicity)+1))
+    }
+    return n
+}
@@ -0,0 +1,109 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// dual is a dual number a + b*ε with ε² = 0. Evaluating a polynomial at
+// x + ε gives p(x) + p'(x)*ε, so the derivative comes out of ordinary
+// arithmetic: forward-mode automatic differentiation.
+type dual struct {
+    re, eps *big.Rat
+}
+
+func (a dual) add(b dual) dual {
+    return dual{new(big.Rat).Add(a.re, b.re), new(big.Rat).Add(a.eps, b.eps)}
+}
+
+// mul multiplies by the product rule, (a + a'ε)(b + b'ε) = ab + (ab' + a'b)ε
+func (a dual) mul(b dual) dual {
+    eps := new(big.Rat).Mul(a.re, b.eps)
+    eps.Add(eps, new(big.Rat).Mul(a.eps, b.re))
+    return dual{new(big.Rat).Mul(a.re, b.re), eps}
+}
+
+// evalDual evaluates p at the dual number x with Horner's scheme
+func (p *Polynomial) evalDual(x dual) dual {
+    result := dual{new(big.Rat), new(big.Rat)}
+    for i := p.Deg(); i >= 0 && i < len(p.coeff); i-- {
+        result = result.mul(x).add(dual{p.coeff[i], new(big.Rat)})
+    }
+    return result
+}
+
+// EvalWithDerivative returns p(x) and p'(x) from a single Horner pass
+func (p *Polynomial) EvalWithDerivative(x *big.Rat) (*big.Rat, *big.Rat) {
+    d := p.evalDual(dual{x, big.NewRat(1, 1)})
+    return d.re, d.eps
+}
+
+// roundDyadic rounds r down to a multiple of 2^-bits, which keeps Newton
+// iterates from accumulating ever longer numerators and denominators
+func roundDyadic(r *big.Rat, bits int) *big.Rat {
+    scale := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+    num := new(big.Int).Mul(r.Num(), scale)
+    num.Div(num, r.Denom())
+    return new(big.Rat).SetFrac(num, scale)
+}
+
+// newtonRoot refines the root of the squarefree polynomial p that lies
+// strictly inside [lo, hi], where p changes sign, until it is known to within
+// 2^-bits. Each step takes value and derivative from one dual-number
+// evaluation; steps that would leave the bracket are replaced by bisection,
+// so the bracket always shrinks.
+func newtonRoot(p *Polynomial, lo, hi *big.Rat, bits int) *big.Rat {
+    lo, hi = new(big.Rat).Set(lo), new(big.Rat).Set(hi)
+    signLo := p.Eval(lo).Sign()
+    eps := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+    halfEps := new(big.Rat).Mul(eps, big.NewRat(1, 2))
+    half := big.NewRat(1, 2)
+
+    x := new(big.Rat).Add(lo, hi)
+    x.Mul(x, half)
+    for new(big.Rat).Sub(hi, lo).Cmp(eps) > 0 {
+        v, d := p.EvalWithDerivative(x)
+        s := v.Sign()
+        switch {
+        case s == 0:
+            return x
+        case s == signLo:
+            lo = x
+        default:
+            hi = x
+        }
+
+        var step *big.Rat
+        if d.Sign() != 0 {
+            step = new(big.Rat).Quo(v, d)
+        }
+        if step != nil && absRat(step).Cmp(halfEps) <= 0 {
+            // Newton has converged; confirm with a sign change around x
+            a, b := new(big.Rat).Sub(x, halfEps), new(big.Rat).Add(x, halfEps)
+            if a.Cmp(lo) >= 0 && b.Cmp(hi) <= 0 {
+                sa, sb := p.Eval(a).Sign(), p.Eval(b).Sign()
+                if sa == 0 {
+                    return a
+                }
+                if sb == 0 {
+                    return b
+                }
+                if sa != sb {
+                    lo, hi = a, b
+                    break
+                }
+            }
+        }
+
+        next := x
+        if step != nil {
+            next = roundDyadic(new(big.Rat).Sub(x, step), bits+8)
+        }
+        if next.Cmp(lo) <= 0 || next.Cmp(hi) >= 0 || next.Cmp(x) == 0 {
+            next = new(big.Rat).Add(lo, hi)
+            next.Mul(next, half)
+        }
+        x = next
+    }
+    mid := new(big.Rat).Add(lo, hi)
+    return mid.Mul(mid, half)
+}
@@ -0,0 +1,185 @@
+package poly
+
+import (
+    "crypto/sha256"
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+// The canonical encoding gives every polynomial exactly one byte string, so
+// that hashes and cache keys of equal polynomials are equal on every
+// platform. Version 1 is
+//
+//  version    1 byte, EncodingVersion
+//  domain     1 byte, DomainRational
+//  count      uvarint, number of coefficients: degree + 1, 0 for zero
+//  count times, lowest degree first:
+//    header   uvarint, 2*len(num) + 1 if negative, 2*len(num) if not
+//    num      big-endian magnitude of the numerator, no leading zero byte
+//    den      for a nonzero coefficient only: uvarint len(den), then the
+//             big-endian denominator, no leading zero byte
+//
+// with coefficients in lowest terms, positive denominators, a nonzero
+// leading coefficient and every uvarint in its shortest form. Decoding
+// rejects anything else, so a decoded polynomial re-encodes to the same bytes.
+//
+// Forward compatibility: the layout of a version never changes once
+// released. A change of layout or meaning gets a new version, which older
+// decoders reject with ErrEncodingVersion instead of misreading; newer
+// decoders keep reading every older version. A new coefficient domain gets a
+// new domain tag within the version, since the tag fixes the layout of the
+// coefficients; decoders reject tags they do not know. Trailing bytes are an
+// error, never an extension point.
+const EncodingVersion = 1
+
+// Domain tags of the canonical encoding
+const (
+    // DomainRational tags polynomials over the rationals, Polynomial
+    DomainRational byte = 1
+)
+
+// ErrEncodingVersion is returned for encodings of an unknown version
+var ErrEncodingVersion = errors.New("encoding: unknown version")
+
+// MarshalBinary returns the canonical encoding of p
+func (p *Polynomial) MarshalBinary() ([]byte, error) {
+    if p == nil {
+        return nil, ErrNilPolynomial
+    }
+    n := 0
+    if !p.IsZero() {
+        n = p.Deg() + 1
+    }
+    out := []byte{EncodingVersion, DomainRational}
+    out = appendUvarint(out, uint64(n))
+    for _, c := range p.coeff[:n] {
+        num := c.Num().Bytes()
+        header := 2 * uint64(len(num))
+        if c.Sign() < 0 {
+            header++
+        }
+        out = appendUvarint(out, header)
+        out = append(out, num...)
+        if c.Sign() != 0 {
+            den := c.Denom().Bytes()
+            out = appendUvarint(out, uint64(len(den)))
+            out = append(out, den...)
+        }
+    }
+    return out, nil
+}
+
+// UnmarshalBinary sets p to the polynomial of the canonical encoding data,
+// rejecting encodings that are not canonical
+func (p *Polynomial) UnmarshalBinary(data []byte) error {
+    if len(data) < 2 {
+        return fmt.Errorf("encoding: %d bytes, too short", len(data))
+    }
+    if data[0] != EncodingVersion {
+        return fmt.Errorf("%w %d (this build reads version %d)", ErrEncodingVersion, data[0], EncodingVersion)
+    }
+    if data[1] != DomainRational {
+        return fmt.Errorf("encoding: unknown domain tag %d", data[1])
+    }
+    d := encodingDecoder{data: data[2:]}
+    n, err := d.uvarint()
+    if err != nil {
+        return err
+    }
+    // every coefficient takes at least one byte
+    if n > uint64(len(d.data)) {
+        return fmt.Errorf("encoding: %d coefficients in %d bytes", n, len(d.data))
+    }
+    coeffs := make([]*big.Rat, n)
+    for i := range coeffs {
+        header, err := d.uvarint()
+        if err != nil {
+            return err
+        }
+        num, err := d.magnitude(header / 2)
+        if err != nil {
+            return err
+        }
+        if header%2 == 1 {
+            if num.Sign() == 0 {
+                return fmt.Errorf("encoding: coefficient %d is a negative zero", i)
+            }
+            num.Neg(num)
+        }
+        den := big.NewInt(1)
+        if num.Sign() != 0 {
+            size, err := d.uvarint()
+            if err != nil {
+                return err
+            }
+            if den, err = d.magnitude(size); err != nil {
+                return err
+            }
+            if den.Sign() == 0 {
+                return fmt.Errorf("encoding: coefficient %d has denominator 0", i)
+            }
+            if new(big.Int).GCD(nil, nil, new(big.Int).Abs(num), den).Cmp(big.NewInt(1)) != 0 {
+                return fmt.Errorf("encoding: coefficient %d is not in lowest terms", i)
+            }
+        }
+        coeffs[i] = new(big.Rat).SetFrac(num, den)
+    }
+    if len(d.data) > 0 {
+        return fmt.Errorf("encoding: %d trailing bytes", len(d.data))
+    }
+    if n > 0 && coeffs[n-1].Sign() == 0 {
+        return fmt.Errorf("encoding: leading coefficient is 0")
+    }
+    if n == 0 {
+        coeffs = []*big.Rat{new(big.Rat)}
+    }
+    p.coeff = coeffs
+    return nil
+}
+
+// appendUvarint appends the shortest uvarint encoding of v to b
+func appendUvarint(b []byte, v uint64) []byte {
+    var buf [binary.MaxVarintLen64]byte
+    return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// encodingDecoder reads the fields of an encoding from data
+type encodingDecoder struct {
+    data []byte
+}
+
+// uvarint reads a uvarint in its shortest form
+func (d *encodingDecoder) uvarint() (uint64, error) {
+    v, n := binary.Uvarint(d.data)
+    if n <= 0 {
+        return 0, fmt.Errorf("encoding: truncated or overlong uvarint")
+    }
+    if n != len(appendUvarint(nil, v)) {
+        return 0, fmt.Errorf("encoding: uvarint %d not in its shortest form", v)
+    }
+    d.data = d.data[n:]
+    return v, nil
+}
+
+// magnitude reads a big-endian magnitude of size bytes without a leading
+// zero byte
+func (d *encodingDecoder) magnitude(size uint64) (*big.Int, error) {
+    if size > uint64(len(d.data)) {
+        return nil, fmt.Errorf("encoding: truncated, %d bytes expected, %d left", size, len(d.data))
+    }
+    if size > 0 && d.data[0] == 0 {
+        return nil, fmt.Errorf("encoding: magnitude with a leading zero byte")
+    }
+    v := new(big.Int).SetBytes(d.data[:size])
+    d.data = d.data[size:]
+    return v, nil
+}
+
+// Digest returns the SHA-256 hash of the canonical encoding of p, which
+// identifies p exactly
+func (p *Polynomial) Digest() [sha256.Size]byte {
+    data, _ := p.MarshalBinary()
+    return sha256.Sum256(data)
+}
@@ -0,0 +1,119 @@
+package poly_test
+
+import (
+    "encoding/hex"
+    "errors"
+    "math/big"
+    "math/rand"
+    "testing"
+
+    "euclid/poly"
+)
+
+// encodingVectors are coefficient lists, highest degree first, with their
+// canonical encodings in hex, the same as the encode lines of
+// testdata/conformance.txt
+var encodingVectors = []struct {
+    coeffs, hex string
+}{
+    {"0", "010100"},
+    {"0,0,5", "01010102050101"},
+    {"1,0,-1", "010103030101010002010101"},
+    {"-1/2,0,3/4,0", "01010400020301040003010102"},
+    {"1/340282366920938463463374607431768211457,-65536", "0101020701000001010201110100000000000000000000000000000001"},
+}
+
+func TestEncodingVectors(t *testing.T) {
+    for _, v := range encodingVectors {
+        p, err := poly.ParseCoefficients(v.coeffs)
+        if err != nil {
+            t.Fatalf("ParseCoefficients(%q): %v", v.coeffs, err)
+        }
+        enc, err := p.MarshalBinary()
+        if err != nil {
+            t.Fatalf("MarshalBinary(%v): %v", p, err)
+        }
+        if got := hex.EncodeToString(enc); got != v.hex {
+            t.Errorf("%s encodes to %s, want %s", v.coeffs, got, v.hex)
+        }
+    }
+}
+
+// TestEncodingRoundTrip checks that random polynomials decode from their
+// encoding to themselves, that the decoded polynomial re-encodes to the
+// same bytes and that equal polynomials get the same digest however their
+// coefficients are stored
+func TestEncodingRoundTrip(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for i := 0; i < 500; i++ {
+        coeffs := make([]*big.Rat, 1+rng.Intn(12))
+        bits := 1 + rng.Intn(200)
+        for k := range coeffs {
+            coeffs[k] = randomRat(rng, bits)
+        }
+        p := poly.NewPolyNoCopy(coeffs)
+        enc, err := p.MarshalBinary()
+        if err != nil {
+            t.Fatalf("MarshalBinary(%v): %v", p, err)
+        }
+        var q poly.Polynomial
+        if err := q.UnmarshalBinary(enc); err != nil {
+            t.Fatalf("UnmarshalBinary(%x) of %v: %v", enc, p, err)
+        }
+        if !q.Equal(p) {
+            t.Fatalf("%v encodes to %x, which decodes to %v", p, enc, &q)
+        }
+        if again, _ := q.MarshalBinary(); string(again) != string(enc) {
+            t.Fatalf("%x decodes to %v, which encodes to %x", enc, &q, again)
+        }
+        padded := poly.NewPolyNoCopy(append(append([]*big.Rat{}, coeffs...), new(big.Rat), new(big.Rat)))
+        if padded.Digest() != p.Digest() {
+            t.Fatalf("%v has a different digest with zero leading coefficients", p)
+        }
+    }
+}
+
+// TestEncodingRejectsNonCanonical checks that every alternative encoding of
+// a polynomial is rejected, so that no polynomial has two
+func TestEncodingRejectsNonCanonical(t *testing.T) {
+    cases := []struct {
+        name, hex string
+    }{
+        {"empty", ""},
+        {"no domain", "01"},
+        {"unknown domain", "010200"},
+        {"overlong count", "01018000"},
+        {"zero leading coefficient", "0101020201010100"},
+        {"negative zero", "01010101"},
+        {"not in lowest terms", "010101020201020102"},
+        {"leading zero byte", "01010104000101"},
+        {"denominator 0", "010101020100"},
+        {"truncated", "010102020101"},
+        {"trailing bytes", "01010000"},
+    }
+    for _, c := range cases {
+        data, err := hex.DecodeString(c.hex)
+        if err != nil {
+            t.Fatalf("%s: bad test vector: %v", c.name, err)
+        }
+        var p poly.Polynomial
+        if err := p.UnmarshalBinary(data); err == nil {
+            t.Errorf("%s: %s decodes to %v, want an error", c.name, c.hex, &p)
+        }
+    }
+}
+
+func TestEncodingUnknownVersion(t *testing.T) {
+    var p poly.Polynomial
+    err := p.UnmarshalBinary([]byte{poly.EncodingVersion + 1, poly.DomainRational, 0})
+    if !errors.Is(err, poly.ErrEncodingVersion) {
+        t.Errorf("version %d: got %v, want ErrEncodingVersion", poly.EncodingVersion+1, err)
+    }
+}
+
+func TestFuzzEncodingSeeds(t *testing.T) {
+    for _, v := range encodingVectors {
+        data, _ := hex.DecodeString(v.hex)
+        poly.FuzzEncoding(data[2:])
+    }
+}
@@ -0,0 +1,206 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "strconv"
+)
+
+// ExpressionError reports a polynomial expression ParsePolynomial cannot read
+type ExpressionError struct {
+    Input string
+    Pos   int // byte offset of the offending character
+    Msg   string
+}
+
+func (e *ExpressionError) Error() string {
+    input := e.Input
+    if len(input) > 40 {
+        input = input[:40] + "..."
+    }
+    return fmt.Sprintf("polynomial %q, offset %d: %s", input, e.Pos, e.Msg)
+}
+
+// ParsePolynomial parses a polynomial written the way people write them,
+// such as "3x^4 - 2/5x + 7", "x^2 - 1" or "-x + 1.5*x^3", within
+// DefaultParseLimits. Each term is an optional rational coefficient (an
+// integer, fraction or decimal), an optional "*", and x with an optional
+// "^exponent"; a coefficient directly before x binds to it, so "2/5x" is
+// (2/5)*x. Spaces are ignored and terms of equal degree are added.
+// ParsePolynomial(p.String()) returns p for every polynomial within the
+// limits; the abbreviated forms of Display and the summary are not meant to
+// be read back.
+func ParsePolynomial(s string) (*Polynomial, error) {
+    return ParsePolynomialWithLimits(s, defaultParseLimits)
+}
+
+// exprParser is the state of a single ParsePolynomial call
+type exprParser struct {
+    s      string
+    pos    int
+    limits ParseLimits
+    terms  map[int]*big.Rat
+}
+
+// ParsePolynomialWithLimits parses a polynomial expression as
+// ParsePolynomial does, within the given limits
+func ParsePolynomialWithLimits(s string, limits ParseLimits) (*Polynomial, error) {
+    if limits.MaxLength > 0 && len(s) > limits.MaxLength {
+        return nil, &LimitError{Input: s, Pos: limits.MaxLength, Limit: "length", Max: limits.MaxLength, Got: len(s)}
+    }
+    p := &exprParser{s: s, limits: limits, terms: map[int]*big.Rat{}}
+    if err := p.parse(); err != nil {
+        return nil, err
+    }
+    deg := 0
+    for e := range p.terms {
+        deg = max(deg, e)
+    }
+    coeffs := make([]*big.Rat, deg+1)
+    for i := range coeffs {
+        if c, ok := p.terms[i]; ok {
+            coeffs[i] = c
+        } else {
+            coeffs[i] = new(big.Rat)
+        }
+    }
+    return NewPolyNoCopy(coeffs), nil
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+    return &ExpressionError{Input: p.s, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *exprParser) skipSpaces() {
+    for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+        p.pos++
+    }
+}
+
+// peek returns the next non-space byte, or 0 at the end of the input
+func (p *exprParser) peek() byte {
+    p.skipSpaces()
+    if p.pos == len(p.s) {
+        return 0
+    }
+    return p.s[p.pos]
+}
+
+// parse reads a sum of terms, the first one optionally signed
+func (p *exprParser) parse() error {
+    if p.peek() == 0 {
+        return p.errorf("empty polynomial")
+    }
+    for first := true; first || p.peek() != 0; first = false {
+        negative := false
+        switch p.peek() {
+        case '+':
+            p.pos++
+        case '-':
+            negative = true
+            p.pos++
+        default:
+            if !first {
+                return p.errorf("expected + or - between terms, got %q", p.s[p.pos])
+            }
+        }
+        if err := p.parseTerm(negative); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// parseTerm reads [coefficient] [*] [x [^ exponent]] and adds it to the terms
+func (p *exprParser) parseTerm(negative bool) error {
+    coeff := big.NewRat(1, 1)
+    hasCoeff := false
+    if c := p.peek(); isDigit(c) || c == '.' {
+        var err error
+        if coeff, err = p.parseCoefficient(); err != nil {
+            return err
+        }
+        hasCoeff = true
+    }
+    exponent := 0
+    star := p.peek() == '*'
+    if star {
+        p.pos++
+    }
+    if c := p.peek(); c == 'x' || c == 'X' {
+        p.pos++
+        exponent = 1
+        if p.peek() == '^' {
+            p.pos++
+            var err error
+            if exponent, err = p.parseExponent(); err != nil {
+                return err
+            }
+        }
+    } else if star || !hasCoeff {
+        if c == 0 {
+            return p.errorf("unexpected end of input, expected a coefficient or x")
+        }
+        return p.errorf("expected a coefficient or x, got %q", c)
+    }
+    if negative {
+        coeff.Neg(coeff)
+    }
+    if sum, ok := p.terms[exponent]; ok {
+        sum.Add(sum, coeff)
+    } else {
+        p.terms[exponent] = coeff
+    }
+    return nil
+}
+
+// parseCoefficient reads an unsigned integer, decimal or fraction
+func (p *exprParser) parseCoefficient() (*big.Rat, error) {
+    start := p.pos
+    p.scanNumber(true)
+    if p.pos < len(p.s) && p.s[p.pos] == '/' {
+        p.pos++
+        if p.pos == len(p.s) || !isDigit(p.s[p.pos]) {
+            return nil, p.errorf("expected a denominator after /")
+        }
+        p.scanNumber(false)
+    }
+    token := p.s[start:p.pos]
+    if digits := coefficientDigits(token); p.limits.MaxCoefficientDigits > 0 && digits > p.limits.MaxCoefficientDigits {
+        return nil, &LimitError{Input: p.s, Pos: start, Limit: "coefficient digits", Max: p.limits.MaxCoefficientDigits, Got: digits, Field: token}
+    }
+    c, ok := new(big.Rat).SetString(token)
+    if !ok {
+        p.pos = start
+        return nil, p.errorf("invalid coefficient %q", token)
+    }
+    return c, nil
+}
+
+// scanNumber advances over digits and, if decimal is set, a decimal point
+func (p *exprParser) scanNumber(decimal bool) {
+    for p.pos < len(p.s) && (isDigit(p.s[p.pos]) || decimal && p.s[p.pos] == '.') {
+        p.pos++
+    }
+}
+
+// parseExponent reads a nonnegative integer exponent within the degree limit
+func (p *exprParser) parseExponent() (int, error) {
+    p.skipSpaces()
+    start := p.pos
+    p.scanNumber(false)
+    if start == p.pos {
+        return 0, p.errorf("expected an exponent after ^")
+    }
+    e, err := strconv.Atoi(p.s[start:p.pos])
+    if err != nil || p.limits.MaxDegree > 0 && e > p.limits.MaxDegree {
+        got := e
+        if err != nil {
+            got = int(^uint(0) >> 1)
+        }
+        return 0, &LimitError{Input: p.s, Pos: start, Limit: "degree", Max: p.limits.MaxDegree, Got: got}
+    }
+    return e, nil
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
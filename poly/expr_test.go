@@ -0,0 +1,133 @@
+package poly_test
+
+import (
+    "errors"
+    "math/big"
+    "math/rand"
+    "strings"
+    "testing"
+
+    "euclid/poly"
+)
+
+// randomRat returns a random rational with a numerator of up to bits bits,
+// either sign, and a denominator of up to 8 bits; zero one time in four
+func randomRat(rng *rand.Rand, bits int) *big.Rat {
+    if rng.Intn(4) == 0 {
+        return new(big.Rat)
+    }
+    num := new(big.Int).Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+    if rng.Intn(2) == 0 {
+        num.Neg(num)
+    }
+    return new(big.Rat).SetFrac(num, big.NewInt(rng.Int63n(255)+1))
+}
+
+// checkStringRoundTrip fails t unless p.String() parses back to p
+func checkStringRoundTrip(t *testing.T, p *poly.Polynomial) {
+    t.Helper()
+    s := p.String()
+    q, err := poly.ParsePolynomial(s)
+    if err != nil {
+        t.Errorf("ParsePolynomial(%q): %v", s, err)
+        return
+    }
+    if !q.Equal(p) {
+        t.Errorf("ParsePolynomial(%q) = %v, want the polynomial it was printed from", s, q)
+    }
+}
+
+func TestStringRoundTripEdgeCases(t *testing.T) {
+    rat := func(a, b int64) *big.Rat { return big.NewRat(a, b) }
+    cases := [][]*big.Rat{
+        {rat(0, 1)},
+        {rat(0, 1), rat(0, 1), rat(0, 1)},
+        {rat(-1, 1)},
+        {rat(-3, 4)},
+        {rat(0, 1), rat(-1, 1)},
+        {rat(1, 1), rat(0, 1), rat(-1, 1)},
+        {rat(-5, 1), rat(0, 1), rat(-1, 2)},
+        {rat(1, 3), rat(-2, 7), rat(0, 1), rat(-11, 5)},
+        {rat(0, 1), rat(1, 1), rat(1, 1)},
+        {rat(-1, 1), rat(-1, 1), rat(-1, 1), rat(0, 1)},
+    }
+    for _, coeffs := range cases {
+        checkStringRoundTrip(t, poly.NewPolynomial(coeffs))
+    }
+}
+
+// TestStringRoundTripProperty checks ParsePolynomial(p.String()) == p on
+// random polynomials with fractional, negative and zero coefficients
+func TestStringRoundTripProperty(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for i := 0; i < 500; i++ {
+        coeffs := make([]*big.Rat, 1+rng.Intn(12))
+        bits := 1 + rng.Intn(100)
+        for k := range coeffs {
+            coeffs[k] = randomRat(rng, bits)
+        }
+        checkStringRoundTrip(t, poly.NewPolyNoCopy(coeffs))
+    }
+}
+
+func TestParseWithLimits(t *testing.T) {
+    limits := poly.DefaultParseLimits()
+    limits.MaxDegree = 3
+    limits.MaxCoefficientDigits = 4
+    short := limits
+    short.MaxLength = 5
+    cases := []struct {
+        parse  func(string, poly.ParseLimits) (*poly.Polynomial, error)
+        s      string
+        limits poly.ParseLimits
+        want   string // the error, empty if s parses
+    }{
+        {poly.ParsePolynomialWithLimits, "x^3 + 1", limits, ""},
+        {poly.ParsePolynomialWithLimits, "x^2 + x^4", limits, `polynomial "x^2 + x^4", offset 8: degree 4 exceeds limit 3`},
+        {poly.ParsePolynomialWithLimits, "x + 12345", limits, `polynomial "x + 12345", offset 4: coefficient "12345": coefficient digits 5 exceeds limit 4`},
+        {poly.ParsePolynomialWithLimits, "x + 1 ", short, `polynomial "x + 1 ", offset 5: length 6 exceeds limit 5`},
+        {poly.ParseCoefficientsWithLimits, "0, 0, 1,0,0,0", limits, ""},
+        {poly.ParseCoefficientsWithLimits, "0, 1,0,0,0,0", limits, `polynomial "0, 1,0,0,0,0", offset 3: degree 4 exceeds limit 3`},
+        {poly.ParseCoefficientsWithLimits, "1, 12345", limits, `polynomial "1, 12345", offset 3: coefficient "12345": coefficient digits 5 exceeds limit 4`},
+        {poly.ParseCoefficientsWithLimits, "1,2,3", short, ""},
+        {poly.ParseCoefficientsWithLimits, "1,"+strings.Repeat("0,", 200000)+"1", poly.ParseLimits{}, ""},
+    }
+    for _, c := range cases {
+        _, err := c.parse(c.s, c.limits)
+        var limitErr *poly.LimitError
+        switch {
+        case c.want == "" && err != nil:
+            t.Errorf("parse %.20q: %v", c.s, err)
+        case c.want == "":
+        case !errors.As(err, &limitErr):
+            t.Errorf("parse %q: error %v, want a *LimitError", c.s, err)
+        case err.Error() != c.want:
+            t.Errorf("parse %q: error %q, want %q", c.s, err, c.want)
+        }
+    }
+}
+
+// TestParseCoefficientsDecimalOnly checks that coefficient lists accept the
+// decimal coefficients of ParsePolynomial and reject the other forms
+// big.Rat.SetString reads, such as base prefixes and exponents
+func TestParseCoefficientsDecimalOnly(t *testing.T) {
+    for s, want := range map[string]string{
+        "1,-3,2":         "x^2 - 3x + 2",
+        "+1/2, 0, -0.25": "1/2x^2 - 1/4",
+        "3/6,007":        "1/2x + 7",
+    } {
+        p, err := poly.ParseCoefficients(s)
+        if w, _ := poly.ParsePolynomial(want); err != nil || !p.Equal(w) {
+            t.Errorf("ParseCoefficients(%q) = %v, %v, want %s", s, p, err, want)
+        }
+    }
+    for _, s := range []string{"0x10", "1,0b101", "0o17,1", "1e3", "1,2E-2", "1_000", "1/0x2", "1/-2", "--1", "1/", "/2", "."} {
+        var syntaxErr *poly.SyntaxError
+        if p, err := poly.ParseCoefficients(s); !errors.As(err, &syntaxErr) {
+            t.Errorf("ParseCoefficients(%q) = %v, %v, want a *SyntaxError", s, p, err)
+        }
+        if rs, err := poly.ParseRatList(s); err == nil {
+            t.Errorf("ParseRatList(%q) = %v, want an error", s, rs)
+        }
+    }
+}
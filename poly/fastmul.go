@@ -0,0 +1,51 @@
+package poly
+
+import (
+    "math/big"
+
+    "euclid/fft"
+)
+
+// nttMinDegree is the smallest degree of the smaller factor for which "auto"
+// multiplies by number-theoretic transforms instead of Kronecker
+// substitution. MulBench has them break even around degree 256 to 512 and
+// the transforms win by 1.2x at degree 1024 and 2.3x at 4096 on integer
+// coefficients. Karatsuba is never chosen automatically: on integer
+// coefficients the one big product of Kronecker substitution, which math/big
+// multiplies by Karatsuba itself, beats it at every degree, and it only wins
+// (by up to 1.5x between degrees 128 and 512) when independent denominators
+// blow up the cleared coefficients.
+const nttMinDegree = 1024
+
+// mulViaIntegers multiplies p and q by clearing denominators, multiplying
+// the integer coefficient vectors with mulInts and dividing the scale back
+// out of the product
+func (p *Polynomial) mulViaIntegers(q *Polynomial, mulInts func(a, b []*big.Int) []*big.Int) *Polynomial {
+    if p.IsZero() || q.IsZero() {
+        return Zero()
+    }
+    a, b := p.integerCoeffs(), q.integerCoeffs()
+    // the scales that integerCoeffs multiplied p and q by
+    scale := new(big.Rat).Quo(new(big.Rat).SetInt(a[len(a)-1]), p.coeff[p.Deg()])
+    scale.Mul(scale, new(big.Rat).Quo(new(big.Rat).SetInt(b[len(b)-1]), q.coeff[q.Deg()]))
+
+    coeffs := mulInts(a, b)
+    result := make([]*big.Rat, len(coeffs))
+    for i, c := range coeffs {
+        result[i] = new(big.Rat).SetInt(c)
+        result[i].Quo(result[i], scale)
+    }
+    return NewPolyNoCopy(result)
+}
+
+// mulKaratsuba multiplies p and q by Karatsuba's algorithm on the integer
+// coefficients
+func (p *Polynomial) mulKaratsuba(q *Polynomial) *Polynomial {
+    return p.mulViaIntegers(q, fft.Karatsuba)
+}
+
+// mulNTT multiplies p and q by number-theoretic transforms on the integer
+// coefficients
+func (p *Polynomial) mulNTT(q *Polynomial) *Polynomial {
+    return p.mulViaIntegers(q, fft.Mul)
+}
@@ -0,0 +1,54 @@
+package poly
+
+import (
+    "math/big"
+
+    "euclid/intring"
+)
+
+// lameBound returns Lamé's bound on the number of division steps of the
+// Euclidean algorithm: five times the number of decimal digits of the smaller input
+func lameBound(b *big.Int) int {
+    return 5 * len(new(big.Int).Abs(b).String())
+}
+
+// FibonacciRow is one input pair F_(K+1), F_K of FibonacciWorstCase: the
+// number of decimal digits of F_K, the division steps the extended
+// Euclidean algorithm takes on the pair and Lamé's bound on them
+type FibonacciRow struct {
+    K, Digits, Iterations, LameBound int
+}
+
+// FibonacciWorstCase runs the integer extended Euclidean algorithm on
+// consecutive Fibonacci numbers F_(k+1), F_k for k up to maxIndex, the
+// classical worst case, and compares the iteration counts with Lamé's bound.
+// It returns one row per k and the plot of both against k.
+func FibonacciWorstCase(maxIndex int) ([]FibonacciRow, *Figure) {
+    var rows []FibonacciRow
+    steps := make([]Point, 0, maxIndex)
+    bounds := make([]Point, 0, maxIndex)
+
+    for k := 2; k <= maxIndex; k++ {
+        a, b := fibonacciIntPair(k)
+        _, _, _, n := intring.ExtendedEuclidean(a, b)
+        bound := lameBound(b)
+        rows = append(rows, FibonacciRow{k, len(b.String()), n, bound})
+
+        steps = append(steps, Point{X: float64(k), Y: float64(n)})
+        bounds = append(bounds, Point{X: float64(k), Y: float64(bound)})
+    }
+
+    return rows, &Figure{
+        Title:  "Euclid on consecutive Fibonacci numbers",
+        XLabel: "k (input F_(k+1), F_k)",
+        YLabel: "Division steps",
+        Width:  6,
+        Height: 4,
+        Series: []Series{
+            {Label: "iterations", Points: steps, Color: figureBlack},
+            {Label: "Lamé bound", Points: bounds, Color: figureBlack, Dashes: []float64{4, 2}},
+        },
+        LegendTop:  true,
+        LegendLeft: true,
+    }
+}
@@ -0,0 +1,65 @@
+package poly
+
+import "image/color"
+
+// Figure describes a plot without drawing it, so that the package needs no
+// plotting library: the demos that plot return a Figure, and the caller
+// renders it, with the plotutil package (gonum/plot) or any other. Sizes are
+// in inches and points, as in print.
+type Figure struct {
+    Title, XLabel, YLabel string
+    Width, Height         float64 // inches
+    Grid                  bool
+    Series                []Series
+    // LegendTop and LegendLeft place the legend, at the bottom right by
+    // default
+    LegendTop, LegendLeft bool
+    // LogX and LogY put the axes on a logarithmic scale; every coordinate,
+    // error bars included, must then be positive
+    LogX, LogY bool
+    // Pad widens both axes by this fraction of their data range plus one on
+    // each side, for markers sitting on the border of the data
+    Pad float64
+}
+
+// Point is a point of a Series
+type Point struct {
+    X, Y float64
+}
+
+// Glyph is the marker shape of a series; GlyphAuto lets the renderer pick
+// one from its palette
+type Glyph int
+
+const (
+    GlyphAuto Glyph = iota
+    GlyphCircle
+    GlyphRing
+    GlyphPyramid
+    GlyphCross
+)
+
+// Series is one data set of a Figure: a line through the points, with
+// markers at the points when Markers is set, or only markers when Scatter is
+// set. A nil Color lets the renderer pick one from its palette, and a series
+// without a Label is left out of the legend. Low and High, when set, hold
+// per point the extent of an error bar below and above Y.
+type Series struct {
+    Label   string
+    Points  []Point
+    Scatter bool
+    Markers bool
+    Glyph   Glyph
+    Color   color.Color
+    Radius  float64   // marker radius in points, the renderer's default when 0
+    Dashes  []float64 // dash pattern in points, solid when empty
+    Low     []float64
+    High    []float64
+}
+
+var (
+    figureBlack = color.Black
+    figureRed   = color.RGBA{R: 200, A: 255}
+    figureBlue  = color.RGBA{B: 200, A: 255}
+    figureGold  = color.RGBA{R: 220, G: 160, A: 255}
+)
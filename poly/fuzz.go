@@ -0,0 +1,338 @@
+package poly
+
+import (
+    "fmt"
+    "io"
+    "math/big"
+    "math/rand"
+)
+
+// The Fuzz* functions follow the go-fuzz convention: they take arbitrary
+// bytes, panic when an invariant is broken and return 1 for inputs that
+// exercised the interesting path. The fuzz command drives them with random
+// inputs; they can equally be plugged into an external fuzzing harness.
+
+// polyFromBytes decodes a polynomial from fuzzer input: one byte for the
+// number of coefficients, then three bytes per coefficient (a signed 16-bit
+// numerator and an 8-bit denominator). Leading and all-zero coefficients are
+// allowed on purpose. The unused rest of the input is returned.
+func polyFromBytes(data []byte) (*Polynomial, []byte) {
+    if len(data) == 0 {
+        return Zero(), nil
+    }
+    n := int(data[0]%12) + 1
+    data = data[1:]
+    coeffs := make([]*big.Rat, n)
+    for i := range coeffs {
+        num, den := int64(0), int64(1)
+        if len(data) >= 3 {
+            num = int64(int16(uint16(data[0])<<8 | uint16(data[1])))
+            den = int64(data[2]) + 1
+            data = data[3:]
+        } else {
+            data = nil
+        }
+        coeffs[i] = big.NewRat(num, den)
+    }
+    return NewPolyNoCopy(coeffs), data
+}
+
+// FuzzArithmetic checks the ring identities, division with remainder and
+// Bézout's identity on two polynomials decoded from data
+func FuzzArithmetic(data []byte) int {
+    f, rest := polyFromBytes(data)
+    g, _ := polyFromBytes(rest)
+
+    if !f.Add(g).Sub(g).Equal(f) {
+        panic(fmt.Sprintf("(f + g) - g != f for f = %v, g = %v", f, g))
+    }
+    if !f.mul(g).Equal(g.mul(f)) {
+        panic(fmt.Sprintf("f*g != g*f for f = %v, g = %v", f, g))
+    }
+    if !f.mulKronecker(g).Equal(f.mulNaive(g)) {
+        panic(fmt.Sprintf("Kronecker substitution disagrees with schoolbook multiplication for f = %v, g = %v", f, g))
+    }
+    if !f.mulNTT(g).Equal(f.mulNaive(g)) {
+        panic(fmt.Sprintf("NTT multiplication disagrees with schoolbook multiplication for f = %v, g = %v", f, g))
+    }
+    if g.Deg() == 0 && len(g.coeff) > 0 {
+        // automatic differentiation agrees with the formal derivative
+        v, d := f.EvalWithDerivative(g.coeff[0])
+        if v.Cmp(f.Eval(g.coeff[0])) != 0 || d.Cmp(f.Derivative().Eval(g.coeff[0])) != 0 {
+            panic(fmt.Sprintf("dual evaluation of %v at %s is wrong", f, g.coeff[0].RatString()))
+        }
+    }
+    // forward differencing agrees with Horner's scheme
+    if g.Deg() == 0 && len(g.coeff) > 0 && g.coeff[0].Sign() != 0 {
+        x0, h := big.NewRat(-3, 2), g.coeff[0]
+        for k, v := range f.sampleEquallySpaced(x0, h, 20) {
+            x := new(big.Rat).Mul(h, big.NewRat(int64(k), 1))
+            if v.Cmp(f.Eval(x.Add(x, x0))) != 0 {
+                panic(fmt.Sprintf("forward differencing of %v at step %s is wrong at k = %d", f, h.RatString(), k))
+            }
+        }
+    }
+    // the recurrence found by Berlekamp–Massey reproduces the sequence
+    if seq := f.coeff; len(seq) > 0 {
+        rec := recurrenceFromMinimal(MinimalPolynomial(seq), seq)
+        for i, a := range rec.Terms(len(seq)) {
+            if a.Cmp(seq[i]) != 0 {
+                panic(fmt.Sprintf("minimal recurrence of %s does not reproduce it", RatList(seq)))
+            }
+        }
+    }
+    if g.IsZero() {
+        return 0
+    }
+
+    q, r := f.div(g)
+    if !q.mul(g).Add(r).Equal(f) {
+        panic(fmt.Sprintf("q*g + r != f for f = %v, g = %v", f, g))
+    }
+    if !r.IsZero() && r.Deg() >= g.Deg() {
+        panic(fmt.Sprintf("deg r >= deg g for f = %v, g = %v", f, g))
+    }
+
+    res := extendedGCDResult(f, g)
+    if !res.S.mul(f).Add(res.T.mul(g)).Equal(res.GCD) {
+        panic(fmt.Sprintf("s*f + t*g != gcd for f = %v, g = %v", f, g))
+    }
+    if res.Iterations > res.MaxIterations {
+        panic(fmt.Sprintf("%d iterations exceed the bound %d for f = %v, g = %v", res.Iterations, res.MaxIterations, f, g))
+    }
+    return 1
+}
+
+// FuzzFormat runs every formatter and basis conversion on polynomials
+// decoded from data and checks that the conversions round-trip
+func FuzzFormat(data []byte) int {
+    f, rest := polyFromBytes(data)
+    g, _ := polyFromBytes(rest)
+
+    if n, _ := f.WriteTo(io.Discard); n != int64(len(f.String())) {
+        panic(fmt.Sprintf("WriteTo wrote %d bytes for %q", n, f.String()))
+    }
+    if q, err := ParsePolynomial(f.String()); err != nil || !q.Equal(f) || q.String() != f.String() {
+        panic(fmt.Sprintf("%q does not parse back to itself: %v, %v", f.String(), q, err))
+    }
+    _ = f.elidedString(2)
+    _ = layoutPolyString(f)
+    _ = latexPolyString(f)
+    _ = f.Reciprocal()
+    if !FromChebyshev(f.ToChebyshev()).Equal(f) {
+        panic(fmt.Sprintf("Chebyshev conversion does not round-trip for %v", f))
+    }
+    if !FromBernstein(f.toBernstein(f.Deg())).Equal(f) {
+        panic(fmt.Sprintf("Bernstein conversion does not round-trip for %v", f))
+    }
+    if g.IsZero() {
+        return 0
+    }
+
+    _ = longDivision(f, g)
+    _ = longDivisionLaTeX(f, g)
+    if g.Deg() == 1 {
+        _ = syntheticDivision(f, g)
+    }
+    if err := WriteMarkdown(io.Discard, f, g); err != nil {
+        panic(err)
+    }
+    return 1
+}
+
+// FuzzParse parses data as a coefficient list and checks that formatting the
+// result as a coefficient list or with String and parsing it again gives the
+// same polynomial
+func FuzzParse(data []byte) int {
+    p, err := ParseCoefficients(string(data))
+    if err != nil {
+        return 0
+    }
+    q, err := ParseCoefficients(coefficientList(p))
+    if err != nil {
+        panic(fmt.Sprintf("cannot reparse %q: %v", coefficientList(p), err))
+    }
+    if !p.Equal(q) {
+        panic(fmt.Sprintf("%q parses to %v, reparsed as %v", data, p, q))
+    }
+    if e, err := ParsePolynomial(p.String()); err != nil || !e.Equal(p) {
+        panic(fmt.Sprintf("%q parses to %v, its String to %v (%v)", data, p, e, err))
+    }
+    return 1
+}
+
+// FuzzAliasing checks that no polynomial shares storage with another: a
+// polynomial built by NewPolynomial ignores later changes to the caller's
+// slice, and overwriting every coefficient of every result leaves the
+// operands untouched
+func FuzzAliasing(data []byte) int {
+    f, rest := polyFromBytes(data)
+    g, _ := polyFromBytes(rest)
+    before := coefficientList(f) + ";" + coefficientList(g)
+
+    coeffs := make([]*big.Rat, len(f.coeff))
+    for i, c := range f.coeff {
+        coeffs[i] = new(big.Rat).Set(c)
+    }
+    h := NewPolynomial(coeffs)
+    coeffs[0] = big.NewRat(7, 1)
+    for _, c := range coeffs[1:] {
+        c.SetInt64(7)
+    }
+    if coefficientList(h) != coefficientList(f) {
+        panic(fmt.Sprintf("newPolyRing kept the caller's slice for %v", f))
+    }
+
+    results := []*Polynomial{f.Add(g), f.Sub(g), f.mul(g), f.Reciprocal(), f.perturb(0, big.NewRat(1, 1))}
+    if !g.IsZero() {
+        q, r := f.div(g)
+        res := extendedGCDResult(f, g)
+        results = append(results, q, r, res.GCD, res.S, res.T)
+        for _, step := range res.Steps {
+            results = append(results, step.Dividend, step.Divisor, step.Quotient, step.Remainder)
+        }
+    }
+    for _, r := range results {
+        for _, c := range r.coeff {
+            c.SetInt64(7)
+        }
+    }
+    if after := coefficientList(f) + ";" + coefficientList(g); after != before {
+        panic(fmt.Sprintf("operands changed from %s to %s by writing to results", before, after))
+    }
+    return 1
+}
+
+// FuzzEncoding checks that the canonical encoding round-trips: a polynomial
+// decoded from data encodes to bytes that decode to the same polynomial, and
+// data taken as the body of a version 1 encoding is either rejected or
+// re-encodes to exactly the same bytes, so that no polynomial has two
+// encodings
+func FuzzEncoding(data []byte) int {
+    f, _ := polyFromBytes(data)
+    enc, err := f.MarshalBinary()
+    if err != nil {
+        panic(fmt.Sprintf("cannot encode %v: %v", f, err))
+    }
+    var g Polynomial
+    if err := g.UnmarshalBinary(enc); err != nil || !g.Equal(f) {
+        panic(fmt.Sprintf("%v encodes to %x, which decodes to %v (%v)", f, enc, &g, err))
+    }
+    raw := append([]byte{EncodingVersion, DomainRational}, data...)
+    var h Polynomial
+    if h.UnmarshalBinary(raw) != nil {
+        return 0
+    }
+    if again, _ := h.MarshalBinary(); string(again) != string(raw) {
+        panic(fmt.Sprintf("%x decodes to %v, which encodes to %x", raw, &h, again))
+    }
+    return 1
+}
+
+// fuzzTargets lists the fuzz entry points by name
+var fuzzTargets = []struct {
+    name string
+    fn   func([]byte) int
+    text bool
+}{
+    {"arithmetic", FuzzArithmetic, false},
+    {"format", FuzzFormat, false},
+    {"aliasing", FuzzAliasing, false},
+    {"parse", FuzzParse, true},
+    {"encoding", FuzzEncoding, false},
+}
+
+// randomFuzzInput returns random bytes, or random text over the characters of
+// the coefficient-list syntax for text targets
+func randomFuzzInput(rng *rand.Rand, text bool) []byte {
+    const alphabet = "0123456789-+/,. "
+    data := make([]byte, rng.Intn(64))
+    for i := range data {
+        if text {
+            data[i] = alphabet[rng.Intn(len(alphabet))]
+        } else {
+            data[i] = byte(rng.Intn(256))
+        }
+    }
+    return data
+}
+
+// runFuzzTarget runs fn on data and returns its result and what it
+// panicked with, or nil
+func runFuzzTarget(fn func([]byte) int, data []byte) (result int, r interface{}) {
+    defer func() {
+        r = recover()
+    }()
+    return fn(data), nil
+}
+
+// minimizeFuzzInput shrinks data, on which fn panics, to a smaller input on
+// which it still panics. Inputs of the binary targets are shrunk as the two
+// polynomials they decode to, when the re-encoded pair still fails, and
+// byte by byte otherwise.
+func minimizeFuzzInput(fn func([]byte) int, text bool, data []byte) []byte {
+    fails := func(data []byte) bool {
+        _, r := runFuzzTarget(fn, data)
+        return r != nil
+    }
+    if !text {
+        f, rest := polyFromBytes(data)
+        g, _ := polyFromBytes(rest)
+        if fails(polyPairToBytes(f, g)) {
+            f, g = ShrinkPair(f, g, func(f, g *Polynomial) bool { return fails(polyPairToBytes(f, g)) })
+            return polyPairToBytes(f, g)
+        }
+    }
+    return shrinkBytes(data, fails)
+}
+
+// FuzzResult is the outcome of RunFuzz for one target that did not panic:
+// how many inputs it ran and how many of them were interesting
+type FuzzResult struct {
+    Target              string
+    Inputs, Interesting int
+}
+
+// FuzzFailure is the error RunFuzz returns when a target panics. It holds
+// the input and the panic, and the input shrunk by minimizeFuzzInput with
+// the panic it causes; Pair describes the two polynomials the minimized
+// input of a binary target decodes to.
+type FuzzFailure struct {
+    Target           string
+    Input, Minimized []byte
+    Panic, MinPanic  interface{}
+    Pair             string
+}
+
+func (e *FuzzFailure) Error() string {
+    return fmt.Sprintf("fuzz: %s panics on %q: %v", e.Target, e.Minimized, e.MinPanic)
+}
+
+// RunFuzz feeds random inputs to every fuzz target and returns the results
+// of the targets run. On the first panic it shrinks the input with
+// minimizeFuzzInput and stops with a *FuzzFailure.
+func RunFuzz(iterations int, opts ...Option) ([]FuzzResult, error) {
+    rng := newConfig(opts...).rand()
+    var results []FuzzResult
+    for _, target := range fuzzTargets {
+        interesting := 0
+        for i := 0; i < iterations; i++ {
+            data := randomFuzzInput(rng, target.text)
+            result, r := runFuzzTarget(target.fn, data)
+            if r != nil {
+                failure := &FuzzFailure{Target: target.name, Input: data, Panic: r}
+                failure.Minimized = minimizeFuzzInput(target.fn, target.text, data)
+                if !target.text {
+                    f, rest := polyFromBytes(failure.Minimized)
+                    g, _ := polyFromBytes(rest)
+                    failure.Pair = fmt.Sprintf("f = %s, g = %s", coefficientList(f), coefficientList(g))
+                }
+                _, failure.MinPanic = runFuzzTarget(target.fn, failure.Minimized)
+                return results, failure
+            }
+            interesting += result
+        }
+        results = append(results, FuzzResult{target.name, iterations, interesting})
+    }
+    return results, nil
+}
@@ -0,0 +1,47 @@
+package poly_test
+
+import (
+    "testing"
+
+    "euclid/poly"
+)
+
+// binarySeeds are inputs for the targets that decode two polynomials: the
+// empty input, x^2 - 1 with x - 1, and 3/4 + x/2 with a zero polynomial
+var binarySeeds = [][]byte{
+    nil,
+    {2, 0xff, 0xff, 0, 0, 0, 0, 0, 1, 0, 1, 0xff, 0xff, 0, 0, 1, 0},
+    {1, 0, 3, 3, 0, 1, 1, 0, 0, 0, 0},
+}
+
+// fuzzNative runs a go-fuzz entry point under go test -fuzz, starting from
+// seeds; the entry points panic when an invariant is broken, which fails the
+// test and makes go test save the input under testdata/fuzz
+func fuzzNative(f *testing.F, target func([]byte) int, seeds ...[]byte) {
+    for _, seed := range seeds {
+        f.Add(seed)
+    }
+    f.Fuzz(func(t *testing.T, data []byte) {
+        target(data)
+    })
+}
+
+func FuzzArithmetic(f *testing.F) {
+    fuzzNative(f, poly.FuzzArithmetic, binarySeeds...)
+}
+
+func FuzzFormat(f *testing.F) {
+    fuzzNative(f, poly.FuzzFormat, binarySeeds...)
+}
+
+func FuzzAliasing(f *testing.F) {
+    fuzzNative(f, poly.FuzzAliasing, binarySeeds...)
+}
+
+func FuzzEncoding(f *testing.F) {
+    fuzzNative(f, poly.FuzzEncoding, binarySeeds...)
+}
+
+func FuzzParse(f *testing.F) {
+    fuzzNative(f, poly.FuzzParse, []byte(""), []byte("x^2 - 1"), []byte("3/4*x^3 + 2*x - 5"), []byte("((x"))
+}
@@ -0,0 +1,217 @@
+package poly
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "math/big"
+    "os"
+    "strconv"
+)
+
+// GCDVectorsFile is the published vector file checked by the gcdvectors
+// command
+const GCDVectorsFile = "testdata/gcd_vectors.json"
+
+// GCDVector is one published test vector of the extended Euclidean
+// algorithm, for validating other implementations against this package.
+// Polynomials are coefficient lists with rational coefficients, highest
+// degree first, e.g. "1,0,-1/2" for x^2 - 1/2. The expected result is in
+// canonical monic form: GCD is monic (0 only when F and G are both zero) and
+// S*F + T*G = GCD with the S and T of the Euclidean algorithm, so
+// deg S < deg G - deg GCD and deg T < deg F - deg GCD whenever those are
+// positive.
+type GCDVector struct {
+    Name string `json:"name"`
+    F    string `json:"f"`
+    G    string `json:"g"`
+    GCD  string `json:"gcd"`
+    S    string `json:"s"`
+    T    string `json:"t"`
+}
+
+// gcdVectorFile is the layout of the vector file
+type gcdVectorFile struct {
+    Description string      `json:"description"`
+    Vectors     []GCDVector `json:"vectors"`
+}
+
+// LoadGCDVectors reads a vector file such as testdata/gcd_vectors.json
+func LoadGCDVectors(r io.Reader) ([]GCDVector, error) {
+    var file gcdVectorFile
+    if err := json.NewDecoder(r).Decode(&file); err != nil {
+        return nil, fmt.Errorf("gcd vectors: %v", err)
+    }
+    return file.Vectors, nil
+}
+
+// Polys parses the inputs and the expected result of v
+func (v GCDVector) Polys() (f, g, gcd, s, t *Polynomial, err error) {
+    var ps [5]*Polynomial
+    for i, text := range []string{v.F, v.G, v.GCD, v.S, v.T} {
+        if ps[i], err = ParseCoefficients(text); err != nil {
+            return nil, nil, nil, nil, nil, fmt.Errorf("vector %s: %v", v.Name, err)
+        }
+    }
+    return ps[0], ps[1], ps[2], ps[3], ps[4], nil
+}
+
+// Check recomputes v with this package and reports the first difference
+func (v GCDVector) Check() error {
+    f, g, gcd, s, t, err := v.Polys()
+    if err != nil {
+        return err
+    }
+    wantGCD, wantS, wantT := monicGCD(f, g)
+    for _, d := range []struct {
+        name      string
+        got, want *Polynomial
+    }{{"gcd", gcd, wantGCD}, {"s", s, wantS}, {"t", t, wantT}} {
+        if !d.got.Equal(d.want) {
+            return fmt.Errorf("vector %s: %s computed as %s, expected %s", v.Name, d.name, coefficientList(d.want), coefficientList(d.got))
+        }
+    }
+    return nil
+}
+
+// monicGCD returns the extended GCD of f and g in canonical monic form
+func monicGCD(f, g *Polynomial, opts ...Option) (gcd, s, t *Polynomial) {
+    if f.IsZero() && g.IsZero() {
+        return Zero(), Zero(), Zero()
+    }
+    if g.IsZero() {
+        f, g = g, f
+        gcd, t, s = monicGCD(f, g, opts...)
+        return gcd, s, t
+    }
+    res := extendedGCDResult(f, g, opts...)
+    lc := new(big.Rat).Inv(res.GCD.coeff[res.GCD.Deg()])
+    return res.GCD.scale(lc), res.S.scale(lc).trim(), res.T.scale(lc).trim()
+}
+
+func newGCDVector(name string, f, g *Polynomial) GCDVector {
+    gcd, s, t := monicGCD(f, g)
+    return GCDVector{name, coefficientList(f), coefficientList(g), coefficientList(gcd), coefficientList(s), coefficientList(t)}
+}
+
+// crossCheckGCDVector verifies a freshly computed vector by means independent
+// of the Euclidean algorithm that produced it: the Bézout identity, that the
+// gcd divides both inputs, that it is nonconstant exactly when the resultant
+// vanishes, and that every multiplication strategy agrees
+func crossCheckGCDVector(v GCDVector) error {
+    f, g, gcd, s, t, err := v.Polys()
+    if err != nil {
+        return err
+    }
+    if !s.mul(f).Add(t.mul(g)).Equal(gcd) {
+        return fmt.Errorf("vector %s: s*f + t*g != gcd", v.Name)
+    }
+    if gcd.IsZero() {
+        return nil
+    }
+    if !gcd.coeff[gcd.Deg()].IsInt() || gcd.coeff[gcd.Deg()].Num().Cmp(big.NewInt(1)) != 0 {
+        return fmt.Errorf("vector %s: gcd is not monic", v.Name)
+    }
+    for _, p := range []*Polynomial{f, g} {
+        if _, r := p.div(gcd); !r.IsZero() {
+            return fmt.Errorf("vector %s: gcd does not divide %s", v.Name, coefficientList(p))
+        }
+    }
+    if !f.IsZero() && !g.IsZero() && (Resultant(f, g).Sign() == 0) != (gcd.Deg() > 0) {
+        return fmt.Errorf("vector %s: resultant disagrees with gcd degree %d", v.Name, gcd.Deg())
+    }
+    for _, strategy := range mulStrategies {
+        sg, ss, st := monicGCD(f, g, WithStrategy(strategy))
+        if !sg.Equal(gcd) || !ss.Equal(s) || !st.Equal(t) {
+            return fmt.Errorf("vector %s: strategy %s disagrees", v.Name, strategy)
+        }
+    }
+    return nil
+}
+
+// gcdVectorInputs are the hand-picked inputs of the vector file: edge cases
+// and small structured pairs; corpus pairs are added by generateGCDVectors
+var gcdVectorInputs = [][3]string{
+    {"both-zero", "0", "0"},
+    {"f-zero", "0", "2,2"},
+    {"g-zero", "3,-3", "0"},
+    {"constants", "5", "3"},
+    {"constant-and-linear", "1/2", "1,1"},
+    {"coprime-linear", "1,1", "1,-1"},
+    {"equal", "2,0,-2", "1,0,-1"},
+    {"common-root", "1,0,-1", "1,-3,2"},
+    {"divides", "1,0,0,-1", "1,-1"},
+    {"degree-swap", "1,1", "1,0,-1"},
+    {"leading-zeros", "0,0,1,0,-1", "1,1"},
+    {"fractional", "1/2,-3/4,5/6,-7/8", "2/3,0,-1/5"},
+    {"fractional-coprime", "1,0,-1/3,5", "1,1/7,2"},
+    {"cyclotomic", "1,0,0,0,0,0,-1", "1,0,0,-1"},
+    {"repeated-factor", "1,-3,3,-1", "1,-2,1"},
+    {"all-ones", "1,1,1,1,1,1,1,1,1", "1,0,1,0,1"},
+    {"large-coefficients", "123456789012345678901234567890,1,-987654321098765432109876543210", "1,-1"},
+}
+
+// generateGCDVectors computes and cross-checks the published vectors
+func generateGCDVectors() ([]GCDVector, error) {
+    var vectors []GCDVector
+    for _, in := range gcdVectorInputs {
+        f, err1 := ParseCoefficients(in[1])
+        g, err2 := ParseCoefficients(in[2])
+        if err1 != nil || err2 != nil {
+            return nil, fmt.Errorf("input %s: bad polynomial", in[0])
+        }
+        vectors = append(vectors, newGCDVector(in[0], f, g))
+    }
+    for _, family := range CorpusNames() {
+        for _, degree := range []int{3, 5, 8} {
+            for seed := int64(1); seed <= 2; seed++ {
+                f, g := GCDCorpus[family](newConfig(WithSeed(seed)).rand(), degree)
+                name := family + "-" + strconv.Itoa(degree) + "-seed" + strconv.FormatInt(seed, 10)
+                vectors = append(vectors, newGCDVector(name, f, g))
+            }
+        }
+    }
+    for _, v := range vectors {
+        if err := crossCheckGCDVector(v); err != nil {
+            return nil, err
+        }
+    }
+    return vectors, nil
+}
+
+// WriteGCDVectors generates the vector file to w
+func WriteGCDVectors(w io.Writer) error {
+    vectors, err := generateGCDVectors()
+    if err != nil {
+        return err
+    }
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(gcdVectorFile{
+        Description: "Extended GCD test vectors over Q: s*f + t*g = gcd, gcd monic. " +
+            "Polynomials are coefficient lists, highest degree first. Regenerate with `euclid gcdvectors --update`.",
+        Vectors: vectors,
+    })
+}
+
+// CheckGCDVectors loads the vector file at path and checks every vector.
+// It returns the errors of the failing vectors and the number of vectors
+// checked.
+func CheckGCDVectors(path string) ([]error, int, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer file.Close()
+    vectors, err := LoadGCDVectors(file)
+    if err != nil {
+        return nil, 0, fmt.Errorf("%s: %v", path, err)
+    }
+    var failures []error
+    for _, v := range vectors {
+        if err := v.Check(); err != nil {
+            failures = append(failures, err)
+        }
+    }
+    return failures, len(vectors), nil
+}
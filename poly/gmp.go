@@ -0,0 +1,133 @@
+//go:build gmp && cgo
+
+package poly
+
+/*
+#cgo LDFLAGS: -lgmp
+#include <gmp.h>
+#include <stdlib.h>
+
+// mpq_numref, mpq_denref and mpq_sgn are macros, which cgo cannot call
+
+static void q_set_bytes(mpq_ptr q, const void *num, size_t nnum, int neg, const void *den, size_t nden) {
+    mpz_import(mpq_numref(q), nnum, 1, 1, 1, 0, num);
+    if (neg) {
+        mpz_neg(mpq_numref(q), mpq_numref(q));
+    }
+    mpz_import(mpq_denref(q), nden, 1, 1, 1, 0, den);
+    mpq_canonicalize(q);
+}
+
+static int q_sgn(mpq_srcptr q) {
+    return mpq_sgn(q);
+}
+
+static char *q_str(mpq_srcptr q) {
+    size_t n = mpz_sizeinbase(mpq_numref(q), 10) + mpz_sizeinbase(mpq_denref(q), 10) + 3;
+    return mpq_get_str(malloc(n), 10, q);
+}
+*/
+import "C"
+
+import (
+    "fmt"
+    "math/big"
+    "runtime"
+    "unsafe"
+)
+
+// gmpAvailable reports whether the gmp backend uses GMP or the pure-Go fallback
+const gmpAvailable = true
+
+func init() {
+    RegisterBackend("gmp", newGMPBackend)
+}
+
+// gmpRat is a GMP rational. Its limbs live in C memory and are released by a
+// finalizer, so values can be dropped like any Go value.
+type gmpRat struct {
+    q C.mpq_t
+}
+
+func newGMPRat() *gmpRat {
+    z := new(gmpRat)
+    C.mpq_init(&z.q[0])
+    runtime.SetFinalizer(z, func(z *gmpRat) { C.mpq_clear(&z.q[0]) })
+    return z
+}
+
+// gmpBackend computes exactly with GMP's mpq_t, which is considerably faster
+// than math/big on large coefficients
+type gmpBackend struct{}
+
+func newGMPBackend(param string) (Backend, error) {
+    if param != "" {
+        return nil, fmt.Errorf("backend gmp takes no parameter")
+    }
+    return gmpBackend{}, nil
+}
+
+func (gmpBackend) Name() string { return "gmp" }
+
+func (gmpBackend) FromRat(r *big.Rat) (interface{}, error) {
+    z := newGMPRat()
+    num, den := r.Num().Bytes(), r.Denom().Bytes()
+    // mpz_import reads nothing for a zero count, but cgo needs a valid pointer
+    num, den = append(num, 0), append(den, 0)
+    neg := C.int(0)
+    if r.Sign() < 0 {
+        neg = 1
+    }
+    C.q_set_bytes(&z.q[0], unsafe.Pointer(&num[0]), C.size_t(len(num)-1), neg, unsafe.Pointer(&den[0]), C.size_t(len(den)-1))
+    runtime.KeepAlive(num)
+    runtime.KeepAlive(den)
+    return z, nil
+}
+
+func (gmpBackend) Zero() interface{} { return newGMPRat() }
+
+func (gmpBackend) One() interface{} {
+    z := newGMPRat()
+    C.mpq_set_ui(&z.q[0], 1, 1)
+    return z
+}
+
+func (gmpBackend) IsZero(a interface{}) bool {
+    z := a.(*gmpRat)
+    defer runtime.KeepAlive(z)
+    return C.q_sgn(&z.q[0]) == 0
+}
+
+// binary applies a GMP operation to a and b, writing to a new value
+func (gmpBackend) binary(op func(r, a, b *C.__mpq_struct), a, b interface{}) interface{} {
+    x, y := a.(*gmpRat), b.(*gmpRat)
+    z := newGMPRat()
+    op(&z.q[0], &x.q[0], &y.q[0])
+    runtime.KeepAlive(x)
+    runtime.KeepAlive(y)
+    return z
+}
+
+func (g gmpBackend) Add(a, b interface{}) interface{} {
+    return g.binary(func(r, a, b *C.__mpq_struct) { C.mpq_add(r, a, b) }, a, b)
+}
+
+func (g gmpBackend) Sub(a, b interface{}) interface{} {
+    return g.binary(func(r, a, b *C.__mpq_struct) { C.mpq_sub(r, a, b) }, a, b)
+}
+
+func (g gmpBackend) Mul(a, b interface{}) interface{} {
+    return g.binary(func(r, a, b *C.__mpq_struct) { C.mpq_mul(r, a, b) }, a, b)
+}
+
+func (g gmpBackend) Quo(a, b interface{}) interface{} {
+    return g.binary(func(r, a, b *C.__mpq_struct) { C.mpq_div(r, a, b) }, a, b)
+}
+
+func (gmpBackend) String(a interface{}) string {
+    z := a.(*gmpRat)
+    s := C.q_str(&z.q[0])
+    runtime.KeepAlive(z)
+    defer C.free(unsafe.Pointer(s))
+    return C.GoString(s)
+}
@@ -0,0 +1,27 @@
+//go:build !gmp || !cgo
+
+package poly
+
+import "fmt"
+
+// gmpAvailable reports whether the gmp backend uses GMP or the pure-Go fallback
+const gmpAvailable = false
+
+// Without the gmp build tag (or without cgo) the gmp backend falls back to
+// math/big, so programs selecting it by name keep working, only slower.
+// Build with -tags gmp to use GMP.
+func init() {
+    RegisterBackend("gmp", func(param string) (Backend, error) {
+        if param != "" {
+            return nil, fmt.Errorf("backend gmp takes no parameter")
+        }
+        return gmpFallbackBackend{}, nil
+    })
+}
+
+// gmpFallbackBackend is the rat backend under the gmp name
+type gmpFallbackBackend struct {
+    ratBackend
+}
+
+func (gmpFallbackBackend) Name() string { return "gmp (pure-Go fallback, build with -tags gmp)" }
@@ -0,0 +1,129 @@
+package poly
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+)
+
+// graeffe returns the Graeffe root-squaring transform of p: the polynomial
+// whose roots are the squares of the roots of p. Writing p(x) = e(x^2) + x*o(x^2),
+// it is (-1)^n * (e(y)^2 - y*o(y)^2), which keeps the sign of the leading coefficient.
+func (p *Polynomial) graeffe() *Polynomial {
+    n := p.Deg()
+    even := make([]*big.Rat, n/2+1)
+    odd := make([]*big.Rat, n/2+1)
+    for i := range even {
+        even[i], odd[i] = new(big.Rat), new(big.Rat)
+    }
+    for i := 0; i <= n; i++ {
+        if i%2 == 0 {
+            even[i/2].Set(p.coeff[i])
+        } else {
+            odd[i/2].Set(p.coeff[i])
+        }
+    }
+    e, o := NewPolyNoCopy(even), NewPolyNoCopy(odd)
+    y := X()
+
+    result := e.mul(e).Sub(y.mul(o.mul(o)))
+    coeffs := make([]*big.Rat, n+1)
+    for i := range coeffs {
+        coeffs[i] = new(big.Rat)
+        if i < len(result.coeff) {
+            coeffs[i].Set(result.coeff[i])
+        }
+        if n%2 == 1 {
+            coeffs[i].Neg(coeffs[i])
+        }
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// ratLog2 returns log2|r| for nonzero r without overflowing float64, however
+// many bits r has
+func ratLog2(r *big.Rat) float64 {
+    num := new(big.Float).SetInt(r.Num())
+    den := new(big.Float).SetInt(r.Denom())
+    numMant := new(big.Float)
+    denMant := new(big.Float)
+    numExp := num.MantExp(numMant)
+    denExp := den.MantExp(denMant)
+    nm, _ := numMant.Float64()
+    dm, _ := denMant.Float64()
+    return float64(numExp-denExp) + math.Log2(math.Abs(nm)) - math.Log2(dm)
+}
+
+// graeffeRootMagnitudes estimates the absolute values of the roots of p by
+// applying the Graeffe transform the given number of times. After k squarings
+// |r_i|^(2^k) is approximately |c_(n-i) / c_(n-i+1)|, so the estimates become
+// accurate once the root magnitudes are well separated. The result holds one
+// slice of estimates per iteration (the first one is iteration 1), largest
+// root first; roots at zero are reported as 0 and undetermined magnitudes as NaN.
+func graeffeRootMagnitudes(p *Polynomial, iterations int) [][]float64 {
+    n := p.Deg()
+    zeros := max(p.ValuationAtZero(), 0)
+    coeffs := make([]*big.Rat, n-zeros+1)
+    for i := range coeffs {
+        coeffs[i] = new(big.Rat).Set(p.coeff[i+zeros])
+    }
+    q := NewPolyNoCopy(coeffs)
+    m := q.Deg()
+
+    estimates := make([][]float64, 0, iterations)
+    for k := 1; k <= iterations; k++ {
+        q = q.graeffe()
+        row := make([]float64, 0, n)
+        for i := 1; i <= m; i++ {
+            lo, hi := q.coeff[m-i], q.coeff[m-i+1]
+            if lo.Sign() == 0 || hi.Sign() == 0 {
+                row = append(row, math.NaN())
+                continue
+            }
+            log2 := (ratLog2(lo) - ratLog2(hi)) / math.Pow(2, float64(k))
+            row = append(row, math.Pow(2, log2))
+        }
+        for i := 0; i < zeros; i++ {
+            row = append(row, 0)
+        }
+        estimates = append(estimates, row)
+    }
+    return estimates
+}
+
+// GraeffeMagnitudes returns the root magnitude estimates of f after each
+// Graeffe iteration, one row per iteration, and the plot of their
+// convergence. iterations must not be negative.
+func GraeffeMagnitudes(f *Polynomial, iterations int) ([][]float64, *Figure, error) {
+    if f == nil {
+        return nil, nil, ErrNilPolynomial
+    }
+    if iterations < 0 {
+        return nil, nil, fmt.Errorf("graeffe: negative number of iterations %d", iterations)
+    }
+    estimates := graeffeRootMagnitudes(f, iterations)
+
+    fig := &Figure{
+        Title:  "Graeffe root magnitude estimates",
+        XLabel: "Iteration",
+        YLabel: "|root|",
+        Width:  6,
+        Height: 4,
+    }
+    n := 0
+    if len(estimates) > 0 {
+        n = len(estimates[0])
+    }
+    for i := 0; i < n; i++ {
+        points := make([]Point, 0, len(estimates))
+        for k, row := range estimates {
+            if !math.IsNaN(row[i]) && !math.IsInf(row[i], 0) {
+                points = append(points, Point{X: float64(k + 1), Y: row[i]})
+            }
+        }
+        if len(points) > 0 {
+            fig.Series = append(fig.Series, Series{Label: fmt.Sprintf("root %d", i+1), Points: points, Markers: true})
+        }
+    }
+    return estimates, fig, nil
+}
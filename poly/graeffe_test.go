@@ -0,0 +1,33 @@
+package poly
+
+import (
+    "errors"
+    "math"
+    "math/big"
+    "testing"
+)
+
+func TestGraeffeMagnitudes(t *testing.T) {
+    // (x - 2)(x - 1/2): the estimates approach 2 and 1/2
+    f := NewPolynomial([]*big.Rat{big.NewRat(1, 1), big.NewRat(-5, 2), big.NewRat(1, 1)})
+    estimates, fig, err := GraeffeMagnitudes(f, 6)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(estimates) != 6 || len(fig.Series) != 2 {
+        t.Fatalf("%d rows and %d series, want 6 and 2", len(estimates), len(fig.Series))
+    }
+    last := estimates[len(estimates)-1]
+    if math.Abs(last[0]-2) > 1e-6 || math.Abs(last[1]-0.5) > 1e-6 {
+        t.Errorf("estimates %v after 6 iterations, want about [2 0.5]", last)
+    }
+    if estimates, _, err := GraeffeMagnitudes(f, 0); err != nil || len(estimates) != 0 {
+        t.Errorf("0 iterations: %d rows, error %v; want no rows", len(estimates), err)
+    }
+    if _, _, err := GraeffeMagnitudes(f, -1); err == nil {
+        t.Error("-1 iterations: no error")
+    }
+    if _, _, err := GraeffeMagnitudes(nil, 3); !errors.Is(err, ErrNilPolynomial) {
+        t.Errorf("nil f: error %v, want ErrNilPolynomial", err)
+    }
+}
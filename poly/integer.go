@@ -0,0 +1,20 @@
+package poly
+
+import (
+    "math/big"
+
+    "euclid/intring"
+)
+
+// ExtendedGCDInt returns the greatest common divisor of a and b, which is
+// never negative, and Bézout coefficients s and t with s*a + t*b = gcd; it
+// is intring.ExtendedGCD, kept here next to the polynomial version
+func ExtendedGCDInt(a, b *big.Int) (gcd, s, t *big.Int, err error) {
+    return intring.ExtendedGCD(a, b)
+}
+
+// VerifyBezoutInt is intring.VerifyBezout: it checks that gcd is a common
+// divisor of a and b that is not negative and that s*a + t*b = gcd
+func VerifyBezoutInt(a, b, gcd, s, t *big.Int) error {
+    return intring.VerifyBezout(a, b, gcd, s, t)
+}
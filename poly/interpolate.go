@@ -0,0 +1,118 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// checkInterpolationPoints checks that xs and ys have the same nonzero
+// length, no nil entries and distinct xs
+func checkInterpolationPoints(xs, ys []*big.Rat) error {
+    if len(xs) != len(ys) {
+        return fmt.Errorf("interpolate: %d x values and %d y values", len(xs), len(ys))
+    }
+    if len(xs) == 0 {
+        return fmt.Errorf("interpolate: no points")
+    }
+    seen := make(map[string]int, len(xs))
+    for i := range xs {
+        if xs[i] == nil || ys[i] == nil {
+            return fmt.Errorf("interpolate: point %d: %w", i+1, ErrNilPolynomial)
+        }
+        key := xs[i].RatString()
+        if j, ok := seen[key]; ok {
+            return fmt.Errorf("interpolate: points %d and %d have the same x = %s", j+1, i+1, key)
+        }
+        seen[key] = i
+    }
+    return nil
+}
+
+// DividedDifferences returns Newton's divided differences of the points
+// (xs[i], ys[i]), the coefficients of the Newton form
+//
+//  d[0] + d[1](x - xs[0]) + ... + d[n-1](x - xs[0])...(x - xs[n-2])
+//
+// of the interpolating polynomial. The xs must be distinct.
+func DividedDifferences(xs, ys []*big.Rat) ([]*big.Rat, error) {
+    if err := checkInterpolationPoints(xs, ys); err != nil {
+        return nil, err
+    }
+    return dividedDifferences(xs, ys), nil
+}
+
+// dividedDifferences is DividedDifferences for valid points
+func dividedDifferences(xs, ys []*big.Rat) []*big.Rat {
+    n := len(xs)
+    diff := make([]*big.Rat, n)
+    for i := range ys {
+        diff[i] = new(big.Rat).Set(ys[i])
+    }
+    for level := 1; level < n; level++ {
+        for i := n - 1; i >= level; i-- {
+            num := new(big.Rat).Sub(diff[i], diff[i-1])
+            den := new(big.Rat).Sub(xs[i], xs[i-level])
+            diff[i] = num.Quo(num, den)
+        }
+    }
+    return diff
+}
+
+// InterpolateNewton returns the unique polynomial of degree below len(xs)
+// through the points (xs[i], ys[i]), built from Newton's divided
+// differences; adding a point only adds a divided difference and a term.
+// The xs must be distinct.
+func InterpolateNewton(xs, ys []*big.Rat) (*Polynomial, error) {
+    if err := checkInterpolationPoints(xs, ys); err != nil {
+        return nil, err
+    }
+    return interpolate(xs, ys), nil
+}
+
+// interpolate is InterpolateNewton for valid points
+func interpolate(xs, ys []*big.Rat) *Polynomial {
+    diff := dividedDifferences(xs, ys)
+
+    // Horner-like evaluation of the Newton form
+    // diff[0] + (x - x0)(diff[1] + (x - x1)(diff[2] + ...))
+    p := Zero()
+    for i := len(xs) - 1; i >= 0; i-- {
+        linear := X().Sub(Constant(xs[i]))
+        p = p.mul(linear).Add(Constant(diff[i]))
+    }
+    return p
+}
+
+// InterpolateLagrange returns the same polynomial as InterpolateNewton from
+// the Lagrange form: the sum of ys[i] * L_i, where L_i is 1 at xs[i] and 0
+// at the other xs. With M = (x - xs[0])...(x - xs[n-1]), L_i is
+// M/(x - xs[i]) divided by its value at xs[i], so one product and a
+// synthetic division per point give every basis polynomial.
+func InterpolateLagrange(xs, ys []*big.Rat) (*Polynomial, error) {
+    if err := checkInterpolationPoints(xs, ys); err != nil {
+        return nil, err
+    }
+    n := len(xs)
+    m := One()
+    for _, x := range xs {
+        m = m.mul(X().Sub(Constant(x)))
+    }
+    sum := make([]*big.Rat, n)
+    for k := range sum {
+        sum[k] = new(big.Rat)
+    }
+    for i, xi := range xs {
+        // basis = M/(x - xi) by synthetic division, highest degree first
+        basis := make([]*big.Rat, n)
+        carry := new(big.Rat)
+        for k := n; k >= 1; k-- {
+            carry = new(big.Rat).Add(m.coeff[k], new(big.Rat).Mul(carry, xi))
+            basis[k-1] = carry
+        }
+        w := new(big.Rat).Quo(ys[i], NewPolyNoCopy(basis).Eval(xi))
+        for k := range sum {
+            sum[k].Add(sum[k], new(big.Rat).Mul(basis[k], w))
+        }
+    }
+    return NewPolyNoCopy(sum).trim(), nil
+}
@@ -0,0 +1,187 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "strings"
+)
+
+// Interval is a closed interval [lo, hi] of rationals. Arithmetic on
+// intervals encloses every result of the same operation on their points, so
+// uncertainty in the coefficients is carried through exactly.
+type Interval struct {
+    lo, hi *big.Rat
+}
+
+// pointInterval returns the degenerate interval [r, r]
+func pointInterval(r *big.Rat) Interval {
+    return Interval{new(big.Rat).Set(r), new(big.Rat).Set(r)}
+}
+
+// ball returns the interval [c - r, c + r]
+func ball(c, r *big.Rat) Interval {
+    return Interval{new(big.Rat).Sub(c, r), new(big.Rat).Add(c, r)}
+}
+
+func (a Interval) add(b Interval) Interval {
+    return Interval{new(big.Rat).Add(a.lo, b.lo), new(big.Rat).Add(a.hi, b.hi)}
+}
+
+func (a Interval) sub(b Interval) Interval {
+    return Interval{new(big.Rat).Sub(a.lo, b.hi), new(big.Rat).Sub(a.hi, b.lo)}
+}
+
+// mul returns the smallest interval holding the products of the endpoints
+func (a Interval) mul(b Interval) Interval {
+    products := []*big.Rat{
+        new(big.Rat).Mul(a.lo, b.lo), new(big.Rat).Mul(a.lo, b.hi),
+        new(big.Rat).Mul(a.hi, b.lo), new(big.Rat).Mul(a.hi, b.hi),
+    }
+    lo, hi := products[0], products[0]
+    for _, p := range products[1:] {
+        if p.Cmp(lo) < 0 {
+            lo = p
+        }
+        if p.Cmp(hi) > 0 {
+            hi = p
+        }
+    }
+    return Interval{lo, hi}
+}
+
+// Width returns hi - lo
+func (a Interval) Width() *big.Rat {
+    return new(big.Rat).Sub(a.hi, a.lo)
+}
+
+// ContainsZero reports whether lo <= 0 <= hi
+func (a Interval) ContainsZero() bool {
+    return a.lo.Sign() <= 0 && a.hi.Sign() >= 0
+}
+
+// roundOutward widens a to endpoints that are multiples of 2^-bits, rounding
+// lo down and hi up. Exact interval arithmetic lets the endpoints grow without
+// bound; rounding outward keeps them short and the enclosure rigorous.
+func (a Interval) roundOutward(bits int) Interval {
+    hi := roundDyadic(new(big.Rat).Neg(a.hi), bits)
+    return Interval{roundDyadic(a.lo, bits), hi.Neg(hi)}
+}
+
+func (a Interval) String() string {
+    if a.lo.Cmp(a.hi) == 0 {
+        return "[" + a.lo.RatString() + "]"
+    }
+    return "[" + a.lo.RatString() + ", " + a.hi.RatString() + "]"
+}
+
+// intervalPoly is a polynomial with interval coefficients, lowest degree
+// first. Every polynomial whose coefficients lie in the intervals is
+// enclosed by it.
+type intervalPoly struct {
+    coeff []Interval
+}
+
+// toIntervals returns p with every coefficient widened to the ball of the
+// given radius around it
+func (p *Polynomial) toIntervals(radius *big.Rat) *intervalPoly {
+    coeffs := make([]Interval, p.Deg()+1)
+    for i := range coeffs {
+        coeffs[i] = ball(p.coeff[i], radius)
+    }
+    return &intervalPoly{coeffs}
+}
+
+func (p *intervalPoly) add(q *intervalPoly) *intervalPoly {
+    n := max(len(p.coeff), len(q.coeff))
+    coeffs := make([]Interval, n)
+    zero := pointInterval(new(big.Rat))
+    for i := range coeffs {
+        a, b := zero, zero
+        if i < len(p.coeff) {
+            a = p.coeff[i]
+        }
+        if i < len(q.coeff) {
+            b = q.coeff[i]
+        }
+        coeffs[i] = a.add(b)
+    }
+    return &intervalPoly{coeffs}
+}
+
+func (p *intervalPoly) mul(q *intervalPoly) *intervalPoly {
+    if len(p.coeff) == 0 || len(q.coeff) == 0 {
+        return &intervalPoly{}
+    }
+    coeffs := make([]Interval, len(p.coeff)+len(q.coeff)-1)
+    for i := range coeffs {
+        coeffs[i] = pointInterval(new(big.Rat))
+    }
+    for i, a := range p.coeff {
+        for j, b := range q.coeff {
+            coeffs[i+j] = coeffs[i+j].add(a.mul(b))
+        }
+    }
+    return &intervalPoly{coeffs}
+}
+
+// eval encloses the values of p on x with Horner's scheme. If bits is
+// positive, every intermediate result is rounded outward to 2^-bits.
+func (p *intervalPoly) eval(x Interval, bits int) Interval {
+    result := pointInterval(new(big.Rat))
+    for i := len(p.coeff) - 1; i >= 0; i-- {
+        result = result.mul(x).add(p.coeff[i])
+        if bits > 0 {
+            result = result.roundOutward(bits)
+        }
+    }
+    return result
+}
+
+func (p *intervalPoly) String() string {
+    parts := make([]string, 0, len(p.coeff))
+    for i := len(p.coeff) - 1; i >= 0; i-- {
+        switch i {
+        case 0:
+            parts = append(parts, p.coeff[i].String())
+        case 1:
+            parts = append(parts, p.coeff[i].String()+"*x")
+        default:
+            parts = append(parts, fmt.Sprintf("%s*x^%d", p.coeff[i], i))
+        }
+    }
+    return strings.Join(parts, " + ")
+}
+
+// ParseInterval reads an interval "lo:hi" or a single rational
+func ParseInterval(s string) (Interval, error) {
+    ends := strings.SplitN(s, ":", 2)
+    lo, ok := new(big.Rat).SetString(strings.TrimSpace(ends[0]))
+    if !ok {
+        return Interval{}, fmt.Errorf("invalid interval %q", s)
+    }
+    if len(ends) == 1 {
+        return pointInterval(lo), nil
+    }
+    hi, ok := new(big.Rat).SetString(strings.TrimSpace(ends[1]))
+    if !ok || hi.Cmp(lo) < 0 {
+        return Interval{}, fmt.Errorf("invalid interval %q", s)
+    }
+    return Interval{lo, hi}, nil
+}
+
+// IntervalEnclosure is the enclosure of a family of polynomials on an
+// interval made by EncloseInterval: the family as a polynomial with interval
+// coefficients, and its values on the interval enclosed exactly and with
+// outward rounding
+type IntervalEnclosure struct {
+    Family         fmt.Stringer
+    Exact, Rounded Interval
+}
+
+// EncloseInterval widens the coefficients of f by ±radius and encloses the
+// family on x, both exactly and with outward rounding to 2^-bits. If the
+// rounded enclosure excludes 0, no polynomial in the family has a root in x.
+func EncloseInterval(f *Polynomial, radius *big.Rat, x Interval, bits int) IntervalEnclosure {
+    p := f.toIntervals(radius)
+    return IntervalEnclosure{p, p.eval(x, 0), p.eval(x, bits)}
+}
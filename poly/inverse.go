@@ -0,0 +1,41 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+
+    "euclid/polymod"
+)
+
+// ErrNotInvertible is polymod.ErrNotInvertible, wrapped by Inverse when f
+// and m have a common factor
+var ErrNotInvertible = polymod.ErrNotInvertible
+
+// Inverse returns the inverse of f in the ring Q[x]/(m), the polynomial g of
+// degree below deg m with f*g = 1 mod m, the rational counterpart of
+// polymod.InverseMod. The extended Euclidean algorithm gives s*f + t*m = d;
+// f is invertible exactly when d is a nonzero constant, and then s/d
+// reduced modulo m is the inverse. Otherwise the error wraps ErrNotInvertible and
+// names the common factor. opts select the GCD algorithm as in
+// ExtendedGCDResult.
+func Inverse(f, m *Polynomial, opts ...Option) (*Polynomial, error) {
+    if f == nil || m == nil {
+        return nil, ErrNilPolynomial
+    }
+    if err := newConfig(opts...).checkGCD("Q"); err != nil {
+        return nil, err
+    }
+    if m.Deg() < 1 {
+        return nil, fmt.Errorf("inverse: modulus %s has no positive degree", m)
+    }
+    _, r := f.div(m)
+    if r.IsZero() {
+        return nil, fmt.Errorf("inverse: f is 0 modulo %s (%w)", m, ErrNotInvertible)
+    }
+    res := extendedGCDResult(r, m, opts...)
+    if res.GCD.Deg() > 0 {
+        return nil, fmt.Errorf("inverse: f and m have the common factor %s (%w)", res.GCD.monic(), ErrNotInvertible)
+    }
+    _, s := res.S.scale(new(big.Rat).Inv(res.GCD.coeff[0])).div(m)
+    return s.trim(), nil
+}
@@ -0,0 +1,107 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// rootInterval is an isolating interval [lo, hi] of one real root;
+// lo == hi when the root is rational and was hit exactly
+type rootInterval struct {
+    lo, hi *big.Rat
+}
+
+// scaleShift returns p(a + w*x), computed with Horner's scheme
+func (p *Polynomial) scaleShift(a, w *big.Rat) *Polynomial {
+    linear := NewPolynomial([]*big.Rat{a, w})
+    q := Zero()
+    for i := p.Deg(); i >= 0; i-- {
+        q = q.mul(linear).Add(Constant(p.coeff[i]))
+    }
+    return q
+}
+
+// squarefreePart returns p / gcd(p, p'), which has the same roots as p,
+// each of multiplicity one
+func (p *Polynomial) squarefreePart() *Polynomial {
+    d := p.Derivative()
+    if d.IsZero() {
+        return p
+    }
+    gcd := extendedGCDResult(p, d).GCD
+    if gcd.Deg() == 0 {
+        return p
+    }
+    q, _ := p.div(gcd)
+    return q
+}
+
+// signVariations counts the sign changes in cs, ignoring zeros
+func signVariations(cs []*big.Rat) int {
+    count, last := 0, 0
+    for _, c := range cs {
+        s := c.Sign()
+        if s == 0 {
+            continue
+        }
+        if last != 0 && s != last {
+            count++
+        }
+        last = s
+    }
+    return count
+}
+
+// isolateRealRoots returns disjoint intervals inside [a, b], in increasing
+// order, each containing exactly one distinct real root of p. It subdivides
+// [a, b] and bounds the number of roots of each piece by the sign variations
+// of its Bernstein coefficients (Descartes' rule of signs in the Bernstein basis).
+func isolateRealRoots(p *Polynomial, a, b *big.Rat) []rootInterval {
+    if p.Deg() < 1 {
+        return nil
+    }
+    sqf := p.squarefreePart()
+    n := sqf.Deg()
+    half := big.NewRat(1, 2)
+
+    var roots []rootInterval
+    var split func(lo, hi *big.Rat, depth int)
+    split = func(lo, hi *big.Rat, depth int) {
+        width := new(big.Rat).Sub(hi, lo)
+        v := signVariations(sqf.scaleShift(lo, width).toBernstein(n))
+        if v == 0 {
+            return
+        }
+        if v == 1 || depth > 4096 {
+            roots = append(roots, rootInterval{lo, hi})
+            return
+        }
+        mid := new(big.Rat).Add(lo, hi)
+        mid.Mul(mid, half)
+        split(lo, mid, depth+1)
+        if sqf.Eval(mid).Sign() == 0 {
+            roots = append(roots, rootInterval{mid, mid})
+        }
+        split(mid, hi, depth+1)
+    }
+
+    if sqf.Eval(a).Sign() == 0 {
+        roots = append(roots, rootInterval{a, a})
+    }
+    if a.Cmp(b) < 0 {
+        split(a, b, 0)
+        if sqf.Eval(b).Sign() == 0 {
+            roots = append(roots, rootInterval{b, b})
+        }
+    }
+    return roots
+}
+
+// refineRoot narrows the isolating interval of a root of p until it is
+// narrower than 2^-bits and returns the root to that accuracy, using Newton's
+// method safeguarded by bisection
+func refineRoot(p *Polynomial, iv rootInterval, bits int) *big.Rat {
+    if iv.lo.Cmp(iv.hi) == 0 {
+        return iv.lo
+    }
+    return newtonRoot(p.squarefreePart(), iv.lo, iv.hi, bits)
+}
@@ -0,0 +1,216 @@
+package poly
+
+import (
+    "fmt"
+    "math"
+    "math/big"
+    "math/rand"
+
+    "euclid/internal/benchtime"
+)
+
+// kroneckerMinDegree is the smallest degree of the smaller factor for which
+// mul switches from the schoolbook algorithm to Kronecker substitution. MulBench
+// puts the crossover around degree 4 for both integer and rational
+// coefficients; below 8 the gain is within noise.
+const kroneckerMinDegree = 8
+
+// mulStrategies names the multiplication algorithms mulWith accepts
+var mulStrategies = []string{"auto", "naive", "karatsuba", "kronecker", "ntt"}
+
+// IsMulStrategy reports whether name is one of mulStrategies
+func IsMulStrategy(name string) bool {
+    for _, s := range mulStrategies {
+        if s == name {
+            return true
+        }
+    }
+    return false
+}
+
+// Mul multiplies p and q with the algorithm chosen by WithStrategy; an
+// unknown strategy leaves the default, "auto" (see CheckOptions)
+func (p *Polynomial) Mul(q *Polynomial, opts ...Option) *Polynomial {
+    return p.mulWith(q, newConfig(opts...).strategy)
+}
+
+// mulWith multiplies p and q with the named strategy, one of mulStrategies;
+// "auto" picks one by degree
+func (p *Polynomial) mulWith(q *Polynomial, strategy string) *Polynomial {
+    switch strategy {
+    case "naive":
+        return p.mulNaive(q)
+    case "karatsuba":
+        return p.mulKaratsuba(q)
+    case "kronecker":
+        return p.mulKronecker(q)
+    case "ntt":
+        return p.mulNTT(q)
+    }
+    switch n := min(p.Deg(), q.Deg()); {
+    case n >= nttMinDegree:
+        return p.mulNTT(q)
+    case n >= kroneckerMinDegree:
+        return p.mulKronecker(q)
+    }
+    return p.mulNaive(q)
+}
+
+// mulKronecker multiplies p and q by Kronecker substitution: after clearing
+// denominators, the integer polynomials are evaluated at x = 2^k, the two
+// integers are multiplied with big.Int (Karatsuba and better inside
+// math/big), and the coefficients of the product are read back from k-bit
+// slices of the result. k is chosen so that no coefficient of the product
+// overflows its slice, with one bit to spare for the sign.
+func (p *Polynomial) mulKronecker(q *Polynomial) *Polynomial {
+    if p.IsZero() || q.IsZero() {
+        return Zero()
+    }
+    a, b := p.integerCoeffs(), q.integerCoeffs()
+    // the scales that integerCoeffs multiplied p and q by
+    scale := new(big.Rat).Quo(new(big.Rat).SetInt(a[len(a)-1]), p.coeff[p.Deg()])
+    scale.Mul(scale, new(big.Rat).Quo(new(big.Rat).SetInt(b[len(b)-1]), q.coeff[q.Deg()]))
+
+    k := maxBitLen(a) + maxBitLen(b) + bitLen(min(len(a), len(b))) + 2
+    prod := new(big.Int).Mul(kroneckerPack(a, k), kroneckerPack(b, k))
+    coeffs := kroneckerUnpack(prod, k, len(a)+len(b)-1)
+
+    result := make([]*big.Rat, len(coeffs))
+    for i, c := range coeffs {
+        result[i] = new(big.Rat).SetInt(c)
+        result[i].Quo(result[i], scale)
+    }
+    return NewPolyNoCopy(result)
+}
+
+func maxBitLen(xs []*big.Int) int {
+    n := 0
+    for _, x := range xs {
+        n = max(n, x.BitLen())
+    }
+    return n
+}
+
+func bitLen(n int) int {
+    return big.NewInt(int64(n)).BitLen()
+}
+
+// kroneckerPack returns sum c_i 2^(k*i)
+func kroneckerPack(cs []*big.Int, k int) *big.Int {
+    v := new(big.Int)
+    for i := len(cs) - 1; i >= 0; i-- {
+        v.Lsh(v, uint(k))
+        v.Add(v, cs[i])
+    }
+    return v
+}
+
+// kroneckerUnpack reads n signed coefficients with |c| < 2^(k-1) back from
+// v = sum c_i 2^(k*i), lowest first: each k-bit slice above 2^(k-1) stands
+// for a negative coefficient and borrows one from the next slice
+func kroneckerUnpack(v *big.Int, k, n int) []*big.Int {
+    v = new(big.Int).Set(v)
+    mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(k)), big.NewInt(1))
+    half := new(big.Int).Lsh(big.NewInt(1), uint(k-1))
+    full := new(big.Int).Lsh(big.NewInt(1), uint(k))
+    cs := make([]*big.Int, n)
+    for i := range cs {
+        // v & mask works on the two's complement of negative v as well
+        c := new(big.Int).And(v, mask)
+        if c.Cmp(half) >= 0 {
+            c.Sub(c, full)
+        }
+        cs[i] = c
+        v.Sub(v, c)
+        v.Rsh(v, uint(k))
+    }
+    return cs
+}
+
+// mulBenchNaiveMaxDegree is the largest degree at which MulBench still
+// times the schoolbook product
+const mulBenchNaiveMaxDegree = 512
+
+// MulBenchRow holds the ns/op of the multiplication strategies at one
+// degree, +Inf for a strategy not timed at that degree
+type MulBenchRow struct {
+    Degree int
+    Ns     []float64
+}
+
+// MulBenchResult is one table of MulBench: the rows for the strategies
+// except "auto" on coefficients with denominators up to MaxDen, and the
+// thresholds "auto" uses
+type MulBenchResult struct {
+    MaxDen     int64
+    Strategies []string
+    Rows       []MulBenchRow
+    // Crossover[i] is the degree from which on Strategies[i+1] beats
+    // Strategies[i] at every degree measured, or 0 if it does not
+    Crossover                  []int
+    KroneckerMinDeg, NTTMinDeg int
+}
+
+// MulBench times the multiplication strategies (schoolbook, Karatsuba,
+// Kronecker substitution and number-theoretic transforms) against each other
+// on random polynomials of growing degree, with integer coefficients and
+// with rational coefficients of independent denominators (the worst case
+// for clearing denominators), and finds the degrees from which each
+// strategy beats the previous one
+func MulBench() ([]MulBenchResult, error) {
+    var results []MulBenchResult
+    for _, den := range []int64{1, 1 << 10} {
+        res, err := mulBenchCoefficients(den)
+        if err != nil {
+            return results, err
+        }
+        results = append(results, res)
+    }
+    return results, nil
+}
+
+// mulBenchCoefficients runs MulBench on coefficients with random
+// denominators up to maxDen
+func mulBenchCoefficients(maxDen int64) (MulBenchResult, error) {
+    strategies := mulStrategies[1:]
+    res := MulBenchResult{
+        MaxDen:          maxDen,
+        Strategies:      strategies,
+        Crossover:       make([]int, len(strategies)-1),
+        KroneckerMinDeg: kroneckerMinDegree,
+        NTTMinDeg:       nttMinDegree,
+    }
+    for _, n := range []int{4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096} {
+        rng := rand.New(rand.NewSource(int64(n)))
+        randomPoly := func() *Polynomial {
+            coeffs := make([]*big.Rat, n+1)
+            for i := range coeffs {
+                coeffs[i] = big.NewRat(rng.Int63n(1<<20)-1<<19, rng.Int63n(maxDen)+1)
+            }
+            coeffs[n].SetInt64(1)
+            return NewPolyNoCopy(coeffs)
+        }
+        p, q := randomPoly(), randomPoly()
+        want := p.mulKronecker(q)
+        ns := make([]float64, len(strategies))
+        for i, strategy := range strategies {
+            if strategy == "naive" && n > mulBenchNaiveMaxDegree {
+                ns[i] = math.Inf(1)
+                continue
+            }
+            if !p.mulWith(q, strategy).Equal(want) {
+                return res, fmt.Errorf("%s multiplication disagrees with Kronecker substitution at degree %d", strategy, n)
+            }
+            ns[i] = benchtime.Ns(func() { p.mulWith(q, strategy) })
+        }
+        for i := range res.Crossover {
+            if ns[i+1] >= ns[i] {
+                res.Crossover[i] = 0
+            } else if res.Crossover[i] == 0 {
+                res.Crossover[i] = n
+            }
+        }
+        res.Rows = append(res.Rows, MulBenchRow{n, ns})
+    }
+    return res, nil
+}
@@ -0,0 +1,332 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "strings"
+)
+
+// termString formats |c|*x^power the way String does, but with integer
+// coefficients written without the "/1" denominator
+func termString(c *big.Rat, power int) string {
+    var b strings.Builder
+    a := absRat(c)
+    if a.Cmp(big.NewRat(1, 1)) != 0 || power == 0 {
+        b.WriteString(a.RatString())
+        if power > 0 {
+            b.WriteString("*")
+        }
+    }
+    if power > 0 {
+        b.WriteString("x")
+        if power > 1 {
+            b.WriteString("^" + fmt.Sprint(power))
+        }
+    }
+    return b.String()
+}
+
+// layoutRow is one row of a long-division layout, indexed by power of x;
+// nil entries are left blank
+type layoutRow []*big.Rat
+
+// cell formats the entry for x^power, signed, with the sign of the first
+// entry of the row left out when positive
+func (r layoutRow) cell(power int) string {
+    c := r[power]
+    if c == nil {
+        return ""
+    }
+    first := true
+    for i := len(r) - 1; i > power; i-- {
+        if r[i] != nil {
+            first = false
+            break
+        }
+    }
+    switch {
+    case c.Sign() < 0:
+        return "- " + termString(c, power)
+    case first:
+        return termString(c, power)
+    default:
+        return "+ " + termString(c, power)
+    }
+}
+
+// layoutPolyString formats p with integer coefficients written without "/1"
+func layoutPolyString(p *Polynomial) string {
+    if p.IsZero() {
+        return "0"
+    }
+    row := make(layoutRow, p.Deg()+1)
+    for i := range row {
+        if p.coeff[i].Sign() != 0 {
+            row[i] = p.coeff[i]
+        }
+    }
+    var parts []string
+    for i := len(row) - 1; i >= 0; i-- {
+        if s := row.cell(i); s != "" {
+            parts = append(parts, s)
+        }
+    }
+    return strings.Join(parts, " ")
+}
+
+// divisionStep is one subtraction of the long division: the subtracted
+// multiple of the divisor, covering powers hi down to lo, and the partial
+// remainder left after it
+type divisionStep struct {
+    hi, lo    int
+    subtract  layoutRow
+    remainder layoutRow
+}
+
+// longDivisionSteps redoes the division of p by q, recording every subtraction.
+// The returned quotient row is indexed by the powers of the quotient.
+func longDivisionSteps(p, q *Polynomial) (layoutRow, []divisionStep) {
+    pDeg, qDeg := p.Deg(), q.Deg()
+    quotient := make(layoutRow, max(pDeg-qDeg, 0)+1)
+    rem := make([]*big.Rat, pDeg+1)
+    for i := range rem {
+        rem[i] = new(big.Rat).Set(p.coeff[i])
+    }
+
+    var steps []divisionStep
+    for d := pDeg; d >= qDeg; d-- {
+        if rem[d].Sign() == 0 {
+            continue
+        }
+        lead := new(big.Rat).Quo(rem[d], q.coeff[qDeg])
+        quotient[d-qDeg] = lead
+
+        step := divisionStep{hi: d, lo: d - qDeg, subtract: make(layoutRow, pDeg+1), remainder: make(layoutRow, pDeg+1)}
+        for i := 0; i <= qDeg; i++ {
+            temp := new(big.Rat).Mul(lead, q.coeff[i])
+            rem[d-qDeg+i].Sub(rem[d-qDeg+i], temp)
+            if temp.Sign() != 0 {
+                step.subtract[d-qDeg+i] = temp
+            }
+        }
+        for i := d - 1; i >= 0; i-- {
+            if rem[i].Sign() != 0 {
+                step.remainder[i] = new(big.Rat).Set(rem[i])
+            }
+        }
+        steps = append(steps, step)
+    }
+    return quotient, steps
+}
+
+// LongDivision renders the division of p by q in the traditional
+// long-division layout: quotient on top, divisor on the left and the
+// subtracted rows below the dividend
+func LongDivision(p, q *Polynomial) (string, error) {
+    if q.IsZero() {
+        return "", ErrDivisionByZero
+    }
+    return longDivision(p, q), nil
+}
+
+// longDivision renders the layout of LongDivision for a nonzero q
+func longDivision(p, q *Polynomial) string {
+    if p.IsZero() {
+        p = Zero()
+    }
+
+    pDeg, qDeg := p.Deg(), q.Deg()
+    quotient, steps := longDivisionSteps(p, q)
+
+    dividend := make(layoutRow, pDeg+1)
+    for i := range dividend {
+        if p.coeff[i].Sign() != 0 {
+            dividend[i] = p.coeff[i]
+        }
+    }
+
+    // Each row is given as the text of its cell in the column of x^i.
+    // The quotient term for x^k is written above the dividend column x^(k+deg q).
+    top := func(i int) string {
+        if i < qDeg || len(steps) == 0 {
+            return ""
+        }
+        return quotient.cell(i - qDeg)
+    }
+    rows := []func(int) string{top, dividend.cell}
+    for _, st := range steps {
+        rows = append(rows, st.subtract.cell, st.remainder.cell)
+    }
+
+    // Column widths and offsets, highest power first
+    width := make([]int, pDeg+1)
+    width[0] = 1
+    for _, row := range rows {
+        for i := range width {
+            if n := len(row(i)); n > width[i] {
+                width[i] = n
+            }
+        }
+    }
+    offset := make([]int, pDeg+1)
+    pos := 0
+    for i := pDeg; i >= 0; i-- {
+        offset[i] = pos
+        pos += width[i] + 1
+    }
+
+    render := func(row func(int) string) string {
+        var b strings.Builder
+        empty := true
+        for i := pDeg; i >= 0; i-- {
+            if i < pDeg {
+                b.WriteString(" ")
+            }
+            s := row(i)
+            if s != "" {
+                empty = false
+            }
+            b.WriteString(fmt.Sprintf("%*s", width[i], s))
+        }
+        if empty {
+            // an empty row is a zero remainder (or quotient)
+            return fmt.Sprintf("%*s", offset[0]+width[0], "0")
+        }
+        return strings.TrimRight(b.String(), " ")
+    }
+    underline := func(hi, lo int) string {
+        return strings.Repeat(" ", offset[hi]) + strings.Repeat("-", offset[lo]+width[lo]-offset[hi])
+    }
+
+    label := layoutPolyString(q) + " ) "
+    indent := strings.Repeat(" ", len(label))
+
+    var b strings.Builder
+    b.WriteString(indent + render(top) + "\n")
+    b.WriteString(indent + strings.Repeat("-", pos-1) + "\n")
+    b.WriteString(label + render(dividend.cell) + "\n")
+    for _, st := range steps {
+        b.WriteString(indent + render(st.subtract.cell) + "\n")
+        b.WriteString(indent + underline(st.hi, st.lo) + "\n")
+        b.WriteString(indent + render(st.remainder.cell) + "\n")
+    }
+    return b.String()
+}
+
+// latexTerm formats |c|*variable^power for LaTeX, with fractions as \frac
+func latexTerm(c *big.Rat, power int, variable string) string {
+    var b strings.Builder
+    a := absRat(c)
+    if a.Cmp(big.NewRat(1, 1)) != 0 || power == 0 {
+        if a.IsInt() {
+            b.WriteString(a.Num().String())
+        } else {
+            b.WriteString(fmt.Sprintf("\\frac{%s}{%s}", a.Num(), a.Denom()))
+        }
+    }
+    if power > 0 {
+        b.WriteString(variable)
+        if power > 1 {
+            b.WriteString(fmt.Sprintf("^{%d}", power))
+        }
+    }
+    return b.String()
+}
+
+// latexCell is the LaTeX counterpart of cell
+func (r layoutRow) latexCell(power int) string {
+    s := r.cell(power)
+    if s == "" {
+        return ""
+    }
+    switch s[0] {
+    case '-':
+        return "-" + latexTerm(r[power], power, "x")
+    case '+':
+        return "+" + latexTerm(r[power], power, "x")
+    default:
+        return latexTerm(r[power], power, "x")
+    }
+}
+
+// latexPolyString formats p for LaTeX
+func latexPolyString(p *Polynomial) string {
+    if p.IsZero() {
+        return "0"
+    }
+    row := make(layoutRow, p.Deg()+1)
+    for i := range row {
+        if p.coeff[i].Sign() != 0 {
+            row[i] = p.coeff[i]
+        }
+    }
+    var b strings.Builder
+    for i := len(row) - 1; i >= 0; i-- {
+        b.WriteString(row.latexCell(i))
+    }
+    return b.String()
+}
+
+// LongDivisionLaTeX renders the division of p by q in the long-division
+// layout as a LaTeX array, one column per power of x
+func LongDivisionLaTeX(p, q *Polynomial) (string, error) {
+    if q.IsZero() {
+        return "", ErrDivisionByZero
+    }
+    return longDivisionLaTeX(p, q), nil
+}
+
+// longDivisionLaTeX renders the layout of LongDivisionLaTeX for a nonzero q
+func longDivisionLaTeX(p, q *Polynomial) string {
+    if p.IsZero() {
+        p = Zero()
+    }
+
+    pDeg, qDeg := p.Deg(), q.Deg()
+    quotient, steps := longDivisionSteps(p, q)
+
+    dividend := make(layoutRow, pDeg+1)
+    for i := range dividend {
+        if p.coeff[i].Sign() != 0 {
+            dividend[i] = p.coeff[i]
+        }
+    }
+
+    // column of x^i in the array; column 1 holds the divisor
+    column := func(i int) int {
+        return 2 + pDeg - i
+    }
+    render := func(row func(int) string) string {
+        var b strings.Builder
+        empty := true
+        for i := pDeg; i >= 0; i-- {
+            s := row(i)
+            if s != "" {
+                empty = false
+            }
+            if i == 0 && empty {
+                s = "0"
+            }
+            b.WriteString(" & " + s)
+        }
+        return b.String() + " \\\\\n"
+    }
+
+    var b strings.Builder
+    b.WriteString("\\begin{array}{r" + strings.Repeat("r", pDeg+1) + "}\n")
+    b.WriteString(render(func(i int) string {
+        if i < qDeg || len(steps) == 0 {
+            return ""
+        }
+        return quotient.latexCell(i - qDeg)
+    }))
+    b.WriteString(fmt.Sprintf("\\cline{2-%d}\n", column(0)))
+    b.WriteString(latexPolyString(q) + " \\big)" + render(dividend.latexCell))
+    for _, st := range steps {
+        b.WriteString(render(st.subtract.latexCell))
+        b.WriteString(fmt.Sprintf("\\cline{%d-%d}\n", column(st.hi), column(st.lo)))
+        b.WriteString(render(st.remainder.latexCell))
+    }
+    b.WriteString("\\end{array}\n")
+    return b.String()
+}
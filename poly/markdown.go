@@ -0,0 +1,42 @@
+package poly
+
+import (
+    "fmt"
+    "io"
+    "strings"
+)
+
+// WriteMarkdown writes the full worked computation of the extended Euclidean
+// algorithm on f and g as a Markdown document. The output depends only on the
+// inputs (no timings or dates), so it can be committed and diffed.
+func WriteMarkdown(w io.Writer, f, g *Polynomial) error {
+    res := extendedGCDResult(f, g)
+
+    var b strings.Builder
+    b.WriteString("# Extended Euclidean algorithm\n\n")
+    b.WriteString(fmt.Sprintf("- f(x) = `%s`\n", layoutPolyString(f)))
+    b.WriteString(fmt.Sprintf("- g(x) = `%s`\n\n", layoutPolyString(g)))
+
+    for i, st := range res.Steps {
+        b.WriteString(fmt.Sprintf("## Step %d\n\n", i+1))
+        b.WriteString(fmt.Sprintf("Divide `%s` by `%s`:\n\n", layoutPolyString(st.Dividend), layoutPolyString(st.Divisor)))
+        b.WriteString("```text\n")
+        b.WriteString(longDivision(st.Dividend, st.Divisor))
+        b.WriteString("```\n\n")
+        b.WriteString("| | |\n|---|---|\n")
+        b.WriteString(fmt.Sprintf("| quotient | `%s` |\n", layoutPolyString(st.Quotient)))
+        b.WriteString(fmt.Sprintf("| remainder | `%s` |\n", layoutPolyString(st.Remainder)))
+        b.WriteString(fmt.Sprintf("| s | `%s` |\n", layoutPolyString(st.S)))
+        b.WriteString(fmt.Sprintf("| t | `%s` |\n\n", layoutPolyString(st.T)))
+    }
+
+    b.WriteString("## Result\n\n")
+    b.WriteString(fmt.Sprintf("- gcd(f, g) = `%s`\n", layoutPolyString(res.GCD)))
+    b.WriteString(fmt.Sprintf("- s(x) = `%s`\n", layoutPolyString(res.S)))
+    b.WriteString(fmt.Sprintf("- t(x) = `%s`\n", layoutPolyString(res.T)))
+    b.WriteString(fmt.Sprintf("- iterations: %s\n\n", res.IterationsSummary()))
+    b.WriteString("so that s(x)·f(x) + t(x)·g(x) = gcd(f, g).\n")
+
+    _, err := io.WriteString(w, b.String())
+    return err
+}
@@ -0,0 +1,75 @@
+package poly
+
+import (
+    "crypto/rand"
+    "math"
+    "math/big"
+    mrand "math/rand"
+
+    "euclid/internal/benchtime"
+    "euclid/intring"
+)
+
+// modBenchBits are the modulus sizes benchmarked by ModBench
+var modBenchBits = []int{16, 32, 62, 96, 128, 192, 256, 512, 1024, 2048, 4096}
+
+// ModBenchRow holds the ns/op of modular multiplication and inversion for
+// a prime modulus of Bits bits, in word-size and in big.Int arithmetic, and
+// the inverse strategy modInverseStrategy picks at that size. The word-size
+// figures are NaN where the modulus does not fit in a word.
+type ModBenchRow struct {
+    Bits                          int
+    MulWord, MulBig               float64
+    InvWord, InvEuclid, InvModInv float64
+    Strategy                      string
+}
+
+// ModBenchResult holds the rows of ModBench and the crossover points it
+// measured: the largest size at which word arithmetic, and the smallest at
+// which big.Int.ModInverse, is the fastest inverse
+type ModBenchResult struct {
+    Rows                 []ModBenchRow
+    WordUpTo, ModInvFrom int
+}
+
+// ModBench compares word-size modular arithmetic with big.Int arithmetic, and
+// the inverse algorithms against each other, for prime moduli of growing
+// size. It returns ns/op per method and the measured crossover points, to
+// compare with the ones modInverseStrategy uses.
+func ModBench() (*ModBenchResult, error) {
+    res := &ModBenchResult{}
+    for _, n := range modBenchBits {
+        p, err := rand.Prime(rand.Reader, n)
+        if err != nil {
+            return nil, err
+        }
+        a := new(big.Int).Rand(mrand.New(mrand.NewSource(int64(n))), p)
+        if a.Sign() == 0 {
+            a.SetInt64(1)
+        }
+        b := new(big.Int).Sub(p, a)
+
+        row := ModBenchRow{Bits: n, MulWord: math.NaN(), InvWord: math.NaN(), Strategy: modInverseStrategy(n)}
+        row.MulBig = benchtime.Ns(func() {
+            new(big.Int).Mod(new(big.Int).Mul(a, b), p)
+        })
+        row.InvEuclid = benchtime.Ns(func() { invModBig(a, p) })
+        row.InvModInv = benchtime.Ns(func() { new(big.Int).ModInverse(a, p) })
+
+        fastest := row.InvModInv
+        if n <= intring.WordModulusBits {
+            pw, aw, bw := p.Uint64(), a.Uint64(), b.Uint64()
+            row.InvWord = benchtime.Ns(func() { intring.InvMod(aw, pw) })
+            row.MulWord = benchtime.Ns(func() { intring.MulMod(aw, bw, pw) })
+            if row.InvWord < row.InvModInv && row.InvWord < row.InvEuclid {
+                res.WordUpTo = n
+            }
+            fastest = math.Min(row.InvWord, row.InvModInv)
+        }
+        if res.ModInvFrom == 0 && row.InvModInv <= fastest && row.InvModInv < row.InvEuclid {
+            res.ModInvFrom = n
+        }
+        res.Rows = append(res.Rows, row)
+    }
+    return res, nil
+}
@@ -0,0 +1,44 @@
+package poly
+
+import (
+    "math/big"
+
+    "euclid/intring"
+)
+
+// invModBig returns the inverse of a modulo m with the integer extended
+// Euclidean algorithm, and nil if a is not invertible
+func invModBig(a, m *big.Int) *big.Int {
+    gcd, s, _, _ := intring.ExtendedEuclidean(new(big.Int).Mod(a, m), m)
+    if gcd.Cmp(big.NewInt(1)) != 0 {
+        return nil
+    }
+    return s.Mod(s, m)
+}
+
+// modInverseStrategy names the fastest modular inverse for a modulus of the
+// given size in bits. The crossover points are those measured by ModBench:
+// word arithmetic wins wherever it applies, and above intring.WordModulusBits
+// big.Int.ModInverse (Lehmer's algorithm) beats the plain extended Euclidean
+// algorithm on big.Int at every size.
+func modInverseStrategy(modulusBits int) string {
+    if modulusBits <= intring.WordModulusBits {
+        return "word"
+    }
+    return "big-modinverse"
+}
+
+// modInverse returns the inverse of a modulo m using the strategy chosen by
+// modInverseStrategy, and nil if a is not invertible
+func modInverse(a, m *big.Int) *big.Int {
+    switch modInverseStrategy(m.BitLen()) {
+    case "word":
+        inv, ok := intring.InvMod(new(big.Int).Mod(a, m).Uint64(), m.Uint64())
+        if !ok {
+            return nil
+        }
+        return new(big.Int).SetUint64(inv)
+    default:
+        return new(big.Int).ModInverse(new(big.Int).Mod(a, m), m)
+    }
+}
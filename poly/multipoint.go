@@ -0,0 +1,215 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "math/rand"
+
+    "euclid/fft"
+    "euclid/internal/benchtime"
+)
+
+// multipointLeafSize is the number of points below which EvalMany stops
+// splitting the remainder tree and evaluates the remainder at each point by
+// Horner's scheme; the division by a product of a handful of linear
+// factors costs more than the few Horner steps it saves
+const multipointLeafSize = 8
+
+// subproductTree holds the products of x - b over integer points b: level 0
+// has the product over each block of multipointLeafSize consecutive points
+// and every node above is the product of its two children, the last node of
+// a level with an odd number of nodes being carried up unchanged. The root
+// is the product over all the points. All nodes are monic integer
+// polynomials, lowest degree first.
+type subproductTree [][][]*big.Int
+
+func newSubproductTree(points []*big.Int) subproductTree {
+    var leaves [][]*big.Int
+    for i := 0; i < len(points); i += multipointLeafSize {
+        leaf := []*big.Int{big.NewInt(1)}
+        for _, b := range points[i:min(i+multipointLeafSize, len(points))] {
+            leaf = fft.Schoolbook(leaf, []*big.Int{new(big.Int).Neg(b), big.NewInt(1)})
+        }
+        leaves = append(leaves, leaf)
+    }
+    tree := subproductTree{leaves}
+    for level := leaves; len(level) > 1; {
+        next := make([][]*big.Int, (len(level)+1)/2)
+        for j := range next {
+            if 2*j+1 < len(level) {
+                next[j] = fft.Karatsuba(level[2*j], level[2*j+1])
+            } else {
+                next[j] = level[2*j]
+            }
+        }
+        tree = append(tree, next)
+        level = next
+    }
+    return tree
+}
+
+// remMonicInts returns a mod m for a monic integer polynomial m, which only
+// needs integer operations
+func remMonicInts(a, m []*big.Int) []*big.Int {
+    dm := len(m) - 1
+    if len(a) <= dm {
+        return a
+    }
+    r := make([]*big.Int, len(a))
+    for i, c := range a {
+        r[i] = new(big.Int).Set(c)
+    }
+    t := new(big.Int)
+    for k := len(a) - 1 - dm; k >= 0; k-- {
+        c := r[k+dm]
+        for j, mj := range m[:dm] {
+            r[k+j].Sub(r[k+j], t.Mul(c, mj))
+        }
+    }
+    return trimInts(r[:dm])
+}
+
+// hornerInts returns a(b) for an integer polynomial a
+func hornerInts(a []*big.Int, b *big.Int) *big.Int {
+    v := new(big.Int)
+    for i := len(a) - 1; i >= 0; i-- {
+        v.Mul(v, b)
+        v.Add(v, a[i])
+    }
+    return v
+}
+
+// EvalMany returns p at each of the points, in order. Points are grouped by
+// denominator, and each group of multipointLeafSize points or more is
+// evaluated on integers only: with D the common denominator,
+// p(b/D) = q(b)/(c*D^n) for the integer polynomial q(x) = c*D^n*p(x/D) of
+// degree n, and q is reduced modulo the product of x - b over the group and
+// the remainders passed down a tree of such products (subproductTree), all
+// monic, so that each point is finally evaluated on a remainder of degree
+// below multipointLeafSize instead of on p. Smaller groups, such as points
+// with unrelated denominators, are evaluated one by one with Horner's
+// scheme on big.Rat, which reduces a fraction at every step;
+// MultipointBench compares the two.
+func (p *Polynomial) EvalMany(points []*big.Rat) []*big.Rat {
+    values := make([]*big.Rat, len(points))
+    groups := make(map[string][]int)
+    var order []string
+    for i, a := range points {
+        key := a.Denom().String()
+        if _, ok := groups[key]; !ok {
+            order = append(order, key)
+        }
+        groups[key] = append(groups[key], i)
+    }
+    for _, key := range order {
+        group := groups[key]
+        if len(group) < multipointLeafSize || p.IsZero() {
+            for _, i := range group {
+                values[i] = p.Eval(points[i])
+            }
+            continue
+        }
+        b := make([]*big.Int, len(group))
+        for j, i := range group {
+            b[j] = points[i].Num()
+        }
+        for j, v := range p.evalManyInts(b, points[group[0]].Denom()) {
+            values[group[j]] = v
+        }
+    }
+    return values
+}
+
+// evalManyInts returns p at the points b/d by the remainder tree
+func (p *Polynomial) evalManyInts(b []*big.Int, d *big.Int) []*big.Rat {
+    // q_i = c*p_i*d^(n-i) with c clearing the denominators of p
+    a := p.integerCoeffs()
+    n := len(a) - 1
+    c := new(big.Rat).Quo(new(big.Rat).SetInt(a[n]), p.coeff[n])
+    power := big.NewInt(1)
+    for i := n; i >= 0; i-- {
+        a[i].Mul(a[i], power)
+        power.Mul(power, d)
+    }
+    // power is d^(n+1) now
+    scale := new(big.Rat).Mul(c, new(big.Rat).SetFrac(power, d))
+
+    tree := newSubproductTree(b)
+    top := len(tree) - 1
+    rems := [][]*big.Int{remMonicInts(a, tree[top][0])}
+    for level := top - 1; level >= 0; level-- {
+        next := make([][]*big.Int, len(tree[level]))
+        for j := range next {
+            next[j] = remMonicInts(rems[j/2], tree[level][j])
+        }
+        rems = next
+    }
+    values := make([]*big.Rat, len(b))
+    for j, r := range rems {
+        for i := j * multipointLeafSize; i < min((j+1)*multipointLeafSize, len(b)); i++ {
+            values[i] = new(big.Rat).SetInt(hornerInts(r, b[i]))
+            values[i].Quo(values[i], scale)
+        }
+    }
+    return values
+}
+
+// multipointBenchDegrees are the degrees MultipointBench times, each at as
+// many points as the degree
+var multipointBenchDegrees = []int{16, 64, 256}
+
+// MultipointBenchRow holds the ns/op of evaluating a polynomial of degree
+// Degree at as many points of the given kind ("integer", "grid" or
+// "rational") point by point with Horner's scheme and with EvalMany
+type MultipointBenchRow struct {
+    Degree       int
+    Kind         string
+    Horner, Tree float64
+}
+
+// MultipointBench times evaluating random polynomials at as many random
+// points as their degree, once point by point with Horner's scheme and once
+// with EvalMany, for integer points, for points on a grid of step 1/16 and
+// for rational points with unrelated denominators, after checking that both
+// give the same values, and returns ns/op
+func MultipointBench() ([]MultipointBenchRow, error) {
+    var rows []MultipointBenchRow
+    for _, n := range multipointBenchDegrees {
+        for _, kind := range []string{"integer", "grid", "rational"} {
+            rng := rand.New(rand.NewSource(int64(n)))
+            p := RandomPolynomial(rng, n)
+            points := make([]*big.Rat, n)
+            for i := range points {
+                switch kind {
+                case "integer":
+                    points[i] = big.NewRat(int64(rng.Intn(2*n+1)-n), 1)
+                case "grid":
+                    // odd numerators keep the denominator at 16
+                    points[i] = big.NewRat(int64(2*rng.Intn(n+1)-n-1), 16)
+                default:
+                    points[i] = big.NewRat(int64(rng.Intn(2*n+1)-n), int64(rng.Intn(n)+1))
+                }
+            }
+            horner := func() []*big.Rat {
+                values := make([]*big.Rat, len(points))
+                for i, a := range points {
+                    values[i] = p.Eval(a)
+                }
+                return values
+            }
+            want, got := horner(), p.EvalMany(points)
+            for i := range want {
+                if want[i].Cmp(got[i]) != 0 {
+                    return rows, fmt.Errorf("multipoint evaluation disagrees with Horner's scheme at degree %d", n)
+                }
+            }
+            rows = append(rows, MultipointBenchRow{
+                Degree: n,
+                Kind:   kind,
+                Horner: benchtime.Ns(func() { horner() }),
+                Tree:   benchtime.Ns(func() { p.EvalMany(points) }),
+            })
+        }
+    }
+    return rows, nil
+}
@@ -0,0 +1,220 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/rand"
+    "time"
+)
+
+// Option adjusts the configuration of a single call. Every call builds its
+// own configuration from its options, so there is no package-level state to
+// race on and calls are safe to make from concurrent goroutines. An option
+// with an invalid value leaves the setting unchanged and records an error,
+// which CheckOptions and the calls that return an error report.
+type Option func(*config)
+
+// config holds the settings that Option values adjust
+type config struct {
+    // seed seeds the random source of the call; seeded reports whether
+    // WithSeed was given, otherwise the source is seeded from the clock
+    seed   int64
+    seeded bool
+    // variable is the name of the indeterminate in formatted output
+    variable string
+    // strategy names the multiplication algorithm, one of mulStrategies
+    strategy string
+    // gcd names the extended GCD algorithm, one of gcdStrategies
+    gcd string
+    // monic normalizes the results of the extended GCD over Q to a monic
+    // GCD (--monic)
+    monic bool
+    // format is the style of formatted output, one of formatStyles
+    format string
+    // fullOutput prints polynomials of any degree in full instead of as a
+    // summary (--full)
+    fullOutput bool
+    // step, when set, is called by the Euclidean loops after every division
+    // with the number of divisions so far and the degree of the new
+    // remainder (-1 once it is zero)
+    step func(iterations, deg int)
+    // trace, when set, is called by the extended GCD over Q with every
+    // division step as it is made (WithTrace)
+    trace func(step int, st EuclidStep)
+    // err is the error of the first invalid option
+    err error
+}
+
+// newConfig returns the default configuration with opts applied in order
+func newConfig(opts ...Option) config {
+    c := config{variable: "x", format: "plain", strategy: "auto", gcd: "euclid"}
+    for _, opt := range opts {
+        opt(&c)
+    }
+    return c
+}
+
+// fail records err unless an earlier option failed already
+func (c *config) fail(err error) {
+    if c.err == nil {
+        c.err = err
+    }
+}
+
+// CheckOptions returns the error of the first invalid option in opts, such
+// as WithStrategy with an unknown name, or nil
+func CheckOptions(opts ...Option) error {
+    return newConfig(opts...).err
+}
+
+// WithSeed makes the random choices of a call (random test inputs, corpus
+// families, fuzz inputs) reproducible
+func WithSeed(seed int64) Option {
+    return func(c *config) {
+        c.seed, c.seeded = seed, true
+    }
+}
+
+// WithVariableName sets the name of the indeterminate in formatted output,
+// "x" by default
+func WithVariableName(name string) Option {
+    return func(c *config) {
+        c.variable = name
+    }
+}
+
+// WithFormat selects the style of Format and Display, one of formatStyles;
+// "plain" (the default) is String's format, which ParsePolynomial reads
+// back. An unknown name is an error (see Option).
+func WithFormat(style string) Option {
+    return func(c *config) {
+        if !IsFormatStyle(style) {
+            c.fail(fmt.Errorf("unknown format style %q", style))
+            return
+        }
+        c.format = style
+    }
+}
+
+// WithStrategy selects the multiplication algorithm, one of mulStrategies;
+// "auto" (the default) picks one by degree. An unknown name is an error (see
+// Option).
+func WithStrategy(strategy string) Option {
+    return func(c *config) {
+        if !IsMulStrategy(strategy) {
+            c.fail(fmt.Errorf("unknown multiplication strategy %q", strategy))
+            return
+        }
+        c.strategy = strategy
+    }
+}
+
+// gcdStrategies names the extended GCD algorithms WithGCDStrategy accepts:
+// those of polymod.WithGCDStrategy, the classical remainder sequence and the
+// half-GCD algorithm over GF(p), and the subresultant PRS over Q
+var gcdStrategies = []string{"euclid", "halfgcd", "subresultant"}
+
+// gcdStrategyFields names the field of the GCD strategies that run over one
+// field only; "euclid" runs over Q and GF(p)
+var gcdStrategyFields = map[string]string{"halfgcd": "GF(p)", "subresultant": "Q"}
+
+// WithGCDStrategy selects the extended GCD algorithm over Q
+// (ExtendedGCDResult and the functions built on it), one of gcdStrategies;
+// "euclid" is the default. "halfgcd" runs over GF(p) only and makes the
+// call fail with ErrUnsupportedStrategy. An unknown name is an error (see
+// Option).
+func WithGCDStrategy(strategy string) Option {
+    return func(c *config) {
+        if !IsGCDStrategy(strategy) {
+            c.fail(fmt.Errorf("unknown GCD strategy %q", strategy))
+            return
+        }
+        c.gcd = strategy
+    }
+}
+
+// ErrUnsupportedStrategy is wrapped by the error of an extended GCD whose
+// GCD strategy does not run over the field of the call, such as "halfgcd"
+// over Q
+var ErrUnsupportedStrategy = errors.New("GCD strategy does not apply")
+
+// IsGCDStrategy reports whether name is one of gcdStrategies
+func IsGCDStrategy(name string) bool {
+    for _, s := range gcdStrategies {
+        if s == name {
+            return true
+        }
+    }
+    return false
+}
+
+// CheckGCDStrategy returns an error wrapping ErrUnsupportedStrategy if the
+// GCD strategy named strategy does not run over field, "Q" or "GF(p)"
+func CheckGCDStrategy(strategy, field string) error {
+    if f, ok := gcdStrategyFields[strategy]; ok && f != field {
+        return fmt.Errorf("%w: %q runs over %s, not %s", ErrUnsupportedStrategy, strategy, f, field)
+    }
+    return nil
+}
+
+// checkGCD returns the error of the first invalid option of c, or the error
+// of CheckGCDStrategy for its GCD strategy over field
+func (c config) checkGCD(field string) error {
+    if c.err != nil {
+        return c.err
+    }
+    return CheckGCDStrategy(c.gcd, field)
+}
+
+// WithMonicGCD makes the extended GCD over Q (ExtendedGCDResult and the
+// functions built on it) return a monic GCD, scaling S and T along with it
+// so that S*f + T*g = GCD still holds. The GCD is only defined up to a
+// nonzero constant, and the one the algorithms return otherwise depends on
+// the algorithm and the inputs. Over GF(p) the GCD is always monic.
+func WithMonicGCD(monic bool) Option {
+    return func(c *config) {
+        c.monic = monic
+    }
+}
+
+// WithFullOutput prints polynomials of degree above displayMaxDegree in full
+// instead of as a summary
+func WithFullOutput(full bool) Option {
+    return func(c *config) {
+        c.fullOutput = full
+    }
+}
+
+// WithTrace calls trace after every division of the extended GCD over Q,
+// with the step number from 1 and the step record: the division and the
+// updated cofactors s and t of its remainder. GCDResult.Steps holds the same
+// records once the call returns; the callback is for callers that show the
+// steps while they are made, such as a GUI.
+func WithTrace(trace func(step int, st EuclidStep)) Option {
+    return func(c *config) {
+        c.trace = trace
+    }
+}
+
+// withStepHook reports the progress of the Euclidean loops to step
+func withStepHook(step func(iterations, deg int)) Option {
+    return func(c *config) {
+        c.step = step
+    }
+}
+
+// rand returns a new random source for the call. Sources are not shared, so
+// concurrent calls with the same seed see the same sequence.
+func (c config) rand() *rand.Rand {
+    seed := c.seed
+    if !c.seeded {
+        seed = time.Now().UnixNano()
+    }
+    return rand.New(rand.NewSource(seed))
+}
+
+// NewRand returns a random source seeded as WithSeed in opts selects, or from
+// the clock
+func NewRand(opts ...Option) *rand.Rand {
+    return newConfig(opts...).rand()
+}
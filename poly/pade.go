@@ -0,0 +1,63 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+// ErrNoPade is returned by Pade when the series has no [m/n] Padé
+// approximant
+var ErrNoPade = errors.New("pade: no approximant of this type")
+
+// Pade returns the [m/n] Padé approximant of the power series with the
+// given coefficients, lowest degree first: the num/den with deg num <= m,
+// deg den <= n and den(0) = 1 that agrees with the series up to x^(m+n),
+// that is den*series - num = O(x^(m+n+1)). It needs the first m+n+1
+// coefficients; further ones are ignored.
+//
+// The extended Euclidean algorithm on x^(m+n+1) and the truncated series S
+// keeps t_i*S = r_i mod x^(m+n+1) for every remainder r_i, with
+// deg t_i = m+n+1 - deg r_(i-1). The loop stops at the first remainder of
+// degree at most m, whose t then has degree at most n, and r/t scaled to
+// t(0) = 1 is the approximant. When t(0) = 0 no approximant of type [m/n]
+// exists and ErrNoPade is returned.
+func Pade(series []*big.Rat, m, n int) (num, den *Polynomial, err error) {
+    if m < 0 || n < 0 {
+        return nil, nil, fmt.Errorf("pade: negative degree bound in [%d/%d]", m, n)
+    }
+    N := m + n + 1
+    if len(series) < N {
+        return nil, nil, fmt.Errorf("pade: [%d/%d] needs %d coefficients, got %d", m, n, N, len(series))
+    }
+    for _, c := range series[:N] {
+        if c == nil {
+            return nil, nil, fmt.Errorf("pade: nil coefficient")
+        }
+    }
+    xN := make([]*big.Rat, N+1)
+    for i := range xN {
+        xN[i] = new(big.Rat)
+    }
+    xN[N].SetInt64(1)
+    r, t := partialExtendedGCD(NewPolyNoCopy(xN), NewPolynomial(series[:N]), m)
+    c := t.coeff[0]
+    if c.Sign() == 0 {
+        return nil, nil, ErrNoPade
+    }
+    c = new(big.Rat).Inv(c)
+    return r.scale(c), t.scale(c), nil
+}
+
+// partialExtendedGCD runs the extended Euclidean algorithm on f and g until
+// the remainder has degree at most deg, and returns that remainder r with
+// its cofactor t of g, r = s*f + t*g for some s
+func partialExtendedGCD(f, g *Polynomial, deg int) (r, t *Polynomial) {
+    t0, t1 := Zero(), One()
+    for !g.IsZero() && g.Deg() > deg {
+        q, rem := f.div(g)
+        f, g = g, rem
+        t0, t1 = t1, t0.Sub(q.mul(t1)).trim()
+    }
+    return g, t1
+}
@@ -0,0 +1,54 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+// TestPade checks the known approximants of exp and 1/(1 - x) and the
+// defining property den*series - num = O(x^(m+n+1)) with den(0) = 1
+func TestPade(t *testing.T) {
+    exp := []*big.Rat{big.NewRat(1, 1), big.NewRat(1, 1), big.NewRat(1, 2), big.NewRat(1, 6), big.NewRat(1, 24), big.NewRat(1, 120)}
+    geometric := []*big.Rat{big.NewRat(1, 1), big.NewRat(1, 1), big.NewRat(1, 1), big.NewRat(1, 1)}
+    cases := []struct {
+        series           []*big.Rat
+        m, n             int
+        wantNum, wantDen string
+    }{
+        {exp, 2, 2, "1/12x^2 + 1/2x + 1", "1/12x^2 - 1/2x + 1"},
+        {exp, 1, 1, "1/2x + 1", "-1/2x + 1"},
+        {exp, 3, 0, "1/6x^3 + 1/2x^2 + x + 1", "1"},
+        {geometric, 0, 1, "1", "-x + 1"},
+        {geometric, 1, 2, "1", "-x + 1"},
+    }
+    for _, c := range cases {
+        num, den, err := Pade(c.series, c.m, c.n)
+        if err != nil {
+            t.Errorf("[%d/%d]: %v", c.m, c.n, err)
+            continue
+        }
+        wantNum, _ := ParsePolynomial(c.wantNum)
+        wantDen, _ := ParsePolynomial(c.wantDen)
+        if !num.Equal(wantNum) || !den.Equal(wantDen) {
+            t.Errorf("[%d/%d] = (%s)/(%s), want (%s)/(%s)", c.m, c.n, num, den, wantNum, wantDen)
+        }
+        N := c.m + c.n + 1
+        diff := den.Mul(NewPolynomial(c.series[:N])).Sub(num)
+        for i := 0; i < N; i++ {
+            if diff.Coeff(i).Sign() != 0 {
+                t.Errorf("[%d/%d]: den*series - num has the term %s*x^%d", c.m, c.n, diff.Coeff(i).RatString(), i)
+            }
+        }
+    }
+    // x + O(x^2) is no constant over 1 + a*x
+    if _, _, err := Pade([]*big.Rat{new(big.Rat), big.NewRat(1, 1)}, 0, 1); !errors.Is(err, ErrNoPade) {
+        t.Errorf("[0/1] of x returned %v, want ErrNoPade", err)
+    }
+    if _, _, err := Pade(exp, -1, 2); err == nil {
+        t.Error("negative degree bound accepted")
+    }
+    if _, _, err := Pade(exp[:3], 2, 2); err == nil {
+        t.Error("too few coefficients accepted")
+    }
+}
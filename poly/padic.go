@@ -0,0 +1,294 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "strings"
+)
+
+// padicRing is Z_p truncated to precision k, i.e. Z/p^k: a p-adic integer is
+// known up to a multiple of p^k and stored as its residue in [0, p^k)
+type padicRing struct {
+    p   *big.Int
+    k   int
+    mod *big.Int // p^k
+}
+
+func newPadicRing(p *big.Int, k int) *padicRing {
+    return &padicRing{p: p, k: k, mod: new(big.Int).Exp(p, big.NewInt(int64(k)), nil)}
+}
+
+func (z *padicRing) reduce(a *big.Int) *big.Int {
+    return new(big.Int).Mod(a, z.mod)
+}
+
+// fromRat maps a rational with denominator prime to p into Z_p
+func (z *padicRing) fromRat(r *big.Rat) (*big.Int, error) {
+    inv := new(big.Int).ModInverse(new(big.Int).Mod(r.Denom(), z.mod), z.mod)
+    if inv == nil {
+        return nil, fmt.Errorf("padic: %s is not a %s-adic integer", r.RatString(), z.p)
+    }
+    return z.reduce(inv.Mul(inv, r.Num())), nil
+}
+
+// valuation returns the largest v <= k with p^v dividing a
+func (z *padicRing) valuation(a *big.Int) int {
+    a = z.reduce(a)
+    v := 0
+    r := new(big.Int)
+    for v < z.k && a.Sign() != 0 {
+        q, _ := new(big.Int).QuoRem(a, z.p, r)
+        if r.Sign() != 0 {
+            break
+        }
+        a = q
+        v++
+    }
+    if a.Sign() == 0 {
+        return z.k
+    }
+    return v
+}
+
+// digits returns the p-adic digits of a, least significant first
+func (z *padicRing) digits(a *big.Int) []*big.Int {
+    a = z.reduce(a)
+    ds := make([]*big.Int, z.k)
+    for i := range ds {
+        ds[i] = new(big.Int)
+        a.QuoRem(a, z.p, ds[i])
+    }
+    return ds
+}
+
+// format writes a as ...d_2 d_1 d_0 in base p
+func (z *padicRing) format(a *big.Int) string {
+    ds := z.digits(a)
+    parts := make([]string, len(ds))
+    for i, d := range ds {
+        parts[len(ds)-1-i] = d.String()
+    }
+    sep := ""
+    if z.p.Cmp(big.NewInt(10)) > 0 {
+        sep = " "
+    }
+    return "..." + strings.Join(parts, sep) + "_" + z.p.String()
+}
+
+// padicPoly is a polynomial over Z/p^k, lowest degree first
+type padicPoly struct {
+    ring  *padicRing
+    coeff []*big.Int
+}
+
+// toPadic maps p into Z_p[x]; every coefficient must be a p-adic integer
+func (p *Polynomial) toPadic(z *padicRing) (*padicPoly, error) {
+    coeffs := make([]*big.Int, p.Deg()+1)
+    for i := range coeffs {
+        c, err := z.fromRat(p.coeff[i])
+        if err != nil {
+            return nil, err
+        }
+        coeffs[i] = c
+    }
+    return &padicPoly{z, coeffs}, nil
+}
+
+func (f *padicPoly) add(g *padicPoly) *padicPoly {
+    n := max(len(f.coeff), len(g.coeff))
+    coeffs := make([]*big.Int, n)
+    for i := range coeffs {
+        coeffs[i] = new(big.Int)
+        if i < len(f.coeff) {
+            coeffs[i].Add(coeffs[i], f.coeff[i])
+        }
+        if i < len(g.coeff) {
+            coeffs[i].Add(coeffs[i], g.coeff[i])
+        }
+        coeffs[i] = f.ring.reduce(coeffs[i])
+    }
+    return &padicPoly{f.ring, coeffs}
+}
+
+func (f *padicPoly) mul(g *padicPoly) *padicPoly {
+    coeffs := make([]*big.Int, len(f.coeff)+len(g.coeff)-1)
+    for i := range coeffs {
+        coeffs[i] = new(big.Int)
+    }
+    for i, a := range f.coeff {
+        for j, b := range g.coeff {
+            coeffs[i+j].Add(coeffs[i+j], new(big.Int).Mul(a, b))
+        }
+    }
+    for i := range coeffs {
+        coeffs[i] = f.ring.reduce(coeffs[i])
+    }
+    return &padicPoly{f.ring, coeffs}
+}
+
+// evalWithDerivative returns f(a) and f'(a) in Z/p^k
+func (f *padicPoly) evalWithDerivative(a *big.Int) (*big.Int, *big.Int) {
+    v, d := new(big.Int), new(big.Int)
+    for i := len(f.coeff) - 1; i >= 0; i-- {
+        d = f.ring.reduce(d.Mul(d, a).Add(d, v))
+        v = f.ring.reduce(v.Mul(v, a).Add(v, f.coeff[i]))
+    }
+    return v, d
+}
+
+// rootsModP returns the roots of f modulo p by trying every residue, which is
+// fine for the small primes Hensel lifting starts from
+func (f *padicPoly) rootsModP() []*big.Int {
+    if !f.ring.p.IsInt64() || f.ring.p.Int64() > 1<<16 {
+        return nil
+    }
+    var roots []*big.Int
+    for a := int64(0); a < f.ring.p.Int64(); a++ {
+        v, _ := f.evalWithDerivative(big.NewInt(a))
+        if new(big.Int).Mod(v, f.ring.p).Sign() == 0 {
+            roots = append(roots, big.NewInt(a))
+        }
+    }
+    return roots
+}
+
+// errSingularRoot means Newton lifting cannot start because f'(a) is
+// divisible by p
+var errSingularRoot = errors.New("padic: f'(a) = 0 mod p, Hensel's lemma does not apply")
+
+// henselLift lifts a simple root a of f modulo p to a root modulo p^k by
+// Newton's iteration a <- a - f(a)/f'(a), which doubles the number of correct
+// p-adic digits at every step. It also returns the precision reached after
+// each step.
+func (f *padicPoly) henselLift(a *big.Int) (*big.Int, []int, error) {
+    z := f.ring
+    _, d := f.evalWithDerivative(a)
+    if new(big.Int).Mod(d, z.p).Sign() == 0 {
+        return nil, nil, errSingularRoot
+    }
+    a = new(big.Int).Set(a)
+    var precisions []int
+    for prec := 1; prec < z.k; {
+        prec = min(2*prec, z.k)
+        v, d := f.evalWithDerivative(a)
+        inv := new(big.Int).ModInverse(d, z.mod)
+        a = z.reduce(a.Sub(a, inv.Mul(inv, v)))
+        precisions = append(precisions, prec)
+    }
+    return a, precisions, nil
+}
+
+// divLinear divides f by x - a with Horner's scheme, returning the quotient
+// and the remainder f(a)
+func (f *padicPoly) divLinear(a *big.Int) (*padicPoly, *big.Int) {
+    n := len(f.coeff) - 1
+    if n < 1 {
+        return &padicPoly{f.ring, []*big.Int{new(big.Int)}}, f.ring.reduce(f.coeff[0])
+    }
+    quotient := make([]*big.Int, n)
+    acc := new(big.Int).Set(f.coeff[n])
+    for i := n - 1; i >= 0; i-- {
+        quotient[i] = acc
+        acc = f.ring.reduce(new(big.Int).Add(new(big.Int).Mul(acc, a), f.coeff[i]))
+    }
+    return &padicPoly{f.ring, quotient}, acc
+}
+
+func (f *padicPoly) equal(g *padicPoly) bool {
+    diff := f.add(&padicPoly{g.ring, negated(g)})
+    for _, c := range diff.coeff {
+        if c.Sign() != 0 {
+            return false
+        }
+    }
+    return true
+}
+
+func negated(g *padicPoly) []*big.Int {
+    coeffs := make([]*big.Int, len(g.coeff))
+    for i, c := range g.coeff {
+        coeffs[i] = new(big.Int).Neg(c)
+    }
+    return coeffs
+}
+
+func (f *padicPoly) String() string {
+    parts := make([]string, 0, len(f.coeff))
+    for i := len(f.coeff) - 1; i >= 0; i-- {
+        if f.coeff[i].Sign() == 0 && len(f.coeff) > 1 {
+            continue
+        }
+        c := f.coeff[i].String() + "*"
+        if f.coeff[i].Cmp(big.NewInt(1)) == 0 {
+            c = ""
+        }
+        switch i {
+        case 0:
+            parts = append(parts, f.coeff[i].String())
+        case 1:
+            parts = append(parts, c+"x")
+        default:
+            parts = append(parts, fmt.Sprintf("%sx^%d", c, i))
+        }
+    }
+    return strings.Join(parts, " + ")
+}
+
+// PadicLift is a simple root of a polynomial f modulo p lifted to Z/p^k by
+// PadicRoots. Err tells why the root could not be lifted; otherwise
+// Precisions holds the power of p the root is correct to after each Newton
+// step, Lifted the root in Z/p^k with its p-adic digits in Digits, and
+// Valuation the p-adic valuation of f(Lifted). Cofactor is q with
+// f = (x - Lifted)q over Z/p^k, or nil if the product does not check.
+type PadicLift struct {
+    Root       *big.Int
+    Err        error
+    Precisions []int
+    Lifted     *big.Int
+    Digits     string
+    Valuation  int
+    Cofactor   fmt.Stringer
+}
+
+// PadicRoots finds the simple roots of f modulo p and lifts each to a root
+// in Z/p^k by Newton's method. p must be a prime, k at least 1 and f
+// nonzero.
+func PadicRoots(f *Polynomial, p *big.Int, k int) ([]PadicLift, error) {
+    if f == nil {
+        return nil, ErrNilPolynomial
+    }
+    if p == nil || !p.ProbablyPrime(20) {
+        return nil, fmt.Errorf("padic: %v is not a prime", p)
+    }
+    if k < 1 {
+        return nil, fmt.Errorf("padic: precision k = %d is not at least 1", k)
+    }
+    if f.IsZero() {
+        return nil, fmt.Errorf("padic: every element is a root of the zero polynomial")
+    }
+    z := newPadicRing(p, k)
+    fp, err := f.toPadic(z)
+    if err != nil {
+        return nil, err
+    }
+    var lifts []PadicLift
+    for _, a0 := range fp.rootsModP() {
+        lift := PadicLift{Root: a0}
+        a, precisions, err := fp.henselLift(a0)
+        if err != nil {
+            lift.Err = err
+            lifts = append(lifts, lift)
+            continue
+        }
+        v, _ := fp.evalWithDerivative(a)
+        lift.Precisions, lift.Lifted, lift.Digits, lift.Valuation = precisions, a, z.format(a), z.valuation(v)
+        q, _ := fp.divLinear(a)
+        linear := &padicPoly{z, []*big.Int{z.reduce(new(big.Int).Neg(a)), big.NewInt(1)}}
+        if linear.mul(q).equal(fp) {
+            lift.Cofactor = q
+        }
+        lifts = append(lifts, lift)
+    }
+    return lifts, nil
+}
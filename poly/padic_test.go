@@ -0,0 +1,53 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+func TestPadicRoots(t *testing.T) {
+    // x^2 + 1 has the roots 2 and 3 modulo 5, lifted to 57 and 68 modulo 125
+    f := NewPolynomial([]*big.Rat{big.NewRat(1, 1), new(big.Rat), big.NewRat(1, 1)})
+    lifts, err := PadicRoots(f, big.NewInt(5), 3)
+    if err != nil {
+        t.Fatal(err)
+    }
+    var got []string
+    for _, lift := range lifts {
+        if lift.Err != nil {
+            t.Fatalf("root %s: %v", lift.Root, lift.Err)
+        }
+        got = append(got, lift.Lifted.String())
+    }
+    if len(got) != 2 || got[0] != "57" || got[1] != "68" {
+        t.Errorf("lifted roots %v, want [57 68]", got)
+    }
+}
+
+func TestPadicRootsChecksInputs(t *testing.T) {
+    f := NewPolynomial([]*big.Rat{big.NewRat(-2, 1), new(big.Rat), big.NewRat(1, 1)})
+    cases := []struct {
+        name string
+        f    *Polynomial
+        p    *big.Int
+        k    int
+    }{
+        {"nil p", f, nil, 3},
+        {"p = 0", f, big.NewInt(0), 3},
+        {"p = 1", f, big.NewInt(1), 3},
+        {"negative p", f, big.NewInt(-7), 3},
+        {"composite p", f, big.NewInt(15), 3},
+        {"k = 0", f, big.NewInt(7), 0},
+        {"negative k", f, big.NewInt(7), -1},
+        {"zero f", Zero(), big.NewInt(7), 3},
+    }
+    for _, c := range cases {
+        if _, err := PadicRoots(c.f, c.p, c.k); err == nil {
+            t.Errorf("%s: no error", c.name)
+        }
+    }
+    if _, err := PadicRoots(nil, big.NewInt(7), 3); !errors.Is(err, ErrNilPolynomial) {
+        t.Errorf("nil f: error %v, want ErrNilPolynomial", err)
+    }
+}
@@ -0,0 +1,205 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "strings"
+)
+
+// ParseLimits bounds the size of polynomial input. The limits are checked on
+// the text before any coefficient is converted, so oversized input is
+// rejected without allocating big numbers for it. A zero field means no limit.
+type ParseLimits struct {
+    MaxDegree            int
+    MaxCoefficientDigits int
+    MaxLength            int
+}
+
+// defaultParseLimits applies to every polynomial read by ParseCoefficients
+// and ParsePolynomial
+var defaultParseLimits = ParseLimits{
+    MaxDegree:            100000,
+    MaxCoefficientDigits: 10000,
+    MaxLength:            1 << 24,
+}
+
+// DefaultParseLimits returns the limits of ParseCoefficients and
+// ParsePolynomial, as a starting point for ParseCoefficientsWithLimits and
+// ParsePolynomialWithLimits
+func DefaultParseLimits() ParseLimits {
+    return defaultParseLimits
+}
+
+// LimitError reports input that exceeds one of the parse limits
+type LimitError struct {
+    Input string
+    Pos   int    // byte offset of the offending text
+    Limit string // "degree", "coefficient digits" or "length"
+    Max   int
+    Got   int
+    Field string // offending coefficient, if any
+}
+
+func (e *LimitError) Error() string {
+    msg := fmt.Sprintf("%s %d exceeds limit %d", e.Limit, e.Got, e.Max)
+    if e.Field != "" {
+        field := e.Field
+        if len(field) > 20 {
+            field = field[:20] + "..."
+        }
+        msg = fmt.Sprintf("coefficient %q: %s", field, msg)
+    }
+    return (&ExpressionError{Input: e.Input, Pos: e.Pos, Msg: msg}).Error()
+}
+
+// SyntaxError reports a coefficient that is not a rational number
+type SyntaxError struct {
+    Field string
+    Index int // position in the list, counted from the highest degree
+}
+
+func (e *SyntaxError) Error() string {
+    return fmt.Sprintf("invalid coefficient %q at position %d", e.Field, e.Index+1)
+}
+
+// ParseCoefficients parses a comma-separated list of rational coefficients,
+// highest degree first, within DefaultParseLimits. Each coefficient is a
+// signed decimal integer, decimal or fraction, as in ParsePolynomial; base
+// prefixes such as "0x10" and exponents such as "1e3" are syntax errors.
+func ParseCoefficients(s string) (*Polynomial, error) {
+    return ParseCoefficientsWithLimits(s, defaultParseLimits)
+}
+
+// ParseCoefficientsWithLimits parses a coefficient list within the given
+// limits and canonicalizes it: spaces around coefficients are ignored and
+// leading zero coefficients are dropped, so that the result has no zero
+// coefficient above its degree.
+func ParseCoefficientsWithLimits(s string, limits ParseLimits) (*Polynomial, error) {
+    if limits.MaxLength > 0 && len(s) > limits.MaxLength {
+        return nil, &LimitError{Input: s, Pos: limits.MaxLength, Limit: "length", Max: limits.MaxLength, Got: len(s)}
+    }
+    fields := strings.Split(s, ",")
+    // offsets[i] is the byte offset of the trimmed field i in s
+    offsets := make([]int, len(fields))
+    offset := 0
+    for i, field := range fields {
+        fields[i] = strings.TrimSpace(field)
+        offsets[i] = offset + strings.Index(field, fields[i])
+        offset += len(field) + 1
+    }
+    // drop leading zeros, keeping the last coefficient of the zero polynomial
+    lead := 0
+    for lead < len(fields)-1 && isZeroLiteral(fields[lead]) {
+        lead++
+    }
+    if limits.MaxDegree > 0 && len(fields)-lead-1 > limits.MaxDegree {
+        return nil, &LimitError{Input: s, Pos: offsets[lead], Limit: "degree", Max: limits.MaxDegree, Got: len(fields) - lead - 1}
+    }
+    for i, field := range fields[lead:] {
+        if !isDecimalLiteral(field) {
+            return nil, &SyntaxError{Field: field, Index: lead + i}
+        }
+        if digits := coefficientDigits(field); limits.MaxCoefficientDigits > 0 && digits > limits.MaxCoefficientDigits {
+            return nil, &LimitError{Input: s, Pos: offsets[lead+i], Limit: "coefficient digits", Max: limits.MaxCoefficientDigits, Got: digits, Field: field}
+        }
+    }
+
+    n := len(fields) - lead
+    coeffs := make([]*big.Rat, n)
+    for i, field := range fields[lead:] {
+        c, ok := new(big.Rat).SetString(field)
+        if !ok {
+            return nil, &SyntaxError{Field: field, Index: lead + i}
+        }
+        coeffs[n-1-i] = c
+    }
+    return NewPolyNoCopy(coeffs), nil
+}
+
+// isZeroLiteral reports whether field is a plain zero such as "0", "-0" or "0/7"
+func isZeroLiteral(field string) bool {
+    num := strings.TrimLeft(field, "+-")
+    if i := strings.IndexByte(num, '/'); i >= 0 {
+        num = num[:i]
+    }
+    return num != "" && strings.Trim(num, "0") == ""
+}
+
+// isDecimalLiteral reports whether field is written in the coefficient
+// grammar of ParsePolynomial with an optional sign: digits and decimal
+// points, optionally followed by "/" and the digits of a denominator.
+// big.Rat.SetString, which checks the rest, would also accept base prefixes
+// such as "0x10" and exponents such as "1e3".
+func isDecimalLiteral(field string) bool {
+    if field != "" && (field[0] == '+' || field[0] == '-') {
+        field = field[1:]
+    }
+    num, den, isFraction := strings.Cut(field, "/")
+    if num == "" || strings.Trim(num, "0123456789.") != "" {
+        return false
+    }
+    return !isFraction || den != "" && strings.Trim(den, "0123456789") == ""
+}
+
+// coefficientDigits counts the digits of numerator and denominator of the
+// decimal coefficient written in field
+func coefficientDigits(field string) int {
+    digits := 0
+    for _, r := range field {
+        if isDigit(byte(r)) {
+            digits++
+        }
+    }
+    return digits
+}
+
+// coefficientList formats p in the syntax read by ParseCoefficients
+func coefficientList(p *Polynomial) string {
+    parts := make([]string, 0, len(p.coeff))
+    for i := p.Deg(); i >= 0; i-- {
+        if i < len(p.coeff) {
+            parts = append(parts, p.coeff[i].RatString())
+        } else {
+            parts = append(parts, "0")
+        }
+    }
+    return strings.Join(parts, ",")
+}
+
+// splitNonEmpty splits s at sep, trimming spaces and dropping empty fields
+func splitNonEmpty(s string, sep rune) []string {
+    var fields []string
+    for _, f := range strings.Split(s, string(sep)) {
+        if f = strings.TrimSpace(f); f != "" {
+            fields = append(fields, f)
+        }
+    }
+    return fields
+}
+
+// RatList formats a list of rationals as "[a, b, c]"
+func RatList(rs []*big.Rat) string {
+    parts := make([]string, len(rs))
+    for i, r := range rs {
+        parts[i] = r.RatString()
+    }
+    return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// ParseRatList parses a comma-separated list of rationals, in the given order,
+// written as the coefficients of ParseCoefficients
+func ParseRatList(s string) ([]*big.Rat, error) {
+    fields := splitNonEmpty(s, ',')
+    rs := make([]*big.Rat, len(fields))
+    for i, field := range fields {
+        if !isDecimalLiteral(field) {
+            return nil, &SyntaxError{Field: field, Index: i}
+        }
+        r, ok := new(big.Rat).SetString(field)
+        if !ok {
+            return nil, &SyntaxError{Field: field, Index: i}
+        }
+        rs[i] = r
+    }
+    return rs, nil
+}
@@ -0,0 +1,90 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// PartialFraction is one term Num / Den^Power of a partial fraction
+// decomposition, with deg Num < deg Den
+type PartialFraction struct {
+    Num   *Polynomial
+    Den   *Polynomial
+    Power int
+}
+
+// checkCoprimeFactors checks that the factors are nonconstant, have positive
+// multiplicities and are pairwise coprime, naming op in the errors
+func checkCoprimeFactors(op string, factors []Factor) error {
+    for i, fa := range factors {
+        if fa.Poly == nil {
+            return ErrNilPolynomial
+        }
+        if fa.Poly.Deg() < 1 {
+            return fmt.Errorf("%s: factor %d (%s) is constant", op, i+1, fa.Poly)
+        }
+        if fa.Multiplicity < 1 {
+            return fmt.Errorf("%s: factor %d has multiplicity %d", op, i+1, fa.Multiplicity)
+        }
+        for j := 0; j < i; j++ {
+            if gcd := extendedGCDResult(fa.Poly, factors[j].Poly).GCD; gcd.Deg() > 0 {
+                return fmt.Errorf("%s: factors %d and %d have the common factor %s", op, j+1, i+1, gcd.monic())
+            }
+        }
+    }
+    return nil
+}
+
+// PartialFractions decomposes num / den, where den is the product of the
+// given pairwise coprime factors raised to their multiplicities, as
+//
+//  poly + sum over the factors f of multiplicity m of A_1/f + ... + A_m/f^m
+//
+// with deg A_j < deg f; terms with A_j = 0 are left out. The factors need
+// not be irreducible: with the square-free factors of SquarefreeFactorization
+// the result is the square-free partial fraction decomposition. Each factor
+// P = f^m is split off the rest W of the denominator with the Bézout
+// coefficients of P and W: s*P + t*W = 1 gives r/(P*W) = (r*t mod P)/P +
+// (r*s mod W)/W for the proper part r/(P*W), and the numerator over P is
+// then written in powers of f by repeated division.
+func PartialFractions(num *Polynomial, den []Factor) (poly *Polynomial, terms []PartialFraction, err error) {
+    if num == nil {
+        return nil, nil, ErrNilPolynomial
+    }
+    if err := checkCoprimeFactors("partial fractions", den); err != nil {
+        return nil, nil, err
+    }
+    powers := make([]*Polynomial, len(den))
+    rest := One()
+    for i, fa := range den {
+        powers[i] = One()
+        for k := 0; k < fa.Multiplicity; k++ {
+            powers[i] = powers[i].mul(fa.Poly)
+        }
+        rest = rest.mul(powers[i])
+    }
+    poly, r := num.div(rest)
+    for i, fa := range den {
+        a := r
+        if i < len(den)-1 {
+            rest, _ = rest.div(powers[i])
+            res := extendedGCDResult(powers[i], rest)
+            // res.GCD is a nonzero constant: scale the cofactors to make it 1
+            c := new(big.Rat).Inv(res.GCD.coeff[0])
+            _, a = r.mul(res.T.scale(c)).div(powers[i])
+            _, r = r.mul(res.S.scale(c)).div(rest)
+        }
+        // a = d_0 + d_1 f + ... + d_(m-1) f^(m-1), and d_k / f^(m-k) is a
+        // term; the terms of a factor are listed by increasing power
+        var own []PartialFraction
+        for k := 0; k < fa.Multiplicity; k++ {
+            var d *Polynomial
+            a, d = a.div(fa.Poly)
+            if !d.IsZero() {
+                own = append([]PartialFraction{{Num: d, Den: fa.Poly, Power: fa.Multiplicity - k}}, own...)
+            }
+        }
+        terms = append(terms, own...)
+    }
+    return poly, terms, nil
+}
@@ -0,0 +1,69 @@
+package poly
+
+import (
+    "testing"
+)
+
+// TestPartialFractions checks known decompositions and that poly*den plus
+// the terms over den adds back up to num
+func TestPartialFractions(t *testing.T) {
+    parse := func(s string) *Polynomial {
+        p, _ := ParsePolynomial(s)
+        return p
+    }
+    cases := []struct {
+        num   string
+        den   []Factor
+        terms int
+    }{
+        {"1", []Factor{{parse("x - 1"), 1}, {parse("x + 1"), 1}}, 2},
+        {"x^3", []Factor{{parse("x - 1"), 2}, {parse("x + 1"), 1}}, 3},
+        {"x^5 + 2", []Factor{{parse("x^2 + 1"), 2}}, 2},
+        {"3x + 1", []Factor{{parse("x"), 1}, {parse("x^2 + x + 1"), 1}}, 2},
+        {"x^2 - 1", []Factor{{parse("x - 2"), 3}}, 3},
+    }
+    for _, c := range cases {
+        num := parse(c.num)
+        den := One()
+        for _, fa := range c.den {
+            for k := 0; k < fa.Multiplicity; k++ {
+                den = den.Mul(fa.Poly)
+            }
+        }
+        poly, terms, err := PartialFractions(num, c.den)
+        if err != nil {
+            t.Errorf("%s / %s: %v", num, den, err)
+            continue
+        }
+        if len(terms) != c.terms {
+            t.Errorf("%s / %s has %d terms, want %d", num, den, len(terms), c.terms)
+        }
+        sum := poly.Mul(den)
+        for _, term := range terms {
+            if term.Num.Deg() >= term.Den.Deg() {
+                t.Errorf("%s / %s: term (%s)/(%s)^%d is not proper", num, den, term.Num, term.Den, term.Power)
+            }
+            power := One()
+            for k := 0; k < term.Power; k++ {
+                power = power.Mul(term.Den)
+            }
+            cofactor, rem, err := den.Div(power)
+            if err != nil || !rem.IsZero() {
+                t.Fatalf("%s / %s: (%s)^%d does not divide the denominator", num, den, term.Den, term.Power)
+            }
+            sum = sum.Add(term.Num.Mul(cofactor))
+        }
+        if !sum.Equal(num) {
+            t.Errorf("%s / %s adds back up to %s / %s", num, den, sum, den)
+        }
+    }
+    for _, den := range [][]Factor{
+        {{parse("x^2 - 1"), 1}, {parse("x - 1"), 1}},
+        {{parse("3"), 1}},
+        {{parse("x"), 0}},
+    } {
+        if _, _, err := PartialFractions(One(), den); err == nil {
+            t.Errorf("denominator %v accepted", den)
+        }
+    }
+}
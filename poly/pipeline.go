@@ -0,0 +1,141 @@
+package poly
+
+import (
+    "fmt"
+    "strings"
+)
+
+// Pipeline is a chain of operations on a polynomial that runs only when
+// Result is called:
+//
+//  NewPipeline(f).Mul(g).Mod(m).Derivative().Result()
+//
+// Building a pipeline allocates no polynomials and reports no errors; Result
+// runs the stages in order, on intermediate results the pipeline owns and
+// need not copy, and returns the first error with the stage that caused it.
+// The stages can be listed with Stages and printed with String before
+// anything runs. Every method returns a new pipeline and leaves its receiver
+// as it was, so a common prefix can be shared by several pipelines.
+type Pipeline struct {
+    start  *Polynomial
+    stages []PipelineStage
+    opts   []Option
+}
+
+// PipelineStage is one operation of a Pipeline: Op names it ("add", "sub",
+// "mul", "quo", "mod", "compose", "derivative", "monic") and Arg is the
+// second operand, nil for the unary operations
+type PipelineStage struct {
+    Op  string
+    Arg *Polynomial
+}
+
+// NewPipeline starts a pipeline at f; opts apply to the stages that take
+// them, as WithStrategy does to the multiplications
+func NewPipeline(f *Polynomial, opts ...Option) *Pipeline {
+    return &Pipeline{start: f, opts: opts}
+}
+
+// then returns a copy of pl with the stage op arg appended
+func (pl *Pipeline) then(op string, arg *Polynomial) *Pipeline {
+    stages := make([]PipelineStage, len(pl.stages), len(pl.stages)+1)
+    copy(stages, pl.stages)
+    return &Pipeline{start: pl.start, stages: append(stages, PipelineStage{Op: op, Arg: arg}), opts: pl.opts}
+}
+
+// Add appends the stage p + q
+func (pl *Pipeline) Add(q *Polynomial) *Pipeline { return pl.then("add", q) }
+
+// Sub appends the stage p - q
+func (pl *Pipeline) Sub(q *Polynomial) *Pipeline { return pl.then("sub", q) }
+
+// Mul appends the stage p * q
+func (pl *Pipeline) Mul(q *Polynomial) *Pipeline { return pl.then("mul", q) }
+
+// Quo appends the stage of the quotient of p by q
+func (pl *Pipeline) Quo(q *Polynomial) *Pipeline { return pl.then("quo", q) }
+
+// Mod appends the stage of the remainder of p by m
+func (pl *Pipeline) Mod(m *Polynomial) *Pipeline { return pl.then("mod", m) }
+
+// Compose appends the stage p(q(x))
+func (pl *Pipeline) Compose(q *Polynomial) *Pipeline { return pl.then("compose", q) }
+
+// Derivative appends the stage p'
+func (pl *Pipeline) Derivative() *Pipeline { return pl.then("derivative", nil) }
+
+// Monic appends the stage dividing p by its leading coefficient; the zero
+// polynomial stays zero
+func (pl *Pipeline) Monic() *Pipeline { return pl.then("monic", nil) }
+
+// Stages returns the stages of pl in the order they run
+func (pl *Pipeline) Stages() []PipelineStage {
+    return append([]PipelineStage(nil), pl.stages...)
+}
+
+// String writes pl as its start followed by its stages, such as
+// "x^2 + 1/1 | mul (x - 1/1) | derivative"
+func (pl *Pipeline) String() string {
+    parts := []string{fmt.Sprint(pl.start)}
+    for _, st := range pl.stages {
+        parts = append(parts, st.String())
+    }
+    return strings.Join(parts, " | ")
+}
+
+func (st PipelineStage) String() string {
+    if st.Arg == nil {
+        return st.Op
+    }
+    return fmt.Sprintf("%s (%s)", st.Op, st.Arg)
+}
+
+// Result runs the stages of pl on its start and returns the final
+// polynomial. Errors are ErrNilPolynomial for a nil start or operand,
+// ErrDivisionByZero for quo or mod by zero; both name the stage.
+func (pl *Pipeline) Result() (*Polynomial, error) {
+    if pl.start == nil {
+        return nil, ErrNilPolynomial
+    }
+    p := NewPolynomial(pl.start.coeff)
+    for i, st := range pl.stages {
+        var err error
+        if p, err = st.apply(p, pl.opts); err != nil {
+            return nil, fmt.Errorf("pipeline stage %d (%s): %w", i+1, st, err)
+        }
+    }
+    return p, nil
+}
+
+// apply runs st on p
+func (st PipelineStage) apply(p *Polynomial, opts []Option) (*Polynomial, error) {
+    switch st.Op {
+    case "derivative":
+        return p.Derivative(), nil
+    case "monic":
+        return p.monic(), nil
+    }
+    if st.Arg == nil {
+        return nil, ErrNilPolynomial
+    }
+    switch st.Op {
+    case "add":
+        return p.Add(st.Arg), nil
+    case "sub":
+        return p.Sub(st.Arg), nil
+    case "mul":
+        return p.Mul(st.Arg, opts...), nil
+    case "compose":
+        return p.Compose(st.Arg), nil
+    case "quo", "mod":
+        if st.Arg.IsZero() {
+            return nil, ErrDivisionByZero
+        }
+        q, r := p.div(st.Arg)
+        if st.Op == "quo" {
+            return q, nil
+        }
+        return r, nil
+    }
+    return nil, fmt.Errorf("unknown operation %q", st.Op)
+}
@@ -0,0 +1,563 @@
+// Package poly implements polynomials with rational coefficients and
+// the extended Euclidean algorithm on them, together with the tools built on
+// top: exact root isolation, rational functions, recurrences, interpolation,
+// alternative coefficient backends, exporters and the demos of the euclid
+// command, which is a thin wrapper around this package. Polynomials over
+// GF(p) and GF(2) are in package polymod, which ModP converts to.
+//
+//     f, _ := poly.ParseCoefficients("1,0,-1")  // x^2 - 1
+//     g, _ := poly.ParseCoefficients("1,-3,2")  // x^2 - 3x + 2
+//     gcd, s, t, err := poly.ExtendedGCD(f, g)  // s*f + t*g = gcd = 3x - 3
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "math/rand"
+    "strings"
+    "time"
+
+    "euclid/polymod"
+)
+
+// Polynomial is a polynomial with rational coefficients, an element of the
+// polynomial ring Q[x]
+type Polynomial struct {
+    coeff []*big.Rat
+}
+
+// NewPolynomial creates a new polynomial from the given coefficients. The
+// slice and the coefficients are copied, so the caller may go on to modify
+// them without affecting the polynomial. An empty slice gives the zero
+// polynomial.
+func NewPolynomial(coeffs []*big.Rat) *Polynomial {
+    if len(coeffs) == 0 {
+        return Zero()
+    }
+    copied := make([]*big.Rat, len(coeffs))
+    for i, c := range coeffs {
+        copied[i] = new(big.Rat).Set(c)
+    }
+    return &Polynomial{coeff: copied}
+}
+
+// NewPolyNoCopy creates a polynomial that takes ownership of coeffs without
+// copying. The caller must not modify the slice or its coefficients
+// afterwards; arithmetic uses it for the slices it has just built. An empty
+// slice gives the zero polynomial.
+func NewPolyNoCopy(coeffs []*big.Rat) *Polynomial {
+    if len(coeffs) == 0 {
+        coeffs = []*big.Rat{new(big.Rat)}
+    }
+    return &Polynomial{coeff: coeffs}
+}
+
+// Zero returns the zero polynomial
+func Zero() *Polynomial {
+    return &Polynomial{coeff: []*big.Rat{new(big.Rat)}}
+}
+
+// One returns the constant polynomial 1
+func One() *Polynomial {
+    return NewPolyNoCopy([]*big.Rat{big.NewRat(1, 1)})
+}
+
+// X returns the polynomial x
+func X() *Polynomial {
+    return NewPolyNoCopy([]*big.Rat{new(big.Rat), big.NewRat(1, 1)})
+}
+
+// Constant returns the constant polynomial r
+func Constant(r *big.Rat) *Polynomial {
+    return NewPolyNoCopy([]*big.Rat{new(big.Rat).Set(r)})
+}
+
+// Monomial returns the polynomial c*x^n. The degree n must not be negative;
+// Monomial panics otherwise, as x^n is then not a polynomial.
+func Monomial(c *big.Rat, n int) *Polynomial {
+    if n < 0 {
+        panic(fmt.Sprintf("poly: Monomial of negative degree %d", n))
+    }
+    coeffs := make([]*big.Rat, n+1)
+    for i := range coeffs {
+        coeffs[i] = new(big.Rat)
+    }
+    coeffs[n].Set(c)
+    return NewPolyNoCopy(coeffs)
+}
+
+// FromRoots creates the monic polynomial (x - r_1)(x - r_2)...(x - r_n)
+func FromRoots(roots []*big.Rat) *Polynomial {
+    p := One()
+    for _, r := range roots {
+        p = p.mul(X().Sub(Constant(r)))
+    }
+    return p
+}
+
+// perturb returns a copy of p with delta added to the coefficient of x^k
+func (p *Polynomial) perturb(k int, delta *big.Rat) *Polynomial {
+    coeffs := make([]*big.Rat, max(len(p.coeff), k+1))
+    for i := range coeffs {
+        coeffs[i] = new(big.Rat)
+        if i < len(p.coeff) {
+            coeffs[i].Set(p.coeff[i])
+        }
+    }
+    coeffs[k].Add(coeffs[k], delta)
+    return NewPolyNoCopy(coeffs)
+}
+
+// Deg returns the degree of the polynomial
+func (p *Polynomial) Deg() int {
+    for i := len(p.coeff) - 1; i >= 0; i-- {
+        if p.coeff[i].Sign() != 0 {
+            return i
+        }
+    }
+    return 0
+}
+
+// Coeff returns a copy of the coefficient of x^i, which is 0 above the degree
+func (p *Polynomial) Coeff(i int) *big.Rat {
+    if i < 0 || i >= len(p.coeff) {
+        return new(big.Rat)
+    }
+    return new(big.Rat).Set(p.coeff[i])
+}
+
+// Eval evaluates the polynomial at x using Horner's scheme
+func (p *Polynomial) Eval(x *big.Rat) *big.Rat {
+    result := new(big.Rat)
+    for i := p.Deg(); i >= 0 && i < len(p.coeff); i-- {
+        result.Mul(result, x)
+        result.Add(result, p.coeff[i])
+    }
+    return result
+}
+
+// trim returns p without zero coefficients above its degree, sharing the
+// coefficients of p; only used on polynomials owned by the caller
+func (p *Polynomial) trim() *Polynomial {
+    if n := p.Deg() + 1; n < len(p.coeff) {
+        return NewPolyNoCopy(p.coeff[:n])
+    }
+    return p
+}
+
+// IsZero checks if the polynomial is zero
+func (p *Polynomial) IsZero() bool {
+    for _, c := range p.coeff {
+        if c.Sign() != 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// Equal checks if two polynomials are equal
+func (p *Polynomial) Equal(q *Polynomial) bool {
+    if p.IsZero() || q.IsZero() {
+        return p.IsZero() && q.IsZero()
+    }
+    if p.Deg() != q.Deg() {
+        return false
+    }
+    for i := 0; i <= p.Deg(); i++ {
+        if p.coeff[i].Cmp(q.coeff[i]) != 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// String formats p from the highest power down, e.g. "x^2 - 1/2", "-x + 3/1"
+// or "0". ParsePolynomial reads the result back to p: every coefficient is
+// written as an exact fraction and every nonzero term is present.
+func (p *Polynomial) String() string {
+    var b strings.Builder
+    p.WriteTo(&b)
+    return b.String()
+}
+
+func absRat(r *big.Rat) *big.Rat {
+    if r.Sign() < 0 {
+        return new(big.Rat).Neg(r)
+    }
+    return r
+}
+
+// Add adds two polynomials
+func (p *Polynomial) Add(q *Polynomial) *Polynomial {
+    maxDeg := max(p.Deg(), q.Deg())
+    result := make([]*big.Rat, maxDeg+1)
+    for i := 0; i <= maxDeg; i++ {
+        result[i] = new(big.Rat)
+        if i <= p.Deg() && i < len(p.coeff) {
+            result[i].Add(result[i], p.coeff[i])
+        }
+        if i <= q.Deg() && i < len(q.coeff) {
+            result[i].Add(result[i], q.coeff[i])
+        }
+    }
+    return NewPolyNoCopy(result)
+}
+
+// Sub subtracts two polynomials
+func (p *Polynomial) Sub(q *Polynomial) *Polynomial {
+    maxDeg := max(p.Deg(), q.Deg())
+    result := make([]*big.Rat, maxDeg+1)
+    for i := 0; i <= maxDeg; i++ {
+        result[i] = new(big.Rat)
+        if i <= p.Deg() && i < len(p.coeff) {
+            result[i].Add(result[i], p.coeff[i])
+        }
+        if i <= q.Deg() && i < len(q.coeff) {
+            result[i].Sub(result[i], q.coeff[i])
+        }
+    }
+    return NewPolyNoCopy(result)
+}
+
+// mul multiplies two polynomials, choosing the algorithm by degree
+func (p *Polynomial) mul(q *Polynomial) *Polynomial {
+    return p.mulWith(q, "auto")
+}
+
+// mulNaive multiplies two polynomials with the schoolbook algorithm
+func (p *Polynomial) mulNaive(q *Polynomial) *Polynomial {
+    result := make([]*big.Rat, p.Deg()+q.Deg()+1)
+    for i := range result {
+        result[i] = new(big.Rat)
+    }
+    for i := 0; i <= p.Deg() && i < len(p.coeff); i++ {
+        for j := 0; j <= q.Deg() && j < len(q.coeff); j++ {
+            temp := new(big.Rat).Mul(p.coeff[i], q.coeff[j])
+            result[i+j].Add(result[i+j], temp)
+        }
+    }
+    return NewPolyNoCopy(result)
+}
+
+// ErrDivisionByZero is returned when dividing by the zero polynomial; it is
+// polymod.ErrDivisionByZero, so that one value covers Q[x] and GF(p)[x]
+var ErrDivisionByZero = polymod.ErrDivisionByZero
+
+// ErrNilPolynomial is returned when a nil *Polynomial is passed where a
+// polynomial is required
+var ErrNilPolynomial = errors.New("nil polynomial")
+
+// Div returns the quotient and remainder of p divided by q, with
+// deg r < deg q, or ErrDivisionByZero if q is the zero polynomial
+func (p *Polynomial) Div(q *Polynomial) (*Polynomial, *Polynomial, error) {
+    if p == nil || q == nil {
+        return nil, nil, ErrNilPolynomial
+    }
+    if q.IsZero() {
+        return nil, nil, ErrDivisionByZero
+    }
+    quo, rem := p.div(q)
+    return quo, rem, nil
+}
+
+// div is Div for callers that have already ruled out a zero divisor. A zero
+// q gives the quotient 0 and the remainder p, which keeps p = quo*q + rem
+// but not deg rem < deg q.
+func (p *Polynomial) div(q *Polynomial) (*Polynomial, *Polynomial) {
+    pDeg, qDeg := p.Deg(), q.Deg()
+    if pDeg < qDeg || q.IsZero() || p.IsZero() {
+        // If the degree of p is less than the degree of q, return quotient as 0 and p as the remainder
+        return Zero(), NewPolynomial(p.coeff)
+    }
+    if qDeg == 1 {
+        // Dividing by a linear polynomial only needs Horner's scheme
+        return p.divLinear(q)
+    }
+
+    quotient := make([]*big.Rat, pDeg-qDeg+1)
+    remainder := make([]*big.Rat, pDeg+1) // Ensure this matches the degree of p
+
+    for i := range quotient {
+        quotient[i] = new(big.Rat)
+    }
+    for i := range remainder {
+        remainder[i] = new(big.Rat).Set(p.coeff[i])
+    }
+
+    for pDeg >= qDeg {
+        leadCoeff := new(big.Rat).Quo(remainder[pDeg], q.coeff[qDeg])
+        quotient[pDeg-qDeg] = leadCoeff
+
+        for i := range q.coeff {
+            temp := new(big.Rat).Mul(leadCoeff, q.coeff[i])
+            if pDeg-qDeg+i < len(remainder) {
+                remainder[pDeg-qDeg+i].Sub(remainder[pDeg-qDeg+i], temp)
+            }
+        }
+
+        for pDeg >= 0 && remainder[pDeg].Sign() == 0 {
+            pDeg--
+        }
+    }
+
+    // Ensure the remainder slice is correctly sliced to match the actual degree
+    if pDeg < 0 {
+        return NewPolyNoCopy(quotient), Zero()
+    }
+    return NewPolyNoCopy(quotient), NewPolyNoCopy(remainder[:pDeg+1])
+}
+
+
+
+
+
+// GCDResult holds the outcome of the extended Euclidean algorithm together with
+// metadata about the run
+type GCDResult struct {
+    GCD, S, T *Polynomial
+
+    // Iterations is the number of division steps performed
+    Iterations int
+    // MaxIterations is the Lamé-style bound on Iterations: min(deg f, deg g) + 1,
+    // plus one step for the initial swap when deg f < deg g
+    MaxIterations int
+    // WorstCase reports whether the run hit MaxIterations, i.e. every remainder
+    // had degree exactly one less than its divisor
+    WorstCase bool
+
+    // Steps records every division of the run, in order
+    Steps []EuclidStep
+
+    // Timing breaks the running time down by phase
+    Timing GCDTiming
+
+    // SquarefreeChanged reports that squarefree preprocessing replaced an
+    // input by a polynomial of lower degree; S and T then belong to the
+    // squarefree parts rather than to the original inputs
+    SquarefreeChanged bool
+}
+
+// GCDOptions controls how the GCD is computed
+type GCDOptions struct {
+    // SquarefreeFirst replaces f and g by their squarefree parts before
+    // running the algorithm. The GCD then has the common roots of f and g,
+    // each once, which is all that matters when only the set of common roots
+    // is wanted, and repeated factors no longer inflate the degrees.
+    SquarefreeFirst bool
+    // MinimalCofactors reduces S and T to the minimal Bézout cofactors,
+    // deg S < deg(g/gcd) and deg T < deg(f/gcd). The Euclidean algorithm
+    // already returns them, so this guards the other strategies.
+    MinimalCofactors bool
+}
+
+// GCDTiming is the time spent in each phase of the extended Euclidean
+// algorithm. Total also covers bookkeeping outside the three phases.
+type GCDTiming struct {
+    // Divisions is the time spent computing quotients and remainders
+    Divisions time.Duration
+    // Updates is the time spent on the multiplications and subtractions
+    // that update the cofactors s and t
+    Updates time.Duration
+    // Normalization is the time spent trimming zero leading coefficients
+    // from the updated cofactors
+    Normalization time.Duration
+    Total         time.Duration
+}
+
+// EuclidStep is one iteration of the extended Euclidean algorithm:
+// Dividend = Quotient*Divisor + Remainder, after which the Bézout
+// cofactors of Remainder are S and T
+type EuclidStep struct {
+    Dividend, Divisor   *Polynomial
+    Quotient, Remainder *Polynomial
+    S, T                *Polynomial
+}
+
+// maxEuclideanIterations returns the largest number of division steps the
+// Euclidean algorithm can take on polynomials f and g
+func maxEuclideanIterations(f, g *Polynomial) int {
+    if g.IsZero() {
+        return 0
+    }
+    if f.Deg() < g.Deg() {
+        return f.Deg() + 2
+    }
+    return g.Deg() + 1
+}
+
+// ExtendedGCD implements the extended Euclidean algorithm for polynomials:
+// it returns gcd, s and t with s*f + t*g = gcd
+func ExtendedGCD(f, g *Polynomial) (gcd, s, t *Polynomial, err error) {
+    res, err := ExtendedGCDResult(f, g)
+    if err != nil {
+        return nil, nil, nil, err
+    }
+    return res.GCD, res.S, res.T, nil
+}
+
+// ExtendedGCDResult runs the extended Euclidean algorithm and
+// returns the result along with its metadata. WithStrategy selects the
+// multiplication algorithm of the cofactor updates and
+// WithGCDStrategy("subresultant") the subresultant PRS, which returns a
+// primitive integer gcd. It fails with ErrNilPolynomial, the error of an
+// invalid option or, for WithGCDStrategy("halfgcd"), which runs over GF(p)
+// only, ErrUnsupportedStrategy.
+func ExtendedGCDResult(f, g *Polynomial, opts ...Option) (*GCDResult, error) {
+    if f == nil || g == nil {
+        return nil, ErrNilPolynomial
+    }
+    if err := newConfig(opts...).checkGCD("Q"); err != nil {
+        return nil, err
+    }
+    return extendedGCDResult(f, g, opts...), nil
+}
+
+// extendedGCDResult is ExtendedGCDResult for callers that already hold
+// valid polynomials
+func extendedGCDResult(f, g *Polynomial, opts ...Option) *GCDResult {
+    cfg := newConfig(opts...)
+    var res *GCDResult
+    if cfg.gcd == "subresultant" {
+        res = subresultantGCDResult(f, g, cfg)
+    } else {
+        res = euclideanGCDResult(f, g, cfg)
+    }
+    if cfg.monic {
+        res.makeMonic()
+    }
+    return res
+}
+
+// euclideanGCDResult runs the extended Euclidean algorithm over Q
+func euclideanGCDResult(f, g *Polynomial, cfg config) *GCDResult {
+    start := time.Now()
+    strategy := cfg.strategy
+    res := &GCDResult{MaxIterations: maxEuclideanIterations(f, g)}
+    // copy the inputs, which would otherwise end up shared with GCD and Steps
+    f, g = NewPolynomial(f.coeff), NewPolynomial(g.coeff)
+
+    s0 := One()
+    s1 := Zero()
+    t0 := Zero()
+    t1 := One()
+
+    for !g.IsZero() {
+        phase := time.Now()
+        q, r := f.div(g)
+        res.Timing.Divisions += time.Since(phase)
+
+        step := EuclidStep{Dividend: f, Divisor: g, Quotient: q, Remainder: r}
+        f, g = g, r
+
+        phase = time.Now()
+        s0, s1 = s1, s0.Sub(q.mulWith(s1, strategy))
+        t0, t1 = t1, t0.Sub(q.mulWith(t1, strategy))
+        res.Timing.Updates += time.Since(phase)
+
+        phase = time.Now()
+        s1, t1 = s1.trim(), t1.trim()
+        res.Timing.Normalization += time.Since(phase)
+
+        step.S, step.T = s1, t1
+        res.Steps = append(res.Steps, step)
+        res.Iterations++
+        if cfg.trace != nil {
+            cfg.trace(res.Iterations, step)
+        }
+        if cfg.step != nil {
+            deg := g.Deg()
+            if g.IsZero() {
+                deg = -1
+            }
+            cfg.step(res.Iterations, deg)
+        }
+    }
+
+    res.GCD, res.S, res.T = f, s0, t0
+    res.WorstCase = res.Iterations == res.MaxIterations
+    res.Timing.Total = time.Since(start)
+    return res
+}
+
+// makeMonic divides GCD, S and T by the leading coefficient of GCD, so that
+// the GCD is monic and S*f + T*g = GCD still holds; a zero GCD is left alone
+func (r *GCDResult) makeMonic() {
+    if r.GCD.IsZero() {
+        return
+    }
+    c := new(big.Rat).Inv(r.GCD.coeff[r.GCD.Deg()])
+    r.GCD, r.S, r.T = r.GCD.scale(c), r.S.scale(c), r.T.scale(c)
+}
+
+// TimingSummary formats the per-phase timing with each phase's share of the total
+func (r *GCDResult) TimingSummary() string {
+    share := func(d time.Duration) float64 {
+        if r.Timing.Total == 0 {
+            return 0
+        }
+        return 100 * d.Seconds() / r.Timing.Total.Seconds()
+    }
+    return fmt.Sprintf("divisions %.6fs (%.0f%%), s/t updates %.6fs (%.0f%%), normalization %.6fs (%.0f%%)",
+        r.Timing.Divisions.Seconds(), share(r.Timing.Divisions),
+        r.Timing.Updates.Seconds(), share(r.Timing.Updates),
+        r.Timing.Normalization.Seconds(), share(r.Timing.Normalization))
+}
+
+// ExtendedGCDWith runs the extended Euclidean algorithm with the
+// given GCD options; opts apply, and fail, as in ExtendedGCDResult
+func ExtendedGCDWith(f, g *Polynomial, gopts GCDOptions, opts ...Option) (*GCDResult, error) {
+    if f == nil || g == nil {
+        return nil, ErrNilPolynomial
+    }
+    if err := newConfig(opts...).checkGCD("Q"); err != nil {
+        return nil, err
+    }
+    changed := false
+    if gopts.SquarefreeFirst {
+        sf, sg := f.squarefreePart(), g.squarefreePart()
+        changed = sf.Deg() < f.Deg() || sg.Deg() < g.Deg()
+        f, g = sf, sg
+    }
+    res := extendedGCDResult(f, g, opts...)
+    res.SquarefreeChanged = changed
+    if gopts.MinimalCofactors {
+        res.S, res.T = minimizeCofactors(f, g, res.GCD, res.S, res.T)
+    }
+    return res, nil
+}
+
+// IterationsSummary formats the iteration count against its bound
+func (r *GCDResult) IterationsSummary() string {
+    s := fmt.Sprintf("%d of at most %d", r.Iterations, r.MaxIterations)
+    if r.WorstCase && r.Iterations > 0 {
+        s += " (worst case)"
+    }
+    return s
+}
+
+func min(a, b int) int {
+    if a < b {
+        return a
+    }
+    return b
+}
+
+func max(a, b int) int {
+    if a > b {
+        return a
+    }
+    return b
+}
+
+// RandomPolynomial returns a polynomial of degree at most degree with
+// integer coefficients drawn uniformly from -5 to 5 by rng; the leading
+// coefficient may be zero, so the degree can come out lower
+func RandomPolynomial(rng *rand.Rand, degree int) *Polynomial {
+    coeffs := make([]*big.Rat, degree+1)
+    for i := 0; i <= degree; i++ {
+        coeff := rng.Intn(11) - 5 // Random coefficient between -5 and 5
+        coeffs[i] = big.NewRat(int64(coeff), 1)
+    }
+    return NewPolyNoCopy(coeffs)
+}
@@ -0,0 +1,93 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+// TestEmptyCoefficientsAreZero checks that polynomials built from empty
+// coefficient slices, or with no coefficients at all, behave as the zero
+// polynomial in division and the extended GCD instead of indexing past the
+// end of the slice
+func TestEmptyCoefficientsAreZero(t *testing.T) {
+    empties := map[string]*Polynomial{
+        "NewPolynomial(nil)":     NewPolynomial(nil),
+        "NewPolyNoCopy([]{})":    NewPolyNoCopy([]*big.Rat{}),
+        "zero value Polynomial": &Polynomial{},
+    }
+    for name, e := range empties {
+        if !e.IsZero() || e.Deg() != 0 || e.String() != "0" {
+            t.Errorf("%s is %q of degree %d, want the zero polynomial", name, e, e.Deg())
+        }
+        quo, rem, err := e.Div(One())
+        if err != nil || !quo.IsZero() || !rem.IsZero() {
+            t.Errorf("%s / 1 = %v rem %v, %v", name, quo, rem, err)
+        }
+        if _, _, err := X().Div(e); !errors.Is(err, ErrDivisionByZero) {
+            t.Errorf("x / %s returned %v, want ErrDivisionByZero", name, err)
+        }
+        gcd, s, u, err := ExtendedGCD(e, X())
+        if err != nil {
+            t.Errorf("ExtendedGCD(%s, x): %v", name, err)
+            continue
+        }
+        if err := Verify(e, X(), gcd, s, u); err != nil || gcd.Deg() != 1 {
+            t.Errorf("ExtendedGCD(%s, x) = %s: %v", name, gcd, err)
+        }
+    }
+}
+
+func TestMonomial(t *testing.T) {
+    if m := Monomial(big.NewRat(3, 2), 4); m.Deg() != 4 || m.Coeff(4).Cmp(big.NewRat(3, 2)) != 0 || m.Coeff(0).Sign() != 0 {
+        t.Errorf("Monomial(3/2, 4) = %v, want 3/2*x^4", m)
+    }
+    if m := Monomial(big.NewRat(5, 1), 0); !m.Equal(Constant(big.NewRat(5, 1))) {
+        t.Errorf("Monomial(5, 0) = %v, want 5", m)
+    }
+    defer func() {
+        if msg, ok := recover().(string); !ok || msg != "poly: Monomial of negative degree -1" {
+            t.Errorf("Monomial(1, -1) recovered %v, want the negative degree panic", msg)
+        }
+    }()
+    Monomial(big.NewRat(1, 1), -1)
+}
+
+// TestExtendedGCDBezout checks the Bézout identity s*f + t*g = gcd and the
+// gcd up to a constant factor with the rational Euclidean algorithm and the
+// subresultant PRS
+func TestExtendedGCDBezout(t *testing.T) {
+    cases := []struct {
+        f, g, gcd string // gcd monic
+    }{
+        {"x^2 - 1", "x^2 - 3x + 2", "x - 1"},
+        {"x^4 + 2x^3 - x - 2", "x^3 - 1", "x^3 - 1"},
+        {"2x^3 + 4x", "6x^2 + 12", "x^2 + 2"},
+        {"x^2 + 1", "x - 3", "1"},
+        {"0", "3x - 6", "x - 2"},
+        {"1/2x^2 - 1/2", "2/3x + 2/3", "x + 1"},
+        // Knuth's example of coefficient growth in the remainder sequence
+        {"x^8 + x^6 - 3x^4 - 3x^3 + 8x^2 + 2x - 5", "3x^6 + 5x^4 - 4x^2 - 9x + 21", "1"},
+    }
+    for _, c := range cases {
+        f, _ := ParsePolynomial(c.f)
+        g, _ := ParsePolynomial(c.g)
+        want, _ := ParsePolynomial(c.gcd)
+        for _, strategy := range []string{"euclid", "subresultant"} {
+            res, err := ExtendedGCDResult(f, g, WithGCDStrategy(strategy))
+            if err != nil {
+                t.Errorf("%s: ExtendedGCDResult(%s, %s): %v", strategy, f, g, err)
+                continue
+            }
+            if err := Verify(f, g, res.GCD, res.S, res.T); err != nil {
+                t.Errorf("%s: gcd(%s, %s): %v", strategy, f, g, err)
+            }
+            if !res.GCD.Monic().Equal(want) {
+                t.Errorf("%s: gcd(%s, %s) = %s, want %s up to a constant", strategy, f, g, res.GCD, want)
+            }
+        }
+    }
+    if _, err := ExtendedGCDResult(X(), One(), WithGCDStrategy("halfgcd")); !errors.Is(err, ErrUnsupportedStrategy) {
+        t.Errorf("halfgcd over Q returned %v, want ErrUnsupportedStrategy", err)
+    }
+}
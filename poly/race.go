@@ -0,0 +1,120 @@
+package poly
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+// raceProgressInterval is the least time between two progress reports of
+// one race entrant; the first and the last step are always reported
+const raceProgressInterval = 100 * time.Millisecond
+
+// RaceProgress is a progress report of one entrant of Race
+type RaceProgress struct {
+    Strategy string
+    // Iterations is the number of divisions so far, Degree the degree of the
+    // current remainder (-1 once it is zero) and Elapsed the time since the
+    // start of the race
+    Iterations int
+    Degree     int
+    Elapsed    time.Duration
+}
+
+// RaceResult is the outcome of one entrant of Race
+type RaceResult struct {
+    Strategy string
+    // Place is 1 for the entrant that finished first
+    Place      int
+    Iterations int
+    Elapsed    time.Duration
+    // GCD is the monic gcd as the entrant's coefficients print it
+    GCD string
+    Err error
+}
+
+// raceEntrant runs the extended Euclidean algorithm one way, passing opts
+// (which carry the step hook) to the algorithm
+type raceEntrant func(f, g *Polynomial, opts ...Option) (string, error)
+
+// newRaceEntrant resolves a race strategy: a multiplication strategy runs
+// Polynomial's own algorithm with it, anything else names a backend
+func newRaceEntrant(name string) (raceEntrant, error) {
+    if IsMulStrategy(name) {
+        return func(f, g *Polynomial, opts ...Option) (string, error) {
+            opts = append(opts[:len(opts):len(opts)], WithStrategy(name))
+            if err := newConfig(opts...).checkGCD("Q"); err != nil {
+                return "", err
+            }
+            res := extendedGCDResult(f, g, opts...)
+            return layoutPolyString(res.GCD.monic()), nil
+        }, nil
+    }
+    b, err := NewBackend(name)
+    if err != nil {
+        return nil, fmt.Errorf("race: %q is neither a multiplication strategy (%s) nor a backend: %v", name, strings.Join(mulStrategies, ", "), err)
+    }
+    return func(f, g *Polynomial, opts ...Option) (string, error) {
+        gcd, _, _, err := extendedEuclideanBackend(b, f, g, opts...)
+        if err != nil {
+            return "", err
+        }
+        return gcd.monic().String(), nil
+    }, nil
+}
+
+// Race runs the extended Euclidean algorithm on f and g with each of
+// strategies concurrently and returns their results in the order they
+// finished. A strategy is a multiplication strategy, such as "naive" or
+// "kronecker", or a backend spec, such as "rat" or "modp:65537". progress,
+// if not nil, receives the entrants' progress reports; it is called from
+// the calling goroutine only, so it needs no locking.
+func Race(f, g *Polynomial, strategies []string, progress func(RaceProgress)) ([]RaceResult, error) {
+    if f == nil || g == nil {
+        return nil, ErrNilPolynomial
+    }
+    if len(strategies) < 2 {
+        return nil, fmt.Errorf("race: need at least two strategies, got %d", len(strategies))
+    }
+    entrants := make([]raceEntrant, len(strategies))
+    for i, name := range strategies {
+        entrant, err := newRaceEntrant(name)
+        if err != nil {
+            return nil, err
+        }
+        entrants[i] = entrant
+    }
+
+    reports := make(chan RaceProgress)
+    done := make(chan RaceResult)
+    start := time.Now()
+    for i, entrant := range entrants {
+        go func(name string, entrant raceEntrant) {
+            var last time.Time
+            iterations := 0
+            step := func(n, deg int) {
+                iterations = n
+                if n == 1 || deg < 0 || time.Since(last) >= raceProgressInterval {
+                    last = time.Now()
+                    reports <- RaceProgress{Strategy: name, Iterations: n, Degree: deg, Elapsed: time.Since(start)}
+                }
+            }
+            gcd, err := entrant(f, g, withStepHook(step))
+            done <- RaceResult{Strategy: name, Iterations: iterations, Elapsed: time.Since(start), GCD: gcd, Err: err}
+        }(strategies[i], entrant)
+    }
+
+    results := make([]RaceResult, 0, len(entrants))
+    for len(results) < len(entrants) {
+        select {
+        case p := <-reports:
+            if progress != nil {
+                progress(p)
+            }
+        case r := <-done:
+            r.Place = len(results) + 1
+            results = append(results, r)
+        }
+    }
+    return results, nil
+}
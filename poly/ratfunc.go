@@ -0,0 +1,166 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+
+    "euclid/polymod"
+)
+
+// RationalFunction is a quotient num/den of polynomials, always kept in
+// lowest terms: the constructor and every operation cancel gcd(num, den)
+// and scale the denominator to be monic, so equal functions have equal
+// representations.
+type RationalFunction struct {
+    num, den *Polynomial
+}
+
+// ErrZeroDenominator is returned for a rational function with denominator
+// 0; it is polymod.ErrZeroDenominator, which covers GF(p)(t) as well
+var ErrZeroDenominator = polymod.ErrZeroDenominator
+
+// NewRationalFunction returns num/den in lowest terms
+func NewRationalFunction(num, den *Polynomial) (*RationalFunction, error) {
+    if num == nil || den == nil {
+        return nil, ErrNilPolynomial
+    }
+    if den.IsZero() {
+        return nil, ErrZeroDenominator
+    }
+    r, _ := ReduceRational(num, den)
+    return r, nil
+}
+
+// ReduceRational cancels the GCD of num and den and makes den monic. It also
+// returns the monic GCD that was cancelled.
+func ReduceRational(num, den *Polynomial) (*RationalFunction, *Polynomial) {
+    if num.IsZero() {
+        return &RationalFunction{Zero(), One()}, den.monic()
+    }
+    gcd := extendedGCDResult(num, den).GCD.monic()
+    num, _ = num.div(gcd)
+    den, _ = den.div(gcd)
+    lead := den.coeff[den.Deg()]
+    return &RationalFunction{num.scale(new(big.Rat).Inv(lead)), den.monic()}, gcd
+}
+
+// Monic returns p divided by its leading coefficient, the representative
+// with leading coefficient 1 of the polynomials equal to p up to a nonzero
+// constant, such as the GCDs of two polynomials; the zero polynomial is
+// returned as zero
+func (p *Polynomial) Monic() *Polynomial {
+    if p.IsZero() {
+        return Zero()
+    }
+    return p.monic()
+}
+
+// monic returns p divided by its leading coefficient; the zero polynomial is
+// returned unchanged
+func (p *Polynomial) monic() *Polynomial {
+    if p.IsZero() {
+        return p
+    }
+    return p.scale(new(big.Rat).Inv(p.coeff[p.Deg()]))
+}
+
+// scale returns c*p
+func (p *Polynomial) scale(c *big.Rat) *Polynomial {
+    coeffs := make([]*big.Rat, p.Deg()+1)
+    for i := range coeffs {
+        coeffs[i] = new(big.Rat).Mul(p.coeff[i], c)
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// Num returns the numerator in lowest terms
+func (r *RationalFunction) Num() *Polynomial {
+    return r.num
+}
+
+// Den returns the monic denominator in lowest terms
+func (r *RationalFunction) Den() *Polynomial {
+    return r.den
+}
+
+// Mul returns r*s. Cross-cancelling first keeps the intermediate degrees low:
+// gcd(r.num, s.den) and gcd(s.num, r.den) are removed before multiplying.
+func (r *RationalFunction) Mul(s *RationalFunction) *RationalFunction {
+    a, _ := ReduceRational(r.num, s.den)
+    b, _ := ReduceRational(s.num, r.den)
+    res, _ := ReduceRational(a.num.mul(b.num), a.den.mul(b.den))
+    return res
+}
+
+// Add returns r + s over the least common denominator: with g = gcd(r.den,
+// s.den) it is (r.num*(s.den/g) + s.num*(r.den/g)) / (r.den*(s.den/g))
+func (r *RationalFunction) Add(s *RationalFunction) *RationalFunction {
+    g := extendedGCDResult(r.den, s.den).GCD
+    rCofactor, _ := s.den.div(g)
+    sCofactor, _ := r.den.div(g)
+    num := r.num.mul(rCofactor).Add(s.num.mul(sCofactor))
+    res, _ := ReduceRational(num, r.den.mul(rCofactor))
+    return res
+}
+
+// Sub returns r - s
+func (r *RationalFunction) Sub(s *RationalFunction) *RationalFunction {
+    return r.Add(&RationalFunction{s.num.scale(big.NewRat(-1, 1)), s.den})
+}
+
+// Div returns r / s, or an error if s is zero
+func (r *RationalFunction) Div(s *RationalFunction) (*RationalFunction, error) {
+    inv, err := s.inverse()
+    if err != nil {
+        return nil, err
+    }
+    return r.Mul(inv), nil
+}
+
+// PoleError reports evaluation of a rational function at one of its poles
+type PoleError struct {
+    At    *big.Rat
+    Order int // multiplicity of At as a root of the reduced denominator
+}
+
+func (e *PoleError) Error() string {
+    return fmt.Sprintf("rational function: pole of order %d at %s", e.Order, e.At.RatString())
+}
+
+// Eval returns r(x). Since r is in lowest terms, the denominator vanishes
+// exactly at the poles of r, which are reported as a *PoleError.
+func (r *RationalFunction) Eval(x *big.Rat) (*big.Rat, error) {
+    den := r.den.Eval(x)
+    if den.Sign() == 0 {
+        return nil, &PoleError{At: new(big.Rat).Set(x), Order: r.den.ValuationAt(x)}
+    }
+    return den.Quo(r.num.Eval(x), den), nil
+}
+
+// inverse returns 1/r, or an error if r is zero
+func (r *RationalFunction) inverse() (*RationalFunction, error) {
+    return NewRationalFunction(r.den, r.num)
+}
+
+// Equal reports whether r and s are the same function; since both are in
+// lowest terms with monic denominators, it compares representations, so
+// that (x^2 - 1)/(x - 1) equals x + 1
+func (r *RationalFunction) Equal(s *RationalFunction) bool {
+    return r.num.Equal(s.num) && r.den.Equal(s.den)
+}
+
+// String formats r as "(num)/(den)", or just num when den is 1
+func (r *RationalFunction) String() string {
+    if r.den.Deg() == 0 {
+        return Display(r.num)
+    }
+    return "(" + Display(r.num) + ")/(" + Display(r.den) + ")"
+}
+
+// LaTeX formats r for LaTeX as \frac{num}{den}, or just num when den is 1
+func (r *RationalFunction) LaTeX() string {
+    if r.den.Deg() == 0 {
+        return latexPolyString(r.num)
+    }
+    return `\frac{` + latexPolyString(r.num) + `}{` + latexPolyString(r.den) + `}`
+}
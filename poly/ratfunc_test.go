@@ -0,0 +1,28 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+func TestNewRationalFunction(t *testing.T) {
+    // (x^2 - 1)/(2x - 2) = (1/2 x + 1/2)/1
+    num := NewPolynomial([]*big.Rat{big.NewRat(-1, 1), new(big.Rat), big.NewRat(1, 1)})
+    den := NewPolynomial([]*big.Rat{big.NewRat(-2, 1), big.NewRat(2, 1)})
+    r, err := NewRationalFunction(num, den)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if want := NewPolynomial([]*big.Rat{big.NewRat(1, 2), big.NewRat(1, 2)}); !r.Num().Equal(want) || !r.Den().Equal(One()) {
+        t.Errorf("(x^2 - 1)/(2x - 2) = %v, want (1/2 x + 1/2)/1", r)
+    }
+    if _, err := NewRationalFunction(num, Zero()); !errors.Is(err, ErrZeroDenominator) {
+        t.Errorf("zero denominator: error %v, want ErrZeroDenominator", err)
+    }
+    for _, c := range []struct{ num, den *Polynomial }{{nil, den}, {num, nil}, {nil, nil}} {
+        if _, err := NewRationalFunction(c.num, c.den); !errors.Is(err, ErrNilPolynomial) {
+            t.Errorf("NewRationalFunction(%v, %v): error %v, want ErrNilPolynomial", c.num, c.den, err)
+        }
+    }
+}
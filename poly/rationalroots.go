@@ -0,0 +1,102 @@
+package poly
+
+import (
+    "math/big"
+    "sort"
+)
+
+// trialDivisionLimit bounds the primes tried when factoring the integers
+// whose divisors are candidate rational roots
+const trialDivisionLimit = 1 << 20
+
+// integerCoeffs returns p scaled by the least common multiple of its
+// denominators, as integers, lowest degree first
+func (p *Polynomial) integerCoeffs() []*big.Int {
+    lcm := big.NewInt(1)
+    for i := 0; i <= p.Deg(); i++ {
+        d := p.coeff[i].Denom()
+        g := new(big.Int).GCD(nil, nil, lcm, d)
+        lcm.Mul(lcm, new(big.Int).Quo(d, g))
+    }
+    ints := make([]*big.Int, p.Deg()+1)
+    for i := range ints {
+        c := new(big.Rat).Mul(p.coeff[i], new(big.Rat).SetInt(lcm))
+        ints[i] = new(big.Int).Set(c.Num())
+    }
+    return ints
+}
+
+// positiveDivisors returns the positive divisors of n != 0. n is factored by
+// trial division up to trialDivisionLimit and any remaining cofactor is
+// treated as prime, so divisors built from two primes beyond the limit can be
+// missed.
+func positiveDivisors(n *big.Int) []*big.Int {
+    n = new(big.Int).Abs(n)
+    divisors := []*big.Int{big.NewInt(1)}
+    addPrime := func(p *big.Int, e int) {
+        var more []*big.Int
+        for _, d := range divisors {
+            pk := new(big.Int).Set(d)
+            for i := 0; i < e; i++ {
+                pk = new(big.Int).Mul(pk, p)
+                more = append(more, pk)
+            }
+        }
+        divisors = append(divisors, more...)
+    }
+    r := new(big.Int)
+    for p := int64(2); p <= trialDivisionLimit && big.NewInt(p*p).Cmp(n) <= 0; p++ {
+        bp := big.NewInt(p)
+        e := 0
+        for {
+            q, _ := new(big.Int).QuoRem(n, bp, r)
+            if r.Sign() != 0 {
+                break
+            }
+            n = q
+            e++
+        }
+        if e > 0 {
+            addPrime(bp, e)
+        }
+    }
+    if n.Cmp(big.NewInt(1)) > 0 {
+        addPrime(n, 1)
+    }
+    return divisors
+}
+
+// rationalRoots returns the distinct rational roots of p in increasing
+// order, by the rational root theorem: every root u/v in lowest terms of an
+// integer polynomial has u dividing the constant and v the leading
+// coefficient
+func rationalRoots(p *Polynomial) []*big.Rat {
+    if p.Deg() < 1 {
+        return nil
+    }
+    var roots []*big.Rat
+    if p.ValuationAtZero() > 0 {
+        roots = append(roots, new(big.Rat))
+    }
+    // divide out x^k so that the constant coefficient is nonzero
+    k := p.ValuationAtZero()
+    coeffs := p.integerCoeffs()[k:]
+    if len(coeffs) > 1 {
+        seen := map[string]bool{}
+        for _, u := range positiveDivisors(coeffs[0]) {
+            for _, v := range positiveDivisors(coeffs[len(coeffs)-1]) {
+                for _, sign := range []int64{1, -1} {
+                    r := new(big.Rat).SetFrac(new(big.Int).Mul(u, big.NewInt(sign)), v)
+                    if key := r.RatString(); !seen[key] {
+                        seen[key] = true
+                        if p.Eval(r).Sign() == 0 {
+                            roots = append(roots, r)
+                        }
+                    }
+                }
+            }
+        }
+    }
+    sort.Slice(roots, func(i, j int) bool { return roots[i].Cmp(roots[j]) < 0 })
+    return roots
+}
@@ -0,0 +1,57 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// Reciprocal returns the reciprocal polynomial x^n * p(1/x), where n = deg p,
+// i.e. p with its coefficients reversed. When x divides p the result has
+// lower degree than p.
+func (p *Polynomial) Reciprocal() *Polynomial {
+    n := p.Deg()
+    coeffs := make([]*big.Rat, n+1)
+    for i := 0; i <= n; i++ {
+        coeffs[i] = new(big.Rat)
+        if n-i < len(p.coeff) {
+            coeffs[i].Set(p.coeff[n-i])
+        }
+    }
+    for len(coeffs) > 1 && coeffs[len(coeffs)-1].Sign() == 0 {
+        coeffs = coeffs[:len(coeffs)-1]
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// IsPalindromic reports whether the coefficients of p read the same in both
+// directions, i.e. p equals its reciprocal
+func (p *Polynomial) IsPalindromic() bool {
+    return p.Equal(p.Reciprocal())
+}
+
+// IsSelfReciprocal reports whether p is self-reciprocal up to sign, that is
+// Reciprocal(p) = sign * p. The sign is 1 for palindromic and -1 for
+// anti-palindromic polynomials, and 0 when p is not self-reciprocal.
+func (p *Polynomial) IsSelfReciprocal() (bool, int) {
+    if p.IsZero() {
+        return false, 0
+    }
+    r := p.Reciprocal()
+    if p.Equal(r) {
+        return true, 1
+    }
+    neg := make([]*big.Rat, len(r.coeff))
+    for i, c := range r.coeff {
+        neg[i] = new(big.Rat).Neg(c)
+    }
+    if p.Equal(NewPolyNoCopy(neg)) {
+        return true, -1
+    }
+    return false, 0
+}
+
+// ReciprocalGCD returns gcd(f, Reciprocal(f)). Its roots are the roots r of f
+// (with r != 0) for which 1/r is a root of f as well.
+func ReciprocalGCD(f *Polynomial) *Polynomial {
+    gcd := extendedGCDResult(f, f.Reciprocal()).GCD
+    return gcd
+}
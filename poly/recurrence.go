@@ -0,0 +1,277 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "math/big"
+    "math/cmplx"
+    "strings"
+)
+
+// LinearRecurrence is a_n = c_1*a_(n-1) + ... + c_d*a_(n-d) with initial
+// values a_0, ..., a_(d-1)
+type LinearRecurrence struct {
+    coeffs  []*big.Rat // c_1, ..., c_d
+    initial []*big.Rat // a_0, ..., a_(d-1)
+}
+
+// NewLinearRecurrence returns the recurrence with coefficients c_1, ..., c_d
+// and initial values a_0, ..., a_(d-1), copying both. It is an error unless
+// there are as many initial values as coefficients, none of them nil.
+func NewLinearRecurrence(coeffs, initial []*big.Rat) (LinearRecurrence, error) {
+    if len(coeffs) != len(initial) {
+        return LinearRecurrence{}, fmt.Errorf("recurrence: %d coefficients but %d initial values", len(coeffs), len(initial))
+    }
+    rec := LinearRecurrence{coeffs: make([]*big.Rat, len(coeffs)), initial: make([]*big.Rat, len(initial))}
+    for i := range coeffs {
+        if coeffs[i] == nil || initial[i] == nil {
+            return LinearRecurrence{}, fmt.Errorf("recurrence: nil coefficient or initial value at index %d", i)
+        }
+        rec.coeffs[i] = new(big.Rat).Set(coeffs[i])
+        rec.initial[i] = new(big.Rat).Set(initial[i])
+    }
+    return rec, nil
+}
+
+// Coeffs returns the coefficients c_1, ..., c_d of rec
+func (rec LinearRecurrence) Coeffs() []*big.Rat {
+    return rec.coeffs
+}
+
+// Terms returns a_0, ..., a_(n-1)
+func (rec LinearRecurrence) Terms(n int) []*big.Rat {
+    a := make([]*big.Rat, 0, n)
+    for i := 0; i < n; i++ {
+        if i < len(rec.initial) {
+            a = append(a, new(big.Rat).Set(rec.initial[i]))
+            continue
+        }
+        next := new(big.Rat)
+        for j, c := range rec.coeffs {
+            next.Add(next, new(big.Rat).Mul(c, a[i-1-j]))
+        }
+        a = append(a, next)
+    }
+    return a
+}
+
+// GeneratingFunction returns sum a_n x^n = P(x)/Q(x) with
+// Q(x) = 1 - c_1 x - ... - c_d x^d and P = Q*(a_0 + ... + a_(d-1) x^(d-1)) mod x^d
+func (rec LinearRecurrence) GeneratingFunction() *RationalFunction {
+    d := len(rec.coeffs)
+    q := make([]*big.Rat, d+1)
+    q[0] = big.NewRat(1, 1)
+    for i, c := range rec.coeffs {
+        q[i+1] = new(big.Rat).Neg(c)
+    }
+    den := NewPolyNoCopy(q)
+    init := make([]*big.Rat, d)
+    for i := range init {
+        init[i] = new(big.Rat)
+        if i < len(rec.initial) {
+            init[i].Set(rec.initial[i])
+        }
+    }
+    prod := den.mul(NewPolynomial(init))
+    num := make([]*big.Rat, d)
+    for i := range num {
+        num[i] = new(big.Rat)
+        if i < len(prod.coeff) {
+            num[i].Set(prod.coeff[i])
+        }
+    }
+    r, _ := ReduceRational(NewPolyNoCopy(num), den)
+    return r
+}
+
+// ClosedForm is a_n = sum_i P_i(n) * r_i^n + sum_j A_j * z_j^n, valid for
+// n > len(corrections)-1; the first terms are additionally shifted by the
+// corrections, which come from the polynomial part of the generating function.
+// The rational characteristic roots r_i are handled exactly, the others
+// (roots z_j of an irreducible remainder) numerically.
+type ClosedForm struct {
+    roots       []*big.Rat  // exact characteristic roots r_i
+    multipliers []*Polynomial // P_i, polynomials in n
+    numeric     []complex128
+    amplitudes  []complex128
+    corrections []*big.Rat
+}
+
+// errNotPowerSeries is returned for generating functions with a pole at 0
+var errNotPowerSeries = errors.New("recurrence: generating function has a pole at 0")
+
+// ClosedFormOf derives the closed form of the coefficients of the generating
+// function r. The denominator is split as L^m * W for each rational root, with
+// L = 1 - x/z linear; the extended Euclidean algorithm gives A*L^m + B*W = 1, so
+// N/(L^m W) = N*B/L^m + N*A/W, and N*B mod L^m written in powers of L yields
+// the partial fractions c_j / L^j, whose coefficients are c_j*C(n+j-1, j-1)*(1/z)^n.
+// Whatever is left over after all rational roots has a denominator without
+// rational roots and is expanded numerically.
+func ClosedFormOf(r *RationalFunction) (*ClosedForm, error) {
+    num, den := r.num, r.den
+    if den.Eval(new(big.Rat)).Sign() == 0 {
+        return nil, errNotPowerSeries
+    }
+    cf := &ClosedForm{}
+    poly := Zero()
+    for _, z := range rationalRoots(den) {
+        m := den.ValuationAt(z)
+        inv := new(big.Rat).Inv(z)
+        // L = 1 - x/z, normalized so that L(0) = 1
+        l := One().Sub(Monomial(inv, 1))
+        lm := One()
+        for i := 0; i < m; i++ {
+            lm = lm.mul(l)
+        }
+        w, _ := den.div(lm)
+        res := extendedGCDResult(lm, w)
+        // res.GCD is a nonzero constant: scale the cofactors to make it 1
+        c := new(big.Rat).Inv(res.GCD.coeff[0])
+        a, b := res.S.scale(c), res.T.scale(c)
+
+        q1, rem := num.mul(b).div(lm)
+        q2, rest := num.mul(a).div(w)
+        poly = poly.Add(q1).Add(q2)
+
+        // rem = sum_k d_k L^k; rem/L^m = sum_k d_k / L^(m-k)
+        multiplier := Zero()
+        for k := 0; k < m; k++ {
+            var d *big.Rat
+            if k < m-1 || rem.Deg() > 0 {
+                var dk *Polynomial
+                rem, dk = rem.div(l)
+                d = dk.coeff[0]
+            } else {
+                d = rem.coeff[0]
+            }
+            j := m - k
+            multiplier = multiplier.Add(binomialInN(j - 1).scale(d))
+        }
+        cf.roots = append(cf.roots, inv)
+        cf.multipliers = append(cf.multipliers, multiplier)
+        num, den = rest, w
+    }
+    if !poly.IsZero() {
+        cf.corrections = make([]*big.Rat, poly.Deg()+1)
+        for i := range cf.corrections {
+            cf.corrections[i] = new(big.Rat).Set(poly.coeff[i])
+        }
+    }
+    if den.Deg() > 0 && !num.IsZero() {
+        if err := cf.expandNumerically(num, den); err != nil {
+            return cf, err
+        }
+    }
+    return cf, nil
+}
+
+// binomialInN returns C(n+j, j) = (n+1)(n+2)...(n+j)/j! as a polynomial in n
+func binomialInN(j int) *Polynomial {
+    p := One()
+    for i := 1; i <= j; i++ {
+        p = p.mul(NewPolyNoCopy([]*big.Rat{big.NewRat(int64(i), 1), big.NewRat(1, 1)})).scale(big.NewRat(1, int64(i)))
+    }
+    return p
+}
+
+// expandNumerically adds the terms of s/w, where w has only simple,
+// non-rational roots z: s/w = sum A/(1 - x/z) with A = -s(z)/(z w'(z)),
+// whose coefficients are A*(1/z)^n
+func (cf *ClosedForm) expandNumerically(s, w *Polynomial) error {
+    if w.squarefreePart().Deg() < w.Deg() {
+        return errors.New("recurrence: repeated irrational characteristic roots are not supported")
+    }
+    const digits = 30
+    zs, err := aberthRoots(w, digits)
+    if err != nil {
+        return err
+    }
+    prec := zs[0].prec()
+    toFloats := func(p *Polynomial) []*big.Float {
+        fs := make([]*big.Float, p.Deg()+1)
+        for i := range fs {
+            fs[i] = new(big.Float).SetPrec(prec).SetRat(p.coeff[i])
+        }
+        return fs
+    }
+    wf, dwf, sf := toFloats(w), toFloats(w.Derivative()), toFloats(s)
+    for _, z := range zs {
+        _, dw := evalBigComplex(wf, dwf, z)
+        sz, _ := evalBigComplex(sf, nil, z)
+        a := sz.quo(z.mul(dw))
+        re, _ := a.re.Float64()
+        im, _ := a.im.Float64()
+        zre, _ := z.re.Float64()
+        zim, _ := z.im.Float64()
+        cf.numeric = append(cf.numeric, 1/complex(zre, zim))
+        cf.amplitudes = append(cf.amplitudes, complex(-re, -im))
+    }
+    return nil
+}
+
+// Exact reports whether every characteristic root is rational, so that
+// the closed form holds exactly
+func (cf *ClosedForm) Exact() bool {
+    return len(cf.numeric) == 0
+}
+
+// ExactTerm returns the exact part of a_n, all of it when cf is Exact
+func (cf *ClosedForm) ExactTerm(n int) *big.Rat {
+    sum := new(big.Rat)
+    for i, r := range cf.roots {
+        pow := new(big.Rat).SetInt64(1)
+        for k := 0; k < n; k++ {
+            pow.Mul(pow, r)
+        }
+        sum.Add(sum, pow.Mul(pow, cf.multipliers[i].Eval(big.NewRat(int64(n), 1))))
+    }
+    if n < len(cf.corrections) {
+        sum.Add(sum, cf.corrections[n])
+    }
+    return sum
+}
+
+// Term returns a_n, which is exact when there are no numeric roots
+func (cf *ClosedForm) Term(n int) complex128 {
+    f, _ := cf.ExactTerm(n).Float64()
+    v := complex(f, 0)
+    for i, z := range cf.numeric {
+        v += cf.amplitudes[i] * cmplx.Pow(z, complex(float64(n), 0))
+    }
+    return v
+}
+
+// String writes the closed form as a formula in n
+func (cf *ClosedForm) String() string {
+    var parts []string
+    for i, r := range cf.roots {
+        mult := Display(cf.multipliers[i], WithVariableName("n"))
+        if cf.multipliers[i].Deg() > 0 {
+            mult = "(" + mult + ")"
+        }
+        parts = append(parts, fmt.Sprintf("%s*(%s)^n", mult, r.RatString()))
+    }
+    for i, z := range cf.numeric {
+        parts = append(parts, fmt.Sprintf("%s*%s^n", complexString(cf.amplitudes[i]), complexString(z)))
+    }
+    if len(parts) == 0 {
+        parts = append(parts, "0")
+    }
+    s := strings.Join(parts, " + ")
+    for n, c := range cf.corrections {
+        if c.Sign() != 0 {
+            s += fmt.Sprintf(" + %s*[n = %d]", c.RatString(), n)
+        }
+    }
+    return s
+}
+
+// complexString formats z, dropping an imaginary part that is only rounding
+// noise
+func complexString(z complex128) string {
+    if math.Abs(imag(z)) <= 1e-12*cmplx.Abs(z) {
+        return fmt.Sprintf("(%.10g)", real(z))
+    }
+    return fmt.Sprintf("(%.10g %+.10gi)", real(z), imag(z))
+}
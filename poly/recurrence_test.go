@@ -0,0 +1,35 @@
+package poly
+
+import (
+    "math/big"
+    "testing"
+)
+
+func TestNewLinearRecurrence(t *testing.T) {
+    one, zero := big.NewRat(1, 1), new(big.Rat)
+    // Fibonacci: a_n = a_(n-1) + a_(n-2), a_0 = 0, a_1 = 1
+    coeffs, initial := []*big.Rat{one, one}, []*big.Rat{zero, one}
+    rec, err := NewLinearRecurrence(coeffs, initial)
+    if err != nil {
+        t.Fatal(err)
+    }
+    initial[1] = big.NewRat(5, 1)
+    if got := RatList(rec.Terms(8)); got != "[0, 1, 1, 2, 3, 5, 8, 13]" {
+        t.Errorf("terms %s, want the Fibonacci numbers, unaffected by the caller's slices", got)
+    }
+
+    cases := []struct {
+        name            string
+        coeffs, initial []*big.Rat
+    }{
+        {"too few initial values", []*big.Rat{one, one}, []*big.Rat{zero}},
+        {"too many initial values", []*big.Rat{one}, []*big.Rat{zero, one}},
+        {"nil coefficient", []*big.Rat{one, nil}, []*big.Rat{zero, one}},
+        {"nil initial value", []*big.Rat{one, one}, []*big.Rat{nil, one}},
+    }
+    for _, c := range cases {
+        if _, err := NewLinearRecurrence(c.coeffs, c.initial); err == nil {
+            t.Errorf("%s: no error", c.name)
+        }
+    }
+}
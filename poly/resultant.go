@@ -0,0 +1,59 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// Resultant computes the resultant of f and g, the determinant of their
+// Sylvester matrix, with the Euclidean remainder sequence, using
+// res(f, g) = (-1)^(deg f * deg g) lc(g)^(deg f - deg r) res(g, r) for
+// r = f mod g, and res(f, c) = c^(deg f) for a constant c. It vanishes
+// exactly when f and g have a common root, and is 0 when either of them
+// is zero.
+func Resultant(f, g *Polynomial) *big.Rat {
+    if f.IsZero() || g.IsZero() {
+        return new(big.Rat)
+    }
+    res := big.NewRat(1, 1)
+    for {
+        m, n := f.Deg(), g.Deg()
+        lc := g.coeff[n]
+        if n == 0 {
+            for i := 0; i < m; i++ {
+                res.Mul(res, lc)
+            }
+            return res
+        }
+        _, r := f.div(g)
+        if r.IsZero() {
+            return new(big.Rat)
+        }
+        if m%2 == 1 && n%2 == 1 {
+            res.Neg(res)
+        }
+        for i := 0; i < m-r.Deg(); i++ {
+            res.Mul(res, lc)
+        }
+        f, g = g, r
+    }
+}
+
+// Discriminant returns the discriminant of f of degree n >= 1,
+// (-1)^(n(n-1)/2) res(f, f') / lc(f), which vanishes exactly when f has a
+// multiple root; for ax^2 + bx + c it is b^2 - 4ac
+func Discriminant(f *Polynomial) (*big.Rat, error) {
+    if f == nil {
+        return nil, ErrNilPolynomial
+    }
+    n := f.Deg()
+    if n < 1 {
+        return nil, fmt.Errorf("discriminant: %s is constant", f)
+    }
+    d := Resultant(f, f.Derivative())
+    d.Quo(d, f.coeff[n])
+    if n*(n-1)/2%2 == 1 {
+        d.Neg(d)
+    }
+    return d, nil
+}
@@ -0,0 +1,34 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+func TestDiscriminant(t *testing.T) {
+    rat := func(a int64) *big.Rat { return big.NewRat(a, 1) }
+    cases := []struct {
+        coeffs []*big.Rat // lowest degree first
+        want   *big.Rat
+    }{
+        {[]*big.Rat{rat(3), rat(2), rat(1)}, rat(-8)},           // x^2 + 2x + 3: 4 - 12
+        {[]*big.Rat{rat(1), rat(-2), rat(1)}, rat(0)},           // (x - 1)^2
+        {[]*big.Rat{rat(-1), rat(0), rat(0), rat(1)}, rat(-27)}, // x^3 - 1
+    }
+    for _, c := range cases {
+        f := NewPolynomial(c.coeffs)
+        d, err := Discriminant(f)
+        if err != nil {
+            t.Errorf("Discriminant(%v): %v", f, err)
+        } else if d.Cmp(c.want) != 0 {
+            t.Errorf("Discriminant(%v) = %s, want %s", f, d.RatString(), c.want.RatString())
+        }
+    }
+    if _, err := Discriminant(Constant(rat(5))); err == nil {
+        t.Error("Discriminant of a constant: no error")
+    }
+    if _, err := Discriminant(nil); !errors.Is(err, ErrNilPolynomial) {
+        t.Errorf("Discriminant(nil): error %v, want ErrNilPolynomial", err)
+    }
+}
@@ -0,0 +1,66 @@
+package poly
+
+// rootPoints converts roots to points of the complex plane
+func rootPoints(roots []bigComplex) []Point {
+    points := make([]Point, len(roots))
+    for i, z := range roots {
+        points[i].X, _ = z.re.Float64()
+        points[i].Y, _ = z.im.Float64()
+    }
+    return points
+}
+
+// RootPlot is the picture of the roots of two polynomials made by PlotRoots,
+// with their GCD and its roots, the common roots
+type RootPlot struct {
+    Figure *Figure
+    GCD    *Polynomial
+    Common []ComplexRoot
+}
+
+// PlotRoots scatters the complex roots of f and g and highlights their common
+// roots, which are exactly the roots of gcd(f, g), giving a picture of what
+// the GCD computes
+func PlotRoots(f, g *Polynomial) (*RootPlot, error) {
+    const digits = 20
+    gcd := extendedGCDResult(f, g).GCD
+
+    rootsF, err := aberthRoots(f, digits)
+    if err != nil {
+        return nil, err
+    }
+    rootsG, err := aberthRoots(g, digits)
+    if err != nil {
+        return nil, err
+    }
+    var common []bigComplex
+    if gcd.Deg() > 0 {
+        common, err = aberthRoots(gcd, digits)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    fig := &Figure{
+        Title:     "Roots in the complex plane",
+        XLabel:    "Re",
+        YLabel:    "Im",
+        Width:     6,
+        Height:    6,
+        Grid:      true,
+        LegendTop: true,
+        // leave some room around roots that sit on the border of the data range
+        Pad: 0.15,
+    }
+    for _, set := range []Series{
+        {Label: "common roots (gcd)", Points: rootPoints(common), Glyph: GlyphRing, Color: figureGold, Radius: 8},
+        {Label: "roots of f", Points: rootPoints(rootsF), Glyph: GlyphCircle, Color: figureRed, Radius: 3},
+        {Label: "roots of g", Points: rootPoints(rootsG), Glyph: GlyphPyramid, Color: figureBlue, Radius: 3},
+    } {
+        if len(set.Points) > 0 {
+            set.Scatter = true
+            fig.Series = append(fig.Series, set)
+        }
+    }
+    return &RootPlot{fig, gcd, complexRoots(common)}, nil
+}
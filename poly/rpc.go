@@ -0,0 +1,280 @@
+package poly
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "math/big"
+    "sort"
+)
+
+// ServeRPC answers JSON-RPC 2.0 requests read from r, one per line, with one
+// response per line on w, until r is exhausted, so that editors, notebooks
+// and other local tools can run the engine as a child process over stdio.
+// A request names an operation and passes polynomials in the syntax of
+// ParsePolynomial, e.g.
+//
+//  {"jsonrpc": "2.0", "id": 1, "method": "gcd", "params": {"f": "x^2 - 1", "g": "x - 1"}}
+//
+// and the result holds polynomials as String writes them, which parse back
+// to the same polynomials; "gcd" adds the division steps as "trace". The
+// methods are listed by the method "methods". Requests without an id are
+// notifications and get no response, as JSON-RPC prescribes. opts apply to
+// every operation as to ExtendedGCDResult.
+func ServeRPC(r io.Reader, w io.Writer, opts ...Option) error {
+    in := bufio.NewReader(r)
+    enc := json.NewEncoder(w)
+    for {
+        line, err := in.ReadBytes('\n')
+        if len(bytes.TrimSpace(line)) > 0 {
+            if resp := handleRPC(line, opts); resp != nil {
+                if werr := enc.Encode(resp); werr != nil {
+                    return werr
+                }
+            }
+        }
+        if err == io.EOF {
+            return nil
+        }
+        if err != nil {
+            return err
+        }
+    }
+}
+
+// The JSON-RPC 2.0 error codes ServeRPC reports; rpcOperationFailed is in
+// the range reserved for implementation-defined server errors
+const (
+    rpcParseError      = -32700
+    rpcInvalidRequest  = -32600
+    rpcMethodNotFound  = -32601
+    rpcInvalidParams   = -32602
+    rpcOperationFailed = -32000
+)
+
+type rpcRequest struct {
+    JSONRPC string          `json:"jsonrpc"`
+    ID      json.RawMessage `json:"id"`
+    Method  string          `json:"method"`
+    Params  rpcParams       `json:"params"`
+}
+
+// rpcParams holds the parameters of every method; each method reads the
+// ones it needs
+type rpcParams struct {
+    F string   `json:"f"`
+    G string   `json:"g"`
+    X []string `json:"x"` // points for "eval"
+    C string   `json:"c"` // constant term for "integral"
+}
+
+type rpcResponse struct {
+    JSONRPC string          `json:"jsonrpc"`
+    ID      json.RawMessage `json:"id"`
+    Result  interface{}     `json:"result,omitempty"`
+    Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+    Code    int    `json:"code"`
+    Message string `json:"message"`
+}
+
+// rpcStep is one division of the "gcd" trace
+type rpcStep struct {
+    Dividend  string `json:"dividend"`
+    Divisor   string `json:"divisor"`
+    Quotient  string `json:"quotient"`
+    Remainder string `json:"remainder"`
+    S         string `json:"s"`
+    T         string `json:"t"`
+}
+
+type rpcGCDResult struct {
+    GCD        string    `json:"gcd"`
+    S          string    `json:"s"`
+    T          string    `json:"t"`
+    Iterations int       `json:"iterations"`
+    Trace      []rpcStep `json:"trace"`
+}
+
+// errInvalidParams marks errors in the parameters of a request, reported
+// with rpcInvalidParams rather than rpcOperationFailed
+var errInvalidParams = errors.New("invalid params")
+
+// polys parses the polynomial parameters named by names, "f" or "g"
+func (p rpcParams) polys(names ...string) ([]*Polynomial, error) {
+    out := make([]*Polynomial, len(names))
+    for i, name := range names {
+        text := p.F
+        if name == "g" {
+            text = p.G
+        }
+        if text == "" {
+            return nil, fmt.Errorf("%w: missing %q", errInvalidParams, name)
+        }
+        poly, err := ParsePolynomial(text)
+        if err != nil {
+            return nil, fmt.Errorf("%w: %s: %v", errInvalidParams, name, err)
+        }
+        out[i] = poly
+    }
+    return out, nil
+}
+
+// rpcMethods maps the method names to their operations
+var rpcMethods = map[string]func(p rpcParams, opts []Option) (interface{}, error){
+    "gcd": func(p rpcParams, opts []Option) (interface{}, error) {
+        fg, err := p.polys("f", "g")
+        if err != nil {
+            return nil, err
+        }
+        res, err := ExtendedGCDResult(fg[0], fg[1], opts...)
+        if err != nil {
+            return nil, err
+        }
+        out := rpcGCDResult{GCD: res.GCD.String(), S: res.S.String(), T: res.T.String(), Iterations: res.Iterations, Trace: []rpcStep{}}
+        for _, st := range res.Steps {
+            out.Trace = append(out.Trace, rpcStep{st.Dividend.String(), st.Divisor.String(),
+                st.Quotient.String(), st.Remainder.String(), st.S.String(), st.T.String()})
+        }
+        return out, nil
+    },
+    "div": func(p rpcParams, _ []Option) (interface{}, error) {
+        fg, err := p.polys("f", "g")
+        if err != nil {
+            return nil, err
+        }
+        q, r, err := fg[0].Div(fg[1])
+        if err != nil {
+            return nil, err
+        }
+        return map[string]string{"quotient": q.String(), "remainder": r.String()}, nil
+    },
+    "add": binaryRPC(func(f, g *Polynomial, _ []Option) *Polynomial { return f.Add(g) }),
+    "sub": binaryRPC(func(f, g *Polynomial, _ []Option) *Polynomial { return f.Sub(g) }),
+    "mul": binaryRPC(func(f, g *Polynomial, opts []Option) *Polynomial { return f.Mul(g, opts...) }),
+    "compose": binaryRPC(func(f, g *Polynomial, _ []Option) *Polynomial { return f.Compose(g) }),
+    "derivative": func(p rpcParams, _ []Option) (interface{}, error) {
+        f, err := p.polys("f")
+        if err != nil {
+            return nil, err
+        }
+        return map[string]string{"result": f[0].Derivative().String()}, nil
+    },
+    "integral": func(p rpcParams, _ []Option) (interface{}, error) {
+        f, err := p.polys("f")
+        if err != nil {
+            return nil, err
+        }
+        c := new(big.Rat)
+        if p.C != "" {
+            if _, ok := c.SetString(p.C); !ok {
+                return nil, fmt.Errorf("%w: c: %q is not a rational number", errInvalidParams, p.C)
+            }
+        }
+        return map[string]string{"result": f[0].Integral(c).String()}, nil
+    },
+    "eval": func(p rpcParams, _ []Option) (interface{}, error) {
+        f, err := p.polys("f")
+        if err != nil {
+            return nil, err
+        }
+        points := make([]*big.Rat, len(p.X))
+        for i, s := range p.X {
+            var ok bool
+            if points[i], ok = new(big.Rat).SetString(s); !ok {
+                return nil, fmt.Errorf("%w: x[%d]: %q is not a rational number", errInvalidParams, i, s)
+            }
+        }
+        values := []string{}
+        for _, v := range f[0].EvalMany(points) {
+            values = append(values, v.RatString())
+        }
+        return map[string][]string{"values": values}, nil
+    },
+}
+
+func init() {
+    // registered here since its body refers to rpcMethods
+    rpcMethods["methods"] = func(rpcParams, []Option) (interface{}, error) {
+        var names []string
+        for name := range rpcMethods {
+            names = append(names, name)
+        }
+        sort.Strings(names)
+        return names, nil
+    }
+}
+
+// binaryRPC wraps an operation on the polynomials f and g as a method
+// returning {"result": ...}
+func binaryRPC(op func(f, g *Polynomial, opts []Option) *Polynomial) func(rpcParams, []Option) (interface{}, error) {
+    return func(p rpcParams, opts []Option) (interface{}, error) {
+        fg, err := p.polys("f", "g")
+        if err != nil {
+            return nil, err
+        }
+        return map[string]string{"result": op(fg[0], fg[1], opts).String()}, nil
+    }
+}
+
+// handleRPC answers one request line, or returns nil for a notification
+func handleRPC(line []byte, opts []Option) *rpcResponse {
+    var req rpcRequest
+    if err := json.Unmarshal(line, &req); err != nil {
+        code, id := rpcInvalidRequest, json.RawMessage("null")
+        var syntax *json.SyntaxError
+        if errors.As(err, &syntax) {
+            code = rpcParseError
+        } else {
+            // a well-formed request with parameters of the wrong shape, or
+            // not an object at all; the id of the former can be echoed
+            var withID struct {
+                ID json.RawMessage `json:"id"`
+            }
+            if json.Unmarshal(line, &withID) == nil && len(withID.ID) > 0 {
+                code, id = rpcInvalidParams, withID.ID
+            }
+        }
+        return &rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{code, err.Error()}}
+    }
+    resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+    if len(req.ID) == 0 {
+        resp.ID = json.RawMessage("null")
+    }
+    if req.JSONRPC != "2.0" || req.Method == "" {
+        resp.Error = &rpcError{rpcInvalidRequest, `expected "jsonrpc": "2.0" and a method`}
+        return resp
+    }
+    method, ok := rpcMethods[req.Method]
+    if !ok {
+        resp.Error = &rpcError{rpcMethodNotFound, fmt.Sprintf("unknown method %q", req.Method)}
+    } else if result, err := callRPC(method, req.Params, opts); err != nil {
+        code := rpcOperationFailed
+        if errors.Is(err, errInvalidParams) {
+            code = rpcInvalidParams
+        }
+        resp.Error = &rpcError{code, err.Error()}
+    } else {
+        resp.Result = result
+    }
+    if len(req.ID) == 0 {
+        return nil
+    }
+    return resp
+}
+
+// callRPC runs method, turning a panic into an error so that one bad
+// request cannot end the session
+func callRPC(method func(rpcParams, []Option) (interface{}, error), p rpcParams, opts []Option) (result interface{}, err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            result, err = nil, fmt.Errorf("%v", r)
+        }
+    }()
+    return method(p, opts)
+}
@@ -0,0 +1,117 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// The shrinkers below minimize a failing input greedily: they try a list of
+// simpler candidates, move to the first one that still fails and start over,
+// until no candidate fails. Every candidate is strictly smaller than the
+// input (fewer coefficients, or smaller numerators and denominators), so
+// they always terminate, in a local minimum that usually makes the bug
+// obvious.
+
+// shrinkCandidates returns simpler variants of the coefficient slice cs,
+// lowest degree first, most aggressive first: without the top coefficient
+// (one degree less), without the constant term (divided by x), and with one
+// coefficient zeroed, stripped of its denominator, halved or reduced to its
+// sign
+func shrinkCandidates(cs []*big.Rat) [][]*big.Rat {
+    var out [][]*big.Rat
+    if len(cs) > 1 {
+        out = append(out, cs[:len(cs)-1], cs[1:])
+    }
+    with := func(i int, c *big.Rat) []*big.Rat {
+        v := append([]*big.Rat(nil), cs...)
+        v[i] = c
+        return v
+    }
+    for i, c := range cs {
+        if c.Sign() == 0 {
+            continue
+        }
+        out = append(out, with(i, new(big.Rat)))
+        if !c.IsInt() {
+            out = append(out, with(i, new(big.Rat).SetInt(c.Num())))
+        }
+        if half := new(big.Int).Quo(c.Num(), big.NewInt(2)); half.Sign() != 0 {
+            out = append(out, with(i, new(big.Rat).SetFrac(half, c.Denom())))
+        }
+        if c.Num().CmpAbs(big.NewInt(1)) != 0 || !c.IsInt() {
+            out = append(out, with(i, big.NewRat(int64(c.Sign()), 1)))
+        }
+    }
+    return out
+}
+
+// ShrinkPair reduces the degrees and coefficient sizes of f and g while
+// fails(f, g) keeps reporting true, and returns the smallest pair found. It
+// is meant for failures of randomized tests: fails should rerun the failing
+// check, and a panic in it counts as a failure. Leading zero coefficients
+// are kept as given, since they can matter to the bug.
+func ShrinkPair(f, g *Polynomial, fails func(f, g *Polynomial) bool) (*Polynomial, *Polynomial) {
+    check := func(a, b []*big.Rat) (failed bool) {
+        defer func() {
+            if r := recover(); r != nil {
+                failed = true
+            }
+        }()
+        return fails(NewPolyNoCopy(a), NewPolyNoCopy(b))
+    }
+    a, b := f.coeff, g.coeff
+    for shrunk := true; shrunk; {
+        shrunk = false
+        for _, c := range shrinkCandidates(a) {
+            if check(c, b) {
+                a, shrunk = c, true
+                break
+            }
+        }
+        if shrunk {
+            continue
+        }
+        for _, c := range shrinkCandidates(b) {
+            if check(a, c) {
+                b, shrunk = c, true
+                break
+            }
+        }
+    }
+    return NewPolynomial(a), NewPolynomial(b)
+}
+
+// shrinkBytes minimizes failing fuzzer input by deleting ever smaller runs
+// of bytes while fails keeps reporting true
+func shrinkBytes(data []byte, fails func([]byte) bool) []byte {
+    for n := len(data) / 2; n >= 1; n /= 2 {
+        for i := 0; i+n <= len(data); {
+            c := append(append([]byte(nil), data[:i]...), data[i+n:]...)
+            if fails(c) {
+                data = c
+            } else {
+                i += n
+            }
+        }
+    }
+    return data
+}
+
+// polyPairToBytes encodes f and g for polyFromBytes, coefficient for
+// coefficient including leading zeros. The coefficients must fit the format
+// (at most 12 of them, 16-bit numerators, denominators up to 256), as those
+// decoded by polyFromBytes and shrunk by ShrinkPair do.
+func polyPairToBytes(f, g *Polynomial) []byte {
+    var data []byte
+    for _, p := range []*Polynomial{f, g} {
+        cs := p.coeff
+        if len(cs) == 0 {
+            cs = []*big.Rat{new(big.Rat)}
+        }
+        data = append(data, byte(len(cs)-1))
+        for _, c := range cs {
+            num := uint16(int16(c.Num().Int64()))
+            data = append(data, byte(num>>8), byte(num), byte(c.Denom().Int64()-1))
+        }
+    }
+    return data
+}
@@ -0,0 +1,95 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "math/bits"
+    "strings"
+)
+
+// CoefficientStats describes the sizes of the coefficients of a polynomial,
+// which is where the cost of exact arithmetic goes when the remainders of
+// the Euclidean algorithm swell. The size of a coefficient is the larger bit
+// length of its numerator and denominator, as in the height of the summary
+// display.
+type CoefficientStats struct {
+    // Terms is the number of coefficients up to the degree, ZeroTerms how
+    // many of them are zero
+    Terms     int
+    ZeroTerms int
+    // Content is the positive rational c for which p/c has coprime integer
+    // coefficients, 0 for the zero polynomial
+    Content *big.Rat
+    // MaxBits and MeanBits are the largest and the mean size of the nonzero
+    // coefficients
+    MaxBits  int
+    MeanBits float64
+    // Histogram counts the nonzero coefficients by size: Histogram[0] those
+    // of 1 bit, Histogram[k] those of 2^(k-1)+1 to 2^k bits
+    Histogram []int
+}
+
+// CoefficientStatistics returns the statistics of the coefficients of p
+func (p *Polynomial) CoefficientStatistics() CoefficientStats {
+    s := CoefficientStats{Content: new(big.Rat)}
+    if p.IsZero() {
+        return s
+    }
+    s.Terms = p.Deg() + 1
+    num, den := new(big.Int), big.NewInt(1)
+    total := 0
+    for i := 0; i < s.Terms; i++ {
+        c := p.coeff[i]
+        if c.Sign() == 0 {
+            s.ZeroTerms++
+            continue
+        }
+        num.GCD(nil, nil, num, new(big.Int).Abs(c.Num()))
+        g := new(big.Int).GCD(nil, nil, den, c.Denom())
+        den.Mul(den, new(big.Int).Quo(c.Denom(), g))
+
+        size := max(c.Num().BitLen(), c.Denom().BitLen())
+        total += size
+        s.MaxBits = max(s.MaxBits, size)
+        bucket := bits.Len(uint(size - 1))
+        for len(s.Histogram) <= bucket {
+            s.Histogram = append(s.Histogram, 0)
+        }
+        s.Histogram[bucket]++
+    }
+    s.Content.SetFrac(num, den)
+    s.MeanBits = float64(total) / float64(s.Terms-s.ZeroTerms)
+    return s
+}
+
+// statsContentDigits is the longest content String prints in full; a
+// longer one is given by the bit lengths of its numerator and denominator
+const statsContentDigits = 40
+
+// String formats the statistics on one line
+func (s CoefficientStats) String() string {
+    var b strings.Builder
+    content := s.Content.RatString()
+    if len(content) > statsContentDigits {
+        content = fmt.Sprintf("(%d bits)/(%d bits)", s.Content.Num().BitLen(), s.Content.Denom().BitLen())
+    }
+    fmt.Fprintf(&b, "%d terms (%d zero), content %s, bits max %d mean %.1f", s.Terms, s.ZeroTerms, content, s.MaxBits, s.MeanBits)
+    if len(s.Histogram) > 0 {
+        b.WriteString(", histogram")
+        for k, n := range s.Histogram {
+            if n == 0 {
+                continue
+            }
+            lo, hi := 1, 1
+            if k > 0 {
+                lo, hi = 1<<(k-1)+1, 1<<k
+            }
+            if lo == hi {
+                fmt.Fprintf(&b, " %d:%d", lo, n)
+            } else {
+                fmt.Fprintf(&b, " %d-%d:%d", lo, hi, n)
+            }
+        }
+    }
+    return b.String()
+}
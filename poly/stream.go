@@ -0,0 +1,182 @@
+package poly
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "math/big"
+    "strings"
+)
+
+// eachTerm calls yield for every nonzero coefficient of p, from the highest
+// power down, until yield returns false
+func (p *Polynomial) eachTerm(yield func(power int, c *big.Rat) bool) {
+    for i := len(p.coeff) - 1; i >= 0; i-- {
+        if p.coeff[i].Sign() != 0 && !yield(i, p.coeff[i]) {
+            return
+        }
+    }
+}
+
+// termCount returns the number of nonzero coefficients of p
+func (p *Polynomial) termCount() int {
+    n := 0
+    p.eachTerm(func(int, *big.Rat) bool {
+        n++
+        return true
+    })
+    return n
+}
+
+// formatStyles names the output styles WithFormat accepts: String's plain
+// ASCII, Unicode with superscript exponents and a real minus sign
+// (3x² − 2x + 1), and LaTeX (3x^{2} - 2x + 1) for papers and MathJax
+var formatStyles = []string{"plain", "unicode", "latex"}
+
+// IsFormatStyle reports whether name is one of formatStyles
+func IsFormatStyle(name string) bool {
+    for _, s := range formatStyles {
+        if s == name {
+            return true
+        }
+    }
+    return false
+}
+
+// WriteTo streams p to w in the format of String, one term at a time, so
+// that huge polynomials can be printed without building the whole string
+func (p *Polynomial) WriteTo(w io.Writer) (int64, error) {
+    return p.writeElided(w, 0, "x", "plain")
+}
+
+// Format returns p in String's format with the options applied, such as
+// WithVariableName and WithFormat
+func (p *Polynomial) Format(opts ...Option) string {
+    c := newConfig(opts...)
+    var b strings.Builder
+    p.writeElided(&b, 0, c.variable, c.format)
+    return b.String()
+}
+
+// writeElided streams p to w like WriteTo, naming the indeterminate variable
+// and writing the terms in the given style, one of formatStyles. If keep is
+// positive and p has more than 2*keep terms, only the first and last keep
+// terms are written, with "…" (\dots in LaTeX) standing for the terms in
+// between.
+func (p *Polynomial) writeElided(w io.Writer, keep int, variable, style string) (int64, error) {
+    cw := &countingWriter{w: w}
+    bw := bufio.NewWriter(cw)
+
+    total := -1
+    if keep > 0 {
+        total = p.termCount()
+    }
+    index, started := 0, false
+    var err error
+    p.eachTerm(func(power int, c *big.Rat) bool {
+        if total > 2*keep && index >= keep && index < total-keep {
+            if index == keep && style == "latex" {
+                _, err = bw.WriteString(" + \\dots")
+            } else if index == keep {
+                _, err = bw.WriteString(" + …")
+            }
+            index++
+            return err == nil
+        }
+        err = writeTerm(bw, power, c, variable, style, started)
+        started = true
+        index++
+        return err == nil
+    })
+    if err == nil && !started {
+        // the zero polynomial has no terms
+        _, err = bw.WriteString("0")
+    }
+    if err == nil {
+        err = bw.Flush()
+    }
+    return cw.n, err
+}
+
+// writeTerm writes one term of String's format in the given variable and
+// style, with the sign written as a separator (" + " or " - "); the first
+// term has no plus sign and a bare minus
+func writeTerm(w *bufio.Writer, power int, c *big.Rat, variable, style string, started bool) error {
+    minus := "-"
+    if style == "unicode" {
+        minus = "−"
+    }
+    switch {
+    case started && c.Sign() > 0:
+        w.WriteString(" + ")
+    case started:
+        w.WriteString(" " + minus + " ")
+    case c.Sign() < 0:
+        w.WriteString(minus)
+    }
+    if style == "latex" {
+        w.WriteString(latexTerm(c, power, variable))
+        return nil
+    }
+    abs := absRat(c)
+    if abs.Cmp(big.NewRat(1, 1)) != 0 || power == 0 {
+        if style == "plain" {
+            w.WriteString(abs.String())
+        } else {
+            w.WriteString(abs.RatString())
+        }
+        switch {
+        case power == 0:
+        case style == "plain":
+            w.WriteString("*")
+        case !abs.IsInt():
+            // 1/2x would read as 1/(2x)
+            w.WriteString("·")
+        }
+    }
+    if power > 0 {
+        w.WriteString(variable)
+        switch {
+        case power == 1:
+        case style == "unicode":
+            w.WriteString(superscript(power))
+        default:
+            w.WriteString("^" + fmt.Sprint(power))
+        }
+    }
+    // bufio.Writer keeps the first write error and returns it from Flush
+    return nil
+}
+
+// superscriptDigits are the Unicode superscripts of 0 to 9
+var superscriptDigits = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+
+// superscript returns the nonnegative n in Unicode superscript digits
+func superscript(n int) string {
+    var b strings.Builder
+    for _, d := range fmt.Sprint(n) {
+        b.WriteRune(superscriptDigits[d-'0'])
+    }
+    return b.String()
+}
+
+// elidedString returns p in String's format, abbreviated to the first and
+// last keep terms
+func (p *Polynomial) elidedString(keep int, opts ...Option) string {
+    c := newConfig(opts...)
+    var b strings.Builder
+    p.writeElided(&b, keep, c.variable, c.format)
+    return b.String()
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+    w io.Writer
+    n int64
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+    n, err := cw.w.Write(b)
+    cw.n += int64(n)
+    return n, err
+}
@@ -0,0 +1,282 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "math/rand"
+    "time"
+
+    "euclid/fft"
+    "euclid/internal/benchtime"
+)
+
+// The subresultant polynomial remainder sequence runs the Euclidean
+// algorithm on integer polynomials without ever leaving the integers and
+// without the coefficient explosion of plain pseudo-division: each
+// pseudo-remainder is divided by a factor beta known in advance to divide
+// it, which keeps the coefficients the size of the subresultants of f and g
+// (determinants of the Sylvester matrix, so polynomial in the input size).
+// Over Q the rational remainders are the same polynomials up to constants,
+// but their reduced fractions carry much larger numerators and
+// denominators, and every operation pays for the gcds that keep them
+// reduced.
+
+// primitiveInts returns the content c and primitive part of p: p = c*a for
+// integer coefficients a, lowest degree first, with gcd 1 and a positive
+// leading one. The zero polynomial has content 1 and no coefficients.
+func primitiveInts(p *Polynomial) (*big.Rat, []*big.Int) {
+    if p.IsZero() {
+        return big.NewRat(1, 1), nil
+    }
+    a := p.integerCoeffs()
+    g := new(big.Int)
+    for _, c := range a {
+        g.GCD(nil, nil, g, new(big.Int).Abs(c))
+    }
+    if a[len(a)-1].Sign() < 0 {
+        g.Neg(g)
+    }
+    for _, c := range a {
+        c.Quo(c, g)
+    }
+    content := new(big.Rat).SetFrac(p.coeff[p.Deg()].Num(), p.coeff[p.Deg()].Denom())
+    content.Quo(content, new(big.Rat).SetInt(a[len(a)-1]))
+    return content, a
+}
+
+// trimInts drops zero leading coefficients
+func trimInts(a []*big.Int) []*big.Int {
+    n := len(a)
+    for n > 0 && a[n-1].Sign() == 0 {
+        n--
+    }
+    return a[:n]
+}
+
+// intsToPoly returns the integer polynomial a as a Polynomial, divided by d
+func intsToPoly(a []*big.Int, d *big.Int) *Polynomial {
+    if len(a) == 0 {
+        return Zero()
+    }
+    coeffs := make([]*big.Rat, len(a))
+    for i, c := range a {
+        coeffs[i] = new(big.Rat).SetFrac(c, d)
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// scaleInts returns c*a
+func scaleInts(a []*big.Int, c *big.Int) []*big.Int {
+    out := make([]*big.Int, len(a))
+    for i, x := range a {
+        out[i] = new(big.Int).Mul(x, c)
+    }
+    return out
+}
+
+// quoInts returns a/c for a c that divides every coefficient of a
+func quoInts(a []*big.Int, c *big.Int) []*big.Int {
+    out := make([]*big.Int, len(a))
+    for i, x := range a {
+        out[i] = new(big.Int).Quo(x, c)
+    }
+    return out
+}
+
+// subInts returns a - b
+func subInts(a, b []*big.Int) []*big.Int {
+    out := make([]*big.Int, max(len(a), len(b)))
+    for i := range out {
+        out[i] = new(big.Int)
+        if i < len(a) {
+            out[i].Set(a[i])
+        }
+        if i < len(b) {
+            out[i].Sub(out[i], b[i])
+        }
+    }
+    return trimInts(out)
+}
+
+// pseudoDivInts returns q and r with lc(b)^(deg a - deg b + 1) * a = q*b + r
+// and deg r < deg b, for deg a >= deg b and b != 0; only integer operations
+// are needed
+func pseudoDivInts(a, b []*big.Int) (q, r []*big.Int) {
+    db := len(b) - 1
+    lead := b[db]
+    r = make([]*big.Int, len(a))
+    for i, c := range a {
+        r[i] = new(big.Int).Set(c)
+    }
+    q = make([]*big.Int, len(a)-db)
+    for i := range q {
+        q[i] = new(big.Int)
+    }
+    t := new(big.Int)
+    for k := len(q) - 1; k >= 0; k-- {
+        // r = lead*r - r[k+db] x^k b, q = lead*q + r[k+db] x^k
+        c := new(big.Int).Set(r[k+db])
+        for i := range q {
+            q[i].Mul(q[i], lead)
+        }
+        q[k].Add(q[k], c)
+        for i := range r[:k+db] {
+            r[i].Mul(r[i], lead)
+        }
+        for j, bj := range b[:db] {
+            r[k+j].Sub(r[k+j], t.Mul(c, bj))
+        }
+        r[k+db].SetInt64(0)
+    }
+    return q, trimInts(r[:db])
+}
+
+// subresultantGCDResult is extendedGCDResult by the subresultant PRS, for
+// WithGCDStrategy("subresultant"). It runs on the primitive parts of f and
+// g and returns the gcd as a primitive integer polynomial with a positive
+// leading coefficient; S and T are scaled to match. Each step records the
+// rational division of an element of the sequence by the next one, as the
+// default mode does; the sequence continues with a constant multiple of the
+// remainder, so a step's divisor is its predecessor's remainder only up to
+// a constant.
+func subresultantGCDResult(f, g *Polynomial, cfg config) *GCDResult {
+    start := time.Now()
+    res := &GCDResult{MaxIterations: maxEuclideanIterations(f, g)}
+    cf, a := primitiveInts(f)
+    cg, b := primitiveInts(g)
+    one, zero := []*big.Int{big.NewInt(1)}, []*big.Int(nil)
+    // r0 = s0*a + t0*b and r1 = s1*a + t1*b throughout
+    r0, r1 := a, b
+    s0, s1, t0, t1 := one, zero, zero, one
+    record := func(dividend, divisor, quo, rem []*big.Int, scale *big.Int, s, t []*big.Int, cofactorScale *big.Rat) {
+        step := EuclidStep{
+            Dividend: intsToPoly(dividend, big.NewInt(1)), Divisor: intsToPoly(divisor, big.NewInt(1)),
+            Quotient: intsToPoly(quo, scale), Remainder: intsToPoly(rem, scale),
+        }
+        // cofactors of Remainder with respect to f and g
+        step.S = intsToPoly(s, big.NewInt(1)).scale(new(big.Rat).Quo(cofactorScale, cf))
+        step.T = intsToPoly(t, big.NewInt(1)).scale(new(big.Rat).Quo(cofactorScale, cg))
+        res.Steps = append(res.Steps, step)
+        res.Iterations++
+        if cfg.trace != nil {
+            cfg.trace(res.Iterations, step)
+        }
+        if cfg.step != nil {
+            cfg.step(res.Iterations, len(rem)-1)
+        }
+    }
+    if len(r1) > 0 && len(r0) < len(r1) {
+        // the first division only swaps, as in the default mode
+        record(r0, r1, nil, r0, big.NewInt(1), s0, t0, big.NewRat(1, 1))
+        r0, r1, s0, s1, t0, t1 = r1, r0, s1, s0, t1, t0
+    }
+
+    var psi *big.Int
+    for len(r1) > 0 {
+        phase := time.Now()
+        delta := len(r0) - len(r1)
+        lead := r1[len(r1)-1]
+        q, r := pseudoDivInts(r0, r1)
+        res.Timing.Divisions += time.Since(phase)
+
+        // beta_1 = (-1)^(delta+1), psi_1 = -1; later
+        // psi_i = (-lc(r0))^delta_(i-1) / psi_(i-1)^(delta_(i-1) - 1) and
+        // beta_i = -lc(r0) * psi_i^delta_i
+        phase = time.Now()
+        var beta *big.Int
+        if psi == nil {
+            psi = big.NewInt(-1)
+            beta = big.NewInt(1)
+            if delta%2 == 0 {
+                beta.Neg(beta)
+            }
+        } else {
+            beta = new(big.Int).Neg(r0[len(r0)-1])
+            beta.Mul(beta, new(big.Int).Exp(psi, big.NewInt(int64(delta)), nil))
+        }
+        power := new(big.Int).Exp(lead, big.NewInt(int64(delta+1)), nil)
+        r2 := quoInts(r, beta)
+        res.Timing.Normalization += time.Since(phase)
+
+        phase = time.Now()
+        s2 := quoInts(subInts(scaleInts(s0, power), fft.Karatsuba(q, s1)), beta)
+        t2 := quoInts(subInts(scaleInts(t0, power), fft.Karatsuba(q, t1)), beta)
+        res.Timing.Updates += time.Since(phase)
+
+        record(r0, r1, q, r, power, s2, t2, new(big.Rat).SetFrac(beta, power))
+        if len(r2) > 0 {
+            // psi for the next step, from this step's delta and lc(r1)
+            minusLead := new(big.Int).Neg(lead)
+            next := new(big.Int).Exp(minusLead, big.NewInt(int64(delta)), nil)
+            if delta == 0 {
+                next.Mul(next, psi)
+            } else {
+                next.Quo(next, new(big.Int).Exp(psi, big.NewInt(int64(delta-1)), nil))
+            }
+            psi = next
+        }
+        r0, r1, s0, s1, t0, t1 = r1, r2, s1, s2, t1, t2
+    }
+
+    if len(r0) == 0 {
+        res.GCD, res.S, res.T = Zero(), One(), Zero()
+    } else {
+        c, _ := primitiveInts(intsToPoly(r0, big.NewInt(1)))
+        res.GCD = intsToPoly(r0, big.NewInt(1)).scale(new(big.Rat).Inv(c))
+        res.S = intsToPoly(s0, big.NewInt(1)).scale(new(big.Rat).Inv(new(big.Rat).Mul(c, cf)))
+        res.T = intsToPoly(t0, big.NewInt(1)).scale(new(big.Rat).Inv(new(big.Rat).Mul(c, cg)))
+    }
+    res.WorstCase = res.Iterations == res.MaxIterations
+    res.Timing.Total = time.Since(start)
+    return res
+}
+
+// subresultantBenchDegrees are the input degrees SubresultantBench times
+var subresultantBenchDegrees = []int{8, 16, 24, 32, 48, 64}
+
+// sequenceMaxBits returns the size in bits of the largest coefficient in the
+// remainder sequence of res
+func sequenceMaxBits(res *GCDResult) int {
+    bits := 0
+    for _, st := range res.Steps {
+        bits = max(bits, st.Divisor.CoefficientStatistics().MaxBits)
+    }
+    return bits
+}
+
+// SubresultantBenchRow holds the ns/op of the extended GCD over Q at one
+// input degree with the Euclidean algorithm and the subresultant PRS, and
+// the size in bits of the largest coefficient each remainder sequence
+// reaches
+type SubresultantBenchRow struct {
+    Degree                 int
+    Euclid, Subres         float64
+    EuclidBits, SubresBits int
+}
+
+// SubresultantBench times the extended Euclidean algorithm over Q against
+// the subresultant PRS on random pairs of growing degree, after checking
+// that both find the same gcd up to a constant, and returns ns/op and the
+// coefficient sizes
+func SubresultantBench() ([]SubresultantBenchRow, error) {
+    var rows []SubresultantBenchRow
+    for _, n := range subresultantBenchDegrees {
+        f, g := randomPair(rand.New(rand.NewSource(int64(n))), n)
+        // a common factor makes the gcd worth checking
+        common := RandomPolynomial(rand.New(rand.NewSource(int64(-n))), n/4)
+        f, g = f.mul(common), g.mul(common)
+        euclid := extendedGCDResult(f, g)
+        subres := extendedGCDResult(f, g, WithGCDStrategy("subresultant"))
+        if !euclid.GCD.monic().Equal(subres.GCD.monic()) {
+            return rows, fmt.Errorf("subresultant PRS disagrees with the Euclidean algorithm at degree %d", n)
+        }
+        rows = append(rows, SubresultantBenchRow{
+            Degree:     n + n/4,
+            Euclid:     benchtime.Ns(func() { extendedGCDResult(f, g) }),
+            Subres:     benchtime.Ns(func() { extendedGCDResult(f, g, WithGCDStrategy("subresultant")) }),
+            EuclidBits: sequenceMaxBits(euclid),
+            SubresBits: sequenceMaxBits(subres),
+        })
+    }
+    return rows, nil
+}
@@ -0,0 +1,36 @@
+package poly
+
+import (
+    "testing"
+)
+
+// TestSubresultantGCD checks that the subresultant PRS returns the gcd as a
+// primitive integer polynomial with a positive leading coefficient and that
+// every recorded step keeps remainder = s*f + t*g
+func TestSubresultantGCD(t *testing.T) {
+    cases := []struct {
+        f, g, gcd string
+    }{
+        {"6x^2 - 6", "4x^2 - 12x + 8", "x - 1"},
+        {"-1/2x^3 + 1/2x", "3/4x^2 - 3/4", "x^2 - 1"},
+        {"x^8 + x^6 - 3x^4 - 3x^3 + 8x^2 + 2x - 5", "3x^6 + 5x^4 - 4x^2 - 9x + 21", "1"},
+        {"2x^2 + 4x + 2", "0", "x^2 + 2x + 1"},
+    }
+    for _, c := range cases {
+        f, _ := ParsePolynomial(c.f)
+        g, _ := ParsePolynomial(c.g)
+        want, _ := ParsePolynomial(c.gcd)
+        res, err := ExtendedGCDResult(f, g, WithGCDStrategy("subresultant"))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if !res.GCD.Equal(want) {
+            t.Errorf("gcd(%s, %s) = %s, want %s", f, g, res.GCD, want)
+        }
+        for i, st := range res.Steps {
+            if sum := st.S.Mul(f).Add(st.T.Mul(g)); !sum.Equal(st.Remainder) {
+                t.Errorf("gcd(%s, %s), step %d: s*f + t*g = %s, remainder %s", f, g, i+1, sum, st.Remainder)
+            }
+        }
+    }
+}
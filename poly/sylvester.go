@@ -0,0 +1,41 @@
+package poly
+
+import "math/big"
+
+// SylvesterMatrix returns the Sylvester matrix of f of degree m and g of
+// degree n, of size m + n: n rows holding the coefficients of f, highest
+// degree first, each shifted one column right of the one above, followed by
+// m rows holding those of g. Its determinant is the resultant of f and g.
+func SylvesterMatrix(f, g *Polynomial) [][]*big.Rat {
+    m, n := f.Deg(), g.Deg()
+    size := m + n
+    rows := make([][]*big.Rat, 0, size)
+    for _, band := range []struct {
+        p     *Polynomial
+        count int
+    }{{f, n}, {g, m}} {
+        d := band.p.Deg()
+        for i := 0; i < band.count; i++ {
+            row := make([]*big.Rat, size)
+            for j := range row {
+                row[j] = new(big.Rat)
+            }
+            for k := 0; k <= d; k++ {
+                row[i+k].Set(band.p.coeff[d-k])
+            }
+            rows = append(rows, row)
+        }
+    }
+    return rows
+}
+
+// ResultantSylvester computes the resultant of f and g as the determinant of
+// their Sylvester matrix by fraction-free Bareiss elimination. It shares no
+// code with Resultant, which follows the remainder sequence, and serves as
+// an independent check of it; it is 0 when either input is zero.
+func ResultantSylvester(f, g *Polynomial) *big.Rat {
+    if f.IsZero() || g.IsZero() {
+        return new(big.Rat)
+    }
+    return bareissDeterminant(SylvesterMatrix(f, g))
+}
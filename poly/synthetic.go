@@ -0,0 +1,109 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "strings"
+)
+
+// syntheticDiv divides p by x - a with Horner's scheme in O(n) and returns
+// the quotient together with the remainder p(a). The second result holds the
+// bottom row of the synthetic-division tableau, highest degree first.
+func (p *Polynomial) syntheticDiv(a *big.Rat) (*Polynomial, *big.Rat, []*big.Rat) {
+    n := p.Deg()
+    row := make([]*big.Rat, n+1)
+    acc := new(big.Rat)
+    for i := n; i >= 0; i-- {
+        acc = new(big.Rat).Mul(acc, a)
+        acc.Add(acc, p.coeff[i])
+        row[n-i] = acc
+    }
+
+    if n == 0 {
+        return Zero(), row[0], row
+    }
+    quotient := make([]*big.Rat, n)
+    for i := 0; i < n; i++ {
+        quotient[i] = row[n-1-i]
+    }
+    return NewPolynomial(quotient), row[n], row
+}
+
+// divLinear divides p by the linear polynomial q = c*x + d using synthetic
+// division by x - (-d/c), then scales the quotient by 1/c
+func (p *Polynomial) divLinear(q *Polynomial) (*Polynomial, *Polynomial) {
+    c := q.coeff[1]
+    a := new(big.Rat).Quo(q.coeff[0], c)
+    a.Neg(a)
+
+    quotient, rem, _ := p.syntheticDiv(a)
+    if c.Cmp(big.NewRat(1, 1)) != 0 {
+        for _, x := range quotient.coeff {
+            x.Quo(x, c)
+        }
+    }
+    return quotient, Constant(rem)
+}
+
+// SyntheticDivision renders the division of p by the linear polynomial q as a
+// synthetic-division (Horner) tableau
+func SyntheticDivision(p, q *Polynomial) (string, error) {
+    if q.Deg() != 1 {
+        return "", errors.New("synthetic division needs a linear divisor")
+    }
+    return syntheticDivision(p, q), nil
+}
+
+// syntheticDivision renders the tableau of SyntheticDivision for a linear q
+func syntheticDivision(p, q *Polynomial) string {
+    c := q.coeff[1]
+    a := new(big.Rat).Quo(q.coeff[0], c)
+    a.Neg(a)
+
+    n := p.Deg()
+    _, rem, bottom := p.syntheticDiv(a)
+
+    top := make([]string, n+1)
+    middle := make([]string, n+1)
+    low := make([]string, n+1)
+    for i := 0; i <= n; i++ {
+        top[i] = p.coeff[n-i].RatString()
+        low[i] = bottom[i].RatString()
+        if i > 0 {
+            middle[i] = new(big.Rat).Mul(bottom[i-1], a).RatString()
+        }
+    }
+
+    width := 1
+    for i := 0; i <= n; i++ {
+        for _, s := range []string{top[i], middle[i], low[i]} {
+            if len(s) > width {
+                width = len(s)
+            }
+        }
+    }
+    cells := func(row []string, from, to int) string {
+        var b strings.Builder
+        for i := from; i < to; i++ {
+            b.WriteString(fmt.Sprintf(" %*s", width, row[i]))
+        }
+        return b.String()
+    }
+
+    label := a.RatString()
+    pad := strings.Repeat(" ", len(label))
+    var b strings.Builder
+    b.WriteString(label + " |" + cells(top, 0, n+1) + "\n")
+    b.WriteString(pad + " |" + cells(middle, 0, n+1) + "\n")
+    b.WriteString(pad + " +" + strings.Repeat("-", (width+1)*(n+1)+2) + "\n")
+    b.WriteString(pad + "  " + cells(low, 0, n) + " |" + cells(low, n, n+1) + "\n")
+    b.WriteString("\n")
+    if c.Cmp(big.NewRat(1, 1)) != 0 {
+        monic := X().Sub(Constant(a))
+        b.WriteString(fmt.Sprintf("dividing by %s; the quotient is then divided by %s\n", layoutPolyString(monic), c.RatString()))
+    }
+    quotient, _ := p.divLinear(q)
+    b.WriteString(fmt.Sprintf("quotient: %s\nremainder: %s\n", layoutPolyString(quotient), rem.RatString()))
+    return b.String()
+}
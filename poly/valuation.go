@@ -0,0 +1,37 @@
+package poly
+
+import (
+    "math/big"
+)
+
+// ValuationAtZero returns the order of vanishing of p at 0, the largest k
+// with x^k dividing p. The zero polynomial is divisible by every power of x;
+// -1 is returned for it.
+func (p *Polynomial) ValuationAtZero() int {
+    if p.IsZero() {
+        return -1
+    }
+    k := 0
+    for p.coeff[k].Sign() == 0 {
+        k++
+    }
+    return k
+}
+
+// ValuationAt returns the multiplicity of a as a root of p, the largest k with
+// (x - a)^k dividing p, found by dividing by x - a until the remainder is
+// nonzero. It is 0 when p(a) != 0 and -1 for the zero polynomial.
+func (p *Polynomial) ValuationAt(a *big.Rat) int {
+    if p.IsZero() {
+        return -1
+    }
+    k := 0
+    for q := p; q.Deg() > 0; k++ {
+        quotient, rem, _ := q.syntheticDiv(a)
+        if rem.Sign() != 0 {
+            break
+        }
+        q = quotient
+    }
+    return k
+}
@@ -0,0 +1,44 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// BezoutError reports cofactors s and t of f and g for which s*f + t*g is
+// not the claimed gcd times a nonzero constant
+type BezoutError struct {
+    Sum *Polynomial // s*f + t*g
+    GCD *Polynomial
+    // Discrepancy is Sum minus the multiple of GCD with the leading
+    // coefficient of Sum, or Sum - GCD when one of them is zero
+    Discrepancy *Polynomial
+}
+
+func (e *BezoutError) Error() string {
+    return fmt.Sprintf("bezout: s*f + t*g = %s is not a unit times gcd %s, discrepancy %s", e.Sum, e.GCD, e.Discrepancy)
+}
+
+// Verify recomputes s*f + t*g and checks that it equals gcd up to a unit,
+// a nonzero constant factor, so that results normalized to a monic or a
+// primitive gcd pass alike. A failure is reported as a *BezoutError with
+// the discrepancy polynomial. Verify does not check that gcd divides f and
+// g; for cofactors that satisfy the identity that only fails when gcd is
+// not the greatest common divisor but a multiple of it.
+func Verify(f, g, gcd, s, t *Polynomial) error {
+    for _, p := range []*Polynomial{f, g, gcd, s, t} {
+        if p == nil {
+            return ErrNilPolynomial
+        }
+    }
+    sum := s.mul(f).Add(t.mul(g))
+    unit := big.NewRat(1, 1)
+    if !sum.IsZero() && !gcd.IsZero() {
+        unit.Quo(sum.coeff[sum.Deg()], gcd.coeff[gcd.Deg()])
+    }
+    discrepancy := sum.Sub(gcd.scale(unit))
+    if discrepancy.IsZero() {
+        return nil
+    }
+    return &BezoutError{Sum: sum, GCD: gcd, Discrepancy: discrepancy}
+}
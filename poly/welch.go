@@ -0,0 +1,57 @@
+package poly
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+)
+
+var errTooManyErrors = errors.New("welch-berlekamp: too many errors to decode")
+
+// WelchBerlekampDecode recovers the polynomial m of degree below k from its
+// values ys at the distinct points xs, of which up to (len(xs) - k) / 2 may be
+// wrong. It is the Welch–Berlekamp rational interpolation in Gao's
+// formulation: with g0 = (x - xs[0])...(x - xs[n-1]) and g1 the interpolant
+// of the received values, the extended Euclidean algorithm on g0 and g1 is
+// stopped at the first remainder r of degree below (n + k) / 2. Its cofactor
+// t of g1 is then a multiple of the error locator, the polynomial vanishing
+// exactly at the wrong points, and m = r / t. It returns m and the indices of
+// the wrong values, or an error if xs and ys differ in length, hold nil or
+// repeated points, or k is not between 1 and len(xs).
+func WelchBerlekampDecode(xs, ys []*big.Rat, k int) (*Polynomial, []int, error) {
+    if err := checkInterpolationPoints(xs, ys); err != nil {
+        return nil, nil, fmt.Errorf("welch-berlekamp: %w", err)
+    }
+    n := len(xs)
+    if k < 1 || k > n {
+        return nil, nil, fmt.Errorf("welch-berlekamp: message length k = %d is not between 1 and the %d points", k, n)
+    }
+    g0 := FromRoots(xs)
+    g1 := interpolate(xs, ys)
+    var r, t *Polynomial
+    if 2*g1.Deg() < n+k {
+        // no errors: the interpolant already has low degree
+        r, t = g1, One()
+    } else {
+        for _, st := range extendedGCDResult(g0, g1).Steps {
+            if 2*st.Remainder.Deg() < n+k || st.Remainder.IsZero() {
+                r, t = st.Remainder, st.T
+                break
+            }
+        }
+    }
+    if r == nil || t.IsZero() {
+        return nil, nil, errTooManyErrors
+    }
+    m, rem := r.div(t)
+    if !rem.IsZero() || (!m.IsZero() && m.Deg() >= k) {
+        return nil, nil, errTooManyErrors
+    }
+    var wrong []int
+    for i, x := range xs {
+        if t.Eval(x).Sign() == 0 {
+            wrong = append(wrong, i)
+        }
+    }
+    return m, wrong, nil
+}
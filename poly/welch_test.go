@@ -0,0 +1,62 @@
+package poly
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+// TestWelchBerlekampDecode corrupts values of a message polynomial and
+// checks that the decoder finds the message and the wrong indices
+func TestWelchBerlekampDecode(t *testing.T) {
+    m, _ := ParseCoefficients("2,-1,3") // 2x^2 - x + 3
+    var xs, ys []*big.Rat
+    for i := 0; i < 9; i++ {
+        x := big.NewRat(int64(i), 1)
+        xs, ys = append(xs, x), append(ys, m.Eval(x))
+    }
+    // n = 9, k = 3 corrects up to 3 wrong values
+    ys[1] = big.NewRat(100, 1)
+    ys[4] = big.NewRat(-1, 2)
+    ys[8] = new(big.Rat)
+    got, wrong, err := WelchBerlekampDecode(xs, ys, 3)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !got.Equal(m) {
+        t.Errorf("decoded %s, want %s", got, m)
+    }
+    if len(wrong) != 3 || wrong[0] != 1 || wrong[1] != 4 || wrong[2] != 8 {
+        t.Errorf("wrong values at %v, want [1 4 8]", wrong)
+    }
+}
+
+// TestWelchBerlekampDecodeChecksInputs checks that malformed inputs are
+// reported as errors rather than panics or meaningless results
+func TestWelchBerlekampDecodeChecksInputs(t *testing.T) {
+    r := func(n int64) *big.Rat { return big.NewRat(n, 1) }
+    xs := []*big.Rat{r(0), r(1), r(2), r(3)}
+    ys := []*big.Rat{r(1), r(2), r(3), r(4)}
+    cases := []struct {
+        name   string
+        xs, ys []*big.Rat
+        k      int
+    }{
+        {"fewer ys", xs, ys[:3], 2},
+        {"more ys", xs[:3], ys, 2},
+        {"no points", nil, nil, 1},
+        {"repeated x", []*big.Rat{r(0), r(1), r(1), r(3)}, ys, 2},
+        {"nil x", []*big.Rat{r(0), nil, r(2), r(3)}, ys, 2},
+        {"nil y", xs, []*big.Rat{r(1), r(2), nil, r(4)}, 2},
+        {"k = 0", xs, ys, 0},
+        {"k < 0", xs, ys, -1},
+        {"k > n", xs, ys, 5},
+    }
+    for _, c := range cases {
+        if _, _, err := WelchBerlekampDecode(c.xs, c.ys, c.k); err == nil {
+            t.Errorf("%s: no error", c.name)
+        } else if errors.Is(err, errTooManyErrors) {
+            t.Errorf("%s: reported as too many errors", c.name)
+        }
+    }
+}
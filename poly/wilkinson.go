@@ -0,0 +1,120 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+    "sort"
+)
+
+// wilkinsonPolynomial returns (x - 1)(x - 2)...(x - n), built exactly
+func wilkinsonPolynomial(n int) *Polynomial {
+    roots := make([]*big.Rat, n)
+    for i := range roots {
+        roots[i] = big.NewRat(int64(i+1), 1)
+    }
+    return FromRoots(roots)
+}
+
+// roundedToFloat64 returns p with every coefficient rounded to the nearest
+// float64, as a floating-point implementation would store it
+func roundedToFloat64(p *Polynomial) *Polynomial {
+    coeffs := make([]*big.Rat, p.Deg()+1)
+    for i := range coeffs {
+        f, _ := p.coeff[i].Float64()
+        coeffs[i] = new(big.Rat).SetFloat64(f)
+    }
+    return NewPolyNoCopy(coeffs)
+}
+
+// DisplacedRoot is a computed root with its distance from the nearest
+// integer, the root of Wilkinson's polynomial it moved away from
+type DisplacedRoot struct {
+    Root         ComplexRoot
+    Displacement float64
+}
+
+// WilkinsonResult holds Wilkinson's polynomial W, the roots of W after the
+// perturbation of one coefficient and after rounding every coefficient to
+// float64, both sorted by real part, and the plot of the three sets of roots
+type WilkinsonResult struct {
+    W                  *Polynomial
+    Perturbed, Rounded []DisplacedRoot
+    Figure             *Figure
+}
+
+// displacedRoots sorts roots by real part, then by decreasing imaginary
+// part, and pairs each with its distance from the nearest integer
+func displacedRoots(roots []bigComplex) []DisplacedRoot {
+    sort.Slice(roots, func(i, j int) bool {
+        ri, _ := roots[i].re.Float64()
+        rj, _ := roots[j].re.Float64()
+        if ri != rj {
+            return ri < rj
+        }
+        return roots[i].im.Cmp(roots[j].im) > 0
+    })
+    displaced := make([]DisplacedRoot, len(roots))
+    for i, z := range roots {
+        re, _ := z.re.Float64()
+        nearest := big.NewFloat(float64(int64(re + 0.5)))
+        d := z.sub(bigComplex{nearest.SetPrec(z.prec()), new(big.Float).SetPrec(z.prec())})
+        displaced[i] = DisplacedRoot{ComplexRoot{z.re, z.im}, d.abs()}
+    }
+    return displaced
+}
+
+// Wilkinson builds Wilkinson's polynomial of degree n exactly, adds delta
+// to the coefficient of x^k and computes how far the roots move. It also
+// computes the roots of the original polynomial after merely rounding its
+// coefficients to float64, the error a floating-point implementation starts
+// from. n must be at least 1 and k between 0 and n. If the roots cannot be
+// computed, the result holds W only.
+func Wilkinson(n, k int, delta *big.Rat) (*WilkinsonResult, error) {
+    if n < 1 {
+        return nil, fmt.Errorf("wilkinson: degree n = %d is not at least 1", n)
+    }
+    if k < 0 || k > n {
+        return nil, fmt.Errorf("wilkinson: coefficient index k = %d is not between 0 and n = %d", k, n)
+    }
+    if delta == nil {
+        return nil, fmt.Errorf("wilkinson: nil perturbation")
+    }
+    const digits = 20
+    w := wilkinsonPolynomial(n)
+    perturbed := w.perturb(k, delta)
+    rounded := roundedToFloat64(w)
+    res := &WilkinsonResult{W: w}
+
+    rootsPerturbed, err := aberthRoots(perturbed, digits)
+    if err != nil {
+        return res, err
+    }
+    rootsRounded, err := aberthRoots(rounded, digits)
+    if err != nil {
+        return res, err
+    }
+    res.Perturbed = displacedRoots(rootsPerturbed)
+    res.Rounded = displacedRoots(rootsRounded)
+
+    original := make([]bigComplex, n)
+    for i := range original {
+        original[i] = newBigComplex(float64(i+1), 0, 64)
+    }
+
+    res.Figure = &Figure{
+        Title:  fmt.Sprintf("Wilkinson's polynomial of degree %d", n),
+        XLabel: "Re",
+        YLabel: "Im",
+        Width:  8,
+        Height: 5,
+        Grid:   true,
+        Series: []Series{
+            {Label: "roots of W", Points: rootPoints(original), Scatter: true, Glyph: GlyphCircle, Color: figureBlack, Radius: 3},
+            {Label: "perturbed coefficient", Points: rootPoints(rootsPerturbed), Scatter: true, Glyph: GlyphPyramid, Color: figureRed, Radius: 3},
+            {Label: "float64 coefficients", Points: rootPoints(rootsRounded), Scatter: true, Glyph: GlyphCross, Color: figureBlue, Radius: 3},
+        },
+        LegendTop:  true,
+        LegendLeft: true,
+    }
+    return res, nil
+}
@@ -0,0 +1,33 @@
+package poly
+
+import (
+    "math/big"
+    "testing"
+)
+
+func TestWilkinsonChecksInputs(t *testing.T) {
+    delta := big.NewRat(-1, 1<<23)
+    cases := []struct {
+        name  string
+        n, k  int
+        delta *big.Rat
+    }{
+        {"n = 0", 0, 0, delta},
+        {"negative n", -3, 0, delta},
+        {"negative k", 5, -1, delta},
+        {"k above n", 5, 6, delta},
+        {"nil delta", 5, 4, nil},
+    }
+    for _, c := range cases {
+        if res, err := Wilkinson(c.n, c.k, c.delta); err == nil || res != nil {
+            t.Errorf("%s: result %v, error %v; want only an error", c.name, res, err)
+        }
+    }
+    res, err := Wilkinson(5, 5, delta)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(res.Perturbed) != 5 || len(res.Rounded) != 5 {
+        t.Errorf("%d perturbed and %d rounded roots, want 5 of each", len(res.Perturbed), len(res.Rounded))
+    }
+}
@@ -0,0 +1,50 @@
+package poly
+
+import (
+    "fmt"
+    "math/big"
+)
+
+// SquarefreeFactorization returns the square-free decomposition of f by
+// Yun's algorithm: f = lead * a_1 * a_2^2 * ... * a_k^k with monic,
+// square-free and pairwise coprime a_i, so that the roots of a_i are
+// exactly the roots of f of multiplicity i. Only the nonconstant a_i are
+// returned, as factors with their multiplicity i in increasing order; they
+// can be passed on to Divisors. With g = gcd(f, f'), b = f/g and
+// c = f'/g, each step takes a_i = gcd(b, c - b'), then b = b/a_i and
+// c = (c - b')/a_i, so it needs one gcd per distinct multiplicity and the
+// gcds get cheaper as b shrinks. opts select the GCD algorithm as for
+// ExtendedGCDResult. The zero polynomial has no decomposition.
+func SquarefreeFactorization(f *Polynomial, opts ...Option) (lead *big.Rat, factors []Factor, err error) {
+    if f == nil {
+        return nil, nil, ErrNilPolynomial
+    }
+    if err := newConfig(opts...).checkGCD("Q"); err != nil {
+        return nil, nil, err
+    }
+    if f.IsZero() {
+        return nil, nil, fmt.Errorf("squarefree: the zero polynomial has no decomposition")
+    }
+    lead = f.Coeff(f.Deg())
+    f = f.monic()
+    if f.Deg() == 0 {
+        return lead, nil, nil
+    }
+    gcd := func(a, b *Polynomial) *Polynomial {
+        return extendedGCDResult(a, b, opts...).GCD.monic()
+    }
+    d := f.Derivative()
+    g := gcd(f, d)
+    b, _ := f.div(g)
+    c, _ := d.div(g)
+    for i := 1; b.Deg() > 0; i++ {
+        e := c.Sub(b.Derivative())
+        a := gcd(b, e)
+        if a.Deg() > 0 {
+            factors = append(factors, Factor{Poly: a, Multiplicity: i})
+        }
+        b, _ = b.div(a)
+        c, _ = e.div(a)
+    }
+    return lead, factors, nil
+}
@@ -0,0 +1,265 @@
+package polymod
+
+import (
+    "errors"
+    "fmt"
+    "math/rand"
+
+    "euclid/internal/benchtime"
+    "euclid/intring"
+)
+
+// inverseStrategies names the modular polynomial inversion algorithms
+// WithInverseStrategy accepts
+var inverseStrategies = []string{"euclid", "almost", "consttime"}
+
+// IsInverseStrategy reports whether name is one of inverseStrategies
+func IsInverseStrategy(name string) bool {
+    for _, s := range inverseStrategies {
+        if s == name {
+            return true
+        }
+    }
+    return false
+}
+
+// shiftUp returns f*x^k
+func (f *PolyMod) shiftUp(k int) *PolyMod {
+    if k == 0 || f.IsZero() {
+        return f
+    }
+    return newPolyModNoCopy(f.p, append(make([]uint64, k), f.coeff...))
+}
+
+// almostInverseMod returns the inverse of the nonzero a, reduced modulo f,
+// by the almost inverse algorithm of Schroeppel, Orman, O'Malley and
+// Spatscheck. It keeps a*b = x^k*u and a*c = x^k*v (mod f), starting from
+// u = a, v = f: factors of x are shifted out of u into k, then the constant
+// term of u is cancelled with a multiple of v. Only constant terms are ever
+// inverted, and no quotient is formed. When u reaches a constant, b/u is
+// x^k times the inverse, and the k factors of x are divided out at the end,
+// each by adding the multiple of f that makes the constant term vanish,
+// which is why f(0) must be nonzero.
+func almostInverseMod(a, f *PolyMod) (*PolyMod, error) {
+    p := f.p
+    b, c := newPolyModNoCopy(p, []uint64{1}), newPolyModNoCopy(p, nil)
+    u, v := a, f
+    k := 0
+    for {
+        if u.IsZero() {
+            return nil, ErrNotInvertible
+        }
+        z := 0
+        for u.coeff[z] == 0 {
+            z++
+        }
+        u = newPolyModNoCopy(p, u.coeff[z:])
+        c = c.shiftUp(z)
+        k += z
+        if u.Deg() == 0 {
+            break
+        }
+        if u.Deg() < v.Deg() {
+            u, v = v, u
+            b, c = c, b
+        }
+        inv, _ := intring.InvMod(v.coeff[0], p)
+        q := intring.MulMod(u.coeff[0], inv, p)
+        u = u.sub(v.scale(q))
+        b = b.sub(c.scale(q))
+    }
+
+    inv, _ := intring.InvMod(u.coeff[0], p)
+    b = b.scale(inv)
+    invF0, _ := intring.InvMod(f.coeff[0], p)
+    for ; k > 0; k-- {
+        b = b.sub(f.scale(intring.MulMod(b.Coeff(0), invF0, p)))
+        if !b.IsZero() {
+            b = newPolyModNoCopy(p, b.coeff[1:])
+        }
+    }
+    _, b = b.div(f)
+    return b, nil
+}
+
+// InverseGF2 returns the inverse of a in GF(2)[x]/(f), which is the field
+// GF(2^deg f) when f is irreducible, or ErrNotInvertible if a and f are not
+// coprime. WithInverseStrategy selects the extended Euclidean algorithm (the
+// default) or the almost inverse; both run on packed words with shifts and
+// xors only. The almost inverse needs f(0) = 1 and falls back to Euclid
+// otherwise; the constant-time strategy is not available here.
+func InverseGF2(a, f *GF2Poly, opts ...Option) (*GF2Poly, error) {
+    if f.Deg() < 1 {
+        return nil, fmt.Errorf("gf2: modulus %s has no positive degree", f)
+    }
+    u := remWords(a.Words(), f.w)
+    if len(u) == 0 {
+        return nil, ErrNotInvertible
+    }
+    c := newConfig(opts...)
+    if c.err != nil {
+        return nil, c.err
+    }
+    switch c.inverse {
+    case "almost":
+        if f.w[0]&1 == 1 {
+            return almostInverseGF2(u, f.w)
+        }
+    case "consttime":
+        return nil, errors.New("gf2: no constant-time inverse over GF(2)")
+    }
+    return euclidInverseGF2(u, f.w)
+}
+
+// euclidInverseGF2 inverts the nonzero u, reduced modulo f, by the extended
+// Euclidean algorithm with the quotient formed one term at a time: the
+// operand of higher degree gets the other shifted to its degree added, and
+// its cofactor the other's cofactor shifted alike
+func euclidInverseGF2(u, f []uint64) (*GF2Poly, error) {
+    v := append([]uint64(nil), f...)
+    g1, g2 := []uint64{1}, []uint64(nil)
+    for degWords(u) > 0 {
+        j := degWords(u) - degWords(v)
+        if j < 0 {
+            u, v = v, u
+            g1, g2 = g2, g1
+            j = -j
+        }
+        u = addShiftedWords(u, v, j)
+        g1 = addShiftedWords(g1, g2, j)
+    }
+    if len(u) == 0 {
+        return nil, ErrNotInvertible
+    }
+    return &GF2Poly{remWords(g1, f)}, nil
+}
+
+// almostInverseGF2 is almostInverseMod on packed words, where cancelling
+// the constant term of u is a single xor with v
+func almostInverseGF2(u, f []uint64) (*GF2Poly, error) {
+    v := append([]uint64(nil), f...)
+    b, c := []uint64{1}, []uint64(nil)
+    k := 0
+    for {
+        if len(u) == 0 {
+            return nil, ErrNotInvertible
+        }
+        if z := trailingZeroWords(u); z > 0 {
+            u = shiftRightWords(u, z)
+            c = addShiftedWords(nil, c, z)
+            k += z
+        }
+        if degWords(u) == 0 {
+            break
+        }
+        if degWords(u) < degWords(v) {
+            u, v = v, u
+            b, c = c, b
+        }
+        u = addShiftedWords(u, v, 0)
+        b = addShiftedWords(b, c, 0)
+    }
+    // a run of zero low bits is shifted out at once, and adding f clears
+    // the lowest bit whenever it is set
+    for k > 0 && len(b) > 0 {
+        if b[0]&1 == 1 {
+            b = addShiftedWords(b, f, 0)
+        }
+        z := min(trailingZeroWords(b), k)
+        b = shiftRightWords(b, z)
+        k -= z
+    }
+    return &GF2Poly{remWords(b, f)}, nil
+}
+
+// binaryFields are the reduction polynomials of the AES field and the NIST
+// binary curve fields, given by their nonzero exponents
+var binaryFields = []struct {
+    name      string
+    exponents []int
+}{
+    {"AES GF(2^8)", []int{8, 4, 3, 1, 0}},
+    {"B-163", []int{163, 7, 6, 3, 0}},
+    {"B-233", []int{233, 74, 0}},
+    {"B-283", []int{283, 12, 7, 5, 0}},
+    {"B-409", []int{409, 87, 0}},
+    {"B-571", []int{571, 10, 5, 2, 0}},
+}
+
+// inverseBenchPrimeDegrees are the degrees of the GF(p)[x] moduli InverseBench
+// uses, with p = 2^31 - 1
+var inverseBenchPrimeDegrees = []int{4, 16, 64, 256}
+
+// InverseBenchRow holds the ns/op of the inverse strategies in one field
+// of InverseBench, in the order of the extended Euclidean, almost inverse
+// and constant-time strategies; Ns is shorter for fields where the last
+// strategies do not apply
+type InverseBenchRow struct {
+    Field string
+    Ns    []float64
+}
+
+// InverseBench compares the extended Euclidean, almost inverse and, where
+// it applies, constant-time strategies for inverses in the binary fields of
+// binaryFields and in GF(p)[x]/(m) for random m of growing degree, checking
+// that the strategies agree with each other and with ExtendedGCDMod, and
+// returns ns/op per strategy
+func InverseBench() ([]InverseBenchRow, error) {
+    var rows []InverseBenchRow
+    rng := rand.New(rand.NewSource(1))
+    for _, field := range binaryFields {
+        m := field.exponents[0]
+        f := NewGF2Poly(nil)
+        for _, e := range field.exponents {
+            f.w = addShiftedWords(f.w, []uint64{1}, e)
+        }
+        a := randomGF2Poly(rng, m-1)
+        row, err := inverseBenchRow(field.name, f.PolyMod(), a.PolyMod(), inverseStrategies[:2],
+            func(opts ...Option) (fmt.Stringer, error) { return InverseGF2(a, f, opts...) })
+        if err != nil {
+            return rows, err
+        }
+        rows = append(rows, row)
+    }
+    const p = 1<<31 - 1
+    for _, n := range inverseBenchPrimeDegrees {
+        f, a := randomPolyMod(rng, p, n), randomPolyMod(rng, p, n-1)
+        row, err := inverseBenchRow(fmt.Sprintf("GF(2^31-1)[x], deg %d", n), f, a, inverseStrategies,
+            func(opts ...Option) (fmt.Stringer, error) { return InverseMod(a, f, opts...) })
+        if err != nil {
+            return rows, err
+        }
+        rows = append(rows, row)
+    }
+    return rows, nil
+}
+
+// inverseBenchRow checks and times one InverseBench row. invert inverts a
+// modulo f with the given options in the representation being measured, for
+// each of strategies.
+func inverseBenchRow(name string, f, a *PolyMod, strategies []string, invert func(opts ...Option) (fmt.Stringer, error)) (InverseBenchRow, error) {
+    gcd, s, _ := extendedGCDMod(a, f)
+    want := "not invertible"
+    if gcd.Deg() == 0 && !gcd.IsZero() {
+        want = s.String()
+    }
+    row := InverseBenchRow{Field: name}
+    for _, strategy := range strategies {
+        got, err := invert(WithInverseStrategy(strategy))
+        if err == nil && got.String() != want || err != nil && want != "not invertible" {
+            return row, fmt.Errorf("%s: %s inverse disagrees with the extended Euclidean algorithm", name, strategy)
+        }
+        row.Ns = append(row.Ns, benchtime.Ns(func() { invert(WithInverseStrategy(strategy)) }))
+    }
+    return row, nil
+}
+
+// randomPolyMod returns a random polynomial over GF(p) of exact degree n with
+// nonzero constant term
+func randomPolyMod(rng *rand.Rand, p uint64, n int) *PolyMod {
+    coeffs := make([]uint64, n+1)
+    for i := range coeffs {
+        coeffs[i] = uint64(rng.Int63n(int64(p)-1)) + 1
+    }
+    return newPolyModNoCopy(p, coeffs)
+}
@@ -0,0 +1,254 @@
+package polymod
+
+import (
+    "errors"
+    "fmt"
+    "math/bits"
+
+    "euclid/intring"
+)
+
+// The inverses in this file run in constant time for a given modulus size:
+// they follow the safegcd algorithm of Bernstein and Yang, "Fast
+// constant-time gcd computation and modular inversion" (2019), with a fixed
+// number of divsteps chosen from the size of the modulus alone, and every
+// data-dependent decision inside a divstep is made with masks instead of
+// branches. Multiplications use Montgomery reduction built from bits.Mul64,
+// bits.Add64 and bits.Sub64, which compile to fixed-latency instructions
+// (intring.Montgomery), rather than the bits.Div64 of intring.MulMod, whose
+// latency depends on its operands on many CPUs. What is not hidden: the modulus, the degree of a polynomial
+// argument (the trimmed PolyMod slice reveals it anyway) and whether the
+// inverse exists. The Go compiler gives no constant-time guarantee, so audit
+// the generated code before relying on this against a local attacker.
+
+// ctModulusBits bounds the moduli of the constant-time inverses: below
+// 2^62 the signed divstep values and the Montgomery sums fit in 64 bits
+const ctModulusBits = 62
+
+// ctIntDivsteps is the number of divsteps ConstTimeInverse always runs, the
+// Bernstein–Yang bound floor((49d + 57)/17) for d-bit inputs, d = ctModulusBits
+const ctIntDivsteps = (49*ctModulusBits + 57) / 17
+
+// errConstTimeModulus rejects moduli the constant-time code cannot handle
+var errConstTimeModulus = fmt.Errorf("consttime: modulus must be odd, at least 3 and below 2^%d", ctModulusBits)
+
+// ctSelect returns a if mask is all ones and b if it is zero
+func ctSelect(mask, a, b uint64) uint64 { return b ^ (mask & (a ^ b)) }
+
+// ctNonZeroMask returns all ones if x != 0 and zero otherwise
+func ctNonZeroMask(x uint64) uint64 { return -((x | -x) >> 63) }
+
+// ctAddMod returns (a + b) mod m for residues a, b < m < 2^63
+func ctAddMod(a, b, m uint64) uint64 {
+    s := a + b
+    d, borrow := bits.Sub64(s, m, 0)
+    return ctSelect(-borrow, s, d)
+}
+
+// ctSubMod returns (a - b) mod m for residues a, b < m
+func ctSubMod(a, b, m uint64) uint64 {
+    d, borrow := bits.Sub64(a, b, 0)
+    return d + (m & -borrow)
+}
+
+// ctHalfMod returns a/2 mod the odd m for a residue a < m < 2^63
+func ctHalfMod(a, m uint64) uint64 {
+    return (a + (m & -(a & 1))) >> 1
+}
+
+// ConstTimeInverse returns the inverse of a modulo the odd m, 3 <= m < 2^62,
+// in constant time: exactly ctIntDivsteps branch-free divsteps whatever a
+// is. a must already be reduced, a < m, since reducing it would take a
+// variable-time division. The error reports an invalid modulus or argument,
+// or that gcd(a, m) != 1.
+func ConstTimeInverse(a, m uint64) (uint64, error) {
+    if m < 3 || m%2 == 0 || m >= 1<<ctModulusBits {
+        return 0, errConstTimeModulus
+    }
+    if a >= m {
+        return 0, fmt.Errorf("consttime: %d is not reduced modulo %d", a, m)
+    }
+    inv, ok, _ := ctInverse(a, m)
+    if !ok {
+        return 0, ErrNotInvertible
+    }
+    return inv, nil
+}
+
+// ctInverse runs the divsteps for ConstTimeInverse and also returns their
+// number. It keeps f = d*a and g = e*a (mod m), starting from f = m, g = a;
+// each divstep halves g after, when g is odd, adding f to it or, when in
+// addition delta > 0, swapping f and g and negating the new g first. After
+// enough steps g = 0 and f = ±gcd(a, m).
+func ctInverse(a, m uint64) (inv uint64, ok bool, steps int) {
+    delta, f, g := int64(1), int64(m), int64(a)
+    d, e := uint64(0), uint64(1)
+    for ; steps < ctIntDivsteps; steps++ {
+        odd := -uint64(g & 1)
+        swap := odd & uint64(-delta>>63)
+        s := int64(swap)
+        delta = (delta ^ s) - s
+        t := (f ^ g) & s
+        f, g = f^t, g^t
+        g = (g ^ s) - s
+        u := (d ^ e) & swap
+        d, e = d^u, e^u
+        e = ctSelect(swap, ctSubMod(0, e, m), e)
+
+        delta++
+        g = (g + f&int64(odd)) >> 1
+        e = ctHalfMod(ctAddMod(e, d&odd, m), m)
+    }
+    negative := uint64(f >> 63)
+    inv = ctSelect(negative, ctSubMod(0, d, m), d)
+    return inv, f == 1 || f == -1, steps
+}
+
+// ctInverseMod returns the inverse of a, deg a < deg f, in GF(p)[x]/(f) and
+// the number of divsteps taken, always 2*deg f - 1. This is the polynomial
+// form of safegcd (Bernstein–Yang, section 6): the divsteps run on the
+// reversed polynomials F = x^n f(1/x) and G = x^(n-1) a(1/x), cancelling
+// constant terms, that is leading terms of f and a, with
+// g = (f(0)*g - g(0)*f)/x, and swapping f and g when delta > 0 and
+// g(0) != 0. Alongside, v and r with f = u*F + v*G and g = q*F + r*G are kept
+// as polynomials in 1/x; afterwards delta/2 is the degree of the gcd, and
+// for a unit gcd the inverse is v shifted down by n - 1, over f(0).
+func ctInverseMod(a, f *PolyMod) (*PolyMod, int, error) {
+    p := f.p
+    if p%2 == 0 || p >= 1<<ctModulusBits {
+        return nil, 0, errConstTimeModulus
+    }
+    mont := intring.NewMontgomery(p)
+    n := f.Deg()
+    F, G := make([]uint64, n+1), make([]uint64, n+1)
+    for i := 0; i <= n; i++ {
+        F[i] = mont.To(f.Coeff(n - i))
+        if i < n {
+            G[i] = mont.To(a.Coeff(n - 1 - i))
+        }
+    }
+    v, r := make([]uint64, 2*n), make([]uint64, 2*n)
+    r[0] = mont.To(1)
+
+    delta := int64(1)
+    steps := 0
+    for ; steps < 2*n-1; steps++ {
+        swap := uint64(-delta>>63) & ctNonZeroMask(G[0])
+        s := int64(swap)
+        delta = (delta ^ s) - s
+        for i := range F {
+            t := (F[i] ^ G[i]) & swap
+            F[i], G[i] = F[i]^t, G[i]^t
+            G[i] = ctSelect(swap, ctSubMod(0, G[i], p), G[i])
+        }
+        for i := range v {
+            t := (v[i] ^ r[i]) & swap
+            v[i], r[i] = v[i]^t, r[i]^t
+            r[i] = ctSelect(swap, ctSubMod(0, r[i], p), r[i])
+        }
+
+        delta++
+        f0, g0 := F[0], G[0]
+        for i := 0; i < n; i++ {
+            G[i] = ctSubMod(mont.Mul(f0, G[i+1]), mont.Mul(g0, F[i+1]), p)
+        }
+        G[n] = 0
+        for i := len(r) - 1; i > 0; i-- {
+            r[i] = ctSubMod(mont.Mul(f0, r[i-1]), mont.Mul(g0, v[i-1]), p)
+        }
+        r[0] = 0
+    }
+    if delta != 0 {
+        return nil, steps, ErrNotInvertible
+    }
+    c := mont.Inv(F[0])
+    inv := make([]uint64, n)
+    for i := range inv {
+        inv[i] = mont.From(mont.Mul(v[i+n-1], c))
+    }
+    return newPolyModNoCopy(p, inv), steps, nil
+}
+
+// ConstTimeCheck is the outcome of CheckConstantTime for one modulus: the
+// number of inputs, the least and the most divsteps any input took and the
+// count expected for every input. Err tells what went wrong, a wrong
+// inverse or a varying number of divsteps, or is nil.
+type ConstTimeCheck struct {
+    Name                                 string
+    Inputs, MinSteps, MaxSteps, Divsteps int
+    Err                                  error
+}
+
+// CheckConstantTime runs the constant-time inverses on edge cases and random
+// inputs for several moduli, compares every result with the variable-time
+// algorithms and checks that the number of divsteps depends only on the
+// size of the modulus. It returns one check per modulus.
+func CheckConstantTime(opts ...Option) []ConstTimeCheck {
+    rng := newConfig(opts...).rand()
+    var checks []ConstTimeCheck
+    report := func(name string, inputs, minSteps, maxSteps, want int, err error) {
+        if err == nil && (minSteps != want || maxSteps != want) {
+            err = fmt.Errorf("divsteps vary from %d to %d", minSteps, maxSteps)
+        }
+        checks = append(checks, ConstTimeCheck{name, inputs, minSteps, maxSteps, want, err})
+    }
+
+    for _, m := range []uint64{3, 15, 65537, 1<<31 - 1, 3 * 5 * 7 * 11 * 13, 1<<61 - 1, 1<<62 - 57} {
+        inputs := []uint64{0, 1, 2, m - 1, m / 2}
+        for i := 0; i < 200; i++ {
+            inputs = append(inputs, uint64(rng.Int63n(int64(m))))
+        }
+        minSteps, maxSteps := ctIntDivsteps+1, -1
+        var err error
+        for _, a := range inputs {
+            inv, ok, steps := ctInverse(a, m)
+            minSteps, maxSteps = min(minSteps, steps), max(maxSteps, steps)
+            want, wantOk := intring.InvMod(a, m)
+            if (ok != wantOk || ok && inv != want) && err == nil {
+                err = fmt.Errorf("inverse of %d is %d (%v), want %d (%v)", a, inv, ok, want, wantOk)
+            }
+        }
+        report(fmt.Sprintf("Z/%d", m), len(inputs), minSteps, maxSteps, ctIntDivsteps, err)
+    }
+
+    for _, p := range []uint64{3, 65537, 1<<61 - 1} {
+        for _, n := range []int{1, 4, 16} {
+            f := randomPolyMod(rng, p, n)
+            inputs := []*PolyMod{
+                newPolyModNoCopy(p, nil),
+                newPolyModNoCopy(p, []uint64{1}),
+                newPolyModNoCopy(p, []uint64{1}).shiftUp(n - 1),
+            }
+            if n > 1 {
+                // a factor of f, which has no inverse modulo f
+                factor := randomPolyMod(rng, p, 1)
+                f = randomPolyMod(rng, p, n-1).mul(factor)
+                inputs = append(inputs, factor)
+            }
+            for i := 0; i < 50; i++ {
+                inputs = append(inputs, randomPolyMod(rng, p, rng.Intn(n)))
+            }
+            minSteps, maxSteps := 2*n, -1
+            var err error
+            for _, a := range inputs {
+                _, a = a.div(f)
+                inv, steps, cerr := ctInverseMod(a, f)
+                minSteps, maxSteps = min(minSteps, steps), max(maxSteps, steps)
+                want, werr := InverseMod(a, f)
+                if err != nil {
+                    continue
+                }
+                switch {
+                case cerr != nil && !errors.Is(cerr, ErrNotInvertible):
+                    err = cerr
+                case (cerr == nil) != (werr == nil):
+                    err = fmt.Errorf("inverse of %s: %v, want %v", a, cerr, werr)
+                case cerr == nil && !inv.Equal(want):
+                    err = fmt.Errorf("inverse of %s is %s, want %s", a, inv, want)
+                }
+            }
+            report(fmt.Sprintf("GF(%d)[x], deg %d", p, n), len(inputs), minSteps, maxSteps, 2*n-1, err)
+        }
+    }
+    return checks
+}
@@ -0,0 +1,94 @@
+package polymod
+
+import (
+    "errors"
+    "math/rand"
+    "testing"
+
+    "euclid/intring"
+)
+
+// TestConstTimeInverseDivsteps checks that ctInverse runs exactly
+// ctIntDivsteps divsteps and agrees with intring.InvMod, whatever the input:
+// zero, units, non-units and inputs of every size
+func TestConstTimeInverseDivsteps(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, m := range []uint64{3, 15, 65537, 1<<31 - 1, 3 * 5 * 7 * 11 * 13, 1<<61 - 1, 1<<62 - 57} {
+        inputs := []uint64{0, 1, 2, 3 % m, m - 1, m / 2}
+        for i := 0; i < 200; i++ {
+            inputs = append(inputs, uint64(rng.Int63n(int64(m))))
+        }
+        for _, a := range inputs {
+            inv, ok, steps := ctInverse(a, m)
+            if steps != ctIntDivsteps {
+                t.Errorf("inverse of %d mod %d took %d divsteps, want %d", a, m, steps, ctIntDivsteps)
+            }
+            want, wantOk := intring.InvMod(a, m)
+            if ok != wantOk || ok && inv != want {
+                t.Errorf("inverse of %d mod %d is %d (%v), want %d (%v)", a, m, inv, ok, want, wantOk)
+            }
+        }
+    }
+}
+
+// TestConstTimeInverseModDivsteps checks that ctInverseMod runs exactly
+// 2*deg f - 1 divsteps for every argument, invertible or not, and agrees
+// with InverseMod
+func TestConstTimeInverseModDivsteps(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, p := range []uint64{3, 65537, 1<<61 - 1} {
+        for _, n := range []int{1, 2, 5, 16} {
+            factor := randomPolyMod(rng, p, 1)
+            f := randomPolyMod(rng, p, n-1).mul(factor)
+            inputs := []*PolyMod{
+                newPolyModNoCopy(p, nil),
+                newPolyModNoCopy(p, []uint64{1}),
+                newPolyModNoCopy(p, []uint64{1}).shiftUp(n - 1),
+            }
+            if n > 1 {
+                inputs = append(inputs, factor)
+            }
+            for i := 0; i < 50; i++ {
+                inputs = append(inputs, randomPolyMod(rng, p, rng.Intn(n)))
+            }
+            for _, a := range inputs {
+                _, a = a.div(f)
+                inv, steps, err := ctInverseMod(a, f)
+                if steps != 2*n-1 {
+                    t.Errorf("inverse of %s mod %s took %d divsteps, want %d", a, f, steps, 2*n-1)
+                }
+                want, werr := InverseMod(a, f)
+                switch {
+                case err != nil && !errors.Is(err, ErrNotInvertible):
+                    t.Errorf("inverse of %s mod %s: %v", a, f, err)
+                case (err == nil) != (werr == nil):
+                    t.Errorf("inverse of %s mod %s: %v, want %v", a, f, err, werr)
+                case err == nil && !inv.Equal(want):
+                    t.Errorf("inverse of %s mod %s is %s, want %s", a, f, inv, want)
+                }
+            }
+        }
+    }
+}
+
+func TestConstTimeInverseRejectsModulus(t *testing.T) {
+    for _, m := range []uint64{0, 1, 2, 4, 1 << 40, 1 << 62, 1<<62 + 1} {
+        if _, err := ConstTimeInverse(1, m); err == nil {
+            t.Errorf("ConstTimeInverse(1, %d) succeeded, want an error", m)
+        }
+    }
+    if _, err := ConstTimeInverse(7, 7); err == nil {
+        t.Error("ConstTimeInverse(7, 7) succeeded, want an error for an unreduced argument")
+    }
+    if _, err := ConstTimeInverse(5, 15); !errors.Is(err, ErrNotInvertible) {
+        t.Errorf("ConstTimeInverse(5, 15): got %v, want ErrNotInvertible", err)
+    }
+}
+
+func TestCheckConstantTime(t *testing.T) {
+    for _, c := range CheckConstantTime(WithSeed(1)) {
+        if c.Err != nil {
+            t.Errorf("%s: %v", c.Name, c.Err)
+        }
+    }
+}
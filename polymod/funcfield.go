@@ -0,0 +1,538 @@
+package polymod
+
+import (
+    "fmt"
+    "math/big"
+    "strconv"
+    "strings"
+)
+
+// Polynomials in x over the function field GF(p)(t) of rational functions
+// in t over GF(p), RatFuncMod. GF(p)(t)[x] is a Euclidean domain like Q[x],
+// so the extended Euclidean algorithm runs unchanged on it; the
+// coefficients are what grows, as quotients of polynomials in t. This is
+// the setting of parametric families of polynomials and of the key
+// equations of algebraic-geometry and Goppa codes.
+
+// FuncFieldVars names the variables of GF(p)(t)[x]: X the variable of the
+// polynomials and T the one of their rational function coefficients. Names
+// are ASCII letters followed by letters or digits; the two must differ and
+// neither may start with the other, so that a product like "xt" without a
+// "*" reads one way only.
+type FuncFieldVars struct {
+    X, T string
+}
+
+// DefaultFuncFieldVars are the names ParseFuncFieldPoly reads, x and t
+var DefaultFuncFieldVars = FuncFieldVars{"x", "t"}
+
+// check reports invalid or ambiguous names
+func (v FuncFieldVars) check() error {
+    for _, name := range []string{v.X, v.T} {
+        if name == "" || !isLetter(name[0]) {
+            return fmt.Errorf("ratfunc: variable name %q does not start with a letter", name)
+        }
+        for i := 1; i < len(name); i++ {
+            if !isLetter(name[i]) && !isDigit(name[i]) {
+                return fmt.Errorf("ratfunc: variable name %q has a character other than letters and digits", name)
+            }
+        }
+    }
+    if strings.HasPrefix(v.X, v.T) || strings.HasPrefix(v.T, v.X) {
+        return fmt.Errorf("ratfunc: variable names %q and %q are ambiguous", v.X, v.T)
+    }
+    return nil
+}
+
+// isLetter reports whether c is an ASCII letter
+func isLetter(c byte) bool {
+    return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// isDigit reports whether c is an ASCII digit
+func isDigit(c byte) bool {
+    return '0' <= c && c <= '9'
+}
+
+// FuncFieldPoly is a polynomial in x with coefficients in GF(p)(t), lowest
+// degree first and without zero leading coefficients. It carries the names
+// of x and t it was parsed with or given, which String writes and the
+// results of arithmetic on it keep.
+type FuncFieldPoly struct {
+    p     uint64
+    vars  FuncFieldVars
+    coeff []*RatFuncMod
+}
+
+// NewFuncFieldPoly returns the polynomial over GF(p)(t) with the given
+// coefficients, lowest degree first; they must all be over GF(p)
+func NewFuncFieldPoly(p uint64, coeffs []*RatFuncMod) (*FuncFieldPoly, error) {
+    if err := CheckModulus(p); err != nil {
+        return nil, err
+    }
+    for i, c := range coeffs {
+        if c.Modulus() != p {
+            return nil, fmt.Errorf("ratfunc: coefficient %d is over GF(%d), not GF(%d)", i, c.Modulus(), p)
+        }
+    }
+    return newFuncFieldPolyNoCopy(p, DefaultFuncFieldVars, append([]*RatFuncMod(nil), coeffs...)), nil
+}
+
+// newFuncFieldPolyNoCopy takes ownership of coeffs and trims them
+func newFuncFieldPolyNoCopy(p uint64, vars FuncFieldVars, coeffs []*RatFuncMod) *FuncFieldPoly {
+    n := len(coeffs)
+    for n > 0 && coeffs[n-1].IsZero() {
+        n--
+    }
+    return &FuncFieldPoly{p, vars, coeffs[:n]}
+}
+
+// Modulus returns the characteristic p
+func (f *FuncFieldPoly) Modulus() uint64 { return f.p }
+
+// Vars returns the variable names of f
+func (f *FuncFieldPoly) Vars() FuncFieldVars { return f.vars }
+
+// WithVars returns f with its variables renamed to vars, to permute or
+// rename them before printing
+func (f *FuncFieldPoly) WithVars(vars FuncFieldVars) (*FuncFieldPoly, error) {
+    if err := vars.check(); err != nil {
+        return nil, err
+    }
+    return &FuncFieldPoly{f.p, vars, f.coeff}, nil
+}
+
+// Deg returns the degree in x, 0 for the zero polynomial
+func (f *FuncFieldPoly) Deg() int { return max(len(f.coeff)-1, 0) }
+
+// IsZero reports whether f is the zero polynomial
+func (f *FuncFieldPoly) IsZero() bool { return len(f.coeff) == 0 }
+
+// Coeff returns the coefficient of x^i, zero beyond the degree
+func (f *FuncFieldPoly) Coeff(i int) *RatFuncMod {
+    if i < 0 || i >= len(f.coeff) {
+        return ratFuncConst(f.p, 0)
+    }
+    return f.coeff[i]
+}
+
+// Equal reports whether f and g are the same polynomial over the same field
+// in the same variables
+func (f *FuncFieldPoly) Equal(g *FuncFieldPoly) bool {
+    if f.p != g.p || f.vars != g.vars || len(f.coeff) != len(g.coeff) {
+        return false
+    }
+    for i := range f.coeff {
+        if !f.coeff[i].Equal(g.coeff[i]) {
+            return false
+        }
+    }
+    return true
+}
+
+// Add returns f + g, or an error wrapping ErrFieldMismatch if f and g live over
+// different fields or are written in different variables
+func (f *FuncFieldPoly) Add(g *FuncFieldPoly) (*FuncFieldPoly, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, err
+    }
+    return f.add(g), nil
+}
+
+// Sub returns f - g, or an error wrapping ErrFieldMismatch if f and g live over
+// different fields or are written in different variables
+func (f *FuncFieldPoly) Sub(g *FuncFieldPoly) (*FuncFieldPoly, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, err
+    }
+    return f.sub(g), nil
+}
+
+// Mul returns f*g, or an error wrapping ErrFieldMismatch if f and g live over
+// different fields or are written in different variables
+func (f *FuncFieldPoly) Mul(g *FuncFieldPoly) (*FuncFieldPoly, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, err
+    }
+    return f.mul(g), nil
+}
+
+// add, sub and mul are Add, Sub and Mul for operands over the same field in
+// the same variables
+func (f *FuncFieldPoly) add(g *FuncFieldPoly) *FuncFieldPoly {
+    sum := make([]*RatFuncMod, max(len(f.coeff), len(g.coeff)))
+    for i := range sum {
+        sum[i] = f.Coeff(i).add(g.Coeff(i))
+    }
+    return newFuncFieldPolyNoCopy(f.p, f.vars, sum)
+}
+
+func (f *FuncFieldPoly) sub(g *FuncFieldPoly) *FuncFieldPoly {
+    diff := make([]*RatFuncMod, max(len(f.coeff), len(g.coeff)))
+    for i := range diff {
+        diff[i] = f.Coeff(i).sub(g.Coeff(i))
+    }
+    return newFuncFieldPolyNoCopy(f.p, f.vars, diff)
+}
+
+func (f *FuncFieldPoly) mul(g *FuncFieldPoly) *FuncFieldPoly {
+    if f.IsZero() || g.IsZero() {
+        return newFuncFieldPolyNoCopy(f.p, f.vars, nil)
+    }
+    product := make([]*RatFuncMod, len(f.coeff)+len(g.coeff)-1)
+    for i := range product {
+        product[i] = ratFuncConst(f.p, 0)
+    }
+    for i, a := range f.coeff {
+        for j, b := range g.coeff {
+            product[i+j] = product[i+j].add(a.mul(b))
+        }
+    }
+    return newFuncFieldPolyNoCopy(f.p, f.vars, product)
+}
+
+// scale returns c*f for a nonzero c
+func (f *FuncFieldPoly) scale(c *RatFuncMod) *FuncFieldPoly {
+    scaled := make([]*RatFuncMod, len(f.coeff))
+    for i, a := range f.coeff {
+        scaled[i] = a.mul(c)
+    }
+    return newFuncFieldPolyNoCopy(f.p, f.vars, scaled)
+}
+
+// div returns the quotient and remainder of f divided by a nonzero g over
+// the same field
+func (f *FuncFieldPoly) div(g *FuncFieldPoly) (*FuncFieldPoly, *FuncFieldPoly) {
+    rem := append([]*RatFuncMod(nil), f.coeff...)
+    if len(f.coeff) < len(g.coeff) {
+        return newFuncFieldPolyNoCopy(f.p, f.vars, nil), newFuncFieldPolyNoCopy(f.p, f.vars, rem)
+    }
+    quo := make([]*RatFuncMod, len(f.coeff)-len(g.coeff)+1)
+    dg := len(g.coeff) - 1
+    for k := len(quo) - 1; k >= 0; k-- {
+        c := rem[k+dg].quo(g.coeff[dg])
+        quo[k] = c
+        for j, b := range g.coeff {
+            rem[k+j] = rem[k+j].sub(c.mul(b))
+        }
+    }
+    return newFuncFieldPolyNoCopy(f.p, f.vars, quo), newFuncFieldPolyNoCopy(f.p, f.vars, rem[:dg])
+}
+
+// String formats f in x with parenthesized coefficients in t, e.g.
+// "1*x^2 + (t + 1)*x + 1/t", using the variable names of f;
+// ParseFuncFieldPolyVars reads it back with the same names
+func (f *FuncFieldPoly) String() string {
+    if f.IsZero() {
+        return "0"
+    }
+    var b strings.Builder
+    for i := len(f.coeff) - 1; i >= 0; i-- {
+        if f.coeff[i].IsZero() {
+            continue
+        }
+        term := f.coeff[i].Format(f.vars.T)
+        if strings.ContainsAny(term, " /") {
+            term = "(" + term + ")"
+        }
+        if b.Len() > 0 {
+            if strings.HasPrefix(term, "-") {
+                b.WriteString(" - ")
+                term = term[1:]
+            } else {
+                b.WriteString(" + ")
+            }
+        }
+        b.WriteString(term)
+        switch {
+        case i == 1:
+            b.WriteString("*" + f.vars.X)
+        case i > 1:
+            fmt.Fprintf(&b, "*%s^%d", f.vars.X, i)
+        }
+    }
+    return b.String()
+}
+
+// sameField returns an error wrapping ErrFieldMismatch if f and g live over
+// different fields or are written in different variables, as PolyMod does
+// for fields
+func (f *FuncFieldPoly) sameField(g *FuncFieldPoly) error {
+    if f.p != g.p {
+        return fmt.Errorf("ratfunc: %w: GF(%d)(t) and GF(%d)(t)", ErrFieldMismatch, f.p, g.p)
+    }
+    if f.vars != g.vars {
+        return fmt.Errorf("ratfunc: %w: variables %s, %s and %s, %s", ErrFieldMismatch, f.vars.X, f.vars.T, g.vars.X, g.vars.T)
+    }
+    return nil
+}
+
+// ExtendedGCDFuncField returns the monic gcd of f and g in GF(p)(t)[x] and
+// s, t with s*f + t*g = gcd. The gcd of two zero polynomials is zero.
+// Operands over different fields or in different variables give
+// ErrFieldMismatch.
+func ExtendedGCDFuncField(f, g *FuncFieldPoly) (gcd, s, t *FuncFieldPoly, err error) {
+    if err := f.sameField(g); err != nil {
+        return nil, nil, nil, err
+    }
+    zero := newFuncFieldPolyNoCopy(f.p, f.vars, nil)
+    one := newFuncFieldPolyNoCopy(f.p, f.vars, []*RatFuncMod{ratFuncConst(f.p, 1)})
+    s0, s1, t0, t1 := one, zero, zero, one
+    for !g.IsZero() {
+        q, r := f.div(g)
+        f, g = g, r
+        s0, s1 = s1, s0.sub(q.mul(s1))
+        t0, t1 = t1, t0.sub(q.mul(t1))
+    }
+    if f.IsZero() {
+        return f, s0, t0, nil
+    }
+    inv := ratFuncConst(f.p, 1).quo(f.coeff[len(f.coeff)-1])
+    return f.scale(inv), s0.scale(inv), t0.scale(inv), nil
+}
+
+// The limits on the input of ParseFuncFieldPolyVars, the default parse
+// limits of package poly
+const (
+    funcFieldMaxDegree            = 100000
+    funcFieldMaxCoefficientDigits = 10000
+    funcFieldMaxLength            = 1 << 24
+)
+
+// ExpressionError reports an expression ParseFuncFieldPolyVars cannot read,
+// or one beyond its limits on the degree, the digits of an integer and the
+// length of the input
+type ExpressionError struct {
+    Input string
+    Pos   int // byte offset of the offending character
+    Msg   string
+}
+
+func (e *ExpressionError) Error() string {
+    input := e.Input
+    if len(input) > 40 {
+        input = input[:40] + "..."
+    }
+    return fmt.Sprintf("polynomial %q, offset %d: %s", input, e.Pos, e.Msg)
+}
+
+// ParseFuncFieldPoly parses a polynomial in x over GF(p)(t) written as an
+// arithmetic expression in x and t with integers, +, -, *, /, ^ and
+// parentheses, such as "x^2 + (t+1)*x + 1/(t^2 - 2)". Integers are reduced
+// mod p, a product may omit the "*" before x, t and "(", and divisors must
+// not involve x.
+func ParseFuncFieldPoly(s string, p uint64) (*FuncFieldPoly, error) {
+    return ParseFuncFieldPolyVars(s, p, DefaultFuncFieldVars)
+}
+
+// ParseFuncFieldPolyVars is ParseFuncFieldPoly with the variables named by
+// vars instead of x and t, so that input from another system keeps its
+// names through printing: ParseFuncFieldPolyVars("y^2 + s*y", p,
+// FuncFieldVars{X: "y", T: "s"}) is a polynomial in y over GF(p)(s), and
+// FuncFieldVars{X: "t", T: "x"} swaps the roles of the usual names.
+func ParseFuncFieldPolyVars(s string, p uint64, vars FuncFieldVars) (*FuncFieldPoly, error) {
+    if err := CheckModulus(p); err != nil {
+        return nil, err
+    }
+    if err := vars.check(); err != nil {
+        return nil, err
+    }
+    if len(s) > funcFieldMaxLength {
+        return nil, &ExpressionError{Input: s, Pos: funcFieldMaxLength, Msg: fmt.Sprintf("length %d exceeds limit %d", len(s), funcFieldMaxLength)}
+    }
+    fp := &funcFieldParser{s: s, p: p, vars: vars}
+    v, err := fp.expr()
+    if err != nil {
+        return nil, err
+    }
+    if fp.peek() != 0 {
+        return nil, fp.errorf("unexpected %q", fp.peek())
+    }
+    return v, nil
+}
+
+// funcFieldParser is the state of a single ParseFuncFieldPolyVars call
+type funcFieldParser struct {
+    s    string
+    pos  int
+    p    uint64
+    vars FuncFieldVars
+}
+
+func (p *funcFieldParser) errorf(format string, args ...interface{}) error {
+    return &ExpressionError{Input: p.s, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// peek returns the next non-space byte, or 0 at the end of the input
+func (p *funcFieldParser) peek() byte {
+    for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+        p.pos++
+    }
+    if p.pos == len(p.s) {
+        return 0
+    }
+    return p.s[p.pos]
+}
+
+// variable returns the name of the variable at the current position, or ""
+func (p *funcFieldParser) variable() string {
+    p.peek()
+    for _, name := range []string{p.vars.X, p.vars.T} {
+        if strings.HasPrefix(p.s[p.pos:], name) {
+            return name
+        }
+    }
+    return ""
+}
+
+// constant returns the constant c as a polynomial in x
+func (p *funcFieldParser) constant(c *RatFuncMod) *FuncFieldPoly {
+    return newFuncFieldPolyNoCopy(p.p, p.vars, []*RatFuncMod{c})
+}
+
+// expr reads a sum of terms, the first one optionally signed
+func (p *funcFieldParser) expr() (*FuncFieldPoly, error) {
+    sum := newFuncFieldPolyNoCopy(p.p, p.vars, nil)
+    op := byte('+')
+    if c := p.peek(); c == '+' || c == '-' {
+        op = c
+        p.pos++
+    }
+    for {
+        t, err := p.term()
+        if err != nil {
+            return nil, err
+        }
+        if op == '-' {
+            sum = sum.sub(t)
+        } else {
+            sum = sum.add(t)
+        }
+        op = p.peek()
+        if op != '+' && op != '-' {
+            return sum, nil
+        }
+        p.pos++
+    }
+}
+
+// term reads a product or quotient of factors
+func (p *funcFieldParser) term() (*FuncFieldPoly, error) {
+    product, err := p.factor()
+    if err != nil {
+        return nil, err
+    }
+    for {
+        c := p.peek()
+        switch {
+        case c == '*':
+            p.pos++
+        case c == '/':
+            p.pos++
+            start := p.pos
+            d, err := p.factor()
+            if err != nil {
+                return nil, err
+            }
+            if len(d.coeff) > 1 {
+                p.pos = start
+                return nil, p.errorf("divisor involves %s", p.vars.X)
+            }
+            if d.IsZero() {
+                p.pos = start
+                return nil, p.errorf("division by zero")
+            }
+            product = product.scale(ratFuncConst(p.p, 1).quo(d.coeff[0]))
+            continue
+        case c == '(' || p.variable() != "":
+        default:
+            return product, nil
+        }
+        f, err := p.factor()
+        if err != nil {
+            return nil, err
+        }
+        product = product.mul(f)
+    }
+}
+
+// factor reads a primary with an optional "^exponent"
+func (p *funcFieldParser) factor() (*FuncFieldPoly, error) {
+    base, err := p.primary()
+    if err != nil {
+        return nil, err
+    }
+    if p.peek() != '^' {
+        return base, nil
+    }
+    p.pos++
+    p.peek()
+    start := p.pos
+    for p.pos < len(p.s) && isDigit(p.s[p.pos]) {
+        p.pos++
+    }
+    if start == p.pos {
+        return nil, p.errorf("expected an exponent after ^")
+    }
+    // the degree in x of the power is bounded by the degree limit
+    e, err := strconv.Atoi(p.s[start:p.pos])
+    if err != nil || e > funcFieldMaxDegree/max(base.Deg(), 1) {
+        got := e
+        if err != nil {
+            got = int(^uint(0) >> 1)
+        }
+        return nil, &ExpressionError{Input: p.s, Pos: start, Msg: fmt.Sprintf("degree %d exceeds limit %d", got, funcFieldMaxDegree)}
+    }
+    power := p.constant(ratFuncConst(p.p, 1))
+    for ; e > 0; e >>= 1 {
+        if e&1 == 1 {
+            power = power.mul(base)
+        }
+        base = base.mul(base)
+    }
+    return power, nil
+}
+
+// primary reads an integer, x, t or a parenthesized expression
+func (p *funcFieldParser) primary() (*FuncFieldPoly, error) {
+    name := p.variable()
+    switch c := p.peek(); {
+    case isDigit(c):
+        start := p.pos
+        for p.pos < len(p.s) && isDigit(p.s[p.pos]) {
+            p.pos++
+        }
+        if digits := p.pos - start; digits > funcFieldMaxCoefficientDigits {
+            field := p.s[start:p.pos]
+            if len(field) > 20 {
+                field = field[:20] + "..."
+            }
+            return nil, &ExpressionError{Input: p.s, Pos: start, Msg: fmt.Sprintf("coefficient %q: coefficient digits %d exceeds limit %d", field, digits, funcFieldMaxCoefficientDigits)}
+        }
+        n, _ := new(big.Int).SetString(p.s[start:p.pos], 10)
+        n.Mod(n, new(big.Int).SetUint64(p.p))
+        return p.constant(ratFuncConst(p.p, n.Uint64())), nil
+    case name != "" && name == p.vars.X:
+        p.pos += len(name)
+        return newFuncFieldPolyNoCopy(p.p, p.vars, []*RatFuncMod{ratFuncConst(p.p, 0), ratFuncConst(p.p, 1)}), nil
+    case name != "":
+        p.pos += len(name)
+        t := newRatFuncMod(newPolyModNoCopy(p.p, []uint64{0, 1}), newPolyModNoCopy(p.p, []uint64{1}))
+        return p.constant(t), nil
+    case c == '(':
+        p.pos++
+        v, err := p.expr()
+        if err != nil {
+            return nil, err
+        }
+        if p.peek() != ')' {
+            return nil, p.errorf("expected )")
+        }
+        p.pos++
+        return v, nil
+    case c == 0:
+        return nil, p.errorf("unexpected end of input")
+    default:
+        return nil, p.errorf("unexpected %q", c)
+    }
+}
@@ -0,0 +1,70 @@
+package polymod
+
+import (
+    "errors"
+    "testing"
+)
+
+// TestExtendedGCDFuncField checks the monic gcd and the Bezout identity in
+// GF(p)(t)[x], and that String reads back with the same variable names
+func TestExtendedGCDFuncField(t *testing.T) {
+    tests := []struct {
+        p       uint64
+        vars    FuncFieldVars
+        f, g    string
+        wantGCD string
+    }{
+        {5, DefaultFuncFieldVars, "x^2 - t^2", "x^2 + (t+1)x + t", "1*x + t"},
+        {7, FuncFieldVars{"y", "s"}, "y^3 + s*y - 1/(s+1)", "y^2 - s", "1"},
+        {3, DefaultFuncFieldVars, "(x - t)^2 (x + 1)", "(x - t)(x^2 + t/(t+1))", "1*x + 2*t"},
+        {5, DefaultFuncFieldVars, "x^2", "0", "1*x^2"},
+        {5, DefaultFuncFieldVars, "0", "0", "0"},
+    }
+    for _, tt := range tests {
+        f, err := ParseFuncFieldPolyVars(tt.f, tt.p, tt.vars)
+        if err != nil {
+            t.Fatal(err)
+        }
+        g, err := ParseFuncFieldPolyVars(tt.g, tt.p, tt.vars)
+        if err != nil {
+            t.Fatal(err)
+        }
+        gcd, s, u, err := ExtendedGCDFuncField(f, g)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if got := gcd.String(); got != tt.wantGCD {
+            t.Errorf("gcd(%s, %s) = %s, want %s", f, g, got, tt.wantGCD)
+        }
+        if sum := s.mul(f).add(u.mul(g)); !sum.Equal(gcd) {
+            t.Errorf("s*f + t*g = %s, not the gcd %s", sum, gcd)
+        }
+        back, err := ParseFuncFieldPolyVars(f.String(), tt.p, tt.vars)
+        if err != nil || !back.Equal(f) {
+            t.Errorf("%s read back as %v, %v", f, back, err)
+        }
+    }
+}
+
+// TestFuncFieldErrors checks the errors of mixed fields and variables and
+// of expressions the parser rejects
+func TestFuncFieldErrors(t *testing.T) {
+    f, _ := ParseFuncFieldPoly("x + t", 5)
+    g, _ := ParseFuncFieldPoly("x + t", 7)
+    if _, _, _, err := ExtendedGCDFuncField(f, g); !errors.Is(err, ErrFieldMismatch) {
+        t.Errorf("GF(5)(t) and GF(7)(t) returned %v", err)
+    }
+    h, _ := f.WithVars(FuncFieldVars{"y", "s"})
+    if _, err := f.Add(h); !errors.Is(err, ErrFieldMismatch) {
+        t.Errorf("different variables returned %v", err)
+    }
+    for _, s := range []string{"x/(x+1)", "x/0", "x^999999", "t^2 +", "(x", "x^", "x @"} {
+        var exprErr *ExpressionError
+        if _, err := ParseFuncFieldPoly(s, 5); !errors.As(err, &exprErr) {
+            t.Errorf("%q returned %v", s, err)
+        }
+    }
+    if _, err := ParseFuncFieldPolyVars("x", 5, FuncFieldVars{"x", "xt"}); err == nil {
+        t.Error("ambiguous variable names accepted")
+    }
+}
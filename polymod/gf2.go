@@ -0,0 +1,251 @@
+package polymod
+
+import (
+    "fmt"
+    "math/bits"
+    "math/rand"
+
+    "euclid/internal/benchtime"
+)
+
+// GF2Poly is a polynomial over GF(2) packed 64 coefficients to a word: bit i
+// of the word slice is the coefficient of x^i. Addition is xor and
+// multiplication by x is a shift, which makes the binary GCD below run a word
+// at a time. The slice never has a zero top word. Values are immutable.
+type GF2Poly struct {
+    w []uint64
+}
+
+// NewGF2Poly returns the polynomial whose coefficients are the bits of words,
+// lowest word first; a CRC-32 generator x^32 + x^26 + ... + 1 is
+// NewGF2Poly([]uint64{0x104C11DB7})
+func NewGF2Poly(words []uint64) *GF2Poly {
+    return &GF2Poly{trimWords(append([]uint64(nil), words...))}
+}
+
+// GF2FromPolyMod packs a polynomial over GF(2)
+func GF2FromPolyMod(f *PolyMod) (*GF2Poly, error) {
+    if f.p != 2 {
+        return nil, fmt.Errorf("gf2: polynomial is over GF(%d), not GF(2)", f.p)
+    }
+    w := make([]uint64, (len(f.coeff)+63)/64)
+    for i, c := range f.coeff {
+        w[i/64] |= c << (i % 64)
+    }
+    return &GF2Poly{trimWords(w)}, nil
+}
+
+// PolyMod unpacks f into a polynomial over GF(2)
+func (f *GF2Poly) PolyMod() *PolyMod {
+    coeffs := make([]uint64, f.Deg()+1)
+    for i := range coeffs {
+        coeffs[i] = f.w[i/64] >> (i % 64) & 1
+    }
+    return newPolyModNoCopy(2, coeffs)
+}
+
+// Words returns the packed coefficients, lowest word first
+func (f *GF2Poly) Words() []uint64 { return append([]uint64(nil), f.w...) }
+
+// Deg returns the degree of f; like poly.Polynomial.Deg it is 0 for the zero
+// polynomial
+func (f *GF2Poly) Deg() int { return max(degWords(f.w), 0) }
+
+// IsZero reports whether f is the zero polynomial
+func (f *GF2Poly) IsZero() bool { return len(f.w) == 0 }
+
+// Equal reports whether f and g are the same polynomial
+func (f *GF2Poly) Equal(g *GF2Poly) bool {
+    if len(f.w) != len(g.w) {
+        return false
+    }
+    for i := range f.w {
+        if f.w[i] != g.w[i] {
+            return false
+        }
+    }
+    return true
+}
+
+// String formats f like a PolyMod, e.g. "x^3 + x + 1"
+func (f *GF2Poly) String() string { return f.PolyMod().String() }
+
+// trimWords drops zero words from the top of w
+func trimWords(w []uint64) []uint64 {
+    n := len(w)
+    for n > 0 && w[n-1] == 0 {
+        n--
+    }
+    return w[:n]
+}
+
+// degWords returns the degree of the trimmed packed polynomial w, -1 for zero
+func degWords(w []uint64) int {
+    if len(w) == 0 {
+        return -1
+    }
+    return 64*(len(w)-1) + bits.Len64(w[len(w)-1]) - 1
+}
+
+// trailingZeroWords returns the largest k with x^k dividing the nonzero w
+func trailingZeroWords(w []uint64) int {
+    for i, word := range w {
+        if word != 0 {
+            return 64*i + bits.TrailingZeros64(word)
+        }
+    }
+    return 0
+}
+
+// shiftRightWords divides w by x^k in place, discarding no set bits when x^k
+// divides w, and returns it trimmed
+func shiftRightWords(w []uint64, k int) []uint64 {
+    q, r := k/64, uint(k%64)
+    n := len(w) - q
+    for i := 0; i < n; i++ {
+        w[i] = w[i+q] >> r
+        if r != 0 && i+q+1 < len(w) {
+            w[i] |= w[i+q+1] << (64 - r)
+        }
+    }
+    return trimWords(w[:n])
+}
+
+// xorShiftedWords adds b*x^s to a in place; a must be long enough to hold
+// every set bit of b*x^s
+func xorShiftedWords(a, b []uint64, s int) {
+    q, r := s/64, uint(s%64)
+    for i, word := range b {
+        a[i+q] ^= word << r
+        if r != 0 && i+q+1 < len(a) {
+            a[i+q+1] ^= word >> (64 - r)
+        }
+    }
+}
+
+// BinaryGCDGF2 returns the gcd of a and b by the polynomial analogue of
+// Stein's binary GCD: the common power of x is split off, then the operand of
+// higher degree is replaced by the xor of both with its factors of x shifted
+// out until it vanishes. There is no division and no coefficient arithmetic
+// beyond xor, so each step costs a pass over the words. The gcd over GF(2) is
+// automatically monic.
+func BinaryGCDGF2(a, b *GF2Poly) *GF2Poly {
+    if a.IsZero() {
+        return b
+    }
+    if b.IsZero() {
+        return a
+    }
+    u, v := a.Words(), b.Words()
+    zu, zv := trailingZeroWords(u), trailingZeroWords(v)
+    u, v = shiftRightWords(u, zu), shiftRightWords(v, zv)
+    for {
+        // u and v both have constant term 1, so their xor is divisible by x
+        if degWords(u) > degWords(v) {
+            u, v = v, u
+        }
+        xorShiftedWords(v, u, 0)
+        v = trimWords(v)
+        if len(v) == 0 {
+            break
+        }
+        v = shiftRightWords(v, trailingZeroWords(v))
+    }
+    shift := min(zu, zv)
+    gcd := make([]uint64, (degWords(u)+shift)/64+1)
+    xorShiftedWords(gcd, u, shift)
+    return &GF2Poly{trimWords(gcd)}
+}
+
+// euclidGCDGF2 returns the gcd of a and b by the Euclidean remainder loop on
+// packed words, each remainder computed by shifted xors
+func euclidGCDGF2(a, b *GF2Poly) *GF2Poly {
+    u, v := a.Words(), b.Words()
+    for len(v) != 0 {
+        u, v = v, remWords(u, v)
+    }
+    return &GF2Poly{u}
+}
+
+// remWords reduces u modulo the nonzero v in place by shifted xors and
+// returns it trimmed
+func remWords(u, v []uint64) []uint64 {
+    dv := degWords(v)
+    for d := degWords(u); d >= dv; d = degWords(u) {
+        xorShiftedWords(u, v, d-dv)
+        u = trimWords(u)
+    }
+    return u
+}
+
+// addShiftedWords returns a + b*x^s, growing a as needed; a is overwritten
+func addShiftedWords(a, b []uint64, s int) []uint64 {
+    if len(b) == 0 {
+        return a
+    }
+    if need := (degWords(b)+s)/64 + 1; need > len(a) {
+        a = append(a, make([]uint64, need-len(a))...)
+    }
+    xorShiftedWords(a, b, s)
+    return trimWords(a)
+}
+
+// gcdMod returns the monic gcd of f and g over GF(p) by the plain remainder
+// loop, without the cofactors ExtendedGCDMod maintains
+func gcdMod(f, g *PolyMod) *PolyMod {
+    for !g.IsZero() {
+        _, r := f.div(g)
+        f, g = g, r
+    }
+    return f.Monic()
+}
+
+// gf2BenchDegrees span CRC generators (8 to 64) up to BCH-size codes
+var gf2BenchDegrees = []int{8, 16, 32, 64, 128, 256, 512, 1024}
+
+// randomGF2Poly returns a random polynomial of exact degree n
+func randomGF2Poly(rng *rand.Rand, n int) *GF2Poly {
+    w := make([]uint64, n/64+1)
+    for i := range w {
+        w[i] = rng.Uint64()
+    }
+    w[n/64] &= 1<<(n%64+1) - 1
+    w[n/64] |= 1 << (n % 64)
+    return &GF2Poly{w}
+}
+
+// GF2BenchRow holds the ns/op of the gcd over GF(2) at one degree with the
+// generic Euclidean loop on PolyMod, the same loop on packed words and
+// BinaryGCDGF2
+type GF2BenchRow struct {
+    Degree                  int
+    Generic, Packed, Binary float64
+}
+
+// GF2Bench compares the gcd over GF(2) computed by the generic Euclidean loop
+// on PolyMod, the same loop on packed words, and BinaryGCDGF2 on pairs of
+// random polynomials sharing a factor, checking that all three agree, and
+// returns ns/op
+func GF2Bench() ([]GF2BenchRow, error) {
+    var rows []GF2BenchRow
+    for _, n := range gf2BenchDegrees {
+        rng := rand.New(rand.NewSource(int64(n)))
+        common := randomGF2Poly(rng, n/4).PolyMod()
+        f := randomGF2Poly(rng, n-n/4).PolyMod().mul(common)
+        g := randomGF2Poly(rng, n-n/4-1).PolyMod().mul(common)
+        fw, _ := GF2FromPolyMod(f)
+        gw, _ := GF2FromPolyMod(g)
+
+        gcd := gcdMod(f, g)
+        if !euclidGCDGF2(fw, gw).PolyMod().Equal(gcd) || !BinaryGCDGF2(fw, gw).PolyMod().Equal(gcd) {
+            return rows, fmt.Errorf("binary GCD over GF(2) disagrees with the Euclidean algorithm at degree %d", n)
+        }
+        rows = append(rows, GF2BenchRow{
+            Degree:  n,
+            Generic: benchtime.Ns(func() { gcdMod(f, g) }),
+            Packed:  benchtime.Ns(func() { euclidGCDGF2(fw, gw) }),
+            Binary:  benchtime.Ns(func() { BinaryGCDGF2(fw, gw) }),
+        })
+    }
+    return rows, nil
+}
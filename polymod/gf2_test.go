@@ -0,0 +1,74 @@
+package polymod
+
+import (
+    "errors"
+    "math/rand"
+    "testing"
+)
+
+// TestBinaryGCDGF2 checks the binary gcd on packed words against the
+// Euclidean loop and the extended gcd over GF(2), across word boundaries
+func TestBinaryGCDGF2(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, n := range []int{1, 8, 63, 64, 65, 200} {
+        c := randomGF2Poly(rng, n/3+1)
+        a, _ := GF2FromPolyMod(mustMul(t, randomGF2Poly(rng, n).PolyMod(), c.PolyMod()))
+        b, _ := GF2FromPolyMod(mustMul(t, randomGF2Poly(rng, n-1).PolyMod(), c.PolyMod()))
+        got := BinaryGCDGF2(a, b)
+        if want := euclidGCDGF2(a, b); !got.Equal(want) {
+            t.Errorf("degree %d: binary gcd %s, Euclid %s", n, got, want)
+        }
+        if want, _, _, _ := ExtendedGCDMod(a.PolyMod(), b.PolyMod()); !got.PolyMod().Equal(want) {
+            t.Errorf("degree %d: binary gcd %s, ExtendedGCDMod %s", n, got, want)
+        }
+        if back, err := GF2FromPolyMod(a.PolyMod()); err != nil || !back.Equal(a) {
+            t.Errorf("degree %d: packing round trip gave %s, %v", n, back, err)
+        }
+    }
+    f, _ := NewPolyMod(3, []uint64{1, 2})
+    if _, err := GF2FromPolyMod(f); err == nil {
+        t.Error("GF2FromPolyMod accepted a polynomial over GF(3)")
+    }
+}
+
+// TestInverseGF2 checks both strategies in GF(2^8) with the AES polynomial
+// and in a ring with zero divisors
+func TestInverseGF2(t *testing.T) {
+    aes := NewGF2Poly([]uint64{0x11b})
+    for a := uint64(1); a < 256; a++ {
+        var first *GF2Poly
+        for _, strategy := range []string{"euclid", "almost"} {
+            inv, err := InverseGF2(NewGF2Poly([]uint64{a}), aes, WithInverseStrategy(strategy))
+            if err != nil {
+                t.Fatalf("%s: inverse of %#x: %v", strategy, a, err)
+            }
+            prod := mustMul(t, NewGF2Poly([]uint64{a}).PolyMod(), inv.PolyMod())
+            if _, r, _ := prod.Div(aes.PolyMod()); r.Deg() != 0 || r.Coeff(0) != 1 {
+                t.Errorf("%s: %s is not the inverse of %#x", strategy, inv, a)
+            }
+            if first == nil {
+                first = inv
+            } else if !inv.Equal(first) {
+                t.Errorf("inverse of %#x: almost %s, euclid %s", a, inv, first)
+            }
+        }
+    }
+    // x^8 + 1 = (x + 1)^8, so x + 1 is a zero divisor
+    ring := NewGF2Poly([]uint64{0x101})
+    if _, err := InverseGF2(NewGF2Poly([]uint64{3}), ring); !errors.Is(err, ErrNotInvertible) {
+        t.Errorf("inverse of x + 1 mod x^8 + 1 returned %v", err)
+    }
+    if _, err := InverseGF2(NewGF2Poly([]uint64{3}), aes, WithInverseStrategy("consttime")); err == nil {
+        t.Error("constant-time inverse over GF(2) did not fail")
+    }
+}
+
+// mustMul returns f*g, failing the test on an error
+func mustMul(t *testing.T, f, g *PolyMod) *PolyMod {
+    t.Helper()
+    prod, err := f.Mul(g)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return prod
+}
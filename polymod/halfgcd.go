@@ -0,0 +1,171 @@
+package polymod
+
+import (
+    "fmt"
+    "math/rand"
+
+    "euclid/internal/benchtime"
+    "euclid/intring"
+)
+
+// gcdStrategies names the extended GCD algorithms WithGCDStrategy accepts:
+// the classical remainder sequence and the half-GCD algorithm
+var gcdStrategies = []string{"euclid", "halfgcd"}
+
+// IsGCDStrategy reports whether name is one of gcdStrategies
+func IsGCDStrategy(name string) bool {
+    for _, s := range gcdStrategies {
+        if s == name {
+            return true
+        }
+    }
+    return false
+}
+
+// halfGCDMinDegree is the degree below which halfGCD runs the classical
+// remainder sequence instead of recursing; the matrix products only pay off
+// once Mul switches to Kronecker substitution
+const halfGCDMinDegree = 2 * polyModKroneckerMinDegree
+
+// polyModMatrix is a 2x2 matrix of polynomials over GF(p) acting on pairs
+// (a, b) as column vectors
+type polyModMatrix [2][2]*PolyMod
+
+func identityMatrix(p uint64) polyModMatrix {
+    zero, one := newPolyModNoCopy(p, nil), newPolyModNoCopy(p, []uint64{1})
+    return polyModMatrix{{one, zero}, {zero, one}}
+}
+
+// mul returns the matrix product m*n
+func (m polyModMatrix) mul(n polyModMatrix) polyModMatrix {
+    var r polyModMatrix
+    for i := 0; i < 2; i++ {
+        for j := 0; j < 2; j++ {
+            r[i][j] = m[i][0].mul(n[0][j]).add(m[i][1].mul(n[1][j]))
+        }
+    }
+    return r
+}
+
+// apply returns m*(a, b)
+func (m polyModMatrix) apply(a, b *PolyMod) (*PolyMod, *PolyMod) {
+    return m[0][0].mul(a).add(m[0][1].mul(b)), m[1][0].mul(a).add(m[1][1].mul(b))
+}
+
+// step returns the matrix of one division step, (a, b) -> (b, a - q*b),
+// times m
+func (m polyModMatrix) step(q *PolyMod) polyModMatrix {
+    return polyModMatrix{m[1], {m[0][0].sub(q.mul(m[1][0])), m[0][1].sub(q.mul(m[1][1]))}}
+}
+
+// shiftDown returns f divided by x^k, dropping the low terms
+func (f *PolyMod) shiftDown(k int) *PolyMod {
+    if k >= len(f.coeff) {
+        return newPolyModNoCopy(f.p, nil)
+    }
+    return newPolyModNoCopy(f.p, f.coeff[k:])
+}
+
+// halfGCD returns the matrix M of the remainder sequence of a and b,
+// deg a > deg b, up to the middle: M*(a, b) = (c, d), two consecutive
+// remainders with deg c >= m > deg d for m = ceil(deg a / 2). It recurses on
+// the top halves of a and b, whose quotients agree with those of a and b for
+// the first half of the sequence, makes one division step in the middle
+// and recurses on the top part of what is left, so the sequence costs
+// O(M(n) log n) for multiplications costing M(n) instead of O(n^2).
+func halfGCD(a, b *PolyMod) polyModMatrix {
+    m := (a.Deg() + 1) / 2
+    if b.IsZero() || b.Deg() < m {
+        return identityMatrix(a.p)
+    }
+    if a.Deg() < halfGCDMinDegree {
+        M := identityMatrix(a.p)
+        for !b.IsZero() && b.Deg() >= m {
+            q, r := a.div(b)
+            a, b = b, r
+            M = M.step(q)
+        }
+        return M
+    }
+    R := halfGCD(a.shiftDown(m), b.shiftDown(m))
+    c, d := R.apply(a, b)
+    if d.IsZero() || d.Deg() < m {
+        return R
+    }
+    q, e := c.div(d)
+    R = R.step(q)
+    k := 2*m - d.Deg()
+    S := halfGCD(d.shiftDown(k), e.shiftDown(k))
+    return S.mul(R)
+}
+
+// halfGCDExtended is ExtendedGCDMod by the half-GCD algorithm: halfGCD
+// halves the degree, one division step moves past the middle, and so on
+// until the remainder vanishes; the product of the matrices holds the
+// cofactors
+func halfGCDExtended(f, g *PolyMod) (gcd, s, t *PolyMod) {
+    M := identityMatrix(f.p)
+    a, b := f, g
+    if !b.IsZero() && a.Deg() <= b.Deg() {
+        // one division step brings the pair to deg a > deg b, as in the
+        // classical sequence
+        q, r := a.div(b)
+        a, b = b, r
+        M = M.step(q)
+    }
+    for !b.IsZero() {
+        H := halfGCD(a, b)
+        a, b = H.apply(a, b)
+        M = H.mul(M)
+        if b.IsZero() {
+            break
+        }
+        q, r := a.div(b)
+        a, b = b, r
+        M = M.step(q)
+    }
+    s, t = M[0][0], M[0][1]
+    if a.IsZero() {
+        return a, s, t
+    }
+    inv, _ := intring.InvMod(a.coeff[len(a.coeff)-1], a.p)
+    return a.scale(inv), s.scale(inv), t.scale(inv)
+}
+
+// halfGCDBenchDegrees are the degrees HalfGCDBench times
+var halfGCDBenchDegrees = []int{100, 300, 1000, 3000, 10000}
+
+// HalfGCDBenchRow holds the ns/op of the extended GCD over GF(2^31 - 1)
+// at one input degree with the classical remainder sequence and with the
+// half-GCD algorithm
+type HalfGCDBenchRow struct {
+    Degree       int
+    Euclid, Half float64
+}
+
+// HalfGCDBench times the extended GCD over GF(2^31 - 1) with the classical
+// remainder sequence and with the half-GCD algorithm on random polynomials
+// of growing degree, checking that both give the same gcd and cofactors,
+// and returns ns/op per algorithm
+func HalfGCDBench() ([]HalfGCDBenchRow, error) {
+    const p = 1<<31 - 1
+    var rows []HalfGCDBenchRow
+    rng := rand.New(rand.NewSource(1))
+    for _, n := range halfGCDBenchDegrees {
+        // a common factor of degree n/10 gives a gcd worth checking
+        common := randomPolyMod(rng, p, n/10)
+        f := randomPolyMod(rng, p, n-n/10).mul(common)
+        g := randomPolyMod(rng, p, n-1-n/10).mul(common)
+        gcd, s, t := extendedGCDMod(f, g)
+        hgcd, hs, ht := halfGCDExtended(f, g)
+        if !gcd.Equal(hgcd) || !s.Equal(hs) || !t.Equal(ht) {
+            return rows, fmt.Errorf("half-GCD disagrees with the extended Euclidean algorithm at degree %d", n)
+        }
+        rows = append(rows, HalfGCDBenchRow{
+            Degree: n,
+            Euclid: benchtime.Ns(func() { ExtendedGCDMod(f, g) }),
+            Half:   benchtime.Ns(func() { ExtendedGCDMod(f, g, WithGCDStrategy("halfgcd")) }),
+        })
+    }
+    return rows, nil
+}
@@ -0,0 +1,95 @@
+package polymod
+
+import (
+    "fmt"
+    "math/rand"
+    "time"
+)
+
+// Option adjusts the configuration of a single call. Every call builds its
+// own configuration from its options, so there is no package-level state to
+// race on and calls are safe to make from concurrent goroutines. An option
+// with an invalid value leaves the setting unchanged and records an error,
+// which CheckOptions and the calls that return an error report.
+type Option func(*config)
+
+// config holds the settings that Option values adjust
+type config struct {
+    // seed seeds the random source of the call; seeded reports whether
+    // WithSeed was given, otherwise the source is seeded from the clock
+    seed   int64
+    seeded bool
+    // inverse names the modular polynomial inversion algorithm, one of
+    // inverseStrategies
+    inverse string
+    // gcd names the extended GCD algorithm, one of gcdStrategies
+    gcd string
+    // err is the error of the first invalid option
+    err error
+}
+
+// newConfig returns the default configuration with opts applied in order
+func newConfig(opts ...Option) config {
+    c := config{inverse: "euclid", gcd: "euclid"}
+    for _, opt := range opts {
+        opt(&c)
+    }
+    return c
+}
+
+// fail records err unless an earlier option failed already
+func (c *config) fail(err error) {
+    if c.err == nil {
+        c.err = err
+    }
+}
+
+// CheckOptions returns the error of the first invalid option in opts, such
+// as WithInverseStrategy with an unknown name, or nil
+func CheckOptions(opts ...Option) error {
+    return newConfig(opts...).err
+}
+
+// WithSeed makes the random inputs of CheckConstantTime and the benchmarks
+// reproducible
+func WithSeed(seed int64) Option {
+    return func(c *config) {
+        c.seed, c.seeded = seed, true
+    }
+}
+
+// WithInverseStrategy selects the algorithm InverseMod and InverseGF2 use, one
+// of inverseStrategies; "euclid" is the default. An unknown name is an error
+// (see Option).
+func WithInverseStrategy(strategy string) Option {
+    return func(c *config) {
+        if !IsInverseStrategy(strategy) {
+            c.fail(fmt.Errorf("unknown inversion strategy %q", strategy))
+            return
+        }
+        c.inverse = strategy
+    }
+}
+
+// WithGCDStrategy selects the extended GCD algorithm of ExtendedGCDMod, one
+// of gcdStrategies; "euclid" is the default. An unknown name is an error
+// (see Option).
+func WithGCDStrategy(strategy string) Option {
+    return func(c *config) {
+        if !IsGCDStrategy(strategy) {
+            c.fail(fmt.Errorf("unknown GCD strategy %q", strategy))
+            return
+        }
+        c.gcd = strategy
+    }
+}
+
+// rand returns a new random source for the call. Sources are not shared, so
+// concurrent calls with the same seed see the same sequence.
+func (c config) rand() *rand.Rand {
+    seed := c.seed
+    if !c.seeded {
+        seed = time.Now().UnixNano()
+    }
+    return rand.New(rand.NewSource(seed))
+}
@@ -0,0 +1,428 @@
+// Package polymod implements polynomials over the finite fields GF(p), p a
+// prime below 2^63, with word-size coefficients: arithmetic, the extended
+// Euclidean and half-GCD algorithms, modular inversion, also in constant
+// time, packed polynomials over GF(2) and the function field GF(p)(t). It
+// depends on package intring only; package poly reduces rational
+// polynomials into it with Polynomial.ModP.
+//
+//     f, _ := polymod.NewPolyMod(7, []uint64{6, 0, 1}) // x^2 - 1 over GF(7)
+//     g, _ := polymod.NewPolyMod(7, []uint64{2, 4, 1}) // x^2 - 3x + 2
+//     gcd, s, t, err := polymod.ExtendedGCDMod(f, g)  // s*f + t*g = gcd = x + 6
+package polymod
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "math/bits"
+    "strconv"
+    "strings"
+
+    "euclid/intring"
+)
+
+// PolyMod is a polynomial over the finite field GF(p) for a prime p below
+// 2^63. Coefficients are residues in [0, p), lowest degree first, computed
+// with the word-size arithmetic of package intring; the slice never has zero
+// coefficients above the degree. Values are immutable.
+type PolyMod struct {
+    p     uint64
+    coeff []uint64
+}
+
+// ErrNotInvertible is returned by InverseMod when a and f have a common
+// factor, and wrapped by poly.Inverse
+var ErrNotInvertible = errors.New("polymod: not invertible modulo f")
+
+// ErrDivisionByZero is returned when dividing by the zero polynomial
+var ErrDivisionByZero = errors.New("division by zero")
+
+// ErrFieldMismatch is returned, wrapped with the two fields, when the
+// operands of an operation live over different fields
+var ErrFieldMismatch = errors.New("mixing different fields")
+
+// CheckModulus returns an error unless p is a prime the word-size arithmetic
+// handles, below 2^63
+func CheckModulus(p uint64) error {
+    if p < 2 {
+        return fmt.Errorf("polymod: modulus %d must be >= 2", p)
+    }
+    if p >= 1<<intring.WordModulusBits {
+        return fmt.Errorf("polymod: modulus %d must be below 2^%d", p, intring.WordModulusBits)
+    }
+    if !new(big.Int).SetUint64(p).ProbablyPrime(20) {
+        return fmt.Errorf("polymod: modulus %d is not prime", p)
+    }
+    return nil
+}
+
+// NewPolyMod returns the polynomial over GF(p) with the given coefficients,
+// lowest degree first, reduced modulo p. p must be a prime below 2^63.
+func NewPolyMod(p uint64, coeffs []uint64) (*PolyMod, error) {
+    if err := CheckModulus(p); err != nil {
+        return nil, err
+    }
+    reduced := make([]uint64, len(coeffs))
+    for i, c := range coeffs {
+        reduced[i] = c % p
+    }
+    return newPolyModNoCopy(p, reduced), nil
+}
+
+// newPolyModNoCopy takes ownership of residues and trims them
+func newPolyModNoCopy(p uint64, residues []uint64) *PolyMod {
+    n := len(residues)
+    for n > 0 && residues[n-1] == 0 {
+        n--
+    }
+    return &PolyMod{p, residues[:n]}
+}
+
+// Modulus returns the characteristic p of the coefficient field
+func (f *PolyMod) Modulus() uint64 { return f.p }
+
+// Deg returns the degree of f; like poly.Polynomial.Deg it is 0 for the zero
+// polynomial
+func (f *PolyMod) Deg() int { return max(len(f.coeff)-1, 0) }
+
+// IsZero reports whether f is the zero polynomial
+func (f *PolyMod) IsZero() bool { return len(f.coeff) == 0 }
+
+// Coeff returns the coefficient of x^i, which is 0 above the degree
+func (f *PolyMod) Coeff(i int) uint64 {
+    if i < 0 || i >= len(f.coeff) {
+        return 0
+    }
+    return f.coeff[i]
+}
+
+// Equal reports whether f and g are the same polynomial over the same field
+func (f *PolyMod) Equal(g *PolyMod) bool {
+    if f.p != g.p || len(f.coeff) != len(g.coeff) {
+        return false
+    }
+    for i := range f.coeff {
+        if f.coeff[i] != g.coeff[i] {
+            return false
+        }
+    }
+    return true
+}
+
+// String formats f with its residues as coefficients, e.g. "x^2 + 4*x + 2"
+func (f *PolyMod) String() string {
+    if f.IsZero() {
+        return "0"
+    }
+    var terms []string
+    for i := len(f.coeff) - 1; i >= 0; i-- {
+        c := f.coeff[i]
+        switch {
+        case c == 0:
+            continue
+        case i == 0:
+            terms = append(terms, strconv.FormatUint(c, 10))
+            continue
+        }
+        term := "x"
+        if i > 1 {
+            term += "^" + strconv.Itoa(i)
+        }
+        if c != 1 {
+            term = strconv.FormatUint(c, 10) + "*" + term
+        }
+        terms = append(terms, term)
+    }
+    return strings.Join(terms, " + ")
+}
+
+// sameField returns an error wrapping ErrFieldMismatch if f and g live over
+// different fields
+func (f *PolyMod) sameField(g *PolyMod) error {
+    if f.p != g.p {
+        return fmt.Errorf("polymod: %w: GF(%d) and GF(%d)", ErrFieldMismatch, f.p, g.p)
+    }
+    return nil
+}
+
+// Eval returns f(x) in GF(p)
+func (f *PolyMod) Eval(x uint64) uint64 {
+    x %= f.p
+    var result uint64
+    for i := len(f.coeff) - 1; i >= 0; i-- {
+        result = intring.AddMod(intring.MulMod(result, x, f.p), f.coeff[i], f.p)
+    }
+    return result
+}
+
+// Add returns f + g, or an error wrapping ErrFieldMismatch if f and g live over
+// different fields
+func (f *PolyMod) Add(g *PolyMod) (*PolyMod, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, err
+    }
+    return f.add(g), nil
+}
+
+// add is Add for operands over the same field
+func (f *PolyMod) add(g *PolyMod) *PolyMod {
+    sum := make([]uint64, max(len(f.coeff), len(g.coeff)))
+    for i := range sum {
+        sum[i] = intring.AddMod(f.Coeff(i), g.Coeff(i), f.p)
+    }
+    return newPolyModNoCopy(f.p, sum)
+}
+
+// Sub returns f - g, or an error wrapping ErrFieldMismatch if f and g live over
+// different fields
+func (f *PolyMod) Sub(g *PolyMod) (*PolyMod, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, err
+    }
+    return f.sub(g), nil
+}
+
+// sub is Sub for operands over the same field
+func (f *PolyMod) sub(g *PolyMod) *PolyMod {
+    diff := make([]uint64, max(len(f.coeff), len(g.coeff)))
+    for i := range diff {
+        diff[i] = intring.SubMod(f.Coeff(i), g.Coeff(i), f.p)
+    }
+    return newPolyModNoCopy(f.p, diff)
+}
+
+// Mul returns f*g, or an error wrapping ErrFieldMismatch if f and g live
+// over different fields
+func (f *PolyMod) Mul(g *PolyMod) (*PolyMod, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, err
+    }
+    return f.mul(g), nil
+}
+
+// mul is Mul for operands over the same field: the schoolbook algorithm, or
+// Kronecker substitution for large degrees
+func (f *PolyMod) mul(g *PolyMod) *PolyMod {
+    if f.IsZero() || g.IsZero() {
+        return newPolyModNoCopy(f.p, nil)
+    }
+    if min(f.Deg(), g.Deg()) >= polyModKroneckerMinDegree && bits.UintSize == 64 {
+        return f.mulKronecker(g)
+    }
+    product := make([]uint64, len(f.coeff)+len(g.coeff)-1)
+    for i, a := range f.coeff {
+        if a == 0 {
+            continue
+        }
+        for j, b := range g.coeff {
+            product[i+j] = intring.AddMod(product[i+j], intring.MulMod(a, b, f.p), f.p)
+        }
+    }
+    return newPolyModNoCopy(f.p, product)
+}
+
+// polyModKroneckerMinDegree is the smallest degree of the smaller factor
+// for which Mul packs the residues into big.Int for Kronecker substitution;
+// timing both ways puts the crossover with the word-size schoolbook loop
+// between degrees 24 and 32 for 31-bit primes
+const polyModKroneckerMinDegree = 32
+
+// mulKronecker multiplies f and g by Kronecker substitution: the residues
+// are packed into slots of k bits, wide enough for any coefficient of the
+// integer product, the two integers are multiplied with big.Int (Karatsuba
+// and better) and each slot of the result is reduced modulo p. It needs
+// 64-bit big.Words.
+func (f *PolyMod) mulKronecker(g *PolyMod) *PolyMod {
+    p := f.p
+    k := 2*bits.Len64(p-1) + bits.Len(uint(min(len(f.coeff), len(g.coeff))))
+    pack := func(cs []uint64) *big.Int {
+        words := make([]big.Word, (len(cs)*k+63)/64+1)
+        for i, c := range cs {
+            w, s := i*k/64, uint(i*k%64)
+            words[w] |= big.Word(c << s)
+            if s > 0 {
+                words[w+1] |= big.Word(c >> (64 - s))
+            }
+        }
+        return new(big.Int).SetBits(words)
+    }
+    words := new(big.Int).Mul(pack(f.coeff), pack(g.coeff)).Bits()
+    word := func(i int) uint64 {
+        if i < len(words) {
+            return uint64(words[i])
+        }
+        return 0
+    }
+    product := make([]uint64, len(f.coeff)+len(g.coeff)-1)
+    for i := range product {
+        // the slot spans at most four words; gather it as hi:mid:lo and
+        // reduce from the top
+        w, s := i*k/64, uint(i*k%64)
+        var slot [3]uint64
+        for j := range slot {
+            slot[j] = word(w+j) >> s
+            if s > 0 {
+                slot[j] |= word(w+j+1) << (64 - s)
+            }
+        }
+        for j := range slot {
+            if rest := k - 64*j; rest <= 0 {
+                slot[j] = 0
+            } else if rest < 64 {
+                slot[j] &= 1<<uint(rest) - 1
+            }
+        }
+        _, r := bits.Div64(slot[2]%p, slot[1], p)
+        _, r = bits.Div64(r, slot[0], p)
+        product[i] = r
+    }
+    return newPolyModNoCopy(p, product)
+}
+
+// scale returns c*f
+func (f *PolyMod) scale(c uint64) *PolyMod {
+    scaled := make([]uint64, len(f.coeff))
+    for i, a := range f.coeff {
+        scaled[i] = intring.MulMod(a, c, f.p)
+    }
+    return newPolyModNoCopy(f.p, scaled)
+}
+
+// Monic returns f divided by its leading coefficient; the zero polynomial is
+// returned unchanged
+func (f *PolyMod) Monic() *PolyMod {
+    if f.IsZero() {
+        return f
+    }
+    inv, _ := intring.InvMod(f.coeff[len(f.coeff)-1], f.p)
+    return f.scale(inv)
+}
+
+// Div returns the quotient and remainder of f divided by g, with
+// deg r < deg g. It fails with ErrDivisionByZero if g is the zero polynomial
+// and with ErrFieldMismatch if f and g live over different fields.
+func (f *PolyMod) Div(g *PolyMod) (*PolyMod, *PolyMod, error) {
+    if err := f.sameField(g); err != nil {
+        return nil, nil, err
+    }
+    if g.IsZero() {
+        return nil, nil, ErrDivisionByZero
+    }
+    q, r := f.div(g)
+    return q, r, nil
+}
+
+// div is Div for callers that have already checked the fields and ruled out
+// a zero divisor. A zero g gives the quotient 0 and the remainder f, which
+// keeps f = q*g + r but not deg r < deg g.
+func (f *PolyMod) div(g *PolyMod) (*PolyMod, *PolyMod) {
+    if len(f.coeff) < len(g.coeff) || g.IsZero() {
+        return newPolyModNoCopy(f.p, nil), f
+    }
+    rem := append([]uint64(nil), f.coeff...)
+    quo := make([]uint64, len(f.coeff)-len(g.coeff)+1)
+    dg := len(g.coeff) - 1
+    inv, _ := intring.InvMod(g.coeff[dg], f.p)
+    for i := len(rem) - 1; i >= dg; i-- {
+        c := intring.MulMod(rem[i], inv, f.p)
+        if c == 0 {
+            continue
+        }
+        quo[i-dg] = c
+        for j, b := range g.coeff {
+            rem[i-dg+j] = intring.SubMod(rem[i-dg+j], intring.MulMod(c, b, f.p), f.p)
+        }
+    }
+    return newPolyModNoCopy(f.p, quo), newPolyModNoCopy(f.p, rem[:dg])
+}
+
+// ExtendedGCDMod returns the monic gcd of f and g over GF(p) and s, t with
+// s*f + t*g = gcd. The gcd of two zero polynomials is zero. WithGCDStrategy
+// selects the classical remainder sequence (the default) or the half-GCD
+// algorithm of halfgcd.go, which is faster from a few hundred degrees on;
+// both return the same gcd and cofactors. It fails with ErrFieldMismatch or
+// the error of an invalid option.
+func ExtendedGCDMod(f, g *PolyMod, opts ...Option) (gcd, s, t *PolyMod, err error) {
+    if err := f.sameField(g); err != nil {
+        return nil, nil, nil, err
+    }
+    c := newConfig(opts...)
+    if c.err != nil {
+        return nil, nil, nil, c.err
+    }
+    if c.gcd == "halfgcd" {
+        gcd, s, t = halfGCDExtended(f, g)
+        return gcd, s, t, nil
+    }
+    gcd, s, t = extendedGCDMod(f, g)
+    return gcd, s, t, nil
+}
+
+// extendedGCDMod is ExtendedGCDMod by the classical remainder sequence, for
+// operands over the same field
+func extendedGCDMod(f, g *PolyMod) (gcd, s, t *PolyMod) {
+    zero, one := newPolyModNoCopy(f.p, nil), newPolyModNoCopy(f.p, []uint64{1})
+    s0, s1, t0, t1 := one, zero, zero, one
+    for !g.IsZero() {
+        q, r := f.div(g)
+        f, g = g, r
+        s0, s1 = s1, s0.sub(q.mul(s1))
+        t0, t1 = t1, t0.sub(q.mul(t1))
+    }
+    if f.IsZero() {
+        return f, s0, t0
+    }
+    inv, _ := intring.InvMod(f.coeff[len(f.coeff)-1], f.p)
+    return f.scale(inv), s0.scale(inv), t0.scale(inv)
+}
+
+// InverseMod returns the inverse of a in the ring GF(p)[x]/(f), the b of
+// degree below deg f with a*b = 1 mod f. When f is irreducible the ring is
+// the field GF(p^deg f) and every nonzero a has an inverse; otherwise a must
+// be coprime to f, or ErrNotInvertible is returned. WithInverseStrategy
+// selects the extended Euclidean algorithm (the default), the almost
+// inverse, which needs f(0) != 0 and falls back to Euclid otherwise, or the
+// constant-time safegcd of consttime.go, which needs an odd p below 2^62.
+// Operands over different fields give ErrFieldMismatch.
+func InverseMod(a, f *PolyMod, opts ...Option) (*PolyMod, error) {
+    if err := a.sameField(f); err != nil {
+        return nil, err
+    }
+    c := newConfig(opts...)
+    if c.err != nil {
+        return nil, c.err
+    }
+    if f.Deg() < 1 {
+        return nil, fmt.Errorf("polymod: modulus %s has no positive degree", f)
+    }
+    _, r := a.div(f)
+    switch c.inverse {
+    case "almost":
+        if f.coeff[0] != 0 {
+            return almostInverseMod(r, f)
+        }
+    case "consttime":
+        inv, _, err := ctInverseMod(r, f)
+        return inv, err
+    }
+    gcd, s, _ := extendedGCDMod(r, f)
+    if gcd.IsZero() || gcd.Deg() > 0 {
+        return nil, ErrNotInvertible
+    }
+    _, s = s.div(f)
+    return s, nil
+}
+
+func min(a, b int) int {
+    if a < b {
+        return a
+    }
+    return b
+}
+
+func max(a, b int) int {
+    if a > b {
+        return a
+    }
+    return b
+}
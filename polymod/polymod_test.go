@@ -0,0 +1,213 @@
+package polymod
+
+import (
+    "errors"
+    "math/rand"
+    "strings"
+    "testing"
+
+    "euclid/intring"
+)
+
+// testPrimes span the smallest field, a small one and word-size ones
+var testPrimes = []uint64{2, 7, 65537, 1<<31 - 1, 1<<61 - 1}
+
+// schoolbook returns f*g term by term, the reference for mul, which
+// switches to Kronecker substitution for large degrees
+func schoolbook(f, g *PolyMod) *PolyMod {
+    if f.IsZero() || g.IsZero() {
+        return newPolyModNoCopy(f.p, nil)
+    }
+    prod := make([]uint64, len(f.coeff)+len(g.coeff)-1)
+    for i, a := range f.coeff {
+        for j, b := range g.coeff {
+            prod[i+j] = intring.AddMod(prod[i+j], intring.MulMod(a, b, f.p), f.p)
+        }
+    }
+    return newPolyModNoCopy(f.p, prod)
+}
+
+// TestArithmetic checks Mul against the schoolbook product across the
+// Kronecker threshold, Div by q*g + r = f, and Eval as a ring map
+func TestArithmetic(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, p := range testPrimes {
+        for _, n := range []int{0, 1, 5, 31, 32, 80} {
+            f, g := randomPolyMod(rng, p, n), randomPolyMod(rng, p, n/2+1)
+            prod, err := f.Mul(g)
+            if err != nil {
+                t.Fatal(err)
+            }
+            if want := schoolbook(f, g); !prod.Equal(want) {
+                t.Errorf("GF(%d), degree %d: Mul differs from the schoolbook product", p, n)
+            }
+            q, r, err := prod.Div(g)
+            if err != nil || !q.Equal(f) || !r.IsZero() {
+                t.Errorf("GF(%d), degree %d: (f*g)/g = %s rem %s, %v", p, n, q, r, err)
+            }
+            h, _ := f.Add(randomPolyMod(rng, p, n/3))
+            q, r, _ = h.Div(g)
+            back, _ := q.Mul(g)
+            if back, _ = back.Add(r); !back.Equal(h) || !r.IsZero() && r.Deg() >= g.Deg() {
+                t.Errorf("GF(%d): %s = (%s)*(%s) + %s fails", p, h, q, g, r)
+            }
+            x := uint64(rng.Int63n(int64(p)))
+            if prod.Eval(x) != intring.MulMod(f.Eval(x), g.Eval(x), p) {
+                t.Errorf("GF(%d): (f*g)(%d) is not f(%d)*g(%d)", p, x, x, x)
+            }
+            diff, _ := h.Sub(h)
+            if !diff.IsZero() {
+                t.Errorf("GF(%d): h - h = %s", p, diff)
+            }
+        }
+    }
+}
+
+// TestArithmeticErrors checks the errors for mixed fields, division by
+// zero and moduli that are not prime or too large
+func TestArithmeticErrors(t *testing.T) {
+    f, _ := NewPolyMod(7, []uint64{1, 2, 3})
+    g, _ := NewPolyMod(11, []uint64{1, 2, 3})
+    if _, err := f.Add(g); !errors.Is(err, ErrFieldMismatch) {
+        t.Errorf("Add over GF(7) and GF(11) returned %v", err)
+    }
+    if _, _, _, err := ExtendedGCDMod(f, g); !errors.Is(err, ErrFieldMismatch) {
+        t.Errorf("ExtendedGCDMod over GF(7) and GF(11) returned %v", err)
+    }
+    zero, _ := NewPolyMod(7, nil)
+    if _, _, err := f.Div(zero); !errors.Is(err, ErrDivisionByZero) {
+        t.Errorf("division by zero returned %v", err)
+    }
+    for _, c := range []struct {
+        p    uint64
+        want string
+    }{
+        {0, "must be >= 2"},
+        {1, "must be >= 2"},
+        {9, "is not prime"},
+        {1 << 63, "must be below 2^63"},
+    } {
+        if _, err := NewPolyMod(c.p, []uint64{1}); err == nil || !strings.Contains(err.Error(), c.want) {
+            t.Errorf("NewPolyMod with the modulus %d returned %v, want an error that it %s", c.p, err, c.want)
+        }
+    }
+}
+
+// TestExtendedGCDMod checks the Bézout identity and the monic gcd of pairs
+// with a known common factor, for the classical and the half-GCD algorithm
+func TestExtendedGCDMod(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, p := range testPrimes[1:] {
+        for _, n := range []int{1, 4, 20, 150} {
+            c := randomPolyMod(rng, p, n/2+1).Monic()
+            a, _ := randomPolyMod(rng, p, n).Mul(c)
+            b, _ := randomPolyMod(rng, p, n-1).Mul(c)
+            var first *PolyMod
+            for _, strategy := range []string{"euclid", "halfgcd"} {
+                gcd, s, u, err := ExtendedGCDMod(a, b, WithGCDStrategy(strategy))
+                if err != nil {
+                    t.Fatalf("%s: %v", strategy, err)
+                }
+                sa, _ := s.Mul(a)
+                ub, _ := u.Mul(b)
+                if sum, _ := sa.Add(ub); !sum.Equal(gcd) {
+                    t.Errorf("%s over GF(%d), degree %d: s*a + t*b = %s, not %s", strategy, p, n, sum, gcd)
+                }
+                if gcd.Coeff(gcd.Deg()) != 1 {
+                    t.Errorf("%s over GF(%d): gcd %s is not monic", strategy, p, gcd)
+                }
+                if _, r, _ := gcd.Div(c); gcd.Deg() < c.Deg() || !r.IsZero() {
+                    t.Errorf("%s over GF(%d): gcd %s misses the common factor %s", strategy, p, gcd, c)
+                }
+                if first == nil {
+                    first = gcd
+                } else if !gcd.Equal(first) {
+                    t.Errorf("GF(%d), degree %d: halfgcd gives %s, euclid %s", p, n, gcd, first)
+                }
+            }
+        }
+    }
+    f, _ := NewPolyMod(7, []uint64{1, 1})
+    // the subresultant PRS is a strategy of package poly over Q only
+    for _, name := range []string{"subresultant", "fastest"} {
+        if _, _, _, err := ExtendedGCDMod(f, f, WithGCDStrategy(name)); err == nil {
+            t.Errorf("unknown GCD strategy %q accepted", name)
+        }
+    }
+}
+
+// TestInverseMod checks that every inversion strategy returns the same
+// inverse and reports non-units with ErrNotInvertible
+func TestInverseMod(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    for _, p := range []uint64{3, 65537, 1<<31 - 1} {
+        for _, n := range []int{1, 3, 16, 40} {
+            factor := randomPolyMod(rng, p, 1)
+            f, _ := randomPolyMod(rng, p, n-1).Mul(factor)
+            a := randomPolyMod(rng, p, n-1)
+            for _, strategy := range inverseStrategies {
+                inv, err := InverseMod(a, f, WithInverseStrategy(strategy))
+                if errors.Is(err, ErrNotInvertible) {
+                    if gcd, _, _, _ := ExtendedGCDMod(a, f); gcd.Deg() == 0 {
+                        t.Errorf("%s: %s reported not invertible mod %s", strategy, a, f)
+                    }
+                    continue
+                }
+                if err != nil {
+                    t.Fatalf("%s: %v", strategy, err)
+                }
+                prod, _ := a.Mul(inv)
+                if _, r, _ := prod.Div(f); r.Deg() != 0 || r.Coeff(0) != 1 || inv.Deg() >= f.Deg() {
+                    t.Errorf("%s over GF(%d): %s is not the inverse of %s mod %s", strategy, p, inv, a, f)
+                }
+            }
+            for _, strategy := range inverseStrategies {
+                if _, err := InverseMod(factor, f, WithInverseStrategy(strategy)); !errors.Is(err, ErrNotInvertible) {
+                    t.Errorf("%s: the factor %s of %s returned %v", strategy, factor, f, err)
+                }
+            }
+        }
+    }
+}
+
+// TestRatFuncMod checks that results are in lowest terms, so that field
+// identities hold with Equal, and that a zero denominator is rejected
+func TestRatFuncMod(t *testing.T) {
+    rng := rand.New(rand.NewSource(1))
+    p := uint64(101)
+    random := func() *RatFuncMod {
+        r, err := NewRatFuncMod(randomPolyMod(rng, p, rng.Intn(4)), randomPolyMod(rng, p, rng.Intn(4)))
+        if err != nil {
+            t.Fatal(err)
+        }
+        return r
+    }
+    for i := 0; i < 50; i++ {
+        a, b := random(), random()
+        sum, _ := a.Add(b)
+        if back, _ := sum.Sub(b); !back.Equal(a) {
+            t.Errorf("(%s + %s) - %s = %s", a, b, b, back)
+        }
+        prod, _ := a.Mul(b)
+        if back, _ := prod.Quo(b); !back.Equal(a) {
+            t.Errorf("(%s * %s) / %s = %s", a, b, b, back)
+        }
+        if d := a.Den(); d.Coeff(d.Deg()) != 1 {
+            t.Errorf("%s has a denominator that is not monic", a)
+        }
+    }
+    // (t^2 - 1)/(t - 1) is t + 1
+    num, _ := NewPolyMod(p, []uint64{p - 1, 0, 1})
+    den, _ := NewPolyMod(p, []uint64{p - 1, 1})
+    r, _ := NewRatFuncMod(num, den)
+    if got := r.Format("t"); got != "t + 1" {
+        t.Errorf("(t^2 - 1)/(t - 1) = %s", got)
+    }
+    zero, _ := NewPolyMod(p, nil)
+    if _, err := NewRatFuncMod(num, zero); !errors.Is(err, ErrZeroDenominator) {
+        t.Errorf("zero denominator returned %v", err)
+    }
+    if _, err := r.Quo(RatFuncConst(p, 0)); !errors.Is(err, ErrDivisionByZero) {
+        t.Errorf("division by 0 returned %v", err)
+    }
+}
@@ -0,0 +1,169 @@
+package polymod
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+
+    "euclid/intring"
+)
+
+// The function field GF(p)(t) of rational functions in t over GF(p), the
+// coefficients of the polynomials in x of funcfield.go.
+
+// ErrZeroDenominator is returned for a rational function with the zero
+// polynomial as its denominator
+var ErrZeroDenominator = errors.New("rational function: zero denominator")
+
+// RatFuncMod is an element num/den of GF(p)(t), kept in lowest terms with a
+// monic denominator, so equal elements have equal representations
+type RatFuncMod struct {
+    num, den *PolyMod
+}
+
+// NewRatFuncMod returns num/den in lowest terms; num and den are
+// polynomials in t over the same GF(p)
+func NewRatFuncMod(num, den *PolyMod) (*RatFuncMod, error) {
+    if num.p != den.p {
+        return nil, fmt.Errorf("ratfunc: numerator over GF(%d), denominator over GF(%d)", num.p, den.p)
+    }
+    if den.IsZero() {
+        return nil, ErrZeroDenominator
+    }
+    return newRatFuncMod(num, den), nil
+}
+
+// newRatFuncMod is NewRatFuncMod for a nonzero den over the field of num
+func newRatFuncMod(num, den *PolyMod) *RatFuncMod {
+    if num.IsZero() {
+        return &RatFuncMod{num, newPolyModNoCopy(num.p, []uint64{1})}
+    }
+    gcd := gcdMod(num, den)
+    num, _ = num.div(gcd)
+    den, _ = den.div(gcd)
+    inv, _ := intring.InvMod(den.coeff[len(den.coeff)-1], den.p)
+    return &RatFuncMod{num.scale(inv), den.scale(inv)}
+}
+
+// RatFuncConst returns the constant c mod p of GF(p)(t). Unlike
+// NewRatFuncMod it does not check p, which must be a prime CheckModulus
+// accepts, so that callers that have checked p already, such as the
+// coefficient backends of package poly, can make constants cheaply.
+func RatFuncConst(p, c uint64) *RatFuncMod {
+    return ratFuncConst(p, c%p)
+}
+
+// ratFuncConst is RatFuncConst for a residue c < p
+func ratFuncConst(p, c uint64) *RatFuncMod {
+    return newRatFuncMod(newPolyModNoCopy(p, []uint64{c}), newPolyModNoCopy(p, []uint64{1}))
+}
+
+// Num returns the numerator in lowest terms
+func (a *RatFuncMod) Num() *PolyMod { return a.num }
+
+// Den returns the monic denominator in lowest terms
+func (a *RatFuncMod) Den() *PolyMod { return a.den }
+
+// Modulus returns the characteristic p
+func (a *RatFuncMod) Modulus() uint64 { return a.num.p }
+
+// IsZero reports whether a is zero
+func (a *RatFuncMod) IsZero() bool { return a.num.IsZero() }
+
+// Equal reports whether a and b are the same element
+func (a *RatFuncMod) Equal(b *RatFuncMod) bool {
+    return a.num.Equal(b.num) && a.den.Equal(b.den)
+}
+
+// Add returns a + b, or an error wrapping ErrFieldMismatch if a and b live
+// over different fields
+func (a *RatFuncMod) Add(b *RatFuncMod) (*RatFuncMod, error) {
+    if err := a.sameField(b); err != nil {
+        return nil, err
+    }
+    return a.add(b), nil
+}
+
+// Sub returns a - b, or an error wrapping ErrFieldMismatch if a and b live
+// over different fields
+func (a *RatFuncMod) Sub(b *RatFuncMod) (*RatFuncMod, error) {
+    if err := a.sameField(b); err != nil {
+        return nil, err
+    }
+    return a.sub(b), nil
+}
+
+// Mul returns a*b, or an error wrapping ErrFieldMismatch if a and b live
+// over different fields
+func (a *RatFuncMod) Mul(b *RatFuncMod) (*RatFuncMod, error) {
+    if err := a.sameField(b); err != nil {
+        return nil, err
+    }
+    return a.mul(b), nil
+}
+
+// Quo returns a/b. It fails with ErrDivisionByZero if b is zero and with
+// ErrFieldMismatch if a and b live over different fields.
+func (a *RatFuncMod) Quo(b *RatFuncMod) (*RatFuncMod, error) {
+    if err := a.sameField(b); err != nil {
+        return nil, err
+    }
+    if b.IsZero() {
+        return nil, ErrDivisionByZero
+    }
+    return a.quo(b), nil
+}
+
+// sameField returns an error wrapping ErrFieldMismatch if a and b live over
+// different fields
+func (a *RatFuncMod) sameField(b *RatFuncMod) error {
+    if a.num.p != b.num.p {
+        return fmt.Errorf("ratfunc: %w: GF(%d)(t) and GF(%d)(t)", ErrFieldMismatch, a.num.p, b.num.p)
+    }
+    return nil
+}
+
+// add, sub and mul are Add, Sub and Mul for operands over the same field
+func (a *RatFuncMod) add(b *RatFuncMod) *RatFuncMod {
+    return newRatFuncMod(a.num.mul(b.den).add(b.num.mul(a.den)), a.den.mul(b.den))
+}
+
+func (a *RatFuncMod) sub(b *RatFuncMod) *RatFuncMod {
+    return newRatFuncMod(a.num.mul(b.den).sub(b.num.mul(a.den)), a.den.mul(b.den))
+}
+
+func (a *RatFuncMod) mul(b *RatFuncMod) *RatFuncMod {
+    return newRatFuncMod(a.num.mul(b.num), a.den.mul(b.den))
+}
+
+// quo is Quo for a nonzero b over the field of a
+func (a *RatFuncMod) quo(b *RatFuncMod) *RatFuncMod {
+    return newRatFuncMod(a.num.mul(b.den), a.den.mul(b.num))
+}
+
+// tString formats a polynomial over GF(p) as a polynomial in the variable
+// t
+func tString(f *PolyMod, t string) string {
+    return strings.ReplaceAll(f.String(), "x", t)
+}
+
+// String formats a in t, e.g. "t^2 + 1" or "(t + 1)/(t^2 + 4)"
+func (a *RatFuncMod) String() string {
+    return a.Format("t")
+}
+
+// Format is String with the variable named t
+func (a *RatFuncMod) Format(t string) string {
+    num := tString(a.num, t)
+    if a.den.Deg() == 0 {
+        return num
+    }
+    den := tString(a.den, t)
+    if strings.Contains(num, " ") {
+        num = "(" + num + ")"
+    }
+    if strings.Contains(den, " ") {
+        den = "(" + den + ")"
+    }
+    return num + "/" + den
+}